@@ -29,6 +29,12 @@ func (o *ListEventsReader) ReadResponse(response runtime.ClientResponse, consume
 			return nil, err
 		}
 		return result, nil
+	case 404:
+		result := NewListEventsNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewListEventsInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -72,6 +78,39 @@ func (o *ListEventsOK) readResponse(response runtime.ClientResponse, consumer ru
 	return nil
 }
 
+// NewListEventsNotFound creates a ListEventsNotFound with default headers values
+func NewListEventsNotFound() *ListEventsNotFound {
+	return &ListEventsNotFound{}
+}
+
+/*ListEventsNotFound handles this case with default header values.
+
+Error.
+*/
+type ListEventsNotFound struct {
+	Payload *models.Error
+}
+
+func (o *ListEventsNotFound) Error() string {
+	return fmt.Sprintf("[GET /events/{entity_id}][%d] listEventsNotFound  %+v", 404, o.Payload)
+}
+
+func (o *ListEventsNotFound) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ListEventsNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewListEventsInternalServerError creates a ListEventsInternalServerError with default headers values
 func NewListEventsInternalServerError() *ListEventsInternalServerError {
 	return &ListEventsInternalServerError{}
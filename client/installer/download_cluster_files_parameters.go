@@ -64,6 +64,16 @@ type DownloadClusterFilesParams struct {
 	ClusterID strfmt.UUID
 	/*FileName*/
 	FileName string
+	/*IfRange
+	  Serves the requested range only when the entity tag or the last modification date match the file.
+
+	*/
+	IfRange *string
+	/*Range
+	  The byte range of the file to download.
+
+	*/
+	Range *string
 
 	timeout    time.Duration
 	Context    context.Context
@@ -125,6 +135,28 @@ func (o *DownloadClusterFilesParams) SetFileName(fileName string) {
 	o.FileName = fileName
 }
 
+// WithIfRange adds the ifRange to the download cluster files params
+func (o *DownloadClusterFilesParams) WithIfRange(ifRange *string) *DownloadClusterFilesParams {
+	o.SetIfRange(ifRange)
+	return o
+}
+
+// SetIfRange adds the ifRange to the download cluster files params
+func (o *DownloadClusterFilesParams) SetIfRange(ifRange *string) {
+	o.IfRange = ifRange
+}
+
+// WithRange adds the range to the download cluster files params
+func (o *DownloadClusterFilesParams) WithRange(rangeVar *string) *DownloadClusterFilesParams {
+	o.SetRange(rangeVar)
+	return o
+}
+
+// SetRange adds the range to the download cluster files params
+func (o *DownloadClusterFilesParams) SetRange(rangeVar *string) {
+	o.Range = rangeVar
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *DownloadClusterFilesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -147,6 +179,24 @@ func (o *DownloadClusterFilesParams) WriteToRequest(r runtime.ClientRequest, reg
 		}
 	}
 
+	if o.IfRange != nil {
+
+		// header param If-Range
+		if err := r.SetHeaderParam("If-Range", *o.IfRange); err != nil {
+			return err
+		}
+
+	}
+
+	if o.Range != nil {
+
+		// header param Range
+		if err := r.SetHeaderParam("Range", *o.Range); err != nil {
+			return err
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -30,6 +30,12 @@ func (o *DownloadClusterFilesReader) ReadResponse(response runtime.ClientRespons
 			return nil, err
 		}
 		return result, nil
+	case 206:
+		result := NewDownloadClusterFilesPartialContent(o.writer)
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
 	case 404:
 		result := NewDownloadClusterFilesNotFound()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -42,6 +48,12 @@ func (o *DownloadClusterFilesReader) ReadResponse(response runtime.ClientRespons
 			return nil, err
 		}
 		return nil, result
+	case 416:
+		result := NewDownloadClusterFilesRequestedRangeNotSatisfiable()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewDownloadClusterFilesInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -87,6 +99,39 @@ func (o *DownloadClusterFilesOK) readResponse(response runtime.ClientResponse, c
 	return nil
 }
 
+// NewDownloadClusterFilesPartialContent creates a DownloadClusterFilesPartialContent with default headers values
+func NewDownloadClusterFilesPartialContent(writer io.Writer) *DownloadClusterFilesPartialContent {
+	return &DownloadClusterFilesPartialContent{
+		Payload: writer,
+	}
+}
+
+/*DownloadClusterFilesPartialContent handles this case with default header values.
+
+Partial content.
+*/
+type DownloadClusterFilesPartialContent struct {
+	Payload io.Writer
+}
+
+func (o *DownloadClusterFilesPartialContent) Error() string {
+	return fmt.Sprintf("[GET /clusters/{cluster_id}/downloads/files][%d] downloadClusterFilesPartialContent  %+v", 206, o.Payload)
+}
+
+func (o *DownloadClusterFilesPartialContent) GetPayload() io.Writer {
+	return o.Payload
+}
+
+func (o *DownloadClusterFilesPartialContent) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewDownloadClusterFilesNotFound creates a DownloadClusterFilesNotFound with default headers values
 func NewDownloadClusterFilesNotFound() *DownloadClusterFilesNotFound {
 	return &DownloadClusterFilesNotFound{}
@@ -153,6 +198,39 @@ func (o *DownloadClusterFilesConflict) readResponse(response runtime.ClientRespo
 	return nil
 }
 
+// NewDownloadClusterFilesRequestedRangeNotSatisfiable creates a DownloadClusterFilesRequestedRangeNotSatisfiable with default headers values
+func NewDownloadClusterFilesRequestedRangeNotSatisfiable() *DownloadClusterFilesRequestedRangeNotSatisfiable {
+	return &DownloadClusterFilesRequestedRangeNotSatisfiable{}
+}
+
+/*DownloadClusterFilesRequestedRangeNotSatisfiable handles this case with default header values.
+
+Error.
+*/
+type DownloadClusterFilesRequestedRangeNotSatisfiable struct {
+	Payload *models.Error
+}
+
+func (o *DownloadClusterFilesRequestedRangeNotSatisfiable) Error() string {
+	return fmt.Sprintf("[GET /clusters/{cluster_id}/downloads/files][%d] downloadClusterFilesRequestedRangeNotSatisfiable  %+v", 416, o.Payload)
+}
+
+func (o *DownloadClusterFilesRequestedRangeNotSatisfiable) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *DownloadClusterFilesRequestedRangeNotSatisfiable) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewDownloadClusterFilesInternalServerError creates a DownloadClusterFilesInternalServerError with default headers values
 func NewDownloadClusterFilesInternalServerError() *DownloadClusterFilesInternalServerError {
 	return &DownloadClusterFilesInternalServerError{}
@@ -62,6 +62,16 @@ type DownloadClusterISOParams struct {
 
 	/*ClusterID*/
 	ClusterID strfmt.UUID
+	/*IfRange
+	  Serves the requested range only when the entity tag or the last modification date match the file.
+
+	*/
+	IfRange *string
+	/*Range
+	  The byte range of the file to download.
+
+	*/
+	Range *string
 
 	timeout    time.Duration
 	Context    context.Context
@@ -112,6 +122,28 @@ func (o *DownloadClusterISOParams) SetClusterID(clusterID strfmt.UUID) {
 	o.ClusterID = clusterID
 }
 
+// WithIfRange adds the ifRange to the download cluster i s o params
+func (o *DownloadClusterISOParams) WithIfRange(ifRange *string) *DownloadClusterISOParams {
+	o.SetIfRange(ifRange)
+	return o
+}
+
+// SetIfRange adds the ifRange to the download cluster i s o params
+func (o *DownloadClusterISOParams) SetIfRange(ifRange *string) {
+	o.IfRange = ifRange
+}
+
+// WithRange adds the range to the download cluster i s o params
+func (o *DownloadClusterISOParams) WithRange(rangeVar *string) *DownloadClusterISOParams {
+	o.SetRange(rangeVar)
+	return o
+}
+
+// SetRange adds the range to the download cluster i s o params
+func (o *DownloadClusterISOParams) SetRange(rangeVar *string) {
+	o.Range = rangeVar
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *DownloadClusterISOParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -125,6 +157,24 @@ func (o *DownloadClusterISOParams) WriteToRequest(r runtime.ClientRequest, reg s
 		return err
 	}
 
+	if o.IfRange != nil {
+
+		// header param If-Range
+		if err := r.SetHeaderParam("If-Range", *o.IfRange); err != nil {
+			return err
+		}
+
+	}
+
+	if o.Range != nil {
+
+		// header param Range
+		if err := r.SetHeaderParam("Range", *o.Range); err != nil {
+			return err
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
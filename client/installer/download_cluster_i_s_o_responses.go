@@ -30,6 +30,12 @@ func (o *DownloadClusterISOReader) ReadResponse(response runtime.ClientResponse,
 			return nil, err
 		}
 		return result, nil
+	case 206:
+		result := NewDownloadClusterISOPartialContent(o.writer)
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
 	case 400:
 		result := NewDownloadClusterISOBadRequest()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -42,6 +48,18 @@ func (o *DownloadClusterISOReader) ReadResponse(response runtime.ClientResponse,
 			return nil, err
 		}
 		return nil, result
+	case 409:
+		result := NewDownloadClusterISOConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 416:
+		result := NewDownloadClusterISORequestedRangeNotSatisfiable()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewDownloadClusterISOInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -87,6 +105,39 @@ func (o *DownloadClusterISOOK) readResponse(response runtime.ClientResponse, con
 	return nil
 }
 
+// NewDownloadClusterISOPartialContent creates a DownloadClusterISOPartialContent with default headers values
+func NewDownloadClusterISOPartialContent(writer io.Writer) *DownloadClusterISOPartialContent {
+	return &DownloadClusterISOPartialContent{
+		Payload: writer,
+	}
+}
+
+/*DownloadClusterISOPartialContent handles this case with default header values.
+
+Partial content.
+*/
+type DownloadClusterISOPartialContent struct {
+	Payload io.Writer
+}
+
+func (o *DownloadClusterISOPartialContent) Error() string {
+	return fmt.Sprintf("[GET /clusters/{cluster_id}/downloads/image][%d] downloadClusterISOPartialContent  %+v", 206, o.Payload)
+}
+
+func (o *DownloadClusterISOPartialContent) GetPayload() io.Writer {
+	return o.Payload
+}
+
+func (o *DownloadClusterISOPartialContent) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewDownloadClusterISOBadRequest creates a DownloadClusterISOBadRequest with default headers values
 func NewDownloadClusterISOBadRequest() *DownloadClusterISOBadRequest {
 	return &DownloadClusterISOBadRequest{}
@@ -153,6 +204,72 @@ func (o *DownloadClusterISONotFound) readResponse(response runtime.ClientRespons
 	return nil
 }
 
+// NewDownloadClusterISOConflict creates a DownloadClusterISOConflict with default headers values
+func NewDownloadClusterISOConflict() *DownloadClusterISOConflict {
+	return &DownloadClusterISOConflict{}
+}
+
+/*DownloadClusterISOConflict handles this case with default header values.
+
+Error.
+*/
+type DownloadClusterISOConflict struct {
+	Payload *models.Error
+}
+
+func (o *DownloadClusterISOConflict) Error() string {
+	return fmt.Sprintf("[GET /clusters/{cluster_id}/downloads/image][%d] downloadClusterISOConflict  %+v", 409, o.Payload)
+}
+
+func (o *DownloadClusterISOConflict) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *DownloadClusterISOConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewDownloadClusterISORequestedRangeNotSatisfiable creates a DownloadClusterISORequestedRangeNotSatisfiable with default headers values
+func NewDownloadClusterISORequestedRangeNotSatisfiable() *DownloadClusterISORequestedRangeNotSatisfiable {
+	return &DownloadClusterISORequestedRangeNotSatisfiable{}
+}
+
+/*DownloadClusterISORequestedRangeNotSatisfiable handles this case with default header values.
+
+Error.
+*/
+type DownloadClusterISORequestedRangeNotSatisfiable struct {
+	Payload *models.Error
+}
+
+func (o *DownloadClusterISORequestedRangeNotSatisfiable) Error() string {
+	return fmt.Sprintf("[GET /clusters/{cluster_id}/downloads/image][%d] downloadClusterISORequestedRangeNotSatisfiable  %+v", 416, o.Payload)
+}
+
+func (o *DownloadClusterISORequestedRangeNotSatisfiable) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *DownloadClusterISORequestedRangeNotSatisfiable) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewDownloadClusterISOInternalServerError creates a DownloadClusterISOInternalServerError with default headers values
 func NewDownloadClusterISOInternalServerError() *DownloadClusterISOInternalServerError {
 	return &DownloadClusterISOInternalServerError{}
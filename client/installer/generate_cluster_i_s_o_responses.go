@@ -23,8 +23,8 @@ type GenerateClusterISOReader struct {
 // ReadResponse reads a server response into the received o.
 func (o *GenerateClusterISOReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
 	switch response.Code() {
-	case 201:
-		result := NewGenerateClusterISOCreated()
+	case 202:
+		result := NewGenerateClusterISOAccepted()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
@@ -59,28 +59,28 @@ func (o *GenerateClusterISOReader) ReadResponse(response runtime.ClientResponse,
 	}
 }
 
-// NewGenerateClusterISOCreated creates a GenerateClusterISOCreated with default headers values
-func NewGenerateClusterISOCreated() *GenerateClusterISOCreated {
-	return &GenerateClusterISOCreated{}
+// NewGenerateClusterISOAccepted creates a GenerateClusterISOAccepted with default headers values
+func NewGenerateClusterISOAccepted() *GenerateClusterISOAccepted {
+	return &GenerateClusterISOAccepted{}
 }
 
-/*GenerateClusterISOCreated handles this case with default header values.
+/*GenerateClusterISOAccepted handles this case with default header values.
 
 Success.
 */
-type GenerateClusterISOCreated struct {
+type GenerateClusterISOAccepted struct {
 	Payload *models.Cluster
 }
 
-func (o *GenerateClusterISOCreated) Error() string {
-	return fmt.Sprintf("[POST /clusters/{cluster_id}/downloads/image][%d] generateClusterISOCreated  %+v", 201, o.Payload)
+func (o *GenerateClusterISOAccepted) Error() string {
+	return fmt.Sprintf("[POST /clusters/{cluster_id}/downloads/image][%d] generateClusterISOAccepted  %+v", 202, o.Payload)
 }
 
-func (o *GenerateClusterISOCreated) GetPayload() *models.Cluster {
+func (o *GenerateClusterISOAccepted) GetPayload() *models.Cluster {
 	return o.Payload
 }
 
-func (o *GenerateClusterISOCreated) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+func (o *GenerateClusterISOAccepted) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
 	o.Payload = new(models.Cluster)
 
@@ -35,10 +35,13 @@ type API interface {
 	DisableHost(ctx context.Context, params *DisableHostParams) (*DisableHostOK, error)
 	/*
 	   DownloadClusterFiles downloads files relating to the installed installing cluster*/
-	DownloadClusterFiles(ctx context.Context, params *DownloadClusterFilesParams, writer io.Writer) (*DownloadClusterFilesOK, error)
+	DownloadClusterFiles(ctx context.Context, params *DownloadClusterFilesParams, writer io.Writer) (*DownloadClusterFilesOK, *DownloadClusterFilesPartialContent, error)
 	/*
 	   DownloadClusterISO downloads the open shift per cluster discovery i s o*/
-	DownloadClusterISO(ctx context.Context, params *DownloadClusterISOParams, writer io.Writer) (*DownloadClusterISOOK, error)
+	DownloadClusterISO(ctx context.Context, params *DownloadClusterISOParams, writer io.Writer) (*DownloadClusterISOOK, *DownloadClusterISOPartialContent, error)
+	/*
+	   DownloadClusterISOPresigned downloads the open shift per cluster discovery i s o with a signed URL*/
+	DownloadClusterISOPresigned(ctx context.Context, params *DownloadClusterISOPresignedParams, writer io.Writer) (*DownloadClusterISOPresignedOK, *DownloadClusterISOPresignedPartialContent, error)
 	/*
 	   DownloadClusterKubeconfig downloads the kubeconfig file for this cluster*/
 	DownloadClusterKubeconfig(ctx context.Context, params *DownloadClusterKubeconfigParams, writer io.Writer) (*DownloadClusterKubeconfigOK, error)
@@ -47,16 +50,22 @@ type API interface {
 	EnableHost(ctx context.Context, params *EnableHostParams) (*EnableHostOK, error)
 	/*
 	   GenerateClusterISO creates a new open shift per cluster discovery i s o*/
-	GenerateClusterISO(ctx context.Context, params *GenerateClusterISOParams) (*GenerateClusterISOCreated, error)
+	GenerateClusterISO(ctx context.Context, params *GenerateClusterISOParams) (*GenerateClusterISOAccepted, error)
 	/*
 	   GetCluster retrieves the details of the open shift bare metal cluster*/
 	GetCluster(ctx context.Context, params *GetClusterParams) (*GetClusterOK, error)
+	/*
+	   GetClusterConnectivityMatrix gets the the cluster admin credentials*/
+	GetClusterConnectivityMatrix(ctx context.Context, params *GetClusterConnectivityMatrixParams) (*GetClusterConnectivityMatrixOK, error)
 	/*
 	   GetCredentials gets the the cluster admin credentials*/
 	GetCredentials(ctx context.Context, params *GetCredentialsParams) (*GetCredentialsOK, error)
 	/*
 	   GetFreeAddresses retrieves the free address list for a network*/
 	GetFreeAddresses(ctx context.Context, params *GetFreeAddressesParams) (*GetFreeAddressesOK, error)
+	/*
+	   GetPresignedForClusterISO creates a time limited URL for downloading the discovery i s o without credentials e g by b m c virtual media*/
+	GetPresignedForClusterISO(ctx context.Context, params *GetPresignedForClusterISOParams) (*GetPresignedForClusterISOOK, error)
 	/*
 	   GetHost retrieves the details of the open shift bare metal host*/
 	GetHost(ctx context.Context, params *GetHostParams) (*GetHostOK, error)
@@ -90,6 +99,9 @@ type API interface {
 	/*
 	   UpdateCluster updates an open shift bare metal cluster definition*/
 	UpdateCluster(ctx context.Context, params *UpdateClusterParams) (*UpdateClusterCreated, error)
+	/*
+	   UpdateClusterInstallConfig overrides values in the install config*/
+	UpdateClusterInstallConfig(ctx context.Context, params *UpdateClusterInstallConfigParams) (*UpdateClusterInstallConfigCreated, error)
 	/*
 	   UpdateHostInstallProgress updates installation progress*/
 	UpdateHostInstallProgress(ctx context.Context, params *UpdateHostInstallProgressParams) (*UpdateHostInstallProgressOK, error)
@@ -239,7 +251,7 @@ func (a *Client) DisableHost(ctx context.Context, params *DisableHostParams) (*D
 /*
 DownloadClusterFiles downloads files relating to the installed installing cluster
 */
-func (a *Client) DownloadClusterFiles(ctx context.Context, params *DownloadClusterFilesParams, writer io.Writer) (*DownloadClusterFilesOK, error) {
+func (a *Client) DownloadClusterFiles(ctx context.Context, params *DownloadClusterFilesParams, writer io.Writer) (*DownloadClusterFilesOK, *DownloadClusterFilesPartialContent, error) {
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
 		ID:                 "DownloadClusterFiles",
@@ -254,16 +266,22 @@ func (a *Client) DownloadClusterFiles(ctx context.Context, params *DownloadClust
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return result.(*DownloadClusterFilesOK), nil
+	switch value := result.(type) {
+	case *DownloadClusterFilesOK:
+		return value, nil, nil
+	case *DownloadClusterFilesPartialContent:
+		return nil, value, nil
+	}
+	return nil, nil, nil
 
 }
 
 /*
 DownloadClusterISO downloads the open shift per cluster discovery i s o
 */
-func (a *Client) DownloadClusterISO(ctx context.Context, params *DownloadClusterISOParams, writer io.Writer) (*DownloadClusterISOOK, error) {
+func (a *Client) DownloadClusterISO(ctx context.Context, params *DownloadClusterISOParams, writer io.Writer) (*DownloadClusterISOOK, *DownloadClusterISOPartialContent, error) {
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
 		ID:                 "DownloadClusterISO",
@@ -278,9 +296,45 @@ func (a *Client) DownloadClusterISO(ctx context.Context, params *DownloadCluster
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	switch value := result.(type) {
+	case *DownloadClusterISOOK:
+		return value, nil, nil
+	case *DownloadClusterISOPartialContent:
+		return nil, value, nil
+	}
+	return nil, nil, nil
+
+}
+
+/*
+DownloadClusterISOPresigned downloads the open shift per cluster discovery i s o with a signed URL
+*/
+func (a *Client) DownloadClusterISOPresigned(ctx context.Context, params *DownloadClusterISOPresignedParams, writer io.Writer) (*DownloadClusterISOPresignedOK, *DownloadClusterISOPresignedPartialContent, error) {
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "DownloadClusterISOPresigned",
+		Method:             "GET",
+		PathPattern:        "/clusters/{cluster_id}/downloads/image-presigned",
+		ProducesMediaTypes: []string{"application/octet-stream"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DownloadClusterISOPresignedReader{formats: a.formats, writer: writer},
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-	return result.(*DownloadClusterISOOK), nil
+	switch value := result.(type) {
+	case *DownloadClusterISOPresignedOK:
+		return value, nil, nil
+	case *DownloadClusterISOPresignedPartialContent:
+		return nil, value, nil
+	}
+	return nil, nil, nil
 
 }
 
@@ -335,7 +389,7 @@ func (a *Client) EnableHost(ctx context.Context, params *EnableHostParams) (*Ena
 /*
 GenerateClusterISO creates a new open shift per cluster discovery i s o
 */
-func (a *Client) GenerateClusterISO(ctx context.Context, params *GenerateClusterISOParams) (*GenerateClusterISOCreated, error) {
+func (a *Client) GenerateClusterISO(ctx context.Context, params *GenerateClusterISOParams) (*GenerateClusterISOAccepted, error) {
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
 		ID:                 "GenerateClusterISO",
@@ -352,7 +406,7 @@ func (a *Client) GenerateClusterISO(ctx context.Context, params *GenerateCluster
 	if err != nil {
 		return nil, err
 	}
-	return result.(*GenerateClusterISOCreated), nil
+	return result.(*GenerateClusterISOAccepted), nil
 
 }
 
@@ -380,6 +434,30 @@ func (a *Client) GetCluster(ctx context.Context, params *GetClusterParams) (*Get
 
 }
 
+/*
+GetClusterConnectivityMatrix gets the the cluster admin credentials
+*/
+func (a *Client) GetClusterConnectivityMatrix(ctx context.Context, params *GetClusterConnectivityMatrixParams) (*GetClusterConnectivityMatrixOK, error) {
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetClusterConnectivityMatrix",
+		Method:             "GET",
+		PathPattern:        "/clusters/{cluster_id}/connectivity-matrix",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetClusterConnectivityMatrixReader{formats: a.formats},
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetClusterConnectivityMatrixOK), nil
+
+}
+
 /*
 GetCredentials gets the the cluster admin credentials
 */
@@ -428,6 +506,30 @@ func (a *Client) GetFreeAddresses(ctx context.Context, params *GetFreeAddressesP
 
 }
 
+/*
+GetPresignedForClusterISO creates a time limited URL for downloading the discovery i s o without credentials e g by b m c virtual media
+*/
+func (a *Client) GetPresignedForClusterISO(ctx context.Context, params *GetPresignedForClusterISOParams) (*GetPresignedForClusterISOOK, error) {
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetPresignedForClusterISO",
+		Method:             "GET",
+		PathPattern:        "/clusters/{cluster_id}/downloads/image-url",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetPresignedForClusterISOReader{formats: a.formats},
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*GetPresignedForClusterISOOK), nil
+
+}
+
 /*
 GetHost retrieves the details of the open shift bare metal host
 */
@@ -692,6 +794,30 @@ func (a *Client) UpdateCluster(ctx context.Context, params *UpdateClusterParams)
 
 }
 
+/*
+UpdateClusterInstallConfig overrides values in the install config
+*/
+func (a *Client) UpdateClusterInstallConfig(ctx context.Context, params *UpdateClusterInstallConfigParams) (*UpdateClusterInstallConfigCreated, error) {
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "UpdateClusterInstallConfig",
+		Method:             "PATCH",
+		PathPattern:        "/clusters/{cluster_id}/install-config",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &UpdateClusterInstallConfigReader{formats: a.formats},
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*UpdateClusterInstallConfigCreated), nil
+
+}
+
 /*
 UpdateHostInstallProgress updates installation progress
 */
@@ -29,6 +29,12 @@ func (o *ListHostsReader) ReadResponse(response runtime.ClientResponse, consumer
 			return nil, err
 		}
 		return result, nil
+	case 404:
+		result := NewListHostsNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewListHostsInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -72,6 +78,39 @@ func (o *ListHostsOK) readResponse(response runtime.ClientResponse, consumer run
 	return nil
 }
 
+// NewListHostsNotFound creates a ListHostsNotFound with default headers values
+func NewListHostsNotFound() *ListHostsNotFound {
+	return &ListHostsNotFound{}
+}
+
+/*ListHostsNotFound handles this case with default header values.
+
+Error.
+*/
+type ListHostsNotFound struct {
+	Payload *models.Error
+}
+
+func (o *ListHostsNotFound) Error() string {
+	return fmt.Sprintf("[GET /clusters/{cluster_id}/hosts][%d] listHostsNotFound  %+v", 404, o.Payload)
+}
+
+func (o *ListHostsNotFound) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ListHostsNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewListHostsInternalServerError creates a ListHostsInternalServerError with default headers values
 func NewListHostsInternalServerError() *ListHostsInternalServerError {
 	return &ListHostsInternalServerError{}
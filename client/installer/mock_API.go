@@ -130,7 +130,7 @@ func (_m *MockAPI) DisableHost(ctx context.Context, params *DisableHostParams) (
 }
 
 // DownloadClusterFiles provides a mock function with given fields: ctx, params, writer
-func (_m *MockAPI) DownloadClusterFiles(ctx context.Context, params *DownloadClusterFilesParams, writer io.Writer) (*DownloadClusterFilesOK, error) {
+func (_m *MockAPI) DownloadClusterFiles(ctx context.Context, params *DownloadClusterFilesParams, writer io.Writer) (*DownloadClusterFilesOK, *DownloadClusterFilesPartialContent, error) {
 	ret := _m.Called(ctx, params, writer)
 
 	var r0 *DownloadClusterFilesOK
@@ -142,18 +142,27 @@ func (_m *MockAPI) DownloadClusterFiles(ctx context.Context, params *DownloadClu
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *DownloadClusterFilesParams, io.Writer) error); ok {
+	var r1 *DownloadClusterFilesPartialContent
+	if rf, ok := ret.Get(1).(func(context.Context, *DownloadClusterFilesParams, io.Writer) *DownloadClusterFilesPartialContent); ok {
 		r1 = rf(ctx, params, writer)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*DownloadClusterFilesPartialContent)
+		}
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *DownloadClusterFilesParams, io.Writer) error); ok {
+		r2 = rf(ctx, params, writer)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // DownloadClusterISO provides a mock function with given fields: ctx, params, writer
-func (_m *MockAPI) DownloadClusterISO(ctx context.Context, params *DownloadClusterISOParams, writer io.Writer) (*DownloadClusterISOOK, error) {
+func (_m *MockAPI) DownloadClusterISO(ctx context.Context, params *DownloadClusterISOParams, writer io.Writer) (*DownloadClusterISOOK, *DownloadClusterISOPartialContent, error) {
 	ret := _m.Called(ctx, params, writer)
 
 	var r0 *DownloadClusterISOOK
@@ -165,14 +174,55 @@ func (_m *MockAPI) DownloadClusterISO(ctx context.Context, params *DownloadClust
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *DownloadClusterISOParams, io.Writer) error); ok {
+	var r1 *DownloadClusterISOPartialContent
+	if rf, ok := ret.Get(1).(func(context.Context, *DownloadClusterISOParams, io.Writer) *DownloadClusterISOPartialContent); ok {
 		r1 = rf(ctx, params, writer)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*DownloadClusterISOPartialContent)
+		}
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *DownloadClusterISOParams, io.Writer) error); ok {
+		r2 = rf(ctx, params, writer)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DownloadClusterISOPresigned provides a mock function with given fields: ctx, params, writer
+func (_m *MockAPI) DownloadClusterISOPresigned(ctx context.Context, params *DownloadClusterISOPresignedParams, writer io.Writer) (*DownloadClusterISOPresignedOK, *DownloadClusterISOPresignedPartialContent, error) {
+	ret := _m.Called(ctx, params, writer)
+
+	var r0 *DownloadClusterISOPresignedOK
+	if rf, ok := ret.Get(0).(func(context.Context, *DownloadClusterISOPresignedParams, io.Writer) *DownloadClusterISOPresignedOK); ok {
+		r0 = rf(ctx, params, writer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*DownloadClusterISOPresignedOK)
+		}
+	}
+
+	var r1 *DownloadClusterISOPresignedPartialContent
+	if rf, ok := ret.Get(1).(func(context.Context, *DownloadClusterISOPresignedParams, io.Writer) *DownloadClusterISOPresignedPartialContent); ok {
+		r1 = rf(ctx, params, writer)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*DownloadClusterISOPresignedPartialContent)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *DownloadClusterISOPresignedParams, io.Writer) error); ok {
+		r2 = rf(ctx, params, writer)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // DownloadClusterKubeconfig provides a mock function with given fields: ctx, params, writer
@@ -222,15 +272,15 @@ func (_m *MockAPI) EnableHost(ctx context.Context, params *EnableHostParams) (*E
 }
 
 // GenerateClusterISO provides a mock function with given fields: ctx, params
-func (_m *MockAPI) GenerateClusterISO(ctx context.Context, params *GenerateClusterISOParams) (*GenerateClusterISOCreated, error) {
+func (_m *MockAPI) GenerateClusterISO(ctx context.Context, params *GenerateClusterISOParams) (*GenerateClusterISOAccepted, error) {
 	ret := _m.Called(ctx, params)
 
-	var r0 *GenerateClusterISOCreated
-	if rf, ok := ret.Get(0).(func(context.Context, *GenerateClusterISOParams) *GenerateClusterISOCreated); ok {
+	var r0 *GenerateClusterISOAccepted
+	if rf, ok := ret.Get(0).(func(context.Context, *GenerateClusterISOParams) *GenerateClusterISOAccepted); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*GenerateClusterISOCreated)
+			r0 = ret.Get(0).(*GenerateClusterISOAccepted)
 		}
 	}
 
@@ -267,6 +317,29 @@ func (_m *MockAPI) GetCluster(ctx context.Context, params *GetClusterParams) (*G
 	return r0, r1
 }
 
+// GetClusterConnectivityMatrix provides a mock function with given fields: ctx, params
+func (_m *MockAPI) GetClusterConnectivityMatrix(ctx context.Context, params *GetClusterConnectivityMatrixParams) (*GetClusterConnectivityMatrixOK, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *GetClusterConnectivityMatrixOK
+	if rf, ok := ret.Get(0).(func(context.Context, *GetClusterConnectivityMatrixParams) *GetClusterConnectivityMatrixOK); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetClusterConnectivityMatrixOK)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *GetClusterConnectivityMatrixParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCredentials provides a mock function with given fields: ctx, params
 func (_m *MockAPI) GetCredentials(ctx context.Context, params *GetCredentialsParams) (*GetCredentialsOK, error) {
 	ret := _m.Called(ctx, params)
@@ -313,6 +386,29 @@ func (_m *MockAPI) GetFreeAddresses(ctx context.Context, params *GetFreeAddresse
 	return r0, r1
 }
 
+// GetPresignedForClusterISO provides a mock function with given fields: ctx, params
+func (_m *MockAPI) GetPresignedForClusterISO(ctx context.Context, params *GetPresignedForClusterISOParams) (*GetPresignedForClusterISOOK, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *GetPresignedForClusterISOOK
+	if rf, ok := ret.Get(0).(func(context.Context, *GetPresignedForClusterISOParams) *GetPresignedForClusterISOOK); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetPresignedForClusterISOOK)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *GetPresignedForClusterISOParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHost provides a mock function with given fields: ctx, params
 func (_m *MockAPI) GetHost(ctx context.Context, params *GetHostParams) (*GetHostOK, error) {
 	ret := _m.Called(ctx, params)
@@ -566,6 +662,29 @@ func (_m *MockAPI) UpdateCluster(ctx context.Context, params *UpdateClusterParam
 	return r0, r1
 }
 
+// UpdateClusterInstallConfig provides a mock function with given fields: ctx, params
+func (_m *MockAPI) UpdateClusterInstallConfig(ctx context.Context, params *UpdateClusterInstallConfigParams) (*UpdateClusterInstallConfigCreated, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *UpdateClusterInstallConfigCreated
+	if rf, ok := ret.Get(0).(func(context.Context, *UpdateClusterInstallConfigParams) *UpdateClusterInstallConfigCreated); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*UpdateClusterInstallConfigCreated)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *UpdateClusterInstallConfigParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateHostInstallProgress provides a mock function with given fields: ctx, params
 func (_m *MockAPI) UpdateHostInstallProgress(ctx context.Context, params *UpdateHostInstallProgressParams) (*UpdateHostInstallProgressOK, error) {
 	ret := _m.Called(ctx, params)
@@ -35,3 +35,26 @@ func (_m *MockAPI) ListComponentVersions(ctx context.Context, params *ListCompon
 
 	return r0, r1
 }
+
+// ListSupportedOpenshiftVersions provides a mock function with given fields: ctx, params
+func (_m *MockAPI) ListSupportedOpenshiftVersions(ctx context.Context, params *ListSupportedOpenshiftVersionsParams) (*ListSupportedOpenshiftVersionsOK, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 *ListSupportedOpenshiftVersionsOK
+	if rf, ok := ret.Get(0).(func(context.Context, *ListSupportedOpenshiftVersionsParams) *ListSupportedOpenshiftVersionsOK); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ListSupportedOpenshiftVersionsOK)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ListSupportedOpenshiftVersionsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
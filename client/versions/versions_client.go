@@ -20,6 +20,9 @@ type API interface {
 	/*
 	   ListComponentVersions lists of componenets versions*/
 	ListComponentVersions(ctx context.Context, params *ListComponentVersionsParams) (*ListComponentVersionsOK, error)
+	/*
+	   ListSupportedOpenshiftVersions retrieves the open shift versions the service can install*/
+	ListSupportedOpenshiftVersions(ctx context.Context, params *ListSupportedOpenshiftVersionsParams) (*ListSupportedOpenshiftVersionsOK, error)
 }
 
 // New creates a new versions API client.
@@ -63,3 +66,27 @@ func (a *Client) ListComponentVersions(ctx context.Context, params *ListComponen
 	return result.(*ListComponentVersionsOK), nil
 
 }
+
+/*
+ListSupportedOpenshiftVersions retrieves the open shift versions the service can install
+*/
+func (a *Client) ListSupportedOpenshiftVersions(ctx context.Context, params *ListSupportedOpenshiftVersionsParams) (*ListSupportedOpenshiftVersionsOK, error) {
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListSupportedOpenshiftVersions",
+		Method:             "GET",
+		PathPattern:        "/openshift_versions",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ListSupportedOpenshiftVersionsReader{formats: a.formats},
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ListSupportedOpenshiftVersionsOK), nil
+
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/go-openapi/strfmt"
@@ -15,10 +19,12 @@ import (
 	"github.com/openshift/assisted-service/internal/cluster"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/connectivity"
+	"github.com/openshift/assisted-service/internal/dns"
 	"github.com/openshift/assisted-service/internal/domains"
 	"github.com/openshift/assisted-service/internal/events"
 	"github.com/openshift/assisted-service/internal/hardware"
 	"github.com/openshift/assisted-service/internal/host"
+	"github.com/openshift/assisted-service/internal/imgbuilder"
 	"github.com/openshift/assisted-service/internal/imgexpirer"
 	"github.com/openshift/assisted-service/internal/metrics"
 	"github.com/openshift/assisted-service/internal/versions"
@@ -52,13 +58,17 @@ var Options struct {
 	InstructionConfig           host.InstructionConfig
 	ClusterStateMonitorInterval time.Duration `envconfig:"CLUSTER_MONITOR_INTERVAL" default:"10s"`
 	S3Config                    s3wrapper.Config
+	StorageConfig               awsS3Client.Config
 	HostStateMonitorInterval    time.Duration `envconfig:"HOST_MONITOR_INTERVAL" default:"8s"`
 	Versions                    versions.Versions
 	UseK8s                      bool          `envconfig:"USE_K8S" default:"true"` // TODO remove when jobs running deprecated
 	CreateS3Bucket              bool          `envconfig:"CREATE_S3_BUCKET" default:"false"`
 	ImageExpirationInterval     time.Duration `envconfig:"IMAGE_EXPIRATION_INTERVAL" default:"30m"`
 	ImageExpirationTime         time.Duration `envconfig:"IMAGE_EXPIRATION_TIME" default:"60m"`
+	DNSReconcileInterval        time.Duration `envconfig:"DNS_RECONCILE_INTERVAL" default:"10m"`
+	ShutdownTimeout             time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"20s"`
 	ClusterConfig               cluster.Config
+	AuthConfig                  auth.Config
 }
 
 func main() {
@@ -69,6 +79,11 @@ func main() {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+	// The k8s jobs that generate the images and the kubeconfig files can only upload them to s3
+	if Options.StorageConfig.Storage == awsS3Client.StorageFilesystem &&
+		(Options.UseK8s || Options.BMConfig.ImageBuilderConfig.Backend == imgbuilder.BackendJob) {
+		log.Fatal("The filesystem storage can't be used with the k8s jobs, set USE_K8S to false and IMAGE_BUILDER_BACKEND to iso")
+	}
 
 	port := flag.String("port", "8090", "define port that the service will listen to")
 	flag.Parse()
@@ -78,7 +93,7 @@ func main() {
 	var kclient client.Client
 	if Options.UseK8s {
 
-		if Options.CreateS3Bucket {
+		if Options.CreateS3Bucket && Options.StorageConfig.Storage == awsS3Client.StorageS3 {
 			if err = s3wrapper.CreateBucket(&Options.S3Config); err != nil {
 				log.Fatal(err)
 			}
@@ -111,16 +126,26 @@ func main() {
 	db.DB().SetMaxOpenConns(0)
 	db.DB().SetConnMaxLifetime(0)
 
-	if err = db.AutoMigrate(&models.Host{}, &common.Cluster{}, &events.Event{}).Error; err != nil {
+	if err = db.AutoMigrate(&models.Host{}, &common.Cluster{}, &events.Event{}, &dns.Record{}).Error; err != nil {
 		log.Fatal("failed to auto migrate, ", err)
 	}
 
-	versionHandler := versions.NewHandler(Options.Versions)
+	openshiftVersions, err := versions.LoadOpenshiftVersions(Options.Versions.OpenshiftVersionsFile)
+	if err != nil {
+		log.Fatal("Failed to load OpenShift versions, ", err)
+	}
+	hardwareProfiles, err := hardware.LoadProfiles(Options.HWValidatorConfig.ProfilesFile)
+	if err != nil {
+		log.Fatal("Failed to load hardware profiles, ", err)
+	}
+	Options.HWValidatorConfig.Profiles = hardwareProfiles
+	Options.BMConfig.HardwareProfiles = hardwareProfiles
+	versionHandler := versions.NewHandler(Options.Versions, openshiftVersions)
 	domainHandler := domains.NewHandler(Options.BMConfig.BaseDNSDomains)
 	eventsHandler := events.New(db, log.WithField("pkg", "events"))
 	hwValidator := hardware.NewValidator(log.WithField("pkg", "validators"), Options.HWValidatorConfig)
 	connectivityValidator := connectivity.NewValidator(log.WithField("pkg", "validators"))
-	instructionApi := host.NewInstructionManager(log.WithField("pkg", "instructions"), db, hwValidator, Options.InstructionConfig, connectivityValidator)
+	instructionApi := host.NewInstructionManager(log.WithField("pkg", "instructions"), db, hwValidator, Options.InstructionConfig, connectivityValidator, versionHandler)
 	prometheusRegistry := prometheus.DefaultRegisterer
 	metricsManager := metrics.NewMetricsManager(prometheusRegistry)
 	hostApi := host.NewManager(log.WithField("pkg", "host-state"), db, eventsHandler, hwValidator, instructionApi, &Options.HWValidatorConfig, metricsManager)
@@ -137,23 +162,39 @@ func main() {
 	hostStateMonitor.Start()
 	defer hostStateMonitor.Stop()
 
-	s3Client, err := awsS3Client.NewS3Client(Options.BMConfig.S3EndpointURL, Options.BMConfig.AwsAccessKeyID, Options.BMConfig.AwsSecretAccessKey, log)
+	dnsManager := dns.NewManager(log.WithField("pkg", "dns-reconciler"), db, eventsHandler,
+		Options.BMConfig.BaseDNSDomains, Options.BMConfig.DNSConfig)
+	dnsReconciler := thread.New(
+		log.WithField("pkg", "dns-reconciler"), "DNS Reconciler", Options.DNSReconcileInterval, dnsManager.ReconcileTask)
+	dnsReconciler.Start()
+	defer dnsReconciler.Stop()
+
+	var s3Client awsS3Client.S3Client
+	switch Options.StorageConfig.Storage {
+	case awsS3Client.StorageS3:
+		s3Client, err = awsS3Client.NewS3Client(Options.BMConfig.S3EndpointURL, Options.BMConfig.AwsAccessKeyID, Options.BMConfig.AwsSecretAccessKey, log)
+	case awsS3Client.StorageFilesystem:
+		s3Client, err = awsS3Client.NewFSClient(Options.StorageConfig.FSBaseDir, log.WithField("pkg", "fs-storage"))
+	default:
+		err = fmt.Errorf("unsupported storage %s", Options.StorageConfig.Storage)
+	}
 	if err != nil {
-		log.Fatal("Failed to setup S3 client", err)
+		log.Fatal("Failed to setup storage client", err)
 	}
 
 	jobApi := job.New(log.WithField("pkg", "k8s-job-wrapper"), kclient, Options.JobConfig)
 
-	bm := bminventory.NewBareMetalInventory(db, log.WithField("pkg", "Inventory"), hostApi, clusterApi, Options.BMConfig, jobApi, eventsHandler, s3Client, metricsManager)
+	urlSigner, err := auth.NewURLSigner(log.WithField("pkg", "auth"), Options.AuthConfig)
+	if err != nil {
+		log.Fatal("Failed to create URL signer, ", err)
+	}
+
+	bm := bminventory.NewBareMetalInventory(db, log.WithField("pkg", "Inventory"), hostApi, clusterApi, Options.BMConfig, jobApi, eventsHandler, s3Client, metricsManager, versionHandler, urlSigner)
 
-	events := events.NewApi(eventsHandler, logrus.WithField("pkg", "eventsApi"))
+	events := events.NewApi(eventsHandler, db, logrus.WithField("pkg", "eventsApi"))
 
-	if Options.UseK8s {
-		s3WrapperClient, s3Err := s3wrapper.NewS3Client(&Options.S3Config)
-		if s3Err != nil {
-			log.Fatal("failed to create S3 client, ", err)
-		}
-		expirer := imgexpirer.NewManager(log, s3WrapperClient, Options.S3Config.S3Bucket, Options.ImageExpirationTime, eventsHandler)
+	if Options.UseK8s || Options.StorageConfig.Storage == awsS3Client.StorageFilesystem {
+		expirer := imgexpirer.NewManager(log, s3Client, Options.BMConfig.S3Bucket, Options.ImageExpirationTime, eventsHandler)
 		imageExpirationMonitor := thread.New(
 			log.WithField("pkg", "image-expiration-monitor"), "Image Expiration Monitor", Options.ImageExpirationInterval, expirer.ExpirationTask)
 		imageExpirationMonitor.Start()
@@ -162,22 +203,52 @@ func main() {
 		log.Info("Disabled image expiration monitor")
 	}
 
+	authenticator, err := auth.NewAuthenticator(log.WithField("pkg", "auth"), Options.AuthConfig)
+	if err != nil {
+		log.Fatal("Failed to create authenticator, ", err)
+	}
+	authClusters := auth.NewClusterStore(db)
+	agentAuthenticator := auth.NewAgentAuthenticator(log.WithField("pkg", "auth"), Options.AuthConfig, authClusters)
+	presignedAuthenticator := auth.NewPresignedAuthenticator(log.WithField("pkg", "auth"), urlSigner, authClusters)
+	authMiddleware := auth.Middleware(log.WithField("pkg", "auth"), authenticator, agentAuthenticator, presignedAuthenticator)
+	authzMiddleware := auth.AuthzMiddleware(log.WithField("pkg", "auth"))
+	metricsMiddleware := metrics.WithMatchedRoute(log.WithField("pkg", "matched-h"), prometheusRegistry)
+
 	h, err := restapi.Handler(restapi.Config{
 		InstallerAPI:      bm,
 		EventsAPI:         events,
 		Logger:            log.Printf,
 		VersionsAPI:       versionHandler,
 		ManagedDomainsAPI: domainHandler,
-		InnerMiddleware:   metrics.WithMatchedRoute(log.WithField("pkg", "matched-h"), prometheusRegistry),
+		InnerMiddleware: func(next http.Handler) http.Handler {
+			return metricsMiddleware(authMiddleware(authzMiddleware(next)))
+		},
 	})
 	h = app.WithMetricsResponderMiddleware(h)
 	h = app.WithHealthMiddleware(h)
-	// TODO: replace this with real auth
-	h = auth.GetUserInfoMiddleware(h)
 	h = requestid.Middleware(h)
 	if err != nil {
 		log.Fatal("Failed to init rest handler,", err)
 	}
 
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", swag.StringValue(port)), h))
+	server := &http.Server{Addr: fmt.Sprintf(":%s", swag.StringValue(port)), Handler: h}
+	go func() {
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	/* On termination the service stops accepting requests, lets the requests in progress complete and then
+	stops the image generations in progress, so they are recorded as failed rather than left building.
+	*/
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
+	<-stop
+	log.Info("Shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), Options.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.WithError(err).Warn("Failed to wait for the requests in progress")
+	}
+	bm.StopImageBuilds()
 }
@@ -6,11 +6,11 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,7 +18,7 @@ import (
 	"text/template"
 	"time"
 
-	"github.com/danielerez/go-dns-client/pkg/dnsproviders"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -27,12 +27,18 @@ import (
 	"github.com/openshift/assisted-service/internal/cluster"
 	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/connectivity"
+	"github.com/openshift/assisted-service/internal/dns"
 	"github.com/openshift/assisted-service/internal/events"
+	"github.com/openshift/assisted-service/internal/hardware"
 	"github.com/openshift/assisted-service/internal/host"
 	"github.com/openshift/assisted-service/internal/identity"
+	"github.com/openshift/assisted-service/internal/imgbuilder"
 	"github.com/openshift/assisted-service/internal/installcfg"
 	"github.com/openshift/assisted-service/internal/metrics"
+	"github.com/openshift/assisted-service/internal/mirrorregistries"
 	"github.com/openshift/assisted-service/internal/network"
+	"github.com/openshift/assisted-service/internal/versions"
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/assisted-service/pkg/auth"
 	"github.com/openshift/assisted-service/pkg/filemiddleware"
@@ -56,6 +62,9 @@ import (
 const kubeconfigPrefix = "generate-kubeconfig"
 const kubeconfig = "kubeconfig"
 
+// maxOpenRangeAttempts bounds how many times a range request is evaluated again when the object keeps changing
+const maxOpenRangeAttempts = 2
+
 const (
 	ResourceKindHost    = "Host"
 	ResourceKindCluster = "Cluster"
@@ -71,13 +80,11 @@ var (
 )
 
 type Config struct {
-	ImageBuilder        string `envconfig:"IMAGE_BUILDER" default:"quay.io/ocpmetal/installer-image-build:latest"`
-	AgentDockerImg      string `envconfig:"AGENT_DOCKER_IMAGE" default:"quay.io/ocpmetal/agent:latest"`
-	KubeconfigGenerator string `envconfig:"KUBECONFIG_GENERATE_IMAGE" default:"quay.io/ocpmetal/ignition-manifests-and-kubeconfig-generate:latest"` // TODO: update the latest once the repository has git workflow
-	//[TODO] -  change the default of Releae image to "", once everyine wll update their environment
-	ReleaseImage       string            `envconfig:"OPENSHIFT_INSTALL_RELEASE_IMAGE" default:"quay.io/openshift-release-dev/ocp-release@sha256:eab93b4591699a5a4ff50ad3517892653f04fb840127895bb3609b3cc68f98f3"`
+	ImageBuilder       string            `envconfig:"IMAGE_BUILDER" default:"quay.io/ocpmetal/installer-image-build:latest"`
+	AgentDockerImg     string            `envconfig:"AGENT_DOCKER_IMAGE" default:"quay.io/ocpmetal/agent:latest"`
 	ServiceURL         string            `envconfig:"SERVICE_URL"`
 	ServicePort        string            `envconfig:"SERVICE_PORT"`
+	ServiceScheme      string            `envconfig:"SERVICE_SCHEME" default:"http"` // Scheme of the image download URLs
 	S3EndpointURL      string            `envconfig:"S3_ENDPOINT_URL" default:"http://10.35.59.36:30925"`
 	S3Bucket           string            `envconfig:"S3_BUCKET" default:"test"`
 	AwsAccessKeyID     string            `envconfig:"AWS_ACCESS_KEY_ID" default:"accessKey1"`
@@ -89,6 +96,11 @@ type Config struct {
 	JobMemoryLimit     string            `envconfig:"JOB_MEMORY_LIMIT" default:"1000Mi"`
 	JobCPURequests     string            `envconfig:"JOB_CPU_REQUESTS" default:"300m"`
 	JobMemoryRequests  string            `envconfig:"JOB_MEMORY_REQUESTS" default:"400Mi"`
+	ImageURLExpiration time.Duration     `envconfig:"IMAGE_URL_EXPIRATION" default:"4h"`
+	ImageBuildTimeout  time.Duration     `envconfig:"IMAGE_BUILD_TIMEOUT" default:"30m"`
+	DNSConfig          dns.Config
+	ImageBuilderConfig imgbuilder.Config
+	HardwareProfiles   *hardware.Profiles `ignored:"true"` // Loaded from the hardware validator profiles file
 }
 
 const agentMessageOfTheDay = `
@@ -111,7 +123,7 @@ const ignitionConfigFormat = `{
 "units": [{
 "name": "agent.service",
 "enabled": true,
-"contents": "[Service]\nType=simple\nRestart=always\nRestartSec=3\nStartLimitIntervalSec=0\nEnvironment=HTTPS_PROXY={{.ProxyURL}}\nEnvironment=HTTP_PROXY={{.ProxyURL}}\nEnvironment=http_proxy={{.ProxyURL}}\nEnvironment=https_proxy={{.ProxyURL}}\nEnvironment=PULL_SECRET_TOKEN={{.PullSecretToken}}\nExecStartPre=podman run --privileged --rm -v /usr/local/bin:/hostbin {{.AgentDockerImg}} cp /usr/bin/agent /hostbin\nExecStart=/usr/local/bin/agent --host {{.ServiceURL}} --port {{.ServicePort}} --cluster-id {{.clusterId}} --agent-version {{.AgentDockerImg}}\n\n[Install]\nWantedBy=multi-user.target"
+"contents": "[Service]\nType=simple\nRestart=always\nRestartSec=3\nStartLimitIntervalSec=0\nEnvironment=HTTPS_PROXY={{.HTTPSProxy}}\nEnvironment=HTTP_PROXY={{.HTTPProxy}}\nEnvironment=http_proxy={{.HTTPProxy}}\nEnvironment=https_proxy={{.HTTPSProxy}}\nEnvironment=NO_PROXY={{.NoProxy}}\nEnvironment=no_proxy={{.NoProxy}}\nEnvironment=PULL_SECRET_TOKEN={{.PullSecretToken}}\nEnvironment=AGENT_TOKEN={{.AgentToken}}\n{{if .TrustBundleFile}}ExecStartPre=update-ca-trust extract\n{{end}}ExecStartPre=podman run --privileged --rm -v /usr/local/bin:/hostbin {{.AgentImage}} cp /usr/bin/agent /hostbin\nExecStart=/usr/local/bin/agent --host {{.ServiceURL}} --port {{.ServicePort}} --cluster-id {{.clusterId}} --agent-version {{.AgentDockerImg}}\n\n[Install]\nWantedBy=multi-user.target"
 }]
 },
 "storage": {
@@ -120,7 +132,7 @@ const ignitionConfigFormat = `{
       "path": "/etc/motd",
       "mode": 644,
       "contents": { "source": "data:,{{.AGENT_MOTD}}" }
-    }]
+    }{{.TrustBundleFile}}{{.MirrorRegistriesFiles}}{{.StaticNetworkFiles}}]
   }
 }`
 
@@ -152,6 +164,13 @@ type bareMetalInventory struct {
 	eventsHandler events.Handler
 	s3Client      awsS3CLient.S3Client
 	metricApi     metrics.API
+	dnsApi        *dns.Manager
+	imageBuilder  imgbuilder.ImageBuilder
+	imageBuilds   sync.WaitGroup
+	buildsCtx     context.Context
+	stopBuilds    context.CancelFunc
+	versions      versions.Handler
+	urlSigner     *auth.URLSigner
 }
 
 var _ restapi.InstallerAPI = &bareMetalInventory{}
@@ -166,6 +185,8 @@ func NewBareMetalInventory(
 	eventsHandler events.Handler,
 	s3Client awsS3CLient.S3Client,
 	metricApi metrics.API,
+	versionsHandler versions.Handler,
+	urlSigner *auth.URLSigner,
 ) *bareMetalInventory {
 
 	b := &bareMetalInventory{
@@ -179,28 +200,53 @@ func NewBareMetalInventory(
 		eventsHandler: eventsHandler,
 		s3Client:      s3Client,
 		metricApi:     metricApi,
+		dnsApi:        dns.NewManager(log, db, eventsHandler, cfg.BaseDNSDomains, cfg.DNSConfig),
+		versions:      versionsHandler,
+		urlSigner:     urlSigner,
 	}
-
-	if b.Config.UseK8s {
-		//Run first ISO dummy for image pull, this is done so that the image will be pulled and the api will take less time.
-		b.generateDummyISOImage()
-	}
+	b.buildsCtx, b.stopBuilds = context.WithCancel(context.Background())
+	b.imageBuilder = b.newImageBuilder()
 	return b
 }
 
-func (b *bareMetalInventory) generateDummyISOImage() {
-	var (
-		dummyId   = "00000000-0000-0000-0000-000000000000"
-		jobName   = fmt.Sprintf("dummyimage-%s-%s", dummyId, time.Now().Format("20060102150405"))
-		imgName   = fmt.Sprintf("discovery-image-%s", dummyId)
-		requestID = requestid.NewID()
-		log       = requestid.RequestIDLogger(b.log, requestID)
-	)
-	// create dummy job without uploading to s3, we just need to pull the image
-	if err := b.job.Create(requestid.ToContext(context.Background(), requestID),
-		b.createImageJob(jobName, imgName, "Dummy", false)); err != nil {
-		log.WithError(err).Errorf("failed to generate dummy ISO image")
+// StopImageBuilds cancels the image generations in progress and waits until they record their failure, so no
+// image is left in the building state when the service stops
+func (b *bareMetalInventory) StopImageBuilds() {
+	b.stopBuilds()
+	b.imageBuilds.Wait()
+}
+
+// newImageBuilder creates the image builder of the configured backend, the k8s job one is
+// the default when running with k8s
+func (b *bareMetalInventory) newImageBuilder() imgbuilder.ImageBuilder {
+	backend := b.ImageBuilderConfig.Backend
+	if backend == "" {
+		backend = imgbuilder.BackendISO
+		if b.UseK8s {
+			backend = imgbuilder.BackendJob
+		}
+	}
+	if backend == imgbuilder.BackendISO {
+		b.log.Infof("Generating images from base ISO %s", b.ImageBuilderConfig.BaseISOPath)
+		return imgbuilder.NewISOBuilder(b.log.WithField("pkg", "iso-builder"), b.s3Client, b.S3Bucket, b.ImageBuilderConfig.BaseISOPath)
+	}
+	jobBuilder := imgbuilder.NewJobBuilder(b.log.WithField("pkg", "job-builder"), b.job, imgbuilder.JobConfig{
+		Image:              b.ImageBuilder,
+		Namespace:          b.Namespace,
+		S3EndpointURL:      b.S3EndpointURL,
+		S3Bucket:           b.S3Bucket,
+		AwsAccessKeyID:     b.AwsAccessKeyID,
+		AwsSecretAccessKey: b.AwsSecretAccessKey,
+		CPULimit:           b.JobCPULimit,
+		MemoryLimit:        b.JobMemoryLimit,
+		CPURequests:        b.JobCPURequests,
+		MemoryRequests:     b.JobMemoryRequests,
+	})
+	if b.UseK8s {
+		//Run first ISO dummy for image pull, this is done so that the image will be pulled and the api will take less time.
+		jobBuilder.GenerateDummyImage()
 	}
+	return jobBuilder
 }
 
 func getQuantity(s string) resource.Quantity {
@@ -208,81 +254,7 @@ func getQuantity(s string) resource.Quantity {
 	return reply
 }
 
-// create discovery image generation job, return job name and error
-func (b *bareMetalInventory) createImageJob(jobName, imgName, ignitionConfig string, performUpload bool) *batch.Job {
-	var command []string
-	if !performUpload {
-		command = []string{"echo", "pass"}
-	}
-	return &batch.Job{
-		TypeMeta: meta.TypeMeta{
-			Kind:       "Job",
-			APIVersion: "batch/v1",
-		},
-		ObjectMeta: meta.ObjectMeta{
-			Name:      jobName,
-			Namespace: b.Namespace,
-		},
-		Spec: batch.JobSpec{
-			BackoffLimit: swag.Int32(2),
-			Template: core.PodTemplateSpec{
-				ObjectMeta: meta.ObjectMeta{
-					Name:      jobName,
-					Namespace: b.Namespace,
-				},
-				Spec: core.PodSpec{
-					Containers: []core.Container{
-						{
-							Resources: core.ResourceRequirements{
-								Limits: core.ResourceList{
-									"cpu":    getQuantity(b.JobCPULimit),
-									"memory": getQuantity(b.JobMemoryLimit),
-								},
-								Requests: core.ResourceList{
-									"cpu":    getQuantity(b.JobCPURequests),
-									"memory": getQuantity(b.JobMemoryRequests),
-								},
-							},
-							Command:         command,
-							Name:            "image-creator",
-							Image:           b.Config.ImageBuilder,
-							ImagePullPolicy: "IfNotPresent",
-							Env: []core.EnvVar{
-								{
-									Name:  "S3_ENDPOINT_URL",
-									Value: b.S3EndpointURL,
-								},
-								{
-									Name:  "IGNITION_CONFIG",
-									Value: ignitionConfig,
-								},
-								{
-									Name:  "IMAGE_NAME",
-									Value: imgName,
-								},
-								{
-									Name:  "S3_BUCKET",
-									Value: b.S3Bucket,
-								},
-								{
-									Name:  "aws_access_key_id",
-									Value: b.AwsAccessKeyID,
-								},
-								{
-									Name:  "aws_secret_access_key",
-									Value: b.AwsSecretAccessKey,
-								},
-							},
-						},
-					},
-					RestartPolicy: "Never",
-				},
-			},
-		},
-	}
-}
-
-func (b *bareMetalInventory) formatIgnitionFile(cluster *common.Cluster, params installer.GenerateClusterISOParams) (string, error) {
+func (b *bareMetalInventory) formatIgnitionFile(cluster *common.Cluster, params installer.GenerateClusterISOParams, agentToken string) (string, error) {
 	creds, err := validations.ParsePullSecret(cluster.PullSecret)
 	if err != nil {
 		return "", err
@@ -292,15 +264,40 @@ func (b *bareMetalInventory) formatIgnitionFile(cluster *common.Cluster, params
 		return "", fmt.Errorf("Pull secret does not contain auth for cloud.openshift.com")
 	}
 
+	staticNetworkFiles, err := formatStaticNetworkFiles(params.ImageCreateParams.StaticNetworkConfig)
+	if err != nil {
+		return "", err
+	}
+	trustBundleFile, err := formatTrustBundleFile(cluster.AdditionalTrustBundle)
+	if err != nil {
+		return "", err
+	}
+	mirrorRegistries, err := mirrorregistries.ParseConfig(cluster.MirrorRegistriesConfig)
+	if err != nil {
+		return "", err
+	}
+	mirrorRegistriesFiles, err := formatMirrorRegistriesFiles(mirrorRegistries)
+	if err != nil {
+		return "", err
+	}
+	proxy := b.getAgentProxy(cluster, params.ImageCreateParams.ProxyURL)
+
 	var ignitionParams = map[string]string{
-		"userSshKey":      b.getUserSshKey(params),
-		"AgentDockerImg":  b.AgentDockerImg,
-		"ServiceURL":      strings.TrimSpace(b.ServiceURL),
-		"ServicePort":     strings.TrimSpace(b.ServicePort),
-		"clusterId":       cluster.ID.String(),
-		"ProxyURL":        params.ImageCreateParams.ProxyURL,
-		"PullSecretToken": r.AuthRaw,
-		"AGENT_MOTD":      url.PathEscape(agentMessageOfTheDay),
+		"userSshKey":            b.getUserSshKey(params),
+		"AgentDockerImg":        b.AgentDockerImg,
+		"AgentImage":            mirrorregistries.RewriteImage(b.AgentDockerImg, mirrorRegistries),
+		"ServiceURL":            strings.TrimSpace(b.ServiceURL),
+		"ServicePort":           strings.TrimSpace(b.ServicePort),
+		"clusterId":             cluster.ID.String(),
+		"HTTPProxy":             proxy.HTTPProxy,
+		"HTTPSProxy":            proxy.HTTPSProxy,
+		"NoProxy":               proxy.NoProxy,
+		"PullSecretToken":       r.AuthRaw,
+		"AgentToken":            agentToken,
+		"AGENT_MOTD":            url.PathEscape(agentMessageOfTheDay),
+		"StaticNetworkFiles":    staticNetworkFiles,
+		"TrustBundleFile":       trustBundleFile,
+		"MirrorRegistriesFiles": mirrorRegistriesFiles,
 	}
 	tmpl, err := template.New("ignitionConfig").Parse(ignitionConfigFormat)
 	if err != nil {
@@ -313,6 +310,113 @@ func (b *bareMetalInventory) formatIgnitionFile(cluster *common.Cluster, params
 	return buf.String(), nil
 }
 
+// agentProxy is the proxy environment of the agent service
+type agentProxy struct {
+	HTTPProxy  string `json:"http_proxy"`
+	HTTPSProxy string `json:"https_proxy"`
+	NoProxy    string `json:"no_proxy"`
+}
+
+/*
+The agents use the proxy of the cluster, without the machine network and the service. Clusters without
+a proxy fall back to the proxy URL of the image, that the agents use to access the service as well.
+*/
+func (b *bareMetalInventory) getAgentProxy(cluster *common.Cluster, proxyURL string) agentProxy {
+	if cluster.HTTPProxy == "" && cluster.HTTPSProxy == "" {
+		return agentProxy{HTTPProxy: proxyURL, HTTPSProxy: proxyURL}
+	}
+	if cluster.NoProxy == "*" {
+		return agentProxy{HTTPProxy: cluster.HTTPProxy, HTTPSProxy: cluster.HTTPSProxy, NoProxy: cluster.NoProxy}
+	}
+	var noProxy []string
+	for _, entry := range append(strings.Split(cluster.NoProxy, ","),
+		cluster.MachineNetworkCidr, cluster.SecondaryMachineNetworkCidr, strings.TrimSpace(b.ServiceURL)) {
+		if entry != "" && !funk.ContainsString(noProxy, entry) {
+			noProxy = append(noProxy, entry)
+		}
+	}
+	return agentProxy{HTTPProxy: cluster.HTTPProxy, HTTPSProxy: cluster.HTTPSProxy, NoProxy: strings.Join(noProxy, ",")}
+}
+
+// formatAgentProxy returns the JSON of the agent proxy of the cluster, or an empty string if the cluster has no proxy
+func (b *bareMetalInventory) formatAgentProxy(cluster *common.Cluster) (string, error) {
+	if cluster.HTTPProxy == "" && cluster.HTTPSProxy == "" {
+		return "", nil
+	}
+	proxy, err := json.Marshal(b.getAgentProxy(cluster, ""))
+	if err != nil {
+		return "", err
+	}
+	return string(proxy), nil
+}
+
+// formatIgnitionStorageFile returns the ignition storage file with the contents, preceded by a comma
+func formatIgnitionStorageFile(path string, mode int, contents string) (string, error) {
+	file, err := json.Marshal(map[string]interface{}{
+		"filesystem": "root",
+		"path":       path,
+		"mode":       mode,
+		"contents":   map[string]string{"source": "data:," + url.PathEscape(contents)},
+	})
+	if err != nil {
+		return "", err
+	}
+	return "," + string(file), nil
+}
+
+// formatStaticNetworkFiles returns the ignition storage files of the NetworkManager keyfiles of the static
+// network configurations, each one preceded by a comma
+func formatStaticNetworkFiles(configs []*models.HostStaticNetworkConfig) (string, error) {
+	var files strings.Builder
+	for _, keyfile := range network.GenerateStaticNetworkKeyfiles(configs) {
+		// NetworkManager ignores keyfiles that other users can read
+		file, err := formatIgnitionStorageFile("/etc/NetworkManager/system-connections/"+keyfile.Name, 0600, keyfile.Contents)
+		if err != nil {
+			return "", err
+		}
+		files.WriteString(file)
+	}
+	return files.String(), nil
+}
+
+/*
+formatMirrorRegistriesFiles returns the ignition storage files of the mirror registries, each one preceded by a comma.
+The registries.conf of the hosts pulls the images from the mirrors, that are trusted with the CA certificate.
+*/
+func formatMirrorRegistriesFiles(config *models.MirrorRegistriesConfig) (string, error) {
+	if config == nil {
+		return "", nil
+	}
+	var files strings.Builder
+	file, err := formatIgnitionStorageFile("/etc/containers/registries.conf", 0644, mirrorregistries.GenerateRegistriesConf(config))
+	if err != nil {
+		return "", err
+	}
+	files.WriteString(file)
+	if config.CaCertificate != "" {
+		for _, registry := range mirrorregistries.GetMirrorRegistries(config) {
+			file, err = formatIgnitionStorageFile(fmt.Sprintf("/etc/containers/certs.d/%s/ca.crt", registry), 0644, config.CaCertificate)
+			if err != nil {
+				return "", err
+			}
+			files.WriteString(file)
+		}
+	}
+	return files.String(), nil
+}
+
+/*
+formatTrustBundleFile returns the ignition storage file of the additional trust bundle of the cluster, preceded by
+a comma, or an empty string if the cluster has no additional trust bundle. The agent service extracts the anchors
+into the trust store of the system before it pulls the agent image.
+*/
+func formatTrustBundleFile(trustBundle string) (string, error) {
+	if trustBundle == "" {
+		return "", nil
+	}
+	return formatIgnitionStorageFile("/etc/pki/ca-trust/source/anchors/assisted-installer-trust-bundle.crt", 0644, trustBundle)
+}
+
 func (b *bareMetalInventory) getUserSshKey(params installer.GenerateClusterISOParams) string {
 	sshKey := params.ImageCreateParams.SSHPublicKey
 	if sshKey == "" {
@@ -341,22 +445,38 @@ func (b *bareMetalInventory) RegisterCluster(ctx context.Context, params install
 	if params.NewClusterParams.ServiceNetworkCidr == nil {
 		params.NewClusterParams.ServiceNetworkCidr = &DefaultServiceNetworkCidr
 	}
+	if params.NewClusterParams.Platform == nil {
+		params.NewClusterParams.Platform = swag.String(models.ClusterPlatformBaremetal)
+	}
+	if params.NewClusterParams.NetworkType == nil {
+		params.NewClusterParams.NetworkType = swag.String(models.ClusterNetworkTypeOpenShiftSDN)
+	}
 
 	cluster := common.Cluster{Cluster: models.Cluster{
-		ID:                       &id,
-		Href:                     swag.String(url.String()),
-		Kind:                     swag.String(ResourceKindCluster),
-		BaseDNSDomain:            params.NewClusterParams.BaseDNSDomain,
-		ClusterNetworkCidr:       swag.StringValue(params.NewClusterParams.ClusterNetworkCidr),
-		ClusterNetworkHostPrefix: params.NewClusterParams.ClusterNetworkHostPrefix,
-		IngressVip:               params.NewClusterParams.IngressVip,
-		Name:                     swag.StringValue(params.NewClusterParams.Name),
-		OpenshiftVersion:         swag.StringValue(params.NewClusterParams.OpenshiftVersion),
-		ServiceNetworkCidr:       swag.StringValue(params.NewClusterParams.ServiceNetworkCidr),
-		SSHPublicKey:             params.NewClusterParams.SSHPublicKey,
-		UpdatedAt:                strfmt.DateTime{},
-		UserID:                   auth.UserIDFromContext(ctx),
-		OrgID:                    auth.OrgIDFromContext(ctx),
+		ID:                                &id,
+		Href:                              swag.String(url.String()),
+		Kind:                              swag.String(ResourceKindCluster),
+		BaseDNSDomain:                     params.NewClusterParams.BaseDNSDomain,
+		ClusterNetworkCidr:                swag.StringValue(params.NewClusterParams.ClusterNetworkCidr),
+		ClusterNetworkHostPrefix:          params.NewClusterParams.ClusterNetworkHostPrefix,
+		IngressVip:                        params.NewClusterParams.IngressVip,
+		Name:                              swag.StringValue(params.NewClusterParams.Name),
+		NetworkType:                       swag.StringValue(params.NewClusterParams.NetworkType),
+		OpenshiftVersion:                  swag.StringValue(params.NewClusterParams.OpenshiftVersion),
+		HardwareProfile:                   params.NewClusterParams.HardwareProfile,
+		Platform:                          swag.StringValue(params.NewClusterParams.Platform),
+		SecondaryClusterNetworkCidr:       swag.StringValue(params.NewClusterParams.SecondaryClusterNetworkCidr),
+		SecondaryClusterNetworkHostPrefix: swag.Int64Value(params.NewClusterParams.SecondaryClusterNetworkHostPrefix),
+		SecondaryServiceNetworkCidr:       swag.StringValue(params.NewClusterParams.SecondaryServiceNetworkCidr),
+		ServiceNetworkCidr:                swag.StringValue(params.NewClusterParams.ServiceNetworkCidr),
+		HTTPProxy:                         params.NewClusterParams.HTTPProxy,
+		HTTPSProxy:                        params.NewClusterParams.HTTPSProxy,
+		NoProxy:                           params.NewClusterParams.NoProxy,
+		AdditionalTrustBundle:             params.NewClusterParams.AdditionalTrustBundle,
+		SSHPublicKey:                      params.NewClusterParams.SSHPublicKey,
+		UpdatedAt:                         strfmt.DateTime{},
+		UserID:                            auth.UserIDFromContext(ctx),
+		OrgID:                             auth.OrgIDFromContext(ctx),
 	}}
 	if params.NewClusterParams.PullSecret != "" {
 		err := validations.ValidatePullSecret(params.NewClusterParams.PullSecret)
@@ -370,8 +490,41 @@ func (b *bareMetalInventory) RegisterCluster(ctx context.Context, params install
 	if err := validations.ValidateClusterNameFormat(swag.StringValue(params.NewClusterParams.Name)); err != nil {
 		return common.NewApiError(http.StatusBadRequest, err)
 	}
+	if err := validateProxySettings(&params.NewClusterParams.HTTPProxy, &params.NewClusterParams.HTTPSProxy,
+		&params.NewClusterParams.NoProxy); err != nil {
+		log.WithError(err).Errorf("Invalid proxy settings for new cluster %s", swag.StringValue(params.NewClusterParams.Name))
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
+	if params.NewClusterParams.AdditionalTrustBundle != "" {
+		if err := installcfg.ValidateTrustBundle(params.NewClusterParams.AdditionalTrustBundle); err != nil {
+			log.WithError(err).Errorf("Invalid additional trust bundle for new cluster %s", swag.StringValue(params.NewClusterParams.Name))
+			return common.NewApiError(http.StatusBadRequest, err)
+		}
+	}
+	if err := validateMirrorRegistries(params.NewClusterParams.MirrorRegistries); err != nil {
+		log.WithError(err).Errorf("Invalid mirror registries for new cluster %s", swag.StringValue(params.NewClusterParams.Name))
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
+	mirrorRegistriesConfig, err := mirrorregistries.FormatConfig(params.NewClusterParams.MirrorRegistries)
+	if err != nil {
+		log.WithError(err).Errorf("failed to format the mirror registries of new cluster %s", swag.StringValue(params.NewClusterParams.Name))
+		return common.NewApiError(http.StatusInternalServerError, err)
+	}
+	cluster.MirrorRegistriesConfig = mirrorRegistriesConfig
+	if _, err := b.versions.GetOpenshiftVersion(cluster.OpenshiftVersion); err != nil {
+		log.WithError(err).Errorf("Unsupported OpenShift version for new cluster %s", swag.StringValue(params.NewClusterParams.Name))
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
+	if err := b.validateHardwareProfile(cluster.HardwareProfile); err != nil {
+		log.WithError(err).Errorf("Invalid hardware profile for new cluster %s", swag.StringValue(params.NewClusterParams.Name))
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
+	if err := network.VerifyClusterNetworks(&cluster.Cluster); err != nil {
+		log.WithError(err).Errorf("Invalid networks for new cluster %s", swag.StringValue(params.NewClusterParams.Name))
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
 
-	err := b.clusterApi.RegisterCluster(ctx, &cluster)
+	err = b.clusterApi.RegisterCluster(ctx, &cluster)
 	if err != nil {
 		log.Errorf("failed to register cluster %s ", swag.StringValue(params.NewClusterParams.Name))
 		return installer.NewRegisterClusterInternalServerError().
@@ -387,7 +540,7 @@ func (b *bareMetalInventory) DeregisterCluster(ctx context.Context, params insta
 	var cluster common.Cluster
 	log.Infof("Deregister cluster id %s", params.ClusterID)
 
-	if err := b.db.First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, b.db).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		return installer.NewDeregisterClusterNotFound().
 			WithPayload(common.GenerateError(http.StatusNotFound, err))
 	}
@@ -403,21 +556,30 @@ func (b *bareMetalInventory) DeregisterCluster(ctx context.Context, params insta
 			WithPayload(common.GenerateError(http.StatusNotFound, err))
 	}
 
+	/* The image URLs are served by the service and signed with the creation time of the image, once the cluster
+	and its image are deleted they no longer download anything, there are no storage backend URLs to revoke.
+	*/
+	if err = b.s3Client.DeleteFileFromS3(ctx, getImageName(params.ClusterID), b.S3Bucket); err != nil {
+		log.WithError(err).Warnf("failed to delete the image of cluster %s", params.ClusterID)
+	}
+
 	return installer.NewDeregisterClusterNoContent()
 }
 
 func (b *bareMetalInventory) DownloadClusterISO(ctx context.Context, params installer.DownloadClusterISOParams) middleware.Responder {
 	log := logutil.FromContext(ctx, b.log)
-	if err := b.db.First(&common.Cluster{}, "id = ?", params.ClusterID).Error; err != nil {
+	var cluster common.Cluster
+	if err := identity.AddUserFilter(ctx, b.db).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to get cluster %s", params.ClusterID)
 		return installer.NewDownloadClusterISONotFound().
 			WithPayload(common.GenerateError(http.StatusNotFound, err))
 	}
+	if err := imageNotReadyError(&cluster); err != nil {
+		return installer.NewDownloadClusterISOConflict().
+			WithPayload(common.GenerateError(http.StatusConflict, err))
+	}
 	imgName := getImageName(params.ClusterID)
-	imageURL := fmt.Sprintf("%s/%s/%s", b.S3EndpointURL, b.S3Bucket, imgName)
-
-	log.Info("Image URL: ", imageURL)
-	resp, err := http.Get(imageURL)
+	exists, err := b.s3Client.DoesObjectExist(ctx, imgName, b.S3Bucket)
 	if err != nil {
 		log.WithError(err).Errorf("Failed to get ISO: %s", imgName)
 		msg := "Failed to download image: error fetching from storage backend"
@@ -425,28 +587,140 @@ func (b *bareMetalInventory) DownloadClusterISO(ctx context.Context, params inst
 		return installer.NewDownloadClusterISOInternalServerError().
 			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
 	}
-	if resp.StatusCode != http.StatusOK {
-		defer resp.Body.Close()
-		body, _ := ioutil.ReadAll(resp.Body)
-		log.WithError(fmt.Errorf("%d - %s", resp.StatusCode, string(body))).
-			Errorf("Failed to get ISO: %s", imgName)
-		if resp.StatusCode == http.StatusNotFound {
-			msg := "Failed to download image: the image was not found (perhaps it expired) - please generate the image and try again"
-			b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
-			return installer.NewDownloadClusterISONotFound().
-				WithPayload(common.GenerateError(http.StatusNotFound, errors.New("The image was not found "+
-					"(perhaps it expired) - please generate the image and try again")))
-		}
-		msg := fmt.Sprintf("Failed to download image: error fetching from storage backend (%d)", resp.StatusCode)
+	if !exists {
+		log.Errorf("Failed to get ISO: %s doesn't exist", imgName)
+		msg := "Failed to download image: the image was not found (perhaps it expired) - please generate the image and try again"
+		b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
+		return installer.NewDownloadClusterISONotFound().
+			WithPayload(common.GenerateError(http.StatusNotFound, errors.New("The image was not found "+
+				"(perhaps it expired) - please generate the image and try again")))
+	}
+
+	fileName := fmt.Sprintf("cluster-%s-discovery.iso", params.ClusterID.String())
+	body, file, rng, err := b.openFileRange(ctx, imgName, fileName, params.Range, params.IfRange)
+	if err == filemiddleware.ErrRangeNotSatisfiable {
+		return filemiddleware.NewRangeNotSatisfiableResponder(installer.NewDownloadClusterISORequestedRangeNotSatisfiable().
+			WithPayload(common.GenerateError(http.StatusRequestedRangeNotSatisfiable, err)), file)
+	}
+	if err != nil {
+		log.WithError(err).Errorf("Failed to get ISO: %s", imgName)
+		msg := "Failed to download image: error fetching from storage backend"
 		b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
 		return installer.NewDownloadClusterISOInternalServerError().
-			WithPayload(common.GenerateError(http.StatusInternalServerError, errors.New(string(body))))
+			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	}
+	// Resumed downloads aren't new downloads of the image
+	if rng == nil || rng.Start == 0 {
+		b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityInfo, "Started image download", time.Now())
+	}
+	if rng != nil {
+		return filemiddleware.NewRangeResponder(installer.NewDownloadClusterISOPartialContent().WithPayload(body), file, rng)
+	}
+	return filemiddleware.NewRangeResponder(installer.NewDownloadClusterISOOK().WithPayload(body), file, nil)
+}
+
+// openFileRange opens the range of the object requested by the Range and If-Range headers,
+// the returned range is nil when the whole object should be served
+func (b *bareMetalInventory) openFileRange(ctx context.Context, objectName, fileName string, rangeHeader,
+	ifRange *string) (io.ReadCloser, filemiddleware.FileInfo, *filemiddleware.Range, error) {
+	/* The range is read only if the object still has the entity tag it was checked against. If the object
+	was replaced in between, the request is evaluated again against the new object, so a client resuming
+	with If-Range gets the whole new object rather than a range of it.
+	*/
+	for attempt := 1; ; attempt++ {
+		info, err := b.s3Client.GetObjectInfo(ctx, objectName, b.S3Bucket)
+		if err != nil {
+			return nil, filemiddleware.FileInfo{}, nil, err
+		}
+		file := filemiddleware.FileInfo{Name: fileName, Size: info.Size, ETag: info.ETag, LastModified: info.LastModified}
+		rng, err := filemiddleware.ParseRange(swag.StringValue(rangeHeader), swag.StringValue(ifRange), file)
+		if err != nil {
+			return nil, file, nil, err
+		}
+		if rng == nil {
+			body, size, err := b.s3Client.DownloadFileFromS3(ctx, objectName, b.S3Bucket)
+			if err != nil {
+				return nil, file, nil, err
+			}
+			file.Size = size
+			return body, file, nil, nil
+		}
+		body, err := b.s3Client.DownloadRange(ctx, objectName, b.S3Bucket, rng.Start, rng.Length, info.ETag)
+		if err == awsS3CLient.ErrObjectChanged && attempt < maxOpenRangeAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, file, nil, err
+		}
+		return body, file, rng, nil
+	}
+}
+
+func (b *bareMetalInventory) GetPresignedForClusterISO(ctx context.Context, params installer.GetPresignedForClusterISOParams) middleware.Responder {
+	log := logutil.FromContext(ctx, b.log)
+	var cluster common.Cluster
+	if err := identity.AddUserFilter(ctx, b.db).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+		log.WithError(err).Errorf("failed to get cluster %s", params.ClusterID)
+		if gorm.IsRecordNotFoundError(err) {
+			return common.NewApiError(http.StatusNotFound, err)
+		}
+		return common.NewApiError(http.StatusInternalServerError, err)
+	}
+	if err := imageNotReadyError(&cluster); err != nil {
+		return common.NewApiError(http.StatusConflict, err)
+	}
+
+	imgName := getImageName(params.ClusterID)
+	exists, err := b.s3Client.DoesObjectExist(ctx, imgName, b.S3Bucket)
+	if err != nil {
+		log.WithError(err).Errorf("failed to get image %s", imgName)
+		return common.NewApiError(http.StatusInternalServerError, err)
 	}
-	b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityInfo, "Started image download", time.Now())
+	if !exists {
+		return common.NewApiError(http.StatusNotFound, errors.New("The image was not found "+
+			"(perhaps it expired) - please generate the image and try again"))
+	}
+
+	/* The URL is served by the service rather than presigned by the storage backend, its signature covers
+	the creation time of the image, so generating a new image revokes the URLs of the previous one.
+	*/
+	expiresAt := time.Now().Add(b.ImageURLExpiration)
+	imageURL, err := b.signedImageURL(&cluster, expiresAt)
+	if err != nil {
+		log.WithError(err).Errorf("failed to create a download URL for image %s", imgName)
+		return common.NewApiError(http.StatusInternalServerError, err)
+	}
+
+	msg := fmt.Sprintf("Created a download URL for the image, valid until %s", expiresAt.UTC().Format(time.RFC3339))
+	b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityInfo, msg, time.Now())
+	expiresAtDateTime := strfmt.DateTime(expiresAt)
+	return installer.NewGetPresignedForClusterISOOK().
+		WithPayload(&models.Presigned{URL: swag.String(imageURL), ExpiresAt: &expiresAtDateTime})
+}
 
-	return filemiddleware.NewResponder(installer.NewDownloadClusterISOOK().WithPayload(resp.Body),
-		fmt.Sprintf("cluster-%s-discovery.iso", params.ClusterID.String()),
-		resp.ContentLength)
+// signedImageURL returns the URL of the service for downloading the cluster image without credentials
+func (b *bareMetalInventory) signedImageURL(cluster *common.Cluster, expiresAt time.Time) (string, error) {
+	imageURL := installer.DownloadClusterISOPresignedURL{
+		ClusterID: *cluster.ID,
+		ExpiresAt: expiresAt.Unix(),
+		Signature: b.urlSigner.Sign(cluster.ID.String(), time.Time(cluster.ImageInfo.CreatedAt), expiresAt.Unix()),
+	}
+	u, err := imageURL.BuildFull(b.ServiceScheme, strings.TrimSpace(b.ServiceURL)+":"+strings.TrimSpace(b.ServicePort))
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// DownloadClusterISOPresigned serves the same responses as DownloadClusterISO, the caller is authenticated
+// by the signature of the URL
+func (b *bareMetalInventory) DownloadClusterISOPresigned(ctx context.Context, params installer.DownloadClusterISOPresignedParams) middleware.Responder {
+	return b.DownloadClusterISO(ctx, installer.DownloadClusterISOParams{
+		HTTPRequest: params.HTTPRequest,
+		ClusterID:   params.ClusterID,
+		IfRange:     params.IfRange,
+		Range:       params.Range,
+	})
 }
 
 func (b *bareMetalInventory) GenerateClusterISO(ctx context.Context, params installer.GenerateClusterISOParams) middleware.Responder {
@@ -475,26 +749,14 @@ func (b *bareMetalInventory) GenerateClusterISO(ctx context.Context, params inst
 			WithPayload(common.GenerateError(http.StatusInternalServerError, errors.New("DB error, failed to start transaction")))
 	}
 
-	if err := tx.First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+	// Concurrent requests for the same cluster are serialized, so each one sees the image state recorded by the previous one
+	transaction.AddForUpdateQueryOption(tx)
+	if err := identity.AddUserFilter(ctx, tx).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to get cluster: %s", params.ClusterID)
 		return installer.NewGenerateClusterISONotFound().
 			WithPayload(common.GenerateError(http.StatusNotFound, err))
 	}
 
-	/* We need to ensure that the metadata in the DB matches the image that will be uploaded to S3,
-	so we check that at least 10 seconds have past since the previous request to reduce the chance
-	of a race between two consecutive requests.
-	*/
-	now := time.Now()
-	previousCreatedAt := time.Time(cluster.ImageInfo.CreatedAt)
-	if previousCreatedAt.Add(10 * time.Second).After(now) {
-		log.Error("request came too soon after previous request")
-		msg := "Failed to generate image: another request to generate an image has been recently submitted - please wait a few seconds and try again"
-		b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
-		return installer.NewGenerateClusterISOConflict().WithPayload(common.GenerateError(http.StatusConflict,
-			errors.New("Another request to generate an image has been recently submitted. Please wait a few seconds and try again.")))
-	}
-
 	if !cluster.PullSecretSet {
 		errMsg := "Can't generate cluster ISO without pull secret"
 		log.Error(errMsg)
@@ -502,18 +764,64 @@ func (b *bareMetalInventory) GenerateClusterISO(ctx context.Context, params inst
 			WithPayload(common.GenerateError(http.StatusBadRequest, errors.New(errMsg)))
 	}
 
-	/* If the request has the same parameters as the previous request and the image is still in S3,
+	if err := network.ValidateStaticNetworkConfig(params.ImageCreateParams.StaticNetworkConfig); err != nil {
+		log.WithError(err).Errorf("invalid static network configuration for cluster %s", params.ClusterID)
+		return installer.NewGenerateClusterISOBadRequest().
+			WithPayload(common.GenerateError(http.StatusBadRequest, err))
+	}
+	staticNetworkConfig, err := formatStaticNetworkConfig(params.ImageCreateParams.StaticNetworkConfig)
+	if err != nil {
+		log.WithError(err).Errorf("failed to format static network configuration for cluster %s", params.ClusterID)
+		return installer.NewGenerateClusterISOInternalServerError().
+			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	}
+
+	agentProxy, err := b.formatAgentProxy(&cluster)
+	if err != nil {
+		log.WithError(err).Errorf("failed to format the proxy settings of cluster %s", params.ClusterID)
+		return installer.NewGenerateClusterISOInternalServerError().
+			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	}
+
+	openshiftVersion, err := b.versions.GetOpenshiftVersion(cluster.OpenshiftVersion)
+	if err != nil {
+		log.WithError(err).Errorf("failed to get OpenShift version of cluster %s", params.ClusterID)
+		return installer.NewGenerateClusterISOInternalServerError().
+			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	}
+
+	now := time.Now()
+	previous := *cluster.ImageInfo
+	sameParams := previous.ProxyURL == params.ImageCreateParams.ProxyURL &&
+		previous.SSHPublicKey == params.ImageCreateParams.SSHPublicKey &&
+		cluster.ImageStaticNetworkConfig == staticNetworkConfig &&
+		cluster.ImageAgentProxy == agentProxy &&
+		cluster.ImageAdditionalTrustBundle == cluster.AdditionalTrustBundle &&
+		cluster.ImageMirrorRegistriesConfig == cluster.MirrorRegistriesConfig &&
+		previous.GeneratorVersion == b.imageBuilder.Version(openshiftVersion.RhcosImage)
+
+	/* A request with the same parameters as the image that is being generated is a duplicate, the caller
+	polls the state of the image that is already on its way.
+	*/
+	if sameParams && b.isImageBuildInProgress(previous, now) {
+		log.Infof("Image of cluster %s is already being generated", params.ClusterID)
+		if err := tx.Commit().Error; err != nil {
+			log.WithError(err).Error("failed to commit the transaction")
+			return installer.NewGenerateClusterISOInternalServerError()
+		}
+		txSuccess = true
+		return installer.NewGenerateClusterISOAccepted().WithPayload(&cluster.Cluster)
+	}
+
+	/* If the request has the same parameters as the previous image and the image is still in S3,
 	just refresh the timestamp.
 	*/
 	var imageExists bool
-	if cluster.ImageInfo.ProxyURL == params.ImageCreateParams.ProxyURL &&
-		cluster.ImageInfo.SSHPublicKey == params.ImageCreateParams.SSHPublicKey &&
-		cluster.ImageInfo.GeneratorVersion == b.Config.ImageBuilder {
-		var err error
+	if sameParams && isImageReady(previous) {
 		imgName := getImageName(params.ClusterID)
 		imageExists, err = b.s3Client.UpdateObjectTag(ctx, imgName, b.S3Bucket, "create_sec_since_epoch", strconv.FormatInt(now.Unix(), 10))
 		if err != nil {
-			log.WithError(tx.Error).Errorf("failed to contact storage backend")
+			log.WithError(err).Errorf("failed to contact storage backend")
 			msg := "Failed to generate image: error contacting storage backend"
 			b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
 			return installer.NewInstallClusterInternalServerError().
@@ -521,11 +829,36 @@ func (b *bareMetalInventory) GenerateClusterISO(ctx context.Context, params inst
 		}
 	}
 
+	/* Every new image gets a new agent token, so agents booted from a previous image of this cluster
+	can no longer authenticate.
+	*/
+	var agentToken string
 	updates := map[string]interface{}{}
+	if imageExists {
+		updates["image_state"] = models.ImageInfoStateReady
+	} else {
+		var agentTokenHash string
+		agentToken, agentTokenHash, err = auth.GenerateAgentToken()
+		if err != nil {
+			log.WithError(err).Errorf("failed to generate agent token for cluster %s", params.ClusterID)
+			msg := "Failed to generate image: error generating agent token"
+			b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
+			return installer.NewGenerateClusterISOInternalServerError().
+				WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+		}
+		updates["agent_token_hash"] = agentTokenHash
+		updates["image_state"] = models.ImageInfoStatePending
+		// The download URLs are signed with the creation time, a re-used image keeps its URLs valid
+		updates["image_created_at"] = strfmt.DateTime(now)
+	}
+	updates["image_error_message"] = ""
 	updates["image_proxy_url"] = params.ImageCreateParams.ProxyURL
 	updates["image_ssh_public_key"] = params.ImageCreateParams.SSHPublicKey
-	updates["image_created_at"] = strfmt.DateTime(now)
-	updates["image_generator_version"] = b.Config.ImageBuilder
+	updates["image_static_network_config"] = staticNetworkConfig
+	updates["image_agent_proxy"] = agentProxy
+	updates["image_additional_trust_bundle"] = cluster.AdditionalTrustBundle
+	updates["image_mirror_registries_config"] = cluster.MirrorRegistriesConfig
+	updates["image_generator_version"] = b.imageBuilder.Version(openshiftVersion.RhcosImage)
 	dbReply := tx.Model(&common.Cluster{}).Where("id = ?", cluster.ID.String()).Updates(updates)
 	if dbReply.Error != nil {
 		log.WithError(dbReply.Error).Errorf("failed to update cluster: %s", params.ClusterID)
@@ -552,58 +885,138 @@ func (b *bareMetalInventory) GenerateClusterISO(ctx context.Context, params inst
 	if imageExists {
 		log.Infof("Re-used existing cluster <%s> image", params.ClusterID)
 		b.eventsHandler.AddEvent(ctx, cluster.ID.String(), models.EventSeverityInfo, "Re-used existing image rather than generating a new one", time.Now())
-		return installer.NewGenerateClusterISOCreated().WithPayload(&cluster.Cluster)
+		return installer.NewGenerateClusterISOAccepted().WithPayload(&cluster.Cluster)
 	}
 
-	// Kill the previous job in case it's still running
-	prevJobName := fmt.Sprintf("createimage-%s-%s", cluster.ID, previousCreatedAt.Format("20060102150405"))
-	log.Info("Attempting to delete job %s", prevJobName)
-	if err := b.job.Delete(ctx, prevJobName, b.Namespace); err != nil {
-		log.WithError(err).Errorf("failed to kill previous job in cluster %s", cluster.ID)
-		msg := "Failed to generate image: error stopping previous image generation"
-		b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
+	// Stop the previous image generation in case it's still running
+	if err := b.imageBuilder.Cancel(ctx, *cluster.ID, time.Time(previous.CreatedAt)); err != nil {
+		log.WithError(err).Errorf("failed to stop previous image generation of cluster %s", cluster.ID)
+		b.failImageBuild(ctx, &cluster, "error stopping previous image generation", err)
 		return installer.NewGenerateClusterISOInternalServerError().
 			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
 	}
 
-	ignitionConfig, formatErr := b.formatIgnitionFile(&cluster, params)
+	ignitionConfig, formatErr := b.formatIgnitionFile(&cluster, params, agentToken)
 	if formatErr != nil {
 		log.WithError(formatErr).Errorf("failed to format ignition config file for cluster %s", cluster.ID)
-		msg := "Failed to generate image: error formatting ignition file"
-		b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
+		b.failImageBuild(ctx, &cluster, "error formatting ignition file", formatErr)
 		return installer.NewGenerateClusterISOInternalServerError().
 			WithPayload(common.GenerateError(http.StatusInternalServerError, formatErr))
 	}
 
-	// This job name is exactly 63 characters which is the maximum for a job - be careful if modifying
-	jobName := fmt.Sprintf("createimage-%s-%s", cluster.ID, now.Format("20060102150405"))
-	imgName := getImageName(params.ClusterID)
-	log.Infof("Creating job %s", jobName)
-	if err := b.job.Create(ctx, b.createImageJob(jobName, imgName, ignitionConfig, true)); err != nil {
-		log.WithError(err).Error("failed to create image job")
-		msg := "Failed to generate image: error creating image generation job"
-		b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
-		return installer.NewGenerateClusterISOInternalServerError().
-			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	// The build outlives the request, it keeps the request ID for logging and events
+	buildCtx := requestid.ToContext(b.buildsCtx, requestid.FromContext(ctx))
+	b.imageBuilds.Add(1)
+	go b.buildImage(buildCtx, cluster, ignitionConfig, openshiftVersion.RhcosImage)
+	return installer.NewGenerateClusterISOAccepted().WithPayload(&cluster.Cluster)
+}
+
+// isImageReady tells whether the image was generated, images generated before their state was recorded
+// have no state
+func isImageReady(imageInfo models.ImageInfo) bool {
+	return imageInfo.State == models.ImageInfoStateReady ||
+		(imageInfo.State == "" && !time.Time(imageInfo.CreatedAt).IsZero())
+}
+
+// imageNotReadyError explains why the image of the cluster can't be downloaded yet, or returns nil when it's ready
+func imageNotReadyError(cluster *common.Cluster) error {
+	if cluster.ImageInfo == nil || time.Time(cluster.ImageInfo.CreatedAt).IsZero() {
+		return errors.Errorf("The image of cluster %s wasn't generated - please generate the image and try again", cluster.ID)
 	}
+	switch {
+	case isImageReady(*cluster.ImageInfo):
+		return nil
+	case cluster.ImageInfo.State == models.ImageInfoStateFailed:
+		return errors.Errorf("The generation of the image of cluster %s failed (%s) - please generate the image and try again",
+			cluster.ID, cluster.ImageInfo.ErrorMessage)
+	default:
+		return errors.Errorf("The image of cluster %s is being generated - please try again later", cluster.ID)
+	}
+}
 
-	if err := b.job.Monitor(ctx, jobName, b.Namespace); err != nil {
+// isImageBuildInProgress tells whether the image is being generated. A build that didn't record its outcome
+// within the build timeout is considered lost, e.g. the service restarted in the meantime.
+func (b *bareMetalInventory) isImageBuildInProgress(imageInfo models.ImageInfo, now time.Time) bool {
+	if imageInfo.State != models.ImageInfoStatePending && imageInfo.State != models.ImageInfoStateBuilding {
+		return false
+	}
+	return time.Time(imageInfo.CreatedAt).Add(b.ImageBuildTimeout).After(now)
+}
+
+// buildImage generates the image in the background and records the outcome on the cluster
+func (b *bareMetalInventory) buildImage(ctx context.Context, cluster common.Cluster, ignitionConfig, rhcosImage string) {
+	defer b.imageBuilds.Done()
+	log := logutil.FromContext(ctx, b.log)
+	if !b.updateImageState(ctx, &cluster, models.ImageInfoStateBuilding, "") {
+		return
+	}
+
+	buildCtx, cancel := context.WithTimeout(ctx, b.ImageBuildTimeout)
+	defer cancel()
+	imgName := getImageName(*cluster.ID)
+	if err := b.imageBuilder.Build(buildCtx, *cluster.ID, time.Time(cluster.ImageInfo.CreatedAt), imgName, ignitionConfig, rhcosImage); err != nil {
 		log.WithError(err).Error("image creation failed")
-		msg := "Failed to generate image: error during image generation job"
-		b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityError, msg, time.Now())
-		return installer.NewGenerateClusterISOInternalServerError().
-			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+		reason := "error during image generation"
+		if b.buildsCtx.Err() != nil {
+			reason = "the service stopped during image generation"
+		}
+		b.failImageBuild(ctx, &cluster, reason, err)
+		return
 	}
 
-	log.Infof("Generated cluster <%s> image with ignition config %s", params.ClusterID, ignitionConfig)
-	msg := fmt.Sprintf("Generated image (proxy URL is \"%s\", ", params.ImageCreateParams.ProxyURL)
-	if params.ImageCreateParams.SSHPublicKey != "" {
+	if !b.updateImageState(ctx, &cluster, models.ImageInfoStateReady, "") {
+		return
+	}
+	// The ignition config isn't logged, it holds the agent token and the pull secret of the cluster
+	log.Infof("Generated cluster <%s> image", cluster.ID)
+	msg := fmt.Sprintf("Generated image (proxy URL is \"%s\", ", cluster.ImageInfo.ProxyURL)
+	if cluster.ImageInfo.SSHPublicKey != "" {
 		msg += "SSH public key is set)"
 	} else {
 		msg += "SSH public key is not set)"
 	}
 	b.eventsHandler.AddEvent(ctx, cluster.ID.String(), models.EventSeverityInfo, msg, time.Now())
-	return installer.NewGenerateClusterISOCreated().WithPayload(&cluster.Cluster)
+}
+
+// failImageBuild records the failure of the generation of the image, the reason is reported to the user
+func (b *bareMetalInventory) failImageBuild(ctx context.Context, cluster *common.Cluster, reason string, err error) {
+	if b.updateImageState(ctx, cluster, models.ImageInfoStateFailed, fmt.Sprintf("%s: %s", reason, err)) {
+		msg := fmt.Sprintf("Failed to generate image: %s", reason)
+		b.eventsHandler.AddEvent(ctx, cluster.ID.String(), models.EventSeverityError, msg, time.Now())
+	}
+}
+
+// updateImageState records the state of the image generation, unless a newer request superseded the
+// generation in the meantime. Returns whether the state was recorded.
+func (b *bareMetalInventory) updateImageState(ctx context.Context, cluster *common.Cluster, state, errorMessage string) bool {
+	log := logutil.FromContext(ctx, b.log)
+	dbReply := b.db.Model(&common.Cluster{}).
+		Where("id = ? and image_created_at = ?", cluster.ID.String(), cluster.ImageInfo.CreatedAt).
+		Updates(map[string]interface{}{"image_state": state, "image_error_message": errorMessage})
+	if dbReply.Error != nil {
+		log.WithError(dbReply.Error).Errorf("failed to update image state of cluster %s to %s", cluster.ID, state)
+		return false
+	}
+	if dbReply.RowsAffected == 0 {
+		log.Infof("Image generation of cluster %s was superseded by a newer request", cluster.ID)
+		return false
+	}
+	cluster.ImageInfo.State = state
+	cluster.ImageInfo.ErrorMessage = errorMessage
+	return true
+}
+
+// formatStaticNetworkConfig serializes the static network configurations, so they can be compared with the
+// ones of the previous image
+func formatStaticNetworkConfig(configs []*models.HostStaticNetworkConfig) (string, error) {
+	if len(configs) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(configs)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
 }
 
 func getImageName(clusterID strfmt.UUID) string {
@@ -739,7 +1152,8 @@ func (b *bareMetalInventory) InstallCluster(ctx context.Context, params installe
 	var cluster common.Cluster
 	var err error
 
-	if err = b.db.Preload("Hosts", "status <> ?", host.HostStatusDisabled).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+	if err = identity.AddUserFilter(ctx, b.db).Preload("Hosts", "status <> ?", host.HostStatusDisabled).
+		First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		return common.NewApiError(http.StatusNotFound, err)
 	}
 	if err = b.refreshAllHosts(ctx, &cluster); err != nil {
@@ -857,10 +1271,16 @@ func (b *bareMetalInventory) generateClusterInstallConfig(ctx context.Context, c
 		return errors.Wrapf(err, "failed to get install config for cluster %s", cluster.ID)
 	}
 
+	openshiftVersion, err := b.versions.GetOpenshiftVersion(cluster.OpenshiftVersion)
+	if err != nil {
+		log.WithError(err).Errorf("failed to get OpenShift version of cluster %s", cluster.ID)
+		return errors.Wrapf(err, "failed to get OpenShift version of cluster %s", cluster.ID)
+	}
+
 	ctime := time.Time(cluster.CreatedAt)
 	cTimestamp := strconv.FormatInt(ctime.Unix(), 10)
 	jobName := fmt.Sprintf("%s-%s-%s", kubeconfigPrefix, cluster.ID.String(), cTimestamp)[:63]
-	if err := b.job.Create(ctx, b.createKubeconfigJob(&cluster, jobName, cfg)); err != nil {
+	if err := b.job.Create(ctx, b.createKubeconfigJob(&cluster, jobName, cfg, openshiftVersion)); err != nil {
 		log.WithError(err).Errorf("Failed to create kubeconfig generation job %s for cluster %s", jobName, cluster.ID)
 		return errors.Wrapf(err, "Failed to create kubeconfig generation job %s for cluster %s", jobName, cluster.ID)
 	}
@@ -870,7 +1290,7 @@ func (b *bareMetalInventory) generateClusterInstallConfig(ctx context.Context, c
 		return errors.Wrapf(err, "Generating kubeconfig files %s failed for cluster %s", jobName, cluster.ID)
 	}
 
-	return b.clusterApi.SetGeneratorVersion(&cluster, b.Config.KubeconfigGenerator, b.db)
+	return b.clusterApi.SetGeneratorVersion(&cluster, openshiftVersion.KubeconfigGeneratorImage, b.db)
 }
 
 func (b *bareMetalInventory) refreshClusterHosts(ctx context.Context, cluster *common.Cluster, tx *gorm.DB, log logrus.FieldLogger) error {
@@ -910,6 +1330,25 @@ func (b *bareMetalInventory) UpdateCluster(ctx context.Context, params installer
 			return common.NewApiError(http.StatusBadRequest, err)
 		}
 	}
+	if err = validateProxySettings(params.ClusterUpdateParams.HTTPProxy, params.ClusterUpdateParams.HTTPSProxy,
+		params.ClusterUpdateParams.NoProxy); err != nil {
+		log.WithError(err).Errorf("Invalid proxy settings for cluster %s", params.ClusterID)
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
+	if trustBundle := swag.StringValue(params.ClusterUpdateParams.AdditionalTrustBundle); trustBundle != "" {
+		if err = installcfg.ValidateTrustBundle(trustBundle); err != nil {
+			log.WithError(err).Errorf("Invalid additional trust bundle for cluster %s", params.ClusterID)
+			return common.NewApiError(http.StatusBadRequest, err)
+		}
+	}
+	if err = validateMirrorRegistries(params.ClusterUpdateParams.MirrorRegistries); err != nil {
+		log.WithError(err).Errorf("Invalid mirror registries for cluster %s", params.ClusterID)
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
+	if err = b.validateHardwareProfile(swag.StringValue(params.ClusterUpdateParams.HardwareProfile)); err != nil {
+		log.WithError(err).Errorf("Invalid hardware profile for cluster %s", params.ClusterID)
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
 
 	txSuccess := false
 	tx := b.db.Begin()
@@ -933,7 +1372,7 @@ func (b *bareMetalInventory) UpdateCluster(ctx context.Context, params installer
 	// in case host monitor already updated the state we need to use FOR UPDATE option
 	transaction.AddForUpdateQueryOption(tx)
 
-	if err = tx.Preload("Hosts").First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+	if err = identity.AddUserFilter(ctx, tx).Preload("Hosts").First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to get cluster: %s", params.ClusterID)
 		return installer.NewUpdateClusterNotFound().WithPayload(common.GenerateError(http.StatusNotFound, err))
 	}
@@ -983,6 +1422,50 @@ func (b *bareMetalInventory) UpdateCluster(ctx context.Context, params installer
 	return installer.NewUpdateClusterCreated().WithPayload(&cluster.Cluster)
 }
 
+// validateProxySettings validates the proxy settings that are set, an empty setting clears it
+func validateProxySettings(httpProxy, httpsProxy, noProxy *string) error {
+	if swag.StringValue(httpProxy) != "" {
+		if err := validations.ValidateHTTPProxyFormat(*httpProxy); err != nil {
+			return errors.Wrap(err, "Failed to validate HTTP Proxy")
+		}
+	}
+	if swag.StringValue(httpsProxy) != "" {
+		if err := validations.ValidateHTTPProxyFormat(*httpsProxy); err != nil {
+			return errors.Wrap(err, "Failed to validate HTTPS Proxy")
+		}
+	}
+	if swag.StringValue(noProxy) != "" {
+		if err := validations.ValidateNoProxyFormat(*noProxy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateMirrorRegistries validates the image content sources and the CA certificate of the mirror registries
+func validateMirrorRegistries(config *models.MirrorRegistriesConfig) error {
+	if config == nil {
+		return nil
+	}
+	if err := mirrorregistries.ValidateConfig(config); err != nil {
+		return err
+	}
+	if config.CaCertificate != "" {
+		if err := installcfg.ValidateTrustBundle(config.CaCertificate); err != nil {
+			return errors.Wrap(err, "Failed to validate the CA certificate of the mirror registries")
+		}
+	}
+	return nil
+}
+
+// validateHardwareProfile validates that the hardware profile that a cluster selects is defined, empty means no selection
+func (b *bareMetalInventory) validateHardwareProfile(name string) error {
+	if name != "" && b.HardwareProfiles.Get(name) == nil {
+		return errors.Errorf("Hardware profile %s is not defined", name)
+	}
+	return nil
+}
+
 func (b *bareMetalInventory) updateClusterData(ctx context.Context, cluster *common.Cluster, params installer.UpdateClusterParams, db *gorm.DB, log logrus.FieldLogger) error {
 	updates := map[string]interface{}{}
 	apiVip := cluster.APIVip
@@ -1013,6 +1496,47 @@ func (b *bareMetalInventory) updateClusterData(ctx context.Context, cluster *com
 	if params.ClusterUpdateParams.SSHPublicKey != nil {
 		updates["ssh_public_key"] = *params.ClusterUpdateParams.SSHPublicKey
 	}
+	if params.ClusterUpdateParams.Platform != nil {
+		updates["platform"] = *params.ClusterUpdateParams.Platform
+	}
+	if params.ClusterUpdateParams.NetworkType != nil {
+		updates["network_type"] = *params.ClusterUpdateParams.NetworkType
+	}
+	if params.ClusterUpdateParams.SecondaryClusterNetworkCidr != nil {
+		updates["secondary_cluster_network_cidr"] = *params.ClusterUpdateParams.SecondaryClusterNetworkCidr
+	}
+	if params.ClusterUpdateParams.SecondaryClusterNetworkHostPrefix != nil {
+		updates["secondary_cluster_network_host_prefix"] = *params.ClusterUpdateParams.SecondaryClusterNetworkHostPrefix
+	}
+	if params.ClusterUpdateParams.SecondaryServiceNetworkCidr != nil {
+		updates["secondary_service_network_cidr"] = *params.ClusterUpdateParams.SecondaryServiceNetworkCidr
+	}
+	if params.ClusterUpdateParams.SecondaryMachineNetworkCidr != nil {
+		updates["secondary_machine_network_cidr"] = *params.ClusterUpdateParams.SecondaryMachineNetworkCidr
+	}
+	if params.ClusterUpdateParams.HTTPProxy != nil {
+		updates["http_proxy"] = *params.ClusterUpdateParams.HTTPProxy
+	}
+	if params.ClusterUpdateParams.HTTPSProxy != nil {
+		updates["https_proxy"] = *params.ClusterUpdateParams.HTTPSProxy
+	}
+	if params.ClusterUpdateParams.NoProxy != nil {
+		updates["no_proxy"] = *params.ClusterUpdateParams.NoProxy
+	}
+	if params.ClusterUpdateParams.AdditionalTrustBundle != nil {
+		updates["additional_trust_bundle"] = *params.ClusterUpdateParams.AdditionalTrustBundle
+	}
+	if params.ClusterUpdateParams.HardwareProfile != nil {
+		updates["hardware_profile"] = *params.ClusterUpdateParams.HardwareProfile
+	}
+	if params.ClusterUpdateParams.MirrorRegistries != nil {
+		mirrorRegistriesConfig, err := mirrorregistries.FormatConfig(params.ClusterUpdateParams.MirrorRegistries)
+		if err != nil {
+			log.WithError(err).Errorf("failed to format the mirror registries of cluster %s", params.ClusterID)
+			return common.NewApiError(http.StatusInternalServerError, err)
+		}
+		updates["mirror_registries_config"] = mirrorRegistriesConfig
+	}
 
 	var machineCidr string
 
@@ -1029,6 +1553,11 @@ func (b *bareMetalInventory) updateClusterData(ctx context.Context, cluster *com
 		return common.NewApiError(http.StatusBadRequest, err)
 	}
 
+	if err = network.VerifyClusterNetworks(updatedClusterNetworks(&cluster.Cluster, params.ClusterUpdateParams, machineCidr)); err != nil {
+		log.WithError(err).Errorf("Network verification failed for cluster: %s", params.ClusterID)
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
+
 	if params.ClusterUpdateParams.PullSecret != nil {
 		cluster.PullSecret = *params.ClusterUpdateParams.PullSecret
 		updates["pull_secret"] = *params.ClusterUpdateParams.PullSecret
@@ -1089,6 +1618,26 @@ func (b *bareMetalInventory) updateHostsData(ctx context.Context, params install
 		}
 	}
 
+	for i := range params.ClusterUpdateParams.HostsInstallationDisks {
+		log.Infof("Update host %s to installation disk %s", params.ClusterUpdateParams.HostsInstallationDisks[i].ID,
+			params.ClusterUpdateParams.HostsInstallationDisks[i].InstallationDiskID)
+		var host models.Host
+		err := db.First(&host, "id = ? and cluster_id = ?",
+			params.ClusterUpdateParams.HostsInstallationDisks[i].ID, params.ClusterID).Error
+		if err != nil {
+			log.WithError(err).Errorf("failed to find host <%s> in cluster <%s>",
+				params.ClusterUpdateParams.HostsInstallationDisks[i].ID, params.ClusterID)
+			return common.NewApiError(http.StatusNotFound, err)
+		}
+		err = b.hostApi.UpdateInstallationDisk(ctx, &host, params.ClusterUpdateParams.HostsInstallationDisks[i].InstallationDiskID, db)
+		if err != nil {
+			log.WithError(err).Errorf("failed to set installation disk <%s> host <%s> in cluster <%s>",
+				params.ClusterUpdateParams.HostsInstallationDisks[i].InstallationDiskID,
+				params.ClusterUpdateParams.HostsInstallationDisks[i].ID, params.ClusterID)
+			return common.NewApiError(http.StatusBadRequest, err)
+		}
+	}
+
 	return nil
 }
 
@@ -1106,6 +1655,37 @@ func (b *bareMetalInventory) updateHostsAndClusterStatus(ctx context.Context, cl
 	return nil
 }
 
+// updatedClusterNetworks returns a copy of the cluster with the network settings of the update applied
+func updatedClusterNetworks(cluster *models.Cluster, params *models.ClusterUpdateParams, machineCidr string) *models.Cluster {
+	ret := *cluster
+	ret.MachineNetworkCidr = machineCidr
+	if params.ClusterNetworkCidr != nil {
+		ret.ClusterNetworkCidr = *params.ClusterNetworkCidr
+	}
+	if params.ClusterNetworkHostPrefix != nil {
+		ret.ClusterNetworkHostPrefix = *params.ClusterNetworkHostPrefix
+	}
+	if params.ServiceNetworkCidr != nil {
+		ret.ServiceNetworkCidr = *params.ServiceNetworkCidr
+	}
+	if params.NetworkType != nil {
+		ret.NetworkType = *params.NetworkType
+	}
+	if params.SecondaryClusterNetworkCidr != nil {
+		ret.SecondaryClusterNetworkCidr = *params.SecondaryClusterNetworkCidr
+	}
+	if params.SecondaryClusterNetworkHostPrefix != nil {
+		ret.SecondaryClusterNetworkHostPrefix = *params.SecondaryClusterNetworkHostPrefix
+	}
+	if params.SecondaryServiceNetworkCidr != nil {
+		ret.SecondaryServiceNetworkCidr = *params.SecondaryServiceNetworkCidr
+	}
+	if params.SecondaryMachineNetworkCidr != nil {
+		ret.SecondaryMachineNetworkCidr = *params.SecondaryMachineNetworkCidr
+	}
+	return &ret
+}
+
 func calculateHostNetworks(log logrus.FieldLogger, cluster *common.Cluster) []*models.HostNetwork {
 	cidrHostsMap := make(map[string][]strfmt.UUID)
 	for _, h := range cluster.Hosts {
@@ -1119,10 +1699,10 @@ func calculateHostNetworks(log logrus.FieldLogger, cluster *common.Cluster) []*m
 			continue
 		}
 		for _, intf := range inventory.Interfaces {
-			for _, ipv4Address := range intf.IPV4Addresses {
-				_, ipnet, err := net.ParseCIDR(ipv4Address)
+			for _, address := range append(append([]string{}, intf.IPV4Addresses...), intf.IPV6Addresses...) {
+				_, ipnet, err := net.ParseCIDR(address)
 				if err != nil {
-					log.WithError(err).Warnf("Could not parse CIDR %s", ipv4Address)
+					log.WithError(err).Warnf("Could not parse CIDR %s", address)
 					continue
 				}
 				cidr := ipnet.String()
@@ -1143,8 +1723,7 @@ func calculateHostNetworks(log logrus.FieldLogger, cluster *common.Cluster) []*m
 func (b *bareMetalInventory) ListClusters(ctx context.Context, params installer.ListClustersParams) middleware.Responder {
 	log := logutil.FromContext(ctx, b.log)
 	var clusters []*common.Cluster
-	query := identity.GetUserIDFilter(ctx)
-	if err := b.db.Preload("Hosts").Find(&clusters).Where(query).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, b.db).Preload("Hosts").Find(&clusters).Error; err != nil {
 		log.WithError(err).Error("failed to list clusters")
 		return installer.NewListClustersInternalServerError().
 			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
@@ -1160,7 +1739,7 @@ func (b *bareMetalInventory) ListClusters(ctx context.Context, params installer.
 func (b *bareMetalInventory) GetCluster(ctx context.Context, params installer.GetClusterParams) middleware.Responder {
 	log := logutil.FromContext(ctx, b.log)
 	var cluster common.Cluster
-	if err := b.db.Preload("Hosts").First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, b.db).Preload("Hosts").First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		// TODO: check for the right error
 		return installer.NewGetClusterNotFound().
 			WithPayload(common.GenerateError(http.StatusNotFound, err))
@@ -1182,7 +1761,7 @@ func (b *bareMetalInventory) RegisterHost(ctx context.Context, params installer.
 	var cluster common.Cluster
 	log.Infof("Register host: %+v", params)
 
-	if err := b.db.First(&cluster, "id = ?", params.ClusterID.String()).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, b.db).First(&cluster, "id = ?", params.ClusterID.String()).Error; err != nil {
 		log.WithError(err).Errorf("failed to get cluster: %s", params.ClusterID.String())
 		if gorm.IsRecordNotFoundError(err) {
 			return common.NewApiError(http.StatusNotFound, err)
@@ -1244,6 +1823,16 @@ func (b *bareMetalInventory) DeregisterHost(ctx context.Context, params installe
 	log := logutil.FromContext(ctx, b.log)
 	log.Infof("Deregister host: %s cluster %s", params.HostID, params.ClusterID)
 
+	if err := identity.AddHostUserFilter(ctx, b.db).First(&models.Host{}, "id = ? and cluster_id = ?",
+		params.HostID, params.ClusterID).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return installer.NewDeregisterHostNotFound().
+				WithPayload(common.GenerateError(http.StatusNotFound, err))
+		}
+		return installer.NewDeregisterHostInternalServerError().
+			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	}
+
 	if err := b.db.Where("id = ? and cluster_id = ?", params.HostID, params.ClusterID).
 		Delete(&models.Host{}).Error; err != nil {
 		// TODO: check error type
@@ -1260,7 +1849,7 @@ func (b *bareMetalInventory) DeregisterHost(ctx context.Context, params installe
 func (b *bareMetalInventory) GetHost(ctx context.Context, params installer.GetHostParams) middleware.Responder {
 	var host models.Host
 	// TODO: validate what is the error
-	if err := b.db.Where("id = ? and cluster_id = ?", params.HostID, params.ClusterID).
+	if err := identity.AddHostUserFilter(ctx, b.db).Where("id = ? and cluster_id = ?", params.HostID, params.ClusterID).
 		First(&host).Error; err != nil {
 		return installer.NewGetHostNotFound().WithPayload(common.GenerateError(http.StatusNotFound, err))
 	}
@@ -1275,6 +1864,14 @@ func (b *bareMetalInventory) GetHost(ctx context.Context, params installer.GetHo
 func (b *bareMetalInventory) ListHosts(ctx context.Context, params installer.ListHostsParams) middleware.Responder {
 	log := logutil.FromContext(ctx, b.log)
 	var hosts []*models.Host
+	if err := identity.AddUserFilter(ctx, b.db).First(&common.Cluster{}, "id = ?", params.ClusterID).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return installer.NewListHostsNotFound().WithPayload(common.GenerateError(http.StatusNotFound, err))
+		}
+		log.WithError(err).Errorf("failed to get cluster %s", params.ClusterID)
+		return installer.NewListHostsInternalServerError().
+			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	}
 	if err := b.db.Find(&hosts, "cluster_id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to get list of hosts for cluster %s", params.ClusterID)
 		return installer.NewListHostsInternalServerError().
@@ -1319,7 +1916,7 @@ func (b *bareMetalInventory) GetNextSteps(ctx context.Context, params installer.
 	}
 
 	//TODO check the error type
-	if err := tx.First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
+	if err := identity.AddHostUserFilter(ctx, tx).First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to find host: %s", params.HostID)
 		return installer.NewGetNextStepsNotFound().
 			WithPayload(common.GenerateError(http.StatusNotFound, err))
@@ -1365,7 +1962,7 @@ func (b *bareMetalInventory) PostStepReply(ctx context.Context, params installer
 		params.HostID, params.Reply.ExitCode, params.Reply.Output, params.Reply.Error)
 
 	var host models.Host
-	if err = b.db.First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
+	if err = identity.AddHostUserFilter(ctx, b.db).First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("Failed to find host <%s> cluster <%s> step <%s> exit code %d stdout <%s> stderr <%s>",
 			params.HostID, params.ClusterID, params.Reply.StepID, params.Reply.ExitCode, params.Reply.Output, params.Reply.Error)
 		return installer.NewPostStepReplyNotFound().
@@ -1483,6 +2080,14 @@ func filterReply(expected interface{}, input string) (string, error) {
 
 func (b *bareMetalInventory) SetDebugStep(ctx context.Context, params installer.SetDebugStepParams) middleware.Responder {
 	log := logutil.FromContext(ctx, b.log)
+	if err := identity.AddHostUserFilter(ctx, b.db).First(&models.Host{}, "id = ? and cluster_id = ?",
+		params.HostID, params.ClusterID).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return installer.NewSetDebugStepNotFound().WithPayload(common.GenerateError(http.StatusNotFound, err))
+		}
+		return installer.NewSetDebugStepInternalServerError().
+			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	}
 	stepID := createStepID(models.StepTypeExecute)
 	b.debugCmdMux.Lock()
 	b.debugCmdMap[params.HostID] = debugCmd{
@@ -1501,7 +2106,7 @@ func (b *bareMetalInventory) DisableHost(ctx context.Context, params installer.D
 	var host models.Host
 	log.Info("disabling host: ", params.HostID)
 
-	if err := b.db.First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
+	if err := identity.AddHostUserFilter(ctx, b.db).First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
 		if gorm.IsRecordNotFoundError(err) {
 			log.WithError(err).Errorf("host %s not found", params.HostID)
 			return common.NewApiError(http.StatusNotFound, err)
@@ -1535,7 +2140,7 @@ func (b *bareMetalInventory) EnableHost(ctx context.Context, params installer.En
 	var host models.Host
 	log.Info("enable host: ", params.HostID)
 
-	if err := b.db.First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
+	if err := identity.AddHostUserFilter(ctx, b.db).First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
 		if gorm.IsRecordNotFoundError(err) {
 			log.WithError(err).Errorf("host %s not found", params.HostID)
 			return common.NewApiError(http.StatusNotFound, err)
@@ -1564,10 +2169,11 @@ func (b *bareMetalInventory) EnableHost(ctx context.Context, params installer.En
 	return installer.NewEnableHostOK().WithPayload(&host)
 }
 
-func (b *bareMetalInventory) createKubeconfigJob(cluster *common.Cluster, jobName string, cfg []byte) *batch.Job {
+func (b *bareMetalInventory) createKubeconfigJob(cluster *common.Cluster, jobName string, cfg []byte, openshiftVersion *models.OpenshiftVersion) *batch.Job {
 	id := cluster.ID
-	// [TODO]  make sure that we use openshift-installer from the release image, otherwise the KubeconfigGenerator image must be updated here per opnshift version
-	kubeConfigGeneratorImage := b.Config.KubeconfigGenerator
+	kubeConfigGeneratorImage := openshiftVersion.KubeconfigGeneratorImage
+	// The mirror registries were already parsed into the install config
+	mirrorRegistries, _ := mirrorregistries.ParseConfig(cluster.MirrorRegistriesConfig)
 	return &batch.Job{
 		TypeMeta: meta.TypeMeta{
 			Kind:       "Job",
@@ -1617,7 +2223,7 @@ func (b *bareMetalInventory) createKubeconfigJob(cluster *common.Cluster, jobNam
 								},
 								{
 									Name:  "OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE",
-									Value: b.ReleaseImage, //TODO: change this to match the cluster openshift version
+									Value: mirrorregistries.RewriteImage(swag.StringValue(openshiftVersion.ReleaseImage), mirrorRegistries),
 								},
 								{
 									Name:  "aws_access_key_id",
@@ -1658,7 +2264,7 @@ func (b *bareMetalInventory) DownloadClusterFiles(ctx context.Context, params in
 		return common.NewApiError(http.StatusBadRequest, err)
 	}
 
-	if err := b.db.First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, b.db).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to find cluster %s", params.ClusterID)
 		if gorm.IsRecordNotFoundError(err) {
 			return installer.NewDownloadClusterFilesNotFound().
@@ -1674,12 +2280,20 @@ func (b *bareMetalInventory) DownloadClusterFiles(ctx context.Context, params in
 			WithPayload(common.GenerateError(http.StatusConflict, err))
 	}
 
-	respBody, contentLength, err := b.s3Client.DownloadFileFromS3(ctx, fmt.Sprintf("%s/%s", params.ClusterID, params.FileName), b.S3Bucket)
+	respBody, file, rng, err := b.openFileRange(ctx, fmt.Sprintf("%s/%s", params.ClusterID, params.FileName), params.FileName,
+		params.Range, params.IfRange)
+	if err == filemiddleware.ErrRangeNotSatisfiable {
+		return filemiddleware.NewRangeNotSatisfiableResponder(installer.NewDownloadClusterFilesRequestedRangeNotSatisfiable().
+			WithPayload(common.GenerateError(http.StatusRequestedRangeNotSatisfiable, err)), file)
+	}
 	if err != nil {
 		return installer.NewDownloadClusterFilesInternalServerError().
 			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
 	}
-	return filemiddleware.NewResponder(installer.NewDownloadClusterFilesOK().WithPayload(respBody), params.FileName, contentLength)
+	if rng != nil {
+		return filemiddleware.NewRangeResponder(installer.NewDownloadClusterFilesPartialContent().WithPayload(respBody), file, rng)
+	}
+	return filemiddleware.NewRangeResponder(installer.NewDownloadClusterFilesOK().WithPayload(respBody), file, nil)
 }
 
 func (b *bareMetalInventory) DownloadClusterKubeconfig(ctx context.Context, params installer.DownloadClusterKubeconfigParams) middleware.Responder {
@@ -1687,7 +2301,7 @@ func (b *bareMetalInventory) DownloadClusterKubeconfig(ctx context.Context, para
 	var cluster common.Cluster
 	log.Infof("Download cluster kubeconfig for cluster %s", params.ClusterID)
 
-	if err := b.db.First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, b.db).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to find cluster %s", params.ClusterID)
 		if gorm.IsRecordNotFoundError(err) {
 			return installer.NewDownloadClusterKubeconfigNotFound().
@@ -1715,7 +2329,7 @@ func (b *bareMetalInventory) GetCredentials(ctx context.Context, params installe
 	log := logutil.FromContext(ctx, b.log)
 	var cluster common.Cluster
 
-	if err := b.db.First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, b.db).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to find cluster %s", params.ClusterID)
 		if gorm.IsRecordNotFoundError(err) {
 			return installer.NewGetCredentialsNotFound().
@@ -1756,7 +2370,7 @@ func (b *bareMetalInventory) GetCredentials(ctx context.Context, params installe
 func (b *bareMetalInventory) UpdateHostInstallProgress(ctx context.Context, params installer.UpdateHostInstallProgressParams) middleware.Responder {
 	log := logutil.FromContext(ctx, b.log)
 	var host models.Host
-	if err := b.db.First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
+	if err := identity.AddHostUserFilter(ctx, b.db).First(&host, "id = ? and cluster_id = ?", params.HostID, params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to find host %s", params.HostID)
 		return installer.NewUpdateHostInstallProgressNotFound().
 			WithPayload(common.GenerateError(http.StatusNotFound, err))
@@ -1780,12 +2394,44 @@ func (b *bareMetalInventory) UpdateHostInstallProgress(ctx context.Context, para
 	return installer.NewUpdateHostInstallProgressOK()
 }
 
+func (b *bareMetalInventory) UpdateClusterInstallConfig(ctx context.Context, params installer.UpdateClusterInstallConfigParams) middleware.Responder {
+	log := logutil.FromContext(ctx, b.log)
+	var cluster common.Cluster
+
+	if err := identity.AddUserFilter(ctx, b.db).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+		log.WithError(err).Errorf("failed to find cluster %s", params.ClusterID)
+		if gorm.IsRecordNotFoundError(err) {
+			return common.NewApiError(http.StatusNotFound, err)
+		}
+		return common.NewApiError(http.StatusInternalServerError, err)
+	}
+
+	if err := b.clusterApi.VerifyClusterUpdatability(&cluster); err != nil {
+		log.WithError(err).Errorf("cluster %s can't be updated in current state", params.ClusterID)
+		return common.NewApiError(http.StatusConflict, err)
+	}
+
+	overrides := string(params.InstallConfigParams)
+	if err := installcfg.ValidateInstallConfigOverrides(&cluster, overrides); err != nil {
+		log.WithError(err).Errorf("invalid install config overrides for cluster %s", params.ClusterID)
+		return common.NewApiError(http.StatusBadRequest, err)
+	}
+
+	if err := b.db.Model(&common.Cluster{}).Where("id = ?", params.ClusterID).
+		Update("install_config_overrides", overrides).Error; err != nil {
+		log.WithError(err).Errorf("failed to update install config overrides of cluster %s", params.ClusterID)
+		return common.NewApiError(http.StatusInternalServerError, err)
+	}
+	b.eventsHandler.AddEvent(ctx, params.ClusterID.String(), models.EventSeverityInfo, "Updated the install config overrides", time.Now())
+	return installer.NewUpdateClusterInstallConfigCreated()
+}
+
 func (b *bareMetalInventory) UploadClusterIngressCert(ctx context.Context, params installer.UploadClusterIngressCertParams) middleware.Responder {
 	log := logutil.FromContext(ctx, b.log)
 	log.Infof("UploadClusterIngressCert for cluster %s with params %s", params.ClusterID, params.IngressCertParams)
 	var cluster common.Cluster
 
-	if err := b.db.First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, b.db).First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to find cluster %s", params.ClusterID)
 		if gorm.IsRecordNotFoundError(err) {
 			return installer.NewUploadClusterIngressCertNotFound().WithPayload(common.GenerateError(http.StatusNotFound, err))
@@ -1921,7 +2567,7 @@ func (b *bareMetalInventory) CancelInstallation(ctx context.Context, params inst
 			common.GenerateError(http.StatusInternalServerError, errors.New(msg)))
 	}
 
-	if err := tx.Preload("Hosts").First(&c, "id = ?", params.ClusterID).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, tx).Preload("Hosts").First(&c, "id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("Failed to cancel installation: could not find cluster %s", params.ClusterID)
 		if gorm.IsRecordNotFoundError(err) {
 			return installer.NewCancelInstallationNotFound().WithPayload(common.GenerateError(http.StatusNotFound, err))
@@ -1979,7 +2625,7 @@ func (b *bareMetalInventory) ResetCluster(ctx context.Context, params installer.
 			common.GenerateError(http.StatusInternalServerError, errors.New("DB error, failed to start transaction")))
 	}
 
-	if err := tx.Preload("Hosts").First(&c, "id = ?", params.ClusterID).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, tx).Preload("Hosts").First(&c, "id = ?", params.ClusterID).Error; err != nil {
 		log.WithError(err).Errorf("failed to find cluster %s", params.ClusterID)
 		if gorm.IsRecordNotFoundError(err) {
 			return installer.NewResetClusterNotFound().WithPayload(common.GenerateError(http.StatusNotFound, err))
@@ -2031,7 +2677,7 @@ func (b *bareMetalInventory) CompleteInstallation(ctx context.Context, params in
 	log.Infof("complete cluster %s installation", params.ClusterID)
 
 	var c common.Cluster
-	if err := b.db.Preload("Hosts").First(&c, "id = ?", params.ClusterID).Error; err != nil {
+	if err := identity.AddUserFilter(ctx, b.db).Preload("Hosts").First(&c, "id = ?", params.ClusterID).Error; err != nil {
 		return common.GenerateErrorResponder(err)
 	}
 
@@ -2053,98 +2699,15 @@ func (b *bareMetalInventory) deleteS3ClusterFiles(ctx context.Context, c *common
 }
 
 func (b *bareMetalInventory) createDNSRecordSets(ctx context.Context, cluster common.Cluster) error {
-	return b.changeDNSRecordSets(ctx, cluster, false)
+	return b.dnsApi.CreateRecordSets(ctx, &cluster)
 }
 
 func (b *bareMetalInventory) deleteDNSRecordSets(ctx context.Context, cluster common.Cluster) error {
-	return b.changeDNSRecordSets(ctx, cluster, true)
+	return b.dnsApi.DeleteRecordSets(ctx, &cluster)
 }
 
-func (b *bareMetalInventory) changeDNSRecordSets(ctx context.Context, cluster common.Cluster, delete bool) error {
-	log := logutil.FromContext(ctx, b.log)
-
-	domain, err := b.getDNSDomain(cluster.Name, cluster.BaseDNSDomain)
-	if err != nil {
-		return err
-	}
-	if domain == nil {
-		// No supported base DNS domain specified
-		return nil
-	}
-
-	switch domain.Provider {
-	case "route53":
-		var dnsProvider dnsproviders.Provider = dnsproviders.Route53{
-			RecordSet: dnsproviders.RecordSet{
-				RecordSetType: "A",
-				TTL:           60,
-			},
-			HostedZoneID: domain.ID,
-			SharedCreds:  true,
-		}
-
-		dnsRecordSetFunc := dnsProvider.CreateRecordSet
-		if delete {
-			dnsRecordSetFunc = dnsProvider.DeleteRecordSet
-		}
-
-		// Create/Delete A record for API Virtual IP
-		_, err := dnsRecordSetFunc(domain.APIDomainName, cluster.APIVip)
-		if err != nil {
-			log.WithError(err).Errorf("failed to update DNS record: (%s, %s)",
-				domain.APIDomainName, cluster.APIVip)
-			return err
-		}
-		// Create/Delete A record for Ingress Virtual IP
-		_, err = dnsRecordSetFunc(domain.IngressDomainName, cluster.IngressVip)
-		if err != nil {
-			log.WithError(err).Errorf("failed to update DNS record: (%s, %s)",
-				domain.IngressDomainName, cluster.IngressVip)
-			return err
-		}
-		log.Infof("Successfully created DNS records for base domain: %s", cluster.BaseDNSDomain)
-	}
-	return nil
-}
-
-type dnsDomain struct {
-	Name              string
-	ID                string
-	Provider          string
-	APIDomainName     string
-	IngressDomainName string
-}
-
-func (b *bareMetalInventory) getDNSDomain(clusterName, baseDNSDomainName string) (*dnsDomain, error) {
-	var dnsDomainID string
-	var dnsProvider string
-
-	// Parse base domains from config
-	if val, ok := b.Config.BaseDNSDomains[baseDNSDomainName]; ok {
-		re := regexp.MustCompile("/")
-		if !re.MatchString(val) {
-			return nil, errors.New(fmt.Sprintf("Invalid DNS domain: %s", val))
-		}
-		s := re.Split(val, 2)
-		dnsDomainID = s[0]
-		dnsProvider = s[1]
-	} else {
-		// No base domains defined in config
-		return nil, nil
-	}
-
-	if dnsDomainID == "" || dnsProvider == "" {
-		// Specified domain is not defined in config
-		return nil, nil
-	}
-
-	return &dnsDomain{
-		Name:              baseDNSDomainName,
-		ID:                dnsDomainID,
-		Provider:          dnsProvider,
-		APIDomainName:     fmt.Sprintf("%s.%s.%s", "api", clusterName, baseDNSDomainName),
-		IngressDomainName: fmt.Sprintf("*.%s.%s.%s", "apps", clusterName, baseDNSDomainName),
-	}, nil
+func (b *bareMetalInventory) getDNSDomain(clusterName, baseDNSDomainName string) (*dns.Domain, error) {
+	return dns.GetDomain(b.Config.BaseDNSDomains, clusterName, baseDNSDomainName)
 }
 
 func (b *bareMetalInventory) validateDNSDomain(params installer.UpdateClusterParams, log logrus.FieldLogger) *installer.UpdateClusterConflict {
@@ -2169,32 +2732,21 @@ func (b *bareMetalInventory) validateDNSDomain(params installer.UpdateClusterPar
 	return nil
 }
 
-func (b *bareMetalInventory) validateBaseDNS(domain *dnsDomain) error {
-	return validations.ValidateBaseDNS(domain.Name, domain.ID, domain.Provider)
-}
-
-func (b *bareMetalInventory) validateDNSRecords(domain *dnsDomain) error {
-	vipAddresses := []string{domain.APIDomainName, domain.IngressDomainName}
-	return validations.CheckDNSRecordsExistence(vipAddresses, domain.ID, domain.Provider)
+func (b *bareMetalInventory) validateBaseDNS(domain *dns.Domain) error {
+	dnsProvider, err := dns.NewProvider(b.DNSConfig, domain)
+	if err != nil {
+		return err
+	}
+	return validations.ValidateBaseDNS(domain.Name, dnsProvider)
 }
 
-func ipAsUint(ipStr string, log logrus.FieldLogger) uint64 {
-	parts := strings.Split(ipStr, ".")
-	if len(parts) != 4 {
-		log.Warnf("Invalid ip %s", ipStr)
-		return 0
-	}
-	var result uint64 = 0
-	for _, p := range parts {
-		result = result << 8
-		converted, err := strconv.ParseUint(p, 10, 64)
-		if err != nil {
-			log.WithError(err).Warnf("Conversion of %s to uint", p)
-			return 0
-		}
-		result += converted
+func (b *bareMetalInventory) validateDNSRecords(domain *dns.Domain) error {
+	dnsProvider, err := dns.NewProvider(b.DNSConfig, domain)
+	if err != nil {
+		return err
 	}
-	return result
+	vipAddresses := []string{domain.APIDomainName, domain.IngressDomainName}
+	return validations.CheckDNSRecordsExistence(vipAddresses, dnsProvider)
 }
 
 func applyLimit(ret models.FreeAddressesList, limitParam *int64) models.FreeAddressesList {
@@ -2204,9 +2756,9 @@ func applyLimit(ret models.FreeAddressesList, limitParam *int64) models.FreeAddr
 	return ret
 }
 
-func (b *bareMetalInventory) getFreeAddresses(params installer.GetFreeAddressesParams, log logrus.FieldLogger) (models.FreeAddressesList, error) {
+func (b *bareMetalInventory) getFreeAddresses(ctx context.Context, params installer.GetFreeAddressesParams, log logrus.FieldLogger) (models.FreeAddressesList, error) {
 	var hosts []*models.Host
-	err := b.db.Select("free_addresses").Find(&hosts, "cluster_id = ? and status in (?)", params.ClusterID.String(), []string{host.HostStatusInsufficient, host.HostStatusKnown}).Error
+	err := identity.AddHostUserFilter(ctx, b.db).Select("free_addresses").Find(&hosts, "cluster_id = ? and status in (?)", params.ClusterID.String(), []string{host.HostStatusInsufficient, host.HostStatusKnown}).Error
 	if err != nil {
 		return nil, common.NewApiError(http.StatusInternalServerError, errors.Wrapf(err, "Error retreiving hosts for cluster %s", params.ClusterID.String()))
 	}
@@ -2222,7 +2774,7 @@ func (b *bareMetalInventory) getFreeAddresses(params installer.GetFreeAddressesP
 
 	// Sort addresses
 	sort.Slice(ret, func(i, j int) bool {
-		return ipAsUint(ret[i].String(), log) < ipAsUint(ret[j].String(), log)
+		return network.CompareIPs(ret[i], ret[j]) < 0
 	})
 
 	ret = applyLimit(ret, params.Limit)
@@ -2233,7 +2785,7 @@ func (b *bareMetalInventory) getFreeAddresses(params installer.GetFreeAddressesP
 func (b *bareMetalInventory) GetFreeAddresses(ctx context.Context, params installer.GetFreeAddressesParams) middleware.Responder {
 	log := logutil.FromContext(ctx, b.log)
 
-	results, err := b.getFreeAddresses(params, log)
+	results, err := b.getFreeAddresses(ctx, params, log)
 	if err != nil {
 		log.WithError(err).Warn("GetFreeAddresses")
 		return common.GenerateErrorResponder(err)
@@ -2241,6 +2793,53 @@ func (b *bareMetalInventory) GetFreeAddresses(ctx context.Context, params instal
 	return installer.NewGetFreeAddressesOK().WithPayload(results)
 }
 
+// csvResponder writes the CSV regardless of the negotiated producer, the format is selected by a query parameter
+// since the operation produces only JSON, for its error payloads too
+type csvResponder struct {
+	fileName string
+	data     []byte
+}
+
+func (c *csvResponder) WriteResponse(rw http.ResponseWriter, _ runtime.Producer) {
+	rw.Header().Set("Content-Type", "text/csv")
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", c.fileName))
+	rw.WriteHeader(http.StatusOK)
+	_, _ = rw.Write(c.data)
+}
+
+func (b *bareMetalInventory) GetClusterConnectivityMatrix(ctx context.Context, params installer.GetClusterConnectivityMatrixParams) middleware.Responder {
+	log := logutil.FromContext(ctx, b.log)
+	var cluster common.Cluster
+	if err := identity.AddUserFilter(ctx, b.db).Preload("Hosts", "status <> ?", host.HostStatusDisabled).
+		First(&cluster, "id = ?", params.ClusterID).Error; err != nil {
+		log.WithError(err).Errorf("failed to find cluster %s", params.ClusterID)
+		if gorm.IsRecordNotFoundError(err) {
+			return installer.NewGetClusterConnectivityMatrixNotFound().
+				WithPayload(common.GenerateError(http.StatusNotFound, err))
+		}
+		return installer.NewGetClusterConnectivityMatrixInternalServerError().
+			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	}
+
+	matrix, err := connectivity.BuildMatrix(cluster.Hosts)
+	if err != nil {
+		log.WithError(err).Errorf("failed to build the connectivity matrix of cluster %s", params.ClusterID)
+		return installer.NewGetClusterConnectivityMatrixInternalServerError().
+			WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+	}
+
+	if swag.StringValue(params.Format) == "csv" {
+		data, err := connectivity.FormatMatrixCSV(matrix)
+		if err != nil {
+			log.WithError(err).Errorf("failed to format the connectivity matrix of cluster %s", params.ClusterID)
+			return installer.NewGetClusterConnectivityMatrixInternalServerError().
+				WithPayload(common.GenerateError(http.StatusInternalServerError, err))
+		}
+		return &csvResponder{fileName: fmt.Sprintf("connectivity-matrix-%s.csv", params.ClusterID), data: data}
+	}
+	return installer.NewGetClusterConnectivityMatrixOK().WithPayload(matrix)
+}
+
 func (b *bareMetalInventory) customizeHost(host *models.Host) error {
 	b.customizeHostStages(host)
 	b.customizeHostname(host)
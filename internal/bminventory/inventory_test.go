@@ -7,17 +7,24 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/openshift/assisted-service/internal/metrics"
 
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/openshift/assisted-service/internal/common"
 
 	"github.com/openshift/assisted-service/internal/events"
+	"github.com/openshift/assisted-service/internal/hardware"
+	"github.com/openshift/assisted-service/pkg/auth"
 	"github.com/openshift/assisted-service/pkg/filemiddleware"
 
 	awsS3Client "github.com/openshift/assisted-service/pkg/s3Client"
@@ -30,8 +37,10 @@ import (
 	_ "github.com/jinzhu/gorm/dialects/postgres"
 	"github.com/openshift/assisted-service/internal/cluster"
 	"github.com/openshift/assisted-service/internal/host"
+	"github.com/openshift/assisted-service/internal/versions"
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/assisted-service/pkg/job"
+	"github.com/openshift/assisted-service/restapi"
 	"github.com/openshift/assisted-service/restapi/operations/installer"
 
 	"github.com/kelseyhightower/envconfig"
@@ -39,6 +48,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
 )
 
 const ClusterStatusInstalled = "installed"
@@ -61,6 +71,14 @@ func strToUUID(s string) *strfmt.UUID {
 	return &u
 }
 
+var testOpenshiftVersion = &models.OpenshiftVersion{
+	DisplayName:              swag.String("4.5"),
+	ReleaseImage:             swag.String("quay.io/openshift-release-dev/ocp-release:4.5.0-x86_64"),
+	KubeconfigGeneratorImage: "quay.io/ocpmetal/ignition-manifests-and-kubeconfig-generate:4.5",
+	InstallerImage:           "quay.io/ocpmetal/assisted-installer:4.5",
+	ControllerImage:          "quay.io/ocpmetal/assisted-installer-controller:4.5",
+}
+
 var _ = Describe("GenerateClusterISO", func() {
 	var (
 		bm         *bareMetalInventory
@@ -80,7 +98,7 @@ var _ = Describe("GenerateClusterISO", func() {
 		mockEvents = events.NewMockHandler(ctrl)
 		mockJob = job.NewMockAPI(ctrl)
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
-		bm = NewBareMetalInventory(db, getTestLog(), nil, nil, cfg, mockJob, mockEvents, nil, nil)
+		bm = NewBareMetalInventory(db, getTestLog(), nil, nil, cfg, mockJob, mockEvents, nil, nil, nil, nil)
 	})
 
 	AfterEach(func() {
@@ -98,19 +116,56 @@ var _ = Describe("GenerateClusterISO", func() {
 		return &cluster
 	}
 
+	generate := func(clusterId *strfmt.UUID, imageParams *models.ImageCreateParams) middleware.Responder {
+		reply := bm.GenerateClusterISO(ctx, installer.GenerateClusterISOParams{
+			ClusterID:         *clusterId,
+			ImageCreateParams: imageParams,
+		})
+		bm.imageBuilds.Wait()
+		return reply
+	}
+
+	getImageInfo := func(clusterId *strfmt.UUID) *models.ImageInfo {
+		var cluster common.Cluster
+		Expect(db.First(&cluster, "id = ?", clusterId).Error).ShouldNot(HaveOccurred())
+		return cluster.ImageInfo
+	}
+
+	setImageInfo := func(clusterId *strfmt.UUID, state, sshPublicKey string, createdAt time.Time) {
+		Expect(db.Model(&common.Cluster{}).Where("id = ?", clusterId).Updates(map[string]interface{}{
+			"image_state":             state,
+			"image_ssh_public_key":    sshPublicKey,
+			"image_created_at":        strfmt.DateTime(createdAt),
+			"image_generator_version": "quay.io/ocpmetal/installer-image-build:latest",
+		}).Error).ShouldNot(HaveOccurred())
+	}
+
 	It("success", func() {
 		clusterId := registerCluster(true).ID
 		mockJob.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityInfo, "Generated image (proxy URL is \"\", SSH public key is not set)", gomock.Any())
-		generateReply := bm.GenerateClusterISO(ctx, installer.GenerateClusterISOParams{
-			ClusterID:         *clusterId,
-			ImageCreateParams: &models.ImageCreateParams{},
-		})
-		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOCreated()))
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		Expect(generateReply.(*installer.GenerateClusterISOAccepted).Payload.ImageInfo.State).Should(Equal(models.ImageInfoStatePending))
 		getReply := bm.GetCluster(ctx, installer.GetClusterParams{ClusterID: *clusterId}).(*installer.GetClusterOK)
 		Expect(getReply.Payload.ImageInfo.GeneratorVersion).To(Equal("quay.io/ocpmetal/installer-image-build:latest"))
+		Expect(getReply.Payload.ImageInfo.State).To(Equal(models.ImageInfoStateReady))
+		Expect(getReply.Payload.ImageInfo.ErrorMessage).To(BeEmpty())
+	})
+
+	It("mints an agent token for a new image", func() {
+		clusterId := registerCluster(true).ID
+		mockJob.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityInfo, gomock.Any(), gomock.Any())
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		var cluster common.Cluster
+		Expect(db.First(&cluster, "id = ?", clusterId).Error).ShouldNot(HaveOccurred())
+		Expect(cluster.AgentTokenHash).ShouldNot(BeEmpty())
 	})
 
 	It("success with proxy", func() {
@@ -120,17 +175,12 @@ var _ = Describe("GenerateClusterISO", func() {
 		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityInfo, "Generated image (proxy URL is \"http://1.1.1.1:1234\", SSH public key "+
 			"is not set)", gomock.Any())
-		generateReply := bm.GenerateClusterISO(ctx, installer.GenerateClusterISOParams{
-			ClusterID:         *clusterId,
-			ImageCreateParams: &models.ImageCreateParams{ProxyURL: "http://1.1.1.1:1234"},
-		})
-		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOCreated()))
+		generateReply := generate(clusterId, &models.ImageCreateParams{ProxyURL: "http://1.1.1.1:1234"})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
 	})
 	It("cluster_not_exists", func() {
-		generateReply := bm.GenerateClusterISO(ctx, installer.GenerateClusterISOParams{
-			ClusterID:         strfmt.UUID(uuid.New().String()),
-			ImageCreateParams: &models.ImageCreateParams{},
-		})
+		clusterId := strfmt.UUID(uuid.New().String())
+		generateReply := generate(&clusterId, &models.ImageCreateParams{})
 		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISONotFound()))
 	})
 
@@ -139,11 +189,11 @@ var _ = Describe("GenerateClusterISO", func() {
 		mockJob.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error")).Times(1)
 		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityError, gomock.Any(), gomock.Any())
-		generateReply := bm.GenerateClusterISO(ctx, installer.GenerateClusterISOParams{
-			ClusterID:         *clusterId,
-			ImageCreateParams: &models.ImageCreateParams{},
-		})
-		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOInternalServerError()))
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		imageInfo := getImageInfo(clusterId)
+		Expect(imageInfo.State).Should(Equal(models.ImageInfoStateFailed))
+		Expect(imageInfo.ErrorMessage).Should(Equal("error during image generation: error"))
 	})
 
 	It("job_failed", func() {
@@ -152,19 +202,36 @@ var _ = Describe("GenerateClusterISO", func() {
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error")).Times(1)
 		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityError, gomock.Any(), gomock.Any())
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		Expect(getImageInfo(clusterId).State).Should(Equal(models.ImageInfoStateFailed))
+	})
+
+	It("fails the image generation in progress when the service stops", func() {
+		clusterId := registerCluster(true).ID
+		mockJob.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _, _ string) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}).Times(1)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityError,
+			"Failed to generate image: the service stopped during image generation", gomock.Any())
 		generateReply := bm.GenerateClusterISO(ctx, installer.GenerateClusterISOParams{
 			ClusterID:         *clusterId,
 			ImageCreateParams: &models.ImageCreateParams{},
 		})
-		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOInternalServerError()))
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		bm.StopImageBuilds()
+		imageInfo := getImageInfo(clusterId)
+		Expect(imageInfo.State).Should(Equal(models.ImageInfoStateFailed))
+		Expect(imageInfo.ErrorMessage).Should(HavePrefix("the service stopped during image generation"))
 	})
 
 	It("failed_missing_pull_secret", func() {
 		clusterId := registerCluster(false).ID
-		generateReply := bm.GenerateClusterISO(ctx, installer.GenerateClusterISOParams{
-			ClusterID:         *clusterId,
-			ImageCreateParams: &models.ImageCreateParams{},
-		})
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
 		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOBadRequest()))
 	})
 
@@ -176,11 +243,294 @@ var _ = Describe("GenerateClusterISO", func() {
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error")).Times(1)
 		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityError, gomock.Any(), gomock.Any())
-		generateReply := bm.GenerateClusterISO(ctx, installer.GenerateClusterISOParams{
-			ClusterID:         *clusterId,
-			ImageCreateParams: &models.ImageCreateParams{},
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+	})
+
+	It("failed_invalid_static_network_config", func() {
+		clusterId := registerCluster(true).ID
+		config := &models.HostStaticNetworkConfig{
+			MacAddress:   swag.String("52:54:00:aa:bb:01"),
+			IPAddress:    swag.String("10.0.0.11"),
+			PrefixLength: swag.Int64(24),
+		}
+		generateReply := generate(clusterId, &models.ImageCreateParams{
+			StaticNetworkConfig: []*models.HostStaticNetworkConfig{config, config},
+		})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOBadRequest()))
+	})
+
+	It("generates a new image for other static network configs", func() {
+		clusterId := registerCluster(true).ID
+		setImageInfo(clusterId, models.ImageInfoStateReady, "", time.Now().Add(-time.Hour))
+		mockJob.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityInfo, gomock.Any(), gomock.Any())
+		generateReply := generate(clusterId, &models.ImageCreateParams{
+			StaticNetworkConfig: []*models.HostStaticNetworkConfig{{
+				MacAddress:   swag.String("52:54:00:aa:bb:01"),
+				IPAddress:    swag.String("10.0.0.11"),
+				PrefixLength: swag.Int64(24),
+			}},
 		})
-		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOInternalServerError()))
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		var cluster common.Cluster
+		Expect(db.First(&cluster, "id = ?", clusterId).Error).ShouldNot(HaveOccurred())
+		Expect(cluster.ImageStaticNetworkConfig).Should(ContainSubstring("52:54:00:aa:bb:01"))
+	})
+
+	It("generates a new image for another proxy of the cluster", func() {
+		clusterId := registerCluster(true).ID
+		setImageInfo(clusterId, models.ImageInfoStateReady, "", time.Now().Add(-time.Hour))
+		Expect(db.Model(&common.Cluster{}).Where("id = ?", clusterId).
+			Update("http_proxy", "http://proxy.example.com:3128").Error).ShouldNot(HaveOccurred())
+		mockJob.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityInfo, gomock.Any(), gomock.Any())
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		var cluster common.Cluster
+		Expect(db.First(&cluster, "id = ?", clusterId).Error).ShouldNot(HaveOccurred())
+		Expect(cluster.ImageAgentProxy).Should(ContainSubstring("http://proxy.example.com:3128"))
+	})
+
+	It("joins the generation of the same image", func() {
+		clusterId := registerCluster(true).ID
+		createdAt := time.Now().Add(-time.Minute)
+		setImageInfo(clusterId, models.ImageInfoStateBuilding, "", createdAt)
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		imageInfo := getImageInfo(clusterId)
+		Expect(imageInfo.State).Should(Equal(models.ImageInfoStateBuilding))
+		Expect(time.Time(imageInfo.CreatedAt)).Should(BeTemporally("~", createdAt, time.Millisecond))
+	})
+
+	It("replaces the generation of an image with other parameters", func() {
+		clusterId := registerCluster(true).ID
+		setImageInfo(clusterId, models.ImageInfoStateBuilding, "ssh-rsa key", time.Now().Add(-time.Minute))
+		mockJob.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityInfo, gomock.Any(), gomock.Any())
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		imageInfo := getImageInfo(clusterId)
+		Expect(imageInfo.State).Should(Equal(models.ImageInfoStateReady))
+		Expect(imageInfo.SSHPublicKey).Should(BeEmpty())
+	})
+
+	It("restarts a generation that didn't finish in time", func() {
+		clusterId := registerCluster(true).ID
+		setImageInfo(clusterId, models.ImageInfoStateBuilding, "", time.Now().Add(-time.Hour))
+		mockJob.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityInfo, gomock.Any(), gomock.Any())
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		Expect(getImageInfo(clusterId).State).Should(Equal(models.ImageInfoStateReady))
+	})
+
+	It("doesn't record the outcome of a replaced generation", func() {
+		clusterId := registerCluster(true).ID
+		mockJob.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _ string) error {
+				// a newer request replaced the image while the job was running
+				setImageInfo(clusterId, models.ImageInfoStatePending, "ssh-rsa key", time.Now())
+				return fmt.Errorf("job was deleted")
+			}).Times(1)
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		imageInfo := getImageInfo(clusterId)
+		Expect(imageInfo.State).Should(Equal(models.ImageInfoStatePending))
+		Expect(imageInfo.ErrorMessage).Should(BeEmpty())
+	})
+
+	It("re-uses the existing image", func() {
+		clusterId := registerCluster(true).ID
+		createdAt := time.Now().Add(-time.Hour)
+		setImageInfo(clusterId, models.ImageInfoStateReady, "", createdAt)
+		mockS3Client := awsS3Client.NewMockS3Client(ctrl)
+		bm.s3Client = mockS3Client
+		mockS3Client.EXPECT().UpdateObjectTag(gomock.Any(), getImageName(*clusterId), "test", "create_sec_since_epoch", gomock.Any()).
+			Return(true, nil).Times(1)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId.String(), models.EventSeverityInfo, "Re-used existing image rather than generating a new one", gomock.Any())
+		generateReply := generate(clusterId, &models.ImageCreateParams{})
+		Expect(generateReply).Should(BeAssignableToTypeOf(installer.NewGenerateClusterISOAccepted()))
+		imageInfo := getImageInfo(clusterId)
+		Expect(imageInfo.State).Should(Equal(models.ImageInfoStateReady))
+		Expect(time.Time(imageInfo.CreatedAt)).Should(BeTemporally("~", createdAt, time.Second))
+	})
+})
+
+var _ = Describe("formatIgnitionFile", func() {
+	It("embeds the agent token in the agent service", func() {
+		bm := &bareMetalInventory{Config: Config{AgentDockerImg: "quay.io/ocpmetal/agent:latest"}}
+		clusterId := strfmt.UUID(uuid.New().String())
+		cluster := common.Cluster{Cluster: models.Cluster{ID: &clusterId},
+			PullSecret: "{\"auths\":{\"cloud.openshift.com\":{\"auth\":\"dG9rZW46dGVzdAo=\",\"email\":\"coyote@acme.com\"}}}"}
+		ignition, err := bm.formatIgnitionFile(&cluster, installer.GenerateClusterISOParams{
+			ClusterID:         clusterId,
+			ImageCreateParams: &models.ImageCreateParams{},
+		}, "agent-token")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ignition).Should(ContainSubstring("Environment=AGENT_TOKEN=agent-token"))
+	})
+
+	It("uses the proxy URL of the image for clusters without a proxy", func() {
+		bm := &bareMetalInventory{Config: Config{AgentDockerImg: "quay.io/ocpmetal/agent:latest", ServiceURL: "10.35.59.36"}}
+		clusterId := strfmt.UUID(uuid.New().String())
+		cluster := common.Cluster{Cluster: models.Cluster{ID: &clusterId},
+			PullSecret: "{\"auths\":{\"cloud.openshift.com\":{\"auth\":\"dG9rZW46dGVzdAo=\",\"email\":\"coyote@acme.com\"}}}"}
+		ignition, err := bm.formatIgnitionFile(&cluster, installer.GenerateClusterISOParams{
+			ClusterID:         clusterId,
+			ImageCreateParams: &models.ImageCreateParams{ProxyURL: "http://1.1.1.1:1234"},
+		}, "agent-token")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ignition).Should(ContainSubstring("Environment=HTTP_PROXY=http://1.1.1.1:1234\\n"))
+		Expect(ignition).Should(ContainSubstring("Environment=HTTPS_PROXY=http://1.1.1.1:1234\\n"))
+		Expect(ignition).Should(ContainSubstring("Environment=NO_PROXY=\\n"))
+	})
+
+	It("uses the proxy of the cluster", func() {
+		bm := &bareMetalInventory{Config: Config{AgentDockerImg: "quay.io/ocpmetal/agent:latest", ServiceURL: "10.35.59.36"}}
+		clusterId := strfmt.UUID(uuid.New().String())
+		cluster := common.Cluster{Cluster: models.Cluster{
+			ID:                          &clusterId,
+			HTTPProxy:                   "http://proxy.example.com:3128",
+			HTTPSProxy:                  "http://proxy.example.com:3129",
+			NoProxy:                     ".example.com,10.35.59.36",
+			MachineNetworkCidr:          "10.11.0.0/16",
+			SecondaryMachineNetworkCidr: "fd00::/64",
+		}, PullSecret: "{\"auths\":{\"cloud.openshift.com\":{\"auth\":\"dG9rZW46dGVzdAo=\",\"email\":\"coyote@acme.com\"}}}"}
+		ignition, err := bm.formatIgnitionFile(&cluster, installer.GenerateClusterISOParams{
+			ClusterID:         clusterId,
+			ImageCreateParams: &models.ImageCreateParams{ProxyURL: "http://1.1.1.1:1234"},
+		}, "agent-token")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ignition).Should(ContainSubstring("Environment=HTTP_PROXY=http://proxy.example.com:3128\\n"))
+		Expect(ignition).Should(ContainSubstring("Environment=https_proxy=http://proxy.example.com:3129\\n"))
+		Expect(ignition).Should(ContainSubstring("Environment=no_proxy=.example.com,10.35.59.36,10.11.0.0/16,fd00::/64\\n"))
+	})
+
+	It("embeds the additional trust bundle of the cluster", func() {
+		bm := &bareMetalInventory{Config: Config{AgentDockerImg: "quay.io/ocpmetal/agent:latest"}}
+		clusterId := strfmt.UUID(uuid.New().String())
+		trustBundle := "-----BEGIN CERTIFICATE-----\nMIIDozCCAougAwIBAgIU\n-----END CERTIFICATE-----\n"
+		cluster := common.Cluster{Cluster: models.Cluster{ID: &clusterId, AdditionalTrustBundle: trustBundle},
+			PullSecret: "{\"auths\":{\"cloud.openshift.com\":{\"auth\":\"dG9rZW46dGVzdAo=\",\"email\":\"coyote@acme.com\"}}}"}
+		ignition, err := bm.formatIgnitionFile(&cluster, installer.GenerateClusterISOParams{
+			ClusterID:         clusterId,
+			ImageCreateParams: &models.ImageCreateParams{},
+		}, "agent-token")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ignition).Should(ContainSubstring("ExecStartPre=update-ca-trust extract\\n"))
+
+		var config struct {
+			Storage struct {
+				Files []struct {
+					Path     string
+					Mode     int
+					Contents struct{ Source string }
+				}
+			}
+		}
+		Expect(json.Unmarshal([]byte(ignition), &config)).ShouldNot(HaveOccurred())
+		Expect(config.Storage.Files).Should(HaveLen(2))
+		file := config.Storage.Files[1]
+		Expect(file.Path).Should(Equal("/etc/pki/ca-trust/source/anchors/assisted-installer-trust-bundle.crt"))
+		Expect(file.Mode).Should(Equal(0644))
+		contents, err := url.PathUnescape(strings.TrimPrefix(file.Contents.Source, "data:,"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(contents).Should(Equal(trustBundle))
+	})
+
+	It("doesn't update the trust store without an additional trust bundle", func() {
+		bm := &bareMetalInventory{Config: Config{AgentDockerImg: "quay.io/ocpmetal/agent:latest"}}
+		clusterId := strfmt.UUID(uuid.New().String())
+		cluster := common.Cluster{Cluster: models.Cluster{ID: &clusterId},
+			PullSecret: "{\"auths\":{\"cloud.openshift.com\":{\"auth\":\"dG9rZW46dGVzdAo=\",\"email\":\"coyote@acme.com\"}}}"}
+		ignition, err := bm.formatIgnitionFile(&cluster, installer.GenerateClusterISOParams{
+			ClusterID:         clusterId,
+			ImageCreateParams: &models.ImageCreateParams{},
+		}, "agent-token")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ignition).ShouldNot(ContainSubstring("update-ca-trust"))
+		Expect(ignition).ShouldNot(ContainSubstring("/etc/pki/ca-trust"))
+	})
+
+	It("pulls the images from the mirror registries", func() {
+		bm := &bareMetalInventory{Config: Config{AgentDockerImg: "quay.io/ocpmetal/agent:latest"}}
+		clusterId := strfmt.UUID(uuid.New().String())
+		cluster := common.Cluster{Cluster: models.Cluster{
+			ID: &clusterId,
+			MirrorRegistriesConfig: `{"image_content_sources":[{"source":"quay.io/ocpmetal","mirrors":["mirror.example.com:5000/ocpmetal",` +
+				`"other.example.com/ocpmetal"]}],"ca_certificate":"-----BEGIN CERTIFICATE-----\\n"}`,
+		}, PullSecret: "{\"auths\":{\"cloud.openshift.com\":{\"auth\":\"dG9rZW46dGVzdAo=\",\"email\":\"coyote@acme.com\"}}}"}
+		ignition, err := bm.formatIgnitionFile(&cluster, installer.GenerateClusterISOParams{
+			ClusterID:         clusterId,
+			ImageCreateParams: &models.ImageCreateParams{},
+		}, "agent-token")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ignition).Should(ContainSubstring("/hostbin mirror.example.com:5000/ocpmetal/agent:latest cp /usr/bin/agent"))
+		Expect(ignition).Should(ContainSubstring("--agent-version quay.io/ocpmetal/agent:latest"))
+
+		var config struct {
+			Storage struct {
+				Files []struct {
+					Path     string
+					Contents struct{ Source string }
+				}
+			}
+		}
+		Expect(json.Unmarshal([]byte(ignition), &config)).ShouldNot(HaveOccurred())
+		Expect(config.Storage.Files).Should(HaveLen(4))
+		Expect(config.Storage.Files[1].Path).Should(Equal("/etc/containers/registries.conf"))
+		contents, err := url.PathUnescape(strings.TrimPrefix(config.Storage.Files[1].Contents.Source, "data:,"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(contents).Should(ContainSubstring("location = \"quay.io/ocpmetal\"\n"))
+		Expect(contents).Should(ContainSubstring("[[registry.mirror]]\n    location = \"mirror.example.com:5000/ocpmetal\"\n"))
+		Expect(config.Storage.Files[2].Path).Should(Equal("/etc/containers/certs.d/mirror.example.com:5000/ca.crt"))
+		Expect(config.Storage.Files[3].Path).Should(Equal("/etc/containers/certs.d/other.example.com/ca.crt"))
+	})
+
+	It("embeds the keyfiles of the static network configs", func() {
+		bm := &bareMetalInventory{Config: Config{AgentDockerImg: "quay.io/ocpmetal/agent:latest"}}
+		clusterId := strfmt.UUID(uuid.New().String())
+		cluster := common.Cluster{Cluster: models.Cluster{ID: &clusterId},
+			PullSecret: "{\"auths\":{\"cloud.openshift.com\":{\"auth\":\"dG9rZW46dGVzdAo=\",\"email\":\"coyote@acme.com\"}}}"}
+		ignition, err := bm.formatIgnitionFile(&cluster, installer.GenerateClusterISOParams{
+			ClusterID: clusterId,
+			ImageCreateParams: &models.ImageCreateParams{StaticNetworkConfig: []*models.HostStaticNetworkConfig{{
+				MacAddress:   swag.String("52:54:00:aa:bb:01"),
+				IPAddress:    swag.String("10.0.0.11"),
+				PrefixLength: swag.Int64(24),
+			}}},
+		}, "agent-token")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var config struct {
+			Storage struct {
+				Files []struct {
+					Path     string
+					Mode     int
+					Contents struct{ Source string }
+				}
+			}
+		}
+		Expect(json.Unmarshal([]byte(ignition), &config)).ShouldNot(HaveOccurred())
+		Expect(config.Storage.Files).Should(HaveLen(2))
+		file := config.Storage.Files[1]
+		Expect(file.Path).Should(Equal("/etc/NetworkManager/system-connections/static-525400aabb01.nmconnection"))
+		Expect(file.Mode).Should(Equal(0600))
+		contents, err := url.PathUnescape(strings.TrimPrefix(file.Contents.Source, "data:,"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(contents).Should(ContainSubstring("mac-address=52:54:00:aa:bb:01\n"))
+		Expect(contents).Should(ContainSubstring("address1=10.0.0.11/24\n"))
 	})
 })
 
@@ -195,7 +545,7 @@ var _ = Describe("RegisterHost", func() {
 
 	BeforeEach(func() {
 		db = common.PrepareTestDB(dbName)
-		bm = NewBareMetalInventory(db, getTestLog(), nil, nil, cfg, nil, nil, nil, nil)
+		bm = NewBareMetalInventory(db, getTestLog(), nil, nil, cfg, nil, nil, nil, nil, nil, nil)
 	})
 
 	AfterEach(func() {
@@ -240,7 +590,7 @@ var _ = Describe("GetNextSteps", func() {
 		mockEvents = events.NewMockHandler(ctrl)
 		mockJob = job.NewMockAPI(ctrl)
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
-		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, nil, cfg, mockJob, mockEvents, nil, nil)
+		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, nil, cfg, mockJob, mockEvents, nil, nil, nil, nil)
 	})
 
 	AfterEach(func() {
@@ -287,7 +637,7 @@ var _ = Describe("GetNextSteps", func() {
 	})
 })
 
-func makeFreeAddresses(network string, ips ...strfmt.IPv4) *models.FreeNetworkAddresses {
+func makeFreeAddresses(network string, ips ...string) *models.FreeNetworkAddresses {
 	return &models.FreeNetworkAddresses{
 		FreeAddresses: ips,
 		Network:       network,
@@ -326,7 +676,7 @@ var _ = Describe("PostStepReply", func() {
 		mockEvents = events.NewMockHandler(ctrl)
 		mockJob = job.NewMockAPI(ctrl)
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
-		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, nil, cfg, mockJob, mockEvents, nil, nil)
+		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, nil, cfg, mockJob, mockEvents, nil, nil, nil, nil)
 	})
 
 	AfterEach(func() {
@@ -407,7 +757,7 @@ var _ = Describe("GetFreeAddresses", func() {
 		mockEvents = events.NewMockHandler(ctrl)
 		mockJob = job.NewMockAPI(ctrl)
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
-		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, nil, cfg, mockJob, mockEvents, nil, nil)
+		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, nil, cfg, mockJob, mockEvents, nil, nil, nil, nil)
 	})
 
 	AfterEach(func() {
@@ -443,9 +793,21 @@ var _ = Describe("GetFreeAddresses", func() {
 		Expect(reply).Should(BeAssignableToTypeOf(installer.NewGetFreeAddressesOK()))
 		actualReply := reply.(*installer.GetFreeAddressesOK)
 		Expect(len(actualReply.Payload)).To(Equal(3))
-		Expect(actualReply.Payload[0]).To(Equal(strfmt.IPv4("10.0.9.250")))
-		Expect(actualReply.Payload[1]).To(Equal(strfmt.IPv4("10.0.10.1")))
-		Expect(actualReply.Payload[2]).To(Equal(strfmt.IPv4("10.0.20.0")))
+		Expect(actualReply.Payload[0]).To(Equal("10.0.9.250"))
+		Expect(actualReply.Payload[1]).To(Equal("10.0.10.1"))
+		Expect(actualReply.Payload[2]).To(Equal("10.0.20.0"))
+	})
+
+	It("success with IPv6", func() {
+		clusterId := strToUUID(uuid.New().String())
+
+		_ = makeHost(clusterId, makeFreeNetworksAddressesStr(makeFreeAddresses("fd00::/120", "fd00::10", "fd00:0::2")), host.HostStatusInsufficient)
+		params := makeGetFreeAddressesParams(*clusterId, "fd00::/120")
+		reply := bm.GetFreeAddresses(ctx, params)
+		Expect(reply).Should(BeAssignableToTypeOf(installer.NewGetFreeAddressesOK()))
+		actualReply := reply.(*installer.GetFreeAddressesOK)
+		Expect(actualReply.Payload).To(Equal(models.FreeAddressesList{"fd00::2", "fd00::10"}))
+		Expect(actualReply.Payload.Validate(strfmt.Default)).ToNot(HaveOccurred())
 	})
 
 	It("success with limit", func() {
@@ -458,8 +820,8 @@ var _ = Describe("GetFreeAddresses", func() {
 		Expect(reply).Should(BeAssignableToTypeOf(installer.NewGetFreeAddressesOK()))
 		actualReply := reply.(*installer.GetFreeAddressesOK)
 		Expect(len(actualReply.Payload)).To(Equal(2))
-		Expect(actualReply.Payload[0]).To(Equal(strfmt.IPv4("10.0.9.250")))
-		Expect(actualReply.Payload[1]).To(Equal(strfmt.IPv4("10.0.10.1")))
+		Expect(actualReply.Payload[0]).To(Equal("10.0.9.250"))
+		Expect(actualReply.Payload[1]).To(Equal("10.0.10.1"))
 	})
 
 	It("success with limit and prefix", func() {
@@ -473,8 +835,8 @@ var _ = Describe("GetFreeAddresses", func() {
 		Expect(reply).Should(BeAssignableToTypeOf(installer.NewGetFreeAddressesOK()))
 		actualReply := reply.(*installer.GetFreeAddressesOK)
 		Expect(len(actualReply.Payload)).To(Equal(2))
-		Expect(actualReply.Payload[0]).To(Equal(strfmt.IPv4("10.0.1.0")))
-		Expect(actualReply.Payload[1]).To(Equal(strfmt.IPv4("10.0.10.1")))
+		Expect(actualReply.Payload[0]).To(Equal("10.0.1.0"))
+		Expect(actualReply.Payload[1]).To(Equal("10.0.10.1"))
 	})
 
 	It("one disconnected", func() {
@@ -488,7 +850,7 @@ var _ = Describe("GetFreeAddresses", func() {
 		Expect(reply).Should(BeAssignableToTypeOf(installer.NewGetFreeAddressesOK()))
 		actualReply := reply.(*installer.GetFreeAddressesOK)
 		Expect(len(actualReply.Payload)).To(Equal(1))
-		Expect(actualReply.Payload).To(ContainElement(strfmt.IPv4("10.0.0.0")))
+		Expect(actualReply.Payload).To(ContainElement("10.0.0.0"))
 	})
 
 	It("empty result", func() {
@@ -519,7 +881,7 @@ var _ = Describe("GetFreeAddresses", func() {
 		Expect(reply).Should(BeAssignableToTypeOf(installer.NewGetFreeAddressesOK()))
 		actualReply := reply.(*installer.GetFreeAddressesOK)
 		Expect(len(actualReply.Payload)).To(Equal(1))
-		Expect(actualReply.Payload).To(ContainElement(strfmt.IPv4("10.0.0.0")))
+		Expect(actualReply.Payload).To(ContainElement("10.0.0.0"))
 	})
 
 	It("no matching  hosts", func() {
@@ -535,6 +897,119 @@ var _ = Describe("GetFreeAddresses", func() {
 	})
 })
 
+var _ = Describe("GetClusterConnectivityMatrix", func() {
+	var (
+		bm        *bareMetalInventory
+		cfg       Config
+		db        *gorm.DB
+		ctx       = context.Background()
+		ctrl      *gomock.Controller
+		mockJob   *job.MockAPI
+		clusterID strfmt.UUID
+		hostIDs   []strfmt.UUID
+		dbName    = "get_cluster_connectivity_matrix"
+	)
+
+	BeforeEach(func() {
+		Expect(envconfig.Process("test", &cfg)).ShouldNot(HaveOccurred())
+		ctrl = gomock.NewController(GinkgoT())
+		db = common.PrepareTestDB(dbName)
+		mockJob = job.NewMockAPI(ctrl)
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		bm = NewBareMetalInventory(db, getTestLog(), nil, nil, cfg, mockJob, nil, nil, nil, nil, nil)
+
+		clusterID = strfmt.UUID(uuid.New().String())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &clusterID}}).Error).ShouldNot(HaveOccurred())
+		hostIDs = []strfmt.UUID{strfmt.UUID(uuid.New().String()), strfmt.UUID(uuid.New().String())}
+		for i := range hostIDs {
+			remote := &models.ConnectivityRemoteHost{
+				HostID: hostIDs[1-i],
+				L2Connectivity: []*models.L2Connectivity{
+					{OutgoingNic: "eth0", RemoteIPAddress: fmt.Sprintf("10.0.0.%d", 2-i), Successful: i == 0},
+				},
+			}
+			report, err := json.Marshal(&models.ConnectivityReport{RemoteHosts: []*models.ConnectivityRemoteHost{remote}})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(db.Create(&models.Host{
+				ID:                &hostIDs[i],
+				ClusterID:         clusterID,
+				Status:            swag.String(host.HostStatusKnown),
+				RequestedHostname: fmt.Sprintf("host%d", i),
+				Connectivity:      string(report),
+			}).Error).ShouldNot(HaveOccurred())
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	matrixParams := func(id strfmt.UUID) installer.GetClusterConnectivityMatrixParams {
+		params := installer.NewGetClusterConnectivityMatrixParams()
+		params.ClusterID = id
+		return params
+	}
+
+	It("returns the connectivity between the hosts", func() {
+		reply := bm.GetClusterConnectivityMatrix(ctx, matrixParams(clusterID))
+		Expect(reply).Should(BeAssignableToTypeOf(installer.NewGetClusterConnectivityMatrixOK()))
+		matrix := reply.(*installer.GetClusterConnectivityMatrixOK).Payload
+		Expect(matrix.Hosts).Should(HaveLen(2))
+		Expect(matrix.HostPairs).Should(HaveLen(2))
+		Expect(matrix.NicPairs).Should(HaveLen(2))
+		for _, p := range matrix.HostPairs {
+			Expect(p.Asymmetric).Should(BeTrue())
+		}
+	})
+
+	It("ignores disabled hosts", func() {
+		Expect(db.Model(&models.Host{}).Where("id = ?", hostIDs[1].String()).
+			Update("status", host.HostStatusDisabled).Error).ShouldNot(HaveOccurred())
+		reply := bm.GetClusterConnectivityMatrix(ctx, matrixParams(clusterID))
+		matrix := reply.(*installer.GetClusterConnectivityMatrixOK).Payload
+		Expect(matrix.Hosts).Should(HaveLen(1))
+		Expect(matrix.HostPairs).Should(BeEmpty())
+	})
+
+	It("returns the matrix as CSV", func() {
+		params := matrixParams(clusterID)
+		params.Format = swag.String("csv")
+		rec := httptest.NewRecorder()
+		bm.GetClusterConnectivityMatrix(ctx, params).WriteResponse(rec, runtime.JSONProducer())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).Should(Equal("text/csv"))
+		Expect(strings.Split(strings.TrimSpace(rec.Body.String()), "\n")).Should(HaveLen(3))
+	})
+
+	It("selects the CSV format by the query parameter only", func() {
+		handler, err := restapi.Handler(restapi.Config{InstallerAPI: bm, Logger: logrus.Printf})
+		Expect(err).ShouldNot(HaveOccurred())
+		get := func(query, accept string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet,
+				fmt.Sprintf("/api/assisted-install/v1/clusters/%s/connectivity-matrix%s", clusterID, query), nil)
+			req.Header.Set("Accept", accept)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			return rec
+		}
+
+		rec := get("", "text/csv")
+		Expect(rec.Code).Should(Equal(http.StatusNotAcceptable))
+		rec = get("?format=csv", "*/*")
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).Should(Equal("text/csv"))
+		rec = get("", "*/*")
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).Should(HavePrefix("application/json"))
+	})
+
+	It("fails for a missing cluster", func() {
+		reply := bm.GetClusterConnectivityMatrix(ctx, matrixParams(strfmt.UUID(uuid.New().String())))
+		Expect(reply).Should(BeAssignableToTypeOf(installer.NewGetClusterConnectivityMatrixNotFound()))
+	})
+})
+
 var _ = Describe("UpdateHostInstallProgress", func() {
 	var (
 		bm                   *bareMetalInventory
@@ -557,7 +1032,7 @@ var _ = Describe("UpdateHostInstallProgress", func() {
 		mockEvents = events.NewMockHandler(ctrl)
 		mockJob = job.NewMockAPI(ctrl)
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
-		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, nil, cfg, mockJob, mockEvents, nil, nil)
+		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, nil, cfg, mockJob, mockEvents, nil, nil, nil, nil)
 		defaultProgressStage = "some progress"
 	})
 
@@ -641,6 +1116,7 @@ var _ = Describe("cluster", func() {
 		clusterID      strfmt.UUID
 		mockEvents     *events.MockHandler
 		mockMetric     *metrics.MockAPI
+		mockVersions   *versions.MockHandler
 		dbName         = "inventory_cluster"
 	)
 
@@ -655,7 +1131,8 @@ var _ = Describe("cluster", func() {
 		mockJob = job.NewMockAPI(ctrl)
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		mockMetric = metrics.NewMockAPI(ctrl)
-		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, mockClusterApi, cfg, mockJob, mockEvents, mockS3Client, mockMetric)
+		mockVersions = versions.NewMockHandler(ctrl)
+		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, mockClusterApi, cfg, mockJob, mockEvents, mockS3Client, mockMetric, mockVersions, nil)
 	})
 
 	AfterEach(func() {
@@ -705,6 +1182,9 @@ var _ = Describe("cluster", func() {
 	set4GetMasterNodesIds := func(mockClusterApi *cluster.MockAPI) {
 		mockClusterApi.EXPECT().GetMasterNodesIds(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*strfmt.UUID{&masterHostId1, &masterHostId2, &masterHostId3, &masterHostId4}, nil)
 	}
+	setDefaultGetOpenshiftVersion := func(mockVersions *versions.MockHandler) {
+		mockVersions.EXPECT().GetOpenshiftVersion(gomock.Any()).Return(testOpenshiftVersion, nil).Times(1)
+	}
 	setDefaultJobCreate := func(mockJobApi *job.MockAPI) {
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 	}
@@ -785,6 +1265,145 @@ var _ = Describe("cluster", func() {
 		return arr
 	}
 
+	Context("RegisterCluster", func() {
+		registerParams := func(openshiftVersion string) installer.RegisterClusterParams {
+			return installer.RegisterClusterParams{
+				NewClusterParams: &models.ClusterCreateParams{
+					Name:             swag.String("some-cluster-name"),
+					OpenshiftVersion: swag.String(openshiftVersion),
+				},
+			}
+		}
+
+		It("supported version", func() {
+			mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(testOpenshiftVersion, nil).Times(1)
+			mockClusterApi.EXPECT().RegisterCluster(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			mockMetric.EXPECT().ClusterRegistered("4.5").Times(1)
+			reply := bm.RegisterCluster(ctx, registerParams("4.5"))
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewRegisterClusterCreated()))
+			Expect(reply.(*installer.RegisterClusterCreated).Payload.OpenshiftVersion).Should(Equal("4.5"))
+		})
+
+		It("with proxy settings", func() {
+			mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(testOpenshiftVersion, nil).Times(1)
+			mockClusterApi.EXPECT().RegisterCluster(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			mockMetric.EXPECT().ClusterRegistered("4.5").Times(1)
+			params := registerParams("4.5")
+			params.NewClusterParams.HTTPProxy = "http://proxy.example.com:3128"
+			params.NewClusterParams.HTTPSProxy = "http://proxy.example.com:3129"
+			params.NewClusterParams.NoProxy = ".example.com,10.0.0.0/8"
+			reply := bm.RegisterCluster(ctx, params)
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewRegisterClusterCreated()))
+			actual := reply.(*installer.RegisterClusterCreated).Payload
+			Expect(actual.HTTPProxy).Should(Equal("http://proxy.example.com:3128"))
+			Expect(actual.HTTPSProxy).Should(Equal("http://proxy.example.com:3129"))
+			Expect(actual.NoProxy).Should(Equal(".example.com,10.0.0.0/8"))
+		})
+
+		It("invalid proxy settings", func() {
+			params := registerParams("4.5")
+			params.NewClusterParams.HTTPSProxy = "proxy.example.com:3129"
+			reply := bm.RegisterCluster(ctx, params)
+			verifyApiError(reply, http.StatusBadRequest)
+		})
+
+		It("invalid additional trust bundle", func() {
+			params := registerParams("4.5")
+			params.NewClusterParams.AdditionalTrustBundle = "not a certificate"
+			reply := bm.RegisterCluster(ctx, params)
+			verifyApiError(reply, http.StatusBadRequest)
+		})
+
+		It("with mirror registries", func() {
+			mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(testOpenshiftVersion, nil).Times(1)
+			mockClusterApi.EXPECT().RegisterCluster(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			mockMetric.EXPECT().ClusterRegistered("4.5").Times(1)
+			params := registerParams("4.5")
+			params.NewClusterParams.MirrorRegistries = &models.MirrorRegistriesConfig{
+				ImageContentSources: []*models.ImageContentSource{{
+					Source:  swag.String("quay.io/ocpmetal"),
+					Mirrors: []string{"mirror.example.com:5000/ocpmetal"},
+				}},
+			}
+			reply := bm.RegisterCluster(ctx, params)
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewRegisterClusterCreated()))
+			Expect(reply.(*installer.RegisterClusterCreated).Payload.MirrorRegistriesConfig).Should(Equal(
+				`{"image_content_sources":[{"mirrors":["mirror.example.com:5000/ocpmetal"],"source":"quay.io/ocpmetal"}]}`))
+		})
+
+		It("invalid mirror registries", func() {
+			params := registerParams("4.5")
+			params.NewClusterParams.MirrorRegistries = &models.MirrorRegistriesConfig{
+				ImageContentSources: []*models.ImageContentSource{{
+					Source:  swag.String("quay.io/ocpmetal"),
+					Mirrors: []string{"https://mirror.example.com:5000/ocpmetal"},
+				}},
+			}
+			reply := bm.RegisterCluster(ctx, params)
+			verifyApiError(reply, http.StatusBadRequest)
+		})
+
+		It("with hardware profile", func() {
+			bm.Config.HardwareProfiles = &hardware.Profiles{Profiles: []*hardware.Profile{{Name: "edge"}}}
+			mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(testOpenshiftVersion, nil).Times(1)
+			mockClusterApi.EXPECT().RegisterCluster(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			mockMetric.EXPECT().ClusterRegistered("4.5").Times(1)
+			params := registerParams("4.5")
+			params.NewClusterParams.HardwareProfile = "edge"
+			reply := bm.RegisterCluster(ctx, params)
+			Expect(reply).Should(BeAssignableToTypeOf(installer.NewRegisterClusterCreated()))
+			Expect(reply.(*installer.RegisterClusterCreated).Payload.HardwareProfile).Should(Equal("edge"))
+		})
+
+		It("undefined hardware profile", func() {
+			mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(testOpenshiftVersion, nil).Times(1)
+			params := registerParams("4.5")
+			params.NewClusterParams.HardwareProfile = "edge"
+			reply := bm.RegisterCluster(ctx, params)
+			verifyApiError(reply, http.StatusBadRequest)
+		})
+
+		It("unsupported version", func() {
+			mockVersions.EXPECT().GetOpenshiftVersion("4.4").
+				Return(nil, errors.New("OpenShift version 4.4 is not supported")).Times(1)
+			reply := bm.RegisterCluster(ctx, registerParams("4.4"))
+			verifyApiError(reply, http.StatusBadRequest)
+		})
+	})
+
+	Context("kubeconfig job", func() {
+		It("uses the images of the cluster version", func() {
+			clusterID = strfmt.UUID(uuid.New().String())
+			c := common.Cluster{Cluster: models.Cluster{ID: &clusterID, OpenshiftVersion: "4.5"}}
+			kubeconfigJob := bm.createKubeconfigJob(&c, "kubeconfig-job", []byte("install-config"), testOpenshiftVersion)
+			container := kubeconfigJob.Spec.Template.Spec.Containers[0]
+			Expect(container.Image).Should(Equal(testOpenshiftVersion.KubeconfigGeneratorImage))
+			Expect(container.Env).Should(ContainElement(core.EnvVar{
+				Name:  "OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE",
+				Value: *testOpenshiftVersion.ReleaseImage,
+			}))
+		})
+	})
+
+	Context("kubeconfig job with mirror registries", func() {
+		It("uses the mirror of the release image", func() {
+			clusterID = strfmt.UUID(uuid.New().String())
+			c := common.Cluster{Cluster: models.Cluster{
+				ID:               &clusterID,
+				OpenshiftVersion: "4.5",
+				MirrorRegistriesConfig: `{"image_content_sources":[{"source":"quay.io/openshift-release-dev/ocp-release",` +
+					`"mirrors":["mirror.example.com:5000/ocp/release"]}]}`,
+			}}
+			openshiftVersion := *testOpenshiftVersion
+			openshiftVersion.ReleaseImage = swag.String("quay.io/openshift-release-dev/ocp-release:4.5.1-x86_64")
+			kubeconfigJob := bm.createKubeconfigJob(&c, "kubeconfig-job", []byte("install-config"), &openshiftVersion)
+			Expect(kubeconfigJob.Spec.Template.Spec.Containers[0].Env).Should(ContainElement(core.EnvVar{
+				Name:  "OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE",
+				Value: "mirror.example.com:5000/ocp/release:4.5.1-x86_64",
+			}))
+		})
+	})
+
 	Context("Get", func() {
 		{
 			BeforeEach(func() {
@@ -875,6 +1494,57 @@ var _ = Describe("cluster", func() {
 			Expect(reply).To(BeAssignableToTypeOf(installer.NewUpdateClusterBadRequest()))
 		})
 
+		It("Invalid proxy settings", func() {
+			for _, params := range []*models.ClusterUpdateParams{
+				{HTTPProxy: swag.String("ftp://proxy.example.com")},
+				{HTTPSProxy: swag.String("http://proxy.example.com/path")},
+				{NoProxy: swag.String("example.com, 10.0.0.0/8")},
+			} {
+				reply := bm.UpdateCluster(ctx, installer.UpdateClusterParams{
+					ClusterID:           clusterID,
+					ClusterUpdateParams: params,
+				})
+				verifyApiError(reply, http.StatusBadRequest)
+			}
+		})
+
+		It("Invalid additional trust bundle", func() {
+			reply := bm.UpdateCluster(ctx, installer.UpdateClusterParams{
+				ClusterID: clusterID,
+				ClusterUpdateParams: &models.ClusterUpdateParams{
+					AdditionalTrustBundle: swag.String("-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n"),
+				},
+			})
+			verifyApiError(reply, http.StatusBadRequest)
+		})
+
+		It("Invalid mirror registries", func() {
+			reply := bm.UpdateCluster(ctx, installer.UpdateClusterParams{
+				ClusterID: clusterID,
+				ClusterUpdateParams: &models.ClusterUpdateParams{
+					MirrorRegistries: &models.MirrorRegistriesConfig{
+						ImageContentSources: []*models.ImageContentSource{{
+							Source:  swag.String("quay.io/ocpmetal"),
+							Mirrors: []string{"mirror.example.com:5000/ocpmetal"},
+						}},
+						CaCertificate: "not a certificate",
+					},
+				},
+			})
+			verifyApiError(reply, http.StatusBadRequest)
+		})
+
+		It("Undefined hardware profile", func() {
+			bm.Config.HardwareProfiles = &hardware.Profiles{Profiles: []*hardware.Profile{{Name: "edge"}}}
+			reply := bm.UpdateCluster(ctx, installer.UpdateClusterParams{
+				ClusterID: clusterID,
+				ClusterUpdateParams: &models.ClusterUpdateParams{
+					HardwareProfile: swag.String("storage-heavy"),
+				},
+			})
+			verifyApiError(reply, http.StatusBadRequest)
+		})
+
 		It("empty pull-secret", func() {
 			pullSecret := ""
 			reply := bm.UpdateCluster(ctx, installer.UpdateClusterParams{
@@ -939,6 +1609,73 @@ var _ = Describe("cluster", func() {
 				Expect(reply).To(BeAssignableToTypeOf(&common.ApiErrorResponse{}))
 				Expect(reply.(*common.ApiErrorResponse).StatusCode()).To(Equal(int32(http.StatusBadRequest)))
 			})
+			It("OpenShiftSDN with an IPv6 service network", func() {
+				serviceNetworkCidr := "fd02::/112"
+				reply := bm.UpdateCluster(ctx, installer.UpdateClusterParams{
+					ClusterID: clusterID,
+					ClusterUpdateParams: &models.ClusterUpdateParams{
+						ClusterNetworkCidr:       swag.String("fd01::/48"),
+						ClusterNetworkHostPrefix: swag.Int64(64),
+						ServiceNetworkCidr:       &serviceNetworkCidr,
+					},
+				})
+				verifyApiError(reply, http.StatusBadRequest)
+			})
+			It("Dual stack with secondary networks of the same family", func() {
+				reply := bm.UpdateCluster(ctx, installer.UpdateClusterParams{
+					ClusterID: clusterID,
+					ClusterUpdateParams: &models.ClusterUpdateParams{
+						NetworkType:                       swag.String(models.ClusterNetworkTypeOVNKubernetes),
+						ClusterNetworkCidr:                swag.String("10.128.0.0/14"),
+						ClusterNetworkHostPrefix:          swag.Int64(23),
+						SecondaryClusterNetworkCidr:       swag.String("10.132.0.0/14"),
+						SecondaryClusterNetworkHostPrefix: swag.Int64(23),
+						SecondaryServiceNetworkCidr:       swag.String("172.31.0.0/16"),
+					},
+				})
+				verifyApiError(reply, http.StatusBadRequest)
+			})
+			It("Update dual stack success", func() {
+				mockHostApi.EXPECT().GetStagesByRole(gomock.Any(), gomock.Any()).Return(nil).Times(3) // Number of hosts
+				mockHostApi.EXPECT().RefreshStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(3)
+				mockClusterApi.EXPECT().RefreshStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				reply := bm.UpdateCluster(ctx, installer.UpdateClusterParams{
+					ClusterID: clusterID,
+					ClusterUpdateParams: &models.ClusterUpdateParams{
+						NetworkType:                       swag.String(models.ClusterNetworkTypeOVNKubernetes),
+						ClusterNetworkCidr:                swag.String("10.128.0.0/14"),
+						ClusterNetworkHostPrefix:          swag.Int64(23),
+						ServiceNetworkCidr:                swag.String("172.30.0.0/16"),
+						SecondaryClusterNetworkCidr:       swag.String("fd01::/48"),
+						SecondaryClusterNetworkHostPrefix: swag.Int64(64),
+						SecondaryServiceNetworkCidr:       swag.String("fd02::/112"),
+					},
+				})
+				Expect(reply).To(BeAssignableToTypeOf(installer.NewUpdateClusterCreated()))
+				actual := reply.(*installer.UpdateClusterCreated)
+				Expect(actual.Payload.NetworkType).To(Equal(models.ClusterNetworkTypeOVNKubernetes))
+				Expect(actual.Payload.SecondaryClusterNetworkCidr).To(Equal("fd01::/48"))
+				Expect(actual.Payload.SecondaryClusterNetworkHostPrefix).To(Equal(int64(64)))
+				Expect(actual.Payload.SecondaryServiceNetworkCidr).To(Equal("fd02::/112"))
+			})
+			It("Update proxy settings", func() {
+				mockHostApi.EXPECT().GetStagesByRole(gomock.Any(), gomock.Any()).Return(nil).Times(3) // Number of hosts
+				mockHostApi.EXPECT().RefreshStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(3)
+				mockClusterApi.EXPECT().RefreshStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				reply := bm.UpdateCluster(ctx, installer.UpdateClusterParams{
+					ClusterID: clusterID,
+					ClusterUpdateParams: &models.ClusterUpdateParams{
+						HTTPProxy:  swag.String("http://proxy.example.com:3128"),
+						HTTPSProxy: swag.String("http://proxy.example.com:3128"),
+						NoProxy:    swag.String(".example.com"),
+					},
+				})
+				Expect(reply).To(BeAssignableToTypeOf(installer.NewUpdateClusterCreated()))
+				actual := reply.(*installer.UpdateClusterCreated)
+				Expect(actual.Payload.HTTPProxy).To(Equal("http://proxy.example.com:3128"))
+				Expect(actual.Payload.HTTPSProxy).To(Equal("http://proxy.example.com:3128"))
+				Expect(actual.Payload.NoProxy).To(Equal(".example.com"))
+			})
 			It("Update success", func() {
 				apiVip := "10.11.12.15"
 				ingressVip := "10.11.12.16"
@@ -1027,6 +1764,7 @@ var _ = Describe("cluster", func() {
 			mockHostPrepareForRefresh(mockHostApi)
 			mockHostPrepareForInstallationSuccess(mockHostApi, 3)
 			mockIsInstallable()
+			setDefaultGetOpenshiftVersion(mockVersions)
 			setDefaultJobCreate(mockJob)
 			setDefaultJobMonitor(mockJob)
 			setIgnitionGeneratorVersionSuccess(mockClusterApi)
@@ -1108,6 +1846,7 @@ var _ = Describe("cluster", func() {
 			mockIsInstallable()
 			mockClusterPrepareForInstallationSuccess(mockClusterApi)
 			mockHostPrepareForInstallationSuccess(mockHostApi, 3)
+			setDefaultGetOpenshiftVersion(mockVersions)
 			setDefaultJobCreate(mockJob)
 			setDefaultJobMonitor(mockJob)
 			setIgnitionGeneratorVersionSuccess(mockClusterApi)
@@ -1140,6 +1879,7 @@ var _ = Describe("cluster", func() {
 			mockIsInstallable()
 			setDefaultInstall(mockClusterApi)
 			setDefaultGetMasterNodesIds(mockClusterApi, 2)
+			setDefaultGetOpenshiftVersion(mockVersions)
 			setDefaultJobCreate(mockJob)
 			setDefaultJobMonitor(mockJob)
 			setIgnitionGeneratorVersionSuccess(mockClusterApi)
@@ -1162,6 +1902,7 @@ var _ = Describe("cluster", func() {
 			mockHostPrepareForInstallationSuccess(mockHostApi, 3)
 			mockIsInstallable()
 			setDefaultInstall(mockClusterApi)
+			setDefaultGetOpenshiftVersion(mockVersions)
 			setDefaultJobCreate(mockJob)
 			setDefaultJobMonitor(mockJob)
 			setIgnitionGeneratorVersionSuccess(mockClusterApi)
@@ -1321,6 +2062,90 @@ var _ = Describe("cluster", func() {
 	})
 })
 
+var _ = Describe("UpdateClusterInstallConfig", func() {
+	var (
+		bm             *bareMetalInventory
+		cfg            Config
+		db             *gorm.DB
+		ctx            = context.Background()
+		ctrl           *gomock.Controller
+		mockClusterApi *cluster.MockAPI
+		mockEvents     *events.MockHandler
+		mockJob        *job.MockAPI
+		clusterID      strfmt.UUID
+		dbName         = "update_cluster_install_config"
+	)
+
+	BeforeEach(func() {
+		Expect(envconfig.Process("test", &cfg)).ShouldNot(HaveOccurred())
+		ctrl = gomock.NewController(GinkgoT())
+		db = common.PrepareTestDB(dbName)
+		mockClusterApi = cluster.NewMockAPI(ctrl)
+		mockEvents = events.NewMockHandler(ctrl)
+		mockJob = job.NewMockAPI(ctrl)
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		bm = NewBareMetalInventory(db, getTestLog(), nil, mockClusterApi, cfg, mockJob, mockEvents, nil, nil, nil, nil)
+		clusterID = strfmt.UUID(uuid.New().String())
+		err := db.Create(&common.Cluster{Cluster: models.Cluster{
+			ID:     &clusterID,
+			Status: swag.String(models.ClusterStatusReady),
+		}}).Error
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	getOverrides := func() string {
+		var c common.Cluster
+		Expect(db.First(&c, "id = ?", clusterID).Error).ShouldNot(HaveOccurred())
+		return c.InstallConfigOverrides
+	}
+
+	It("stores the overrides", func() {
+		overrides := `{"networking":{"networkType":"OVNKubernetes"},"fips":true}`
+		mockClusterApi.EXPECT().VerifyClusterUpdatability(gomock.Any()).Return(nil).Times(1)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityInfo,
+			"Updated the install config overrides", gomock.Any()).Times(1)
+		reply := bm.UpdateClusterInstallConfig(ctx, installer.UpdateClusterInstallConfigParams{
+			ClusterID:           clusterID,
+			InstallConfigParams: models.InstallConfigParams(overrides),
+		})
+		Expect(reply).Should(BeAssignableToTypeOf(installer.NewUpdateClusterInstallConfigCreated()))
+		Expect(getOverrides()).Should(Equal(overrides))
+	})
+
+	It("rejects fields that can't be overridden", func() {
+		mockClusterApi.EXPECT().VerifyClusterUpdatability(gomock.Any()).Return(nil).Times(1)
+		reply := bm.UpdateClusterInstallConfig(ctx, installer.UpdateClusterInstallConfigParams{
+			ClusterID:           clusterID,
+			InstallConfigParams: `{"baseDomain":"example.com"}`,
+		})
+		verifyApiError(reply, http.StatusBadRequest)
+		Expect(getOverrides()).Should(BeEmpty())
+	})
+
+	It("fails when the cluster can't be updated", func() {
+		mockClusterApi.EXPECT().VerifyClusterUpdatability(gomock.Any()).
+			Return(errors.Errorf("cluster is installing")).Times(1)
+		reply := bm.UpdateClusterInstallConfig(ctx, installer.UpdateClusterInstallConfigParams{
+			ClusterID:           clusterID,
+			InstallConfigParams: `{"fips":true}`,
+		})
+		verifyApiError(reply, http.StatusConflict)
+	})
+
+	It("fails for a missing cluster", func() {
+		reply := bm.UpdateClusterInstallConfig(ctx, installer.UpdateClusterInstallConfigParams{
+			ClusterID:           strfmt.UUID(uuid.New().String()),
+			InstallConfigParams: `{"fips":true}`,
+		})
+		verifyApiError(reply, http.StatusNotFound)
+	})
+})
+
 var _ = Describe("KubeConfig download", func() {
 
 	var (
@@ -1348,7 +2173,7 @@ var _ = Describe("KubeConfig download", func() {
 			db, nil, nil, nil)
 
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
-		bm = NewBareMetalInventory(db, getTestLog(), nil, clusterApi, cfg, mockJob, nil, mockS3Client, nil)
+		bm = NewBareMetalInventory(db, getTestLog(), nil, clusterApi, cfg, mockJob, nil, mockS3Client, nil, nil, nil)
 		c = common.Cluster{Cluster: models.Cluster{
 			ID:     &clusterID,
 			APIVip: "10.11.12.13",
@@ -1401,6 +2226,306 @@ var _ = Describe("KubeConfig download", func() {
 	})
 })
 
+var _ = Describe("DownloadClusterISO", func() {
+	var (
+		bm           *bareMetalInventory
+		cfg          Config
+		db           *gorm.DB
+		ctx          = context.Background()
+		ctrl         *gomock.Controller
+		mockS3Client *awsS3Client.MockS3Client
+		mockEvents   *events.MockHandler
+		urlSigner    *auth.URLSigner
+		clusterID    strfmt.UUID
+		imgName      string
+		info         awsS3Client.ObjectInfo
+		dbName       = "download_cluster_iso"
+	)
+
+	BeforeEach(func() {
+		Expect(envconfig.Process("test", &cfg)).ShouldNot(HaveOccurred())
+		ctrl = gomock.NewController(GinkgoT())
+		db = common.PrepareTestDB(dbName)
+		mockS3Client = awsS3Client.NewMockS3Client(ctrl)
+		mockEvents = events.NewMockHandler(ctrl)
+		var err error
+		urlSigner, err = auth.NewURLSigner(getTestLog(), auth.Config{URLSigningKey: "key"})
+		Expect(err).ShouldNot(HaveOccurred())
+		cfg.ServiceURL = "10.35.59.36"
+		cfg.ServicePort = "30485"
+		bm = NewBareMetalInventory(db, getTestLog(), nil, nil, cfg, nil, mockEvents, mockS3Client, nil, nil, urlSigner)
+		clusterID = strfmt.UUID(uuid.New().String())
+		imgName = getImageName(clusterID)
+		info = awsS3Client.ObjectInfo{Key: imgName, Size: 100, ETag: "abc", LastModified: time.Now()}
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &clusterID, ImageInfo: &models.ImageInfo{
+			CreatedAt: strfmt.DateTime(time.Now()),
+			State:     models.ImageInfoStateReady,
+		}}}).Error).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	download := func(rangeHeader string) middleware.Responder {
+		params := installer.DownloadClusterISOParams{ClusterID: clusterID}
+		if rangeHeader != "" {
+			params.Range = swag.String(rangeHeader)
+		}
+		return bm.DownloadClusterISO(ctx, params)
+	}
+
+	setImageState := func(state, errorMessage string) {
+		Expect(db.Model(&common.Cluster{}).Where("id = ?", clusterID).Updates(map[string]interface{}{
+			"image_state": state, "image_error_message": errorMessage}).Error).ShouldNot(HaveOccurred())
+	}
+
+	It("image being generated", func() {
+		setImageState(models.ImageInfoStateBuilding, "")
+		reply := download("")
+		Expect(reply).Should(BeAssignableToTypeOf(installer.NewDownloadClusterISOConflict()))
+		Expect(*reply.(*installer.DownloadClusterISOConflict).Payload.Reason).Should(ContainSubstring("is being generated"))
+	})
+
+	It("image generation failed", func() {
+		setImageState(models.ImageInfoStateFailed, "error during image generation: dummy")
+		reply := download("")
+		Expect(reply).Should(BeAssignableToTypeOf(installer.NewDownloadClusterISOConflict()))
+		Expect(*reply.(*installer.DownloadClusterISOConflict).Payload.Reason).Should(ContainSubstring("dummy"))
+	})
+
+	It("image generated before its state was recorded", func() {
+		setImageState("", "")
+		mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(false, nil)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityError, gomock.Any(), gomock.Any())
+		Expect(download("")).Should(BeAssignableToTypeOf(installer.NewDownloadClusterISONotFound()))
+	})
+
+	It("image not found", func() {
+		mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(false, nil)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityError, gomock.Any(), gomock.Any())
+		Expect(download("")).Should(BeAssignableToTypeOf(installer.NewDownloadClusterISONotFound()))
+	})
+
+	It("storage failure", func() {
+		mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(true, nil)
+		mockS3Client.EXPECT().GetObjectInfo(ctx, imgName, "test").Return(awsS3Client.ObjectInfo{}, errors.Errorf("dummy"))
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityError, gomock.Any(), gomock.Any())
+		Expect(download("")).Should(BeAssignableToTypeOf(installer.NewDownloadClusterISOInternalServerError()))
+	})
+
+	It("downloads the whole image", func() {
+		r := ioutil.NopCloser(bytes.NewReader([]byte("image")))
+		mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(true, nil)
+		mockS3Client.EXPECT().GetObjectInfo(ctx, imgName, "test").Return(info, nil)
+		mockS3Client.EXPECT().DownloadFileFromS3(ctx, imgName, "test").Return(r, int64(100), nil)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityInfo, "Started image download", gomock.Any())
+		file := filemiddleware.FileInfo{Name: fmt.Sprintf("cluster-%s-discovery.iso", clusterID), Size: 100, ETag: "abc", LastModified: info.LastModified}
+		Expect(download("")).Should(Equal(filemiddleware.NewRangeResponder(installer.NewDownloadClusterISOOK().WithPayload(r), file, nil)))
+	})
+
+	It("resumes a download", func() {
+		r := ioutil.NopCloser(bytes.NewReader([]byte("image")))
+		mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(true, nil)
+		mockS3Client.EXPECT().GetObjectInfo(ctx, imgName, "test").Return(info, nil)
+		mockS3Client.EXPECT().DownloadRange(ctx, imgName, "test", int64(95), int64(5), "abc").Return(r, nil)
+		file := filemiddleware.FileInfo{Name: fmt.Sprintf("cluster-%s-discovery.iso", clusterID), Size: 100, ETag: "abc", LastModified: info.LastModified}
+		Expect(download("bytes=95-")).Should(Equal(filemiddleware.NewRangeResponder(
+			installer.NewDownloadClusterISOPartialContent().WithPayload(r), file, &filemiddleware.Range{Start: 95, Length: 5})))
+	})
+
+	It("downloads the whole image when it was replaced while resuming", func() {
+		r := ioutil.NopCloser(bytes.NewReader([]byte("image")))
+		newInfo := awsS3Client.ObjectInfo{Key: imgName, Size: 100, ETag: "def", LastModified: time.Now()}
+		mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(true, nil)
+		gomock.InOrder(
+			mockS3Client.EXPECT().GetObjectInfo(ctx, imgName, "test").Return(info, nil),
+			mockS3Client.EXPECT().DownloadRange(ctx, imgName, "test", int64(95), int64(5), "abc").
+				Return(nil, awsS3Client.ErrObjectChanged),
+			mockS3Client.EXPECT().GetObjectInfo(ctx, imgName, "test").Return(newInfo, nil),
+			mockS3Client.EXPECT().DownloadFileFromS3(ctx, imgName, "test").Return(r, int64(100), nil),
+		)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityInfo, "Started image download", gomock.Any())
+		reply := bm.DownloadClusterISO(ctx, installer.DownloadClusterISOParams{
+			ClusterID: clusterID,
+			Range:     swag.String("bytes=95-"),
+			IfRange:   swag.String(`"abc"`),
+		})
+		file := filemiddleware.FileInfo{Name: fmt.Sprintf("cluster-%s-discovery.iso", clusterID), Size: 100, ETag: "def", LastModified: newInfo.LastModified}
+		Expect(reply).Should(Equal(filemiddleware.NewRangeResponder(installer.NewDownloadClusterISOOK().WithPayload(r), file, nil)))
+	})
+
+	It("range not satisfiable", func() {
+		mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(true, nil)
+		mockS3Client.EXPECT().GetObjectInfo(ctx, imgName, "test").Return(info, nil)
+		reply := download("bytes=100-")
+		rec := httptest.NewRecorder()
+		reply.WriteResponse(rec, runtime.JSONProducer())
+		Expect(rec.Code).Should(Equal(http.StatusRequestedRangeNotSatisfiable))
+		Expect(rec.Header().Get("Content-Range")).Should(Equal("bytes */100"))
+	})
+
+	It("serves the image for a signed URL", func() {
+		r := ioutil.NopCloser(bytes.NewReader([]byte("image")))
+		mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(true, nil)
+		mockS3Client.EXPECT().GetObjectInfo(ctx, imgName, "test").Return(info, nil)
+		mockS3Client.EXPECT().DownloadRange(ctx, imgName, "test", int64(95), int64(5), "abc").Return(r, nil)
+		reply := bm.DownloadClusterISOPresigned(ctx, installer.DownloadClusterISOPresignedParams{
+			ClusterID: clusterID,
+			Range:     swag.String("bytes=95-"),
+		})
+		file := filemiddleware.FileInfo{Name: fmt.Sprintf("cluster-%s-discovery.iso", clusterID), Size: 100, ETag: "abc", LastModified: info.LastModified}
+		Expect(reply).Should(Equal(filemiddleware.NewRangeResponder(
+			installer.NewDownloadClusterISOPartialContent().WithPayload(r), file, &filemiddleware.Range{Start: 95, Length: 5})))
+	})
+
+	Context("GetPresignedForClusterISO", func() {
+		var createdAt time.Time
+
+		BeforeEach(func() {
+			Expect(db.Model(&common.Cluster{}).Where("id = ?", clusterID).
+				Update("image_created_at", strfmt.DateTime(time.Now())).Error).ShouldNot(HaveOccurred())
+			var cluster common.Cluster
+			Expect(db.First(&cluster, "id = ?", clusterID).Error).ShouldNot(HaveOccurred())
+			createdAt = time.Time(cluster.ImageInfo.CreatedAt)
+		})
+
+		getURL := func() middleware.Responder {
+			return bm.GetPresignedForClusterISO(ctx, installer.GetPresignedForClusterISOParams{ClusterID: clusterID})
+		}
+
+		It("returns a signed service URL", func() {
+			mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(true, nil)
+			mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityInfo, gomock.Any(), gomock.Any())
+			reply := getURL().(*installer.GetPresignedForClusterISOOK)
+			Expect(time.Time(*reply.Payload.ExpiresAt)).Should(BeTemporally("~", time.Now().Add(4*time.Hour), time.Minute))
+			u, err := url.Parse(*reply.Payload.URL)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(u.Scheme).Should(Equal("http"))
+			Expect(u.Host).Should(Equal("10.35.59.36:30485"))
+			Expect(u.Path).Should(Equal(fmt.Sprintf("/api/assisted-install/v1/clusters/%s/downloads/image-presigned", clusterID)))
+			expiresAt, err := strconv.ParseInt(u.Query().Get("expires_at"), 10, 64)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(expiresAt).Should(Equal(time.Time(*reply.Payload.ExpiresAt).Unix()))
+			Expect(urlSigner.Verify(clusterID.String(), createdAt, expiresAt, u.Query().Get("signature"))).ShouldNot(HaveOccurred())
+		})
+
+		It("uses the configured scheme", func() {
+			bm.ServiceScheme = "https"
+			mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(true, nil)
+			mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityInfo, gomock.Any(), gomock.Any())
+			reply := getURL().(*installer.GetPresignedForClusterISOOK)
+			Expect(*reply.Payload.URL).Should(HavePrefix("https://10.35.59.36:30485/"))
+		})
+
+		It("revokes the URL when a new image is generated", func() {
+			mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(true, nil)
+			mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityInfo, gomock.Any(), gomock.Any())
+			reply := getURL().(*installer.GetPresignedForClusterISOOK)
+			u, err := url.Parse(*reply.Payload.URL)
+			Expect(err).ShouldNot(HaveOccurred())
+			expiresAt, err := strconv.ParseInt(u.Query().Get("expires_at"), 10, 64)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(urlSigner.Verify(clusterID.String(), createdAt.Add(time.Minute), expiresAt, u.Query().Get("signature"))).Should(HaveOccurred())
+		})
+
+		It("image not generated", func() {
+			Expect(db.Model(&common.Cluster{}).Where("id = ?", clusterID).
+				Update("image_created_at", nil).Error).ShouldNot(HaveOccurred())
+			verifyApiError(getURL(), http.StatusConflict)
+		})
+
+		It("image expired", func() {
+			mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(false, nil)
+			verifyApiError(getURL(), http.StatusNotFound)
+		})
+
+		It("image being generated", func() {
+			setImageState(models.ImageInfoStatePending, "")
+			verifyApiError(getURL(), http.StatusConflict)
+		})
+
+		It("storage failure", func() {
+			mockS3Client.EXPECT().DoesObjectExist(ctx, imgName, "test").Return(false, errors.Errorf("dummy"))
+			verifyApiError(getURL(), http.StatusInternalServerError)
+		})
+	})
+})
+
+var _ = Describe("DownloadClusterFiles", func() {
+	var (
+		bm           *bareMetalInventory
+		cfg          Config
+		db           *gorm.DB
+		ctx          = context.Background()
+		ctrl         *gomock.Controller
+		mockS3Client *awsS3Client.MockS3Client
+		clusterID    strfmt.UUID
+		objectName   string
+		dbName       = "download_cluster_files"
+	)
+
+	BeforeEach(func() {
+		Expect(envconfig.Process("test", &cfg)).ShouldNot(HaveOccurred())
+		ctrl = gomock.NewController(GinkgoT())
+		db = common.PrepareTestDB(dbName)
+		mockS3Client = awsS3Client.NewMockS3Client(ctrl)
+		clusterApi := cluster.NewManager(cluster.Config{}, getTestLog().WithField("pkg", "cluster-monitor"),
+			db, nil, nil, nil)
+		bm = NewBareMetalInventory(db, getTestLog(), nil, clusterApi, cfg, nil, nil, mockS3Client, nil, nil, nil)
+		clusterID = strfmt.UUID(uuid.New().String())
+		objectName = fmt.Sprintf("%s/%s", clusterID, "bootstrap.ign")
+		status := ClusterStatusInstalled
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &clusterID, Status: &status}}).Error).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	It("downloads a range of a file", func() {
+		r := ioutil.NopCloser(bytes.NewReader([]byte("ignition")))
+		info := awsS3Client.ObjectInfo{Key: objectName, Size: 50, ETag: "abc", LastModified: time.Now()}
+		mockS3Client.EXPECT().GetObjectInfo(ctx, objectName, "test").Return(info, nil)
+		mockS3Client.EXPECT().DownloadRange(ctx, objectName, "test", int64(0), int64(10), "abc").Return(r, nil)
+		reply := bm.DownloadClusterFiles(ctx, installer.DownloadClusterFilesParams{
+			ClusterID: clusterID,
+			FileName:  "bootstrap.ign",
+			Range:     swag.String("bytes=0-9"),
+			IfRange:   swag.String(`"abc"`),
+		})
+		file := filemiddleware.FileInfo{Name: "bootstrap.ign", Size: 50, ETag: "abc", LastModified: info.LastModified}
+		Expect(reply).Should(Equal(filemiddleware.NewRangeResponder(
+			installer.NewDownloadClusterFilesPartialContent().WithPayload(r), file, &filemiddleware.Range{Start: 0, Length: 10})))
+	})
+
+	It("downloads the whole file when the file changed", func() {
+		r := ioutil.NopCloser(bytes.NewReader([]byte("ignition")))
+		info := awsS3Client.ObjectInfo{Key: objectName, Size: 50, ETag: "def", LastModified: time.Now()}
+		mockS3Client.EXPECT().GetObjectInfo(ctx, objectName, "test").Return(info, nil)
+		mockS3Client.EXPECT().DownloadFileFromS3(ctx, objectName, "test").Return(r, int64(50), nil)
+		reply := bm.DownloadClusterFiles(ctx, installer.DownloadClusterFilesParams{
+			ClusterID: clusterID,
+			FileName:  "bootstrap.ign",
+			Range:     swag.String("bytes=0-9"),
+			IfRange:   swag.String(`"abc"`),
+		})
+		Expect(reply).Should(BeAssignableToTypeOf(filemiddleware.NewRangeResponder(nil, filemiddleware.FileInfo{}, nil)))
+		rec := httptest.NewRecorder()
+		reply.WriteResponse(rec, runtime.ByteStreamProducer())
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Length")).Should(Equal("50"))
+	})
+
+	It("storage failure", func() {
+		mockS3Client.EXPECT().GetObjectInfo(ctx, objectName, "test").Return(awsS3Client.ObjectInfo{}, errors.Errorf("dummy"))
+		reply := bm.DownloadClusterFiles(ctx, installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "bootstrap.ign"})
+		Expect(reply).Should(BeAssignableToTypeOf(installer.NewDownloadClusterFilesInternalServerError()))
+	})
+})
+
 var _ = Describe("UploadClusterIngressCert test", func() {
 
 	var (
@@ -1442,7 +2567,7 @@ var _ = Describe("UploadClusterIngressCert test", func() {
 		clusterApi = cluster.NewManager(cluster.Config{}, getTestLog().WithField("pkg", "cluster-monitor"),
 			db, nil, nil, nil)
 		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
-		bm = NewBareMetalInventory(db, getTestLog(), nil, clusterApi, cfg, mockJob, nil, mockS3Client, nil)
+		bm = NewBareMetalInventory(db, getTestLog(), nil, clusterApi, cfg, mockJob, nil, mockS3Client, nil, nil, nil)
 		c = common.Cluster{Cluster: models.Cluster{
 			ID:     &clusterID,
 			APIVip: "10.11.12.13",
@@ -0,0 +1,209 @@
+package bminventory
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/kelseyhightower/envconfig"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/host"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/auth"
+	"github.com/openshift/assisted-service/restapi/operations/installer"
+)
+
+func userContext(userID, orgID, role string) context.Context {
+	return auth.IdentityToContext(context.Background(), &auth.Identity{UserID: userID, OrgID: orgID, Role: role})
+}
+
+func responseCode(responder middleware.Responder) int {
+	rec := httptest.NewRecorder()
+	responder.WriteResponse(rec, runtime.JSONProducer())
+	return rec.Code
+}
+
+var _ = Describe("org ownership", func() {
+	var (
+		bm          *bareMetalInventory
+		ctrl        *gomock.Controller
+		mockHostApi *host.MockAPI
+		cfg         Config
+		db          *gorm.DB
+		dbName      = "org_ownership"
+		clusterID   strfmt.UUID
+		hostID      strfmt.UUID
+		owner       = userContext("user1", "org1", "user")
+		stranger    = userContext("user2", "org2", "user")
+		admin       = userContext("admin", "org2", auth.AdminUserRole)
+	)
+
+	BeforeEach(func() {
+		Expect(envconfig.Process("test", &cfg)).ShouldNot(HaveOccurred())
+		db = common.PrepareTestDB(dbName)
+		ctrl = gomock.NewController(GinkgoT())
+		mockHostApi = host.NewMockAPI(ctrl)
+		mockHostApi.EXPECT().GetStagesByRole(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		bm = NewBareMetalInventory(db, getTestLog(), mockHostApi, nil, cfg, nil, nil, nil, nil, nil, nil)
+		clusterID = strfmt.UUID(uuid.New().String())
+		hostID = strfmt.UUID(uuid.New().String())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{
+			ID:     &clusterID,
+			UserID: "user1",
+			OrgID:  "org1",
+		}}).Error).ShouldNot(HaveOccurred())
+		Expect(db.Create(&models.Host{
+			ID:        &hostID,
+			ClusterID: clusterID,
+			Status:    swag.String(host.HostStatusKnown),
+		}).Error).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	routes := []struct {
+		name string
+		call func(ctx context.Context) middleware.Responder
+	}{
+		{"GetCluster", func(ctx context.Context) middleware.Responder {
+			return bm.GetCluster(ctx, installer.GetClusterParams{ClusterID: clusterID})
+		}},
+		{"UpdateCluster", func(ctx context.Context) middleware.Responder {
+			return bm.UpdateCluster(ctx, installer.UpdateClusterParams{ClusterID: clusterID,
+				ClusterUpdateParams: &models.ClusterUpdateParams{}})
+		}},
+		{"UpdateClusterInstallConfig", func(ctx context.Context) middleware.Responder {
+			return bm.UpdateClusterInstallConfig(ctx, installer.UpdateClusterInstallConfigParams{ClusterID: clusterID,
+				InstallConfigParams: `{"fips":true}`})
+		}},
+		{"DeregisterCluster", func(ctx context.Context) middleware.Responder {
+			return bm.DeregisterCluster(ctx, installer.DeregisterClusterParams{ClusterID: clusterID})
+		}},
+		{"GenerateClusterISO", func(ctx context.Context) middleware.Responder {
+			return bm.GenerateClusterISO(ctx, installer.GenerateClusterISOParams{ClusterID: clusterID,
+				ImageCreateParams: &models.ImageCreateParams{}})
+		}},
+		{"DownloadClusterISO", func(ctx context.Context) middleware.Responder {
+			return bm.DownloadClusterISO(ctx, installer.DownloadClusterISOParams{ClusterID: clusterID})
+		}},
+		{"InstallCluster", func(ctx context.Context) middleware.Responder {
+			return bm.InstallCluster(ctx, installer.InstallClusterParams{ClusterID: clusterID})
+		}},
+		{"CancelInstallation", func(ctx context.Context) middleware.Responder {
+			return bm.CancelInstallation(ctx, installer.CancelInstallationParams{ClusterID: clusterID})
+		}},
+		{"ResetCluster", func(ctx context.Context) middleware.Responder {
+			return bm.ResetCluster(ctx, installer.ResetClusterParams{ClusterID: clusterID})
+		}},
+		{"CompleteInstallation", func(ctx context.Context) middleware.Responder {
+			return bm.CompleteInstallation(ctx, installer.CompleteInstallationParams{ClusterID: clusterID,
+				CompletionParams: &models.CompletionParams{IsSuccess: swag.Bool(true)}})
+		}},
+		{"DownloadClusterFiles", func(ctx context.Context) middleware.Responder {
+			return bm.DownloadClusterFiles(ctx, installer.DownloadClusterFilesParams{ClusterID: clusterID,
+				FileName: "bootstrap.ign"})
+		}},
+		{"DownloadClusterKubeconfig", func(ctx context.Context) middleware.Responder {
+			return bm.DownloadClusterKubeconfig(ctx, installer.DownloadClusterKubeconfigParams{ClusterID: clusterID})
+		}},
+		{"GetCredentials", func(ctx context.Context) middleware.Responder {
+			return bm.GetCredentials(ctx, installer.GetCredentialsParams{ClusterID: clusterID})
+		}},
+		{"UploadClusterIngressCert", func(ctx context.Context) middleware.Responder {
+			return bm.UploadClusterIngressCert(ctx, installer.UploadClusterIngressCertParams{ClusterID: clusterID,
+				IngressCertParams: "cert"})
+		}},
+		{"GetFreeAddresses", func(ctx context.Context) middleware.Responder {
+			return bm.GetFreeAddresses(ctx, installer.GetFreeAddressesParams{ClusterID: clusterID,
+				Network: "10.0.0.0/24"})
+		}},
+		{"GetClusterConnectivityMatrix", func(ctx context.Context) middleware.Responder {
+			return bm.GetClusterConnectivityMatrix(ctx, installer.GetClusterConnectivityMatrixParams{ClusterID: clusterID})
+		}},
+		{"ListHosts", func(ctx context.Context) middleware.Responder {
+			return bm.ListHosts(ctx, installer.ListHostsParams{ClusterID: clusterID})
+		}},
+		{"RegisterHost", func(ctx context.Context) middleware.Responder {
+			id := strfmt.UUID(uuid.New().String())
+			return bm.RegisterHost(ctx, installer.RegisterHostParams{ClusterID: clusterID,
+				NewHostParams: &models.HostCreateParams{HostID: &id}})
+		}},
+		{"GetHost", func(ctx context.Context) middleware.Responder {
+			return bm.GetHost(ctx, installer.GetHostParams{ClusterID: clusterID, HostID: hostID})
+		}},
+		{"DeregisterHost", func(ctx context.Context) middleware.Responder {
+			return bm.DeregisterHost(ctx, installer.DeregisterHostParams{ClusterID: clusterID, HostID: hostID})
+		}},
+		{"EnableHost", func(ctx context.Context) middleware.Responder {
+			return bm.EnableHost(ctx, installer.EnableHostParams{ClusterID: clusterID, HostID: hostID})
+		}},
+		{"DisableHost", func(ctx context.Context) middleware.Responder {
+			return bm.DisableHost(ctx, installer.DisableHostParams{ClusterID: clusterID, HostID: hostID})
+		}},
+		{"GetNextSteps", func(ctx context.Context) middleware.Responder {
+			return bm.GetNextSteps(ctx, installer.GetNextStepsParams{ClusterID: clusterID, HostID: hostID})
+		}},
+		{"PostStepReply", func(ctx context.Context) middleware.Responder {
+			return bm.PostStepReply(ctx, installer.PostStepReplyParams{ClusterID: clusterID, HostID: hostID,
+				Reply: &models.StepReply{}})
+		}},
+		{"UpdateHostInstallProgress", func(ctx context.Context) middleware.Responder {
+			return bm.UpdateHostInstallProgress(ctx, installer.UpdateHostInstallProgressParams{ClusterID: clusterID,
+				HostID: hostID, HostProgress: &models.HostProgress{}})
+		}},
+		{"SetDebugStep", func(ctx context.Context) middleware.Responder {
+			return bm.SetDebugStep(ctx, installer.SetDebugStepParams{ClusterID: clusterID, HostID: hostID,
+				Step: &models.DebugStep{Command: swag.String("ls")}})
+		}},
+	}
+
+	for _, r := range routes {
+		call := r.call
+		It("hides the cluster of another org from "+r.name, func() {
+			Expect(responseCode(call(stranger))).Should(Equal(http.StatusNotFound))
+		})
+	}
+
+	It("lists only the clusters of the caller's org", func() {
+		reply := bm.ListClusters(stranger, installer.ListClustersParams{}).(*installer.ListClustersOK)
+		Expect(reply.Payload).Should(BeEmpty())
+		reply = bm.ListClusters(owner, installer.ListClustersParams{}).(*installer.ListClustersOK)
+		Expect(reply.Payload).Should(HaveLen(1))
+		Expect(*reply.Payload[0].ID).Should(Equal(clusterID))
+	})
+
+	It("lists the clusters of every org for admins", func() {
+		otherID := strfmt.UUID(uuid.New().String())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &otherID, OrgID: "org3"}}).Error).
+			ShouldNot(HaveOccurred())
+		reply := bm.ListClusters(admin, installer.ListClustersParams{}).(*installer.ListClustersOK)
+		Expect(reply.Payload).Should(HaveLen(2))
+	})
+
+	for _, c := range []struct {
+		name string
+		ctx  context.Context
+	}{{"the owner org", owner}, {"admins", admin}} {
+		ctx := c.ctx
+		It("lets "+c.name+" access the cluster and its hosts", func() {
+			Expect(bm.GetCluster(ctx, installer.GetClusterParams{ClusterID: clusterID})).
+				Should(BeAssignableToTypeOf(installer.NewGetClusterOK()))
+			Expect(bm.ListHosts(ctx, installer.ListHostsParams{ClusterID: clusterID})).
+				Should(BeAssignableToTypeOf(installer.NewListHostsOK()))
+			Expect(bm.GetHost(ctx, installer.GetHostParams{ClusterID: clusterID, HostID: hostID})).
+				Should(BeAssignableToTypeOf(installer.NewGetHostOK()))
+		})
+	}
+})
@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/jinzhu/gorm"
+	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/events"
 	"github.com/openshift/assisted-service/internal/host"
@@ -65,7 +67,8 @@ type API interface {
 }
 
 type Config struct {
-	PrepareConfig PrepareConfig
+	PrepareConfig        PrepareConfig
+	PullSecretValidation validations.PullSecretValidatorConfig
 }
 
 type Manager struct {
@@ -84,6 +87,7 @@ type Manager struct {
 	eventsHandler   events.Handler
 	sm              stateswitch.StateMachine
 	metricAPI       metrics.API
+	rp              *refreshPreprocessor
 }
 
 func NewManager(cfg Config, log logrus.FieldLogger, db *gorm.DB, eventsHandler events.Handler, hostAPI host.API, metricApi metrics.API) *Manager {
@@ -106,6 +110,7 @@ func NewManager(cfg Config, log logrus.FieldLogger, db *gorm.DB, eventsHandler e
 		eventsHandler:   eventsHandler,
 		sm:              NewClusterStateMachine(th),
 		metricAPI:       metricApi,
+		rp:              newRefreshPreprocessor(log, validations.NewPullSecretValidator(cfg.PullSecretValidation, nil)),
 	}
 }
 
@@ -167,6 +172,9 @@ func (m *Manager) RefreshStatus(ctx context.Context, c *common.Cluster, db *gorm
 	if err != nil {
 		return nil, err
 	}
+	if err = m.refreshValidations(ctx, &cluster, db); err != nil {
+		return nil, err
+	}
 
 	clusterAfterRefresh, err := state.RefreshStatus(ctx, &cluster, db)
 	//report installation finished metric if needed
@@ -178,6 +186,30 @@ func (m *Manager) RefreshStatus(ctx context.Context, c *common.Cluster, db *gorm
 	return clusterAfterRefresh, err
 }
 
+// Validate the clusters that are not installed yet, the states decide whether the cluster is ready by the results
+func (m *Manager) refreshValidations(ctx context.Context, c *common.Cluster, db *gorm.DB) error {
+	status := swag.StringValue(c.Status)
+	if status != models.ClusterStatusInsufficient && status != models.ClusterStatusReady {
+		return nil
+	}
+	validationsInfo, err := m.rp.preprocess(&validationContext{ctx: ctx, cluster: c})
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(validationsInfo)
+	if err != nil {
+		return err
+	}
+	if string(b) == c.ValidationsInfo {
+		return nil
+	}
+	if err = db.Model(&common.Cluster{}).Where("id = ?", c.ID.String()).Update("validations_info", string(b)).Error; err != nil {
+		return errors.Wrapf(err, "failed to update the validations of cluster %s", c.ID.String())
+	}
+	c.ValidationsInfo = string(b)
+	return nil
+}
+
 func (m *Manager) Install(ctx context.Context, c *common.Cluster, db *gorm.DB) error {
 	return m.installationAPI.Install(ctx, c, db)
 }
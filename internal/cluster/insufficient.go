@@ -46,7 +46,7 @@ func (i *insufficientState) RefreshStatus(ctx context.Context, c *common.Cluster
 
 	// Cluster is ready
 	mastersInKnown, ok := mappedMastersByRole[models.HostStatusKnown]
-	if ok && len(mastersInKnown) == minHostsNeededForInstallation && c.APIVip != "" && c.IngressVip != "" {
+	if ok && len(mastersInKnown) == minHostsNeededForInstallation && c.APIVip != "" && c.IngressVip != "" && isValid(c) {
 		log.Infof("Cluster %s has %d known master hosts, cluster is ready.", c.ID, minHostsNeededForInstallation)
 		return updateClusterStatus(log, db, *c.ID, swag.StringValue(c.Status), clusterStatusReady, statusInfoReady)
 
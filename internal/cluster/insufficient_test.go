@@ -10,6 +10,7 @@ import (
 	"github.com/jinzhu/gorm"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/events"
 	"github.com/openshift/assisted-service/internal/host"
@@ -39,6 +40,7 @@ var _ = Describe("insufficient_state", func() {
 			insufficient:    NewInsufficientState(getTestLog(), db, mockHostAPI),
 			registrationAPI: NewRegistrar(getTestLog(), db),
 			eventsHandler:   mockEvents,
+			rp:              newRefreshPreprocessor(getTestLog(), validations.NewPullSecretValidator(validations.PullSecretValidatorConfig{}, nil)),
 		}
 
 		id = strfmt.UUID(uuid.New().String())
@@ -95,6 +97,19 @@ var _ = Describe("insufficient_state", func() {
 			Expect(*refreshedCluster.Status).Should(Equal(models.ClusterStatusReady))
 
 		})
+
+		It("answering requirement to be ready but failing the validations", func() {
+			manager.(*Manager).rp = newRefreshPreprocessor(getTestLog(), validations.NewPullSecretValidator(validations.PullSecretValidatorConfig{
+				RequiredRegistries: []string{"registry.redhat.io"},
+			}, nil))
+			addInstallationRequirements(id, db)
+			mockHostAPIIsRequireUserActionResetFalse(3)
+			refreshedCluster, updateErr := manager.RefreshStatus(ctx, &cluster, db)
+			Expect(updateErr).Should(BeNil())
+			Expect(*refreshedCluster.Status).Should(Equal(models.ClusterStatusInsufficient))
+			c := geCluster(*cluster.ID, db)
+			Expect(c.ValidationsInfo).Should(ContainSubstring("Pull secret is not set"))
+		})
 	})
 
 	AfterEach(func() {
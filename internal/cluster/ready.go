@@ -40,6 +40,10 @@ func (r *readyState) RefreshStatus(ctx context.Context, c *common.Cluster, db *g
 		log.Infof("Cluster %s dos not have exactly %d known master hosts, cluster is insufficient.", c.ID, minHostsNeededForInstallation)
 		return updateClusterStatus(log, db, *c.ID, swag.StringValue(c.Status), clusterStatusInsufficient, statusInfoInsufficient)
 
+	} else if !isValid(c) {
+		log.Infof("Cluster %s failed its validations, cluster is insufficient.", c.ID)
+		return updateClusterStatus(log, db, *c.ID, swag.StringValue(c.Status), clusterStatusInsufficient, statusInfoValidationsFailed)
+
 		//cluster is still ready
 	} else {
 		return c, nil
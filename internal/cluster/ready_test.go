@@ -9,10 +9,14 @@ import (
 	"github.com/jinzhu/gorm"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/cluster/validations"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/models"
 )
 
+// #nosec
+const testPullSecret = "{\"auths\":{\"cloud.openshift.com\":{\"auth\":\"dXNlcjpwYXNzd29yZAo=\",\"email\":\"r@r.com\"}}}"
+
 var _ = Describe("ready_state", func() {
 	var (
 		ctx     = context.Background()
@@ -25,7 +29,8 @@ var _ = Describe("ready_state", func() {
 
 	BeforeEach(func() {
 		db = common.PrepareTestDB(dbName)
-		state = &Manager{log: getTestLog(), ready: NewReadyState(getTestLog(), db)}
+		state = &Manager{log: getTestLog(), ready: NewReadyState(getTestLog(), db),
+			rp: newRefreshPreprocessor(getTestLog(), validations.NewPullSecretValidator(validations.PullSecretValidatorConfig{}, nil))}
 
 		id = strfmt.UUID(uuid.New().String())
 		cluster = common.Cluster{Cluster: models.Cluster{
@@ -57,6 +62,24 @@ var _ = Describe("ready_state", func() {
 			Expect(updateErr).Should(BeNil())
 			Expect(*clusterAfterRefresh.Status).Should(Equal(clusterStatusInsufficient))
 		})
+
+		It("cluster is failing its validations", func() {
+			state = &Manager{log: getTestLog(), ready: NewReadyState(getTestLog(), db),
+				rp: newRefreshPreprocessor(getTestLog(), validations.NewPullSecretValidator(validations.PullSecretValidatorConfig{
+					RequiredRegistries: []string{"registry.redhat.io"},
+				}, nil))}
+			Expect(db.Model(&cluster).Updates(map[string]interface{}{"pull_secret_set": true, "pull_secret": testPullSecret}).Error).
+				ShouldNot(HaveOccurred())
+
+			cluster = geCluster(*cluster.ID, db)
+			clusterAfterRefresh, updateErr := state.RefreshStatus(ctx, &cluster, db)
+
+			Expect(updateErr).Should(BeNil())
+			Expect(*clusterAfterRefresh.Status).Should(Equal(clusterStatusInsufficient))
+			Expect(swag.StringValue(clusterAfterRefresh.StatusInfo)).Should(Equal(statusInfoValidationsFailed))
+			Expect(clusterAfterRefresh.ValidationsInfo).Should(ContainSubstring(
+				"Pull secret has no credentials for the required registries registry.redhat.io"))
+		})
 	})
 	AfterEach(func() {
 		common.DeleteTestDB(db, dbName)
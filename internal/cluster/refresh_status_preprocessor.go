@@ -0,0 +1,85 @@
+package cluster
+
+import (
+	"encoding/json"
+
+	"github.com/openshift/assisted-service/internal/cluster/validations"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/sirupsen/logrus"
+)
+
+type validationResult struct {
+	ID      validationID     `json:"id"`
+	Status  validationStatus `json:"status"`
+	Message string           `json:"message"`
+}
+
+type refreshPreprocessor struct {
+	log         logrus.FieldLogger
+	validations []validation
+}
+
+func newRefreshPreprocessor(log logrus.FieldLogger, pullSecretValidator validations.PullSecretValidator) *refreshPreprocessor {
+	return &refreshPreprocessor{
+		log:         log,
+		validations: newValidations(log, pullSecretValidator),
+	}
+}
+
+func (r *refreshPreprocessor) preprocess(c *validationContext) (map[string][]validationResult, error) {
+	validationsOutput := make(map[string][]validationResult)
+	for _, v := range r.validations {
+		st := v.condition(c)
+		message := v.formatter(c, st)
+		category, err := v.id.category()
+		if err != nil {
+			r.log.WithError(err).Warn("id.category()")
+			return nil, err
+		}
+		validationsOutput[category] = append(validationsOutput[category], validationResult{
+			ID:      v.id,
+			Status:  st,
+			Message: message,
+		})
+	}
+	return validationsOutput, nil
+}
+
+func newValidations(log logrus.FieldLogger, pullSecretValidator validations.PullSecretValidator) []validation {
+	v := validator{
+		log:                 log,
+		pullSecretValidator: pullSecretValidator,
+	}
+	ret := []validation{
+		{
+			id:        PullSecretHasRequiredRegistries,
+			condition: v.hasRequiredRegistries,
+			formatter: v.printHasRequiredRegistries,
+		},
+		{
+			id:        PullSecretCredentialsValid,
+			condition: v.hasValidCredentials,
+			formatter: v.printHasValidCredentials,
+		},
+	}
+	return ret
+}
+
+// isValid reports whether all the validations of the cluster succeeded, clusters that were not validated yet are valid
+func isValid(c *common.Cluster) bool {
+	if c.ValidationsInfo == "" {
+		return true
+	}
+	var validationsInfo map[string][]validationResult
+	if err := json.Unmarshal([]byte(c.ValidationsInfo), &validationsInfo); err != nil {
+		return false
+	}
+	for _, results := range validationsInfo {
+		for _, result := range results {
+			if result.Status != ValidationSuccess {
+				return false
+			}
+		}
+	}
+	return true
+}
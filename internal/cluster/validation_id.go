@@ -0,0 +1,28 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+)
+
+type validationID models.ClusterValidationID
+
+const (
+	PullSecretHasRequiredRegistries = validationID(models.ClusterValidationIDPullSecretHasRequiredRegistries)
+	PullSecretCredentialsValid      = validationID(models.ClusterValidationIDPullSecretCredentialsValid)
+)
+
+func (v validationID) category() (string, error) {
+	switch v {
+	case PullSecretHasRequiredRegistries, PullSecretCredentialsValid:
+		return "pull-secret", nil
+	}
+	return "", common.NewApiError(http.StatusInternalServerError, errors.Errorf("Unexpected validation id %s", string(v)))
+}
+
+func (v validationID) String() string {
+	return string(v)
+}
@@ -0,0 +1,307 @@
+package validations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The pull secret authenticates against cloud.openshift.com for telemetry, it is not an image registry
+var nonRegistryAuths = []string{"cloud.openshift.com"}
+
+// The registry that serves the images of docker.io, the pull secret entries refer to it by its index
+const dockerHubRegistry = "registry-1.docker.io"
+
+type PullSecretValidatorConfig struct {
+	// Registries that the pull secrets must have credentials for, e.g. registry.redhat.io
+	RequiredRegistries []string `envconfig:"PULL_SECRET_REQUIRED_REGISTRIES" default:""`
+	// Log in to the registries of the pull secrets to check that their credentials are accepted
+	CheckRegistryAuth   bool          `envconfig:"PULL_SECRET_CHECK_REGISTRY_AUTH" default:"false"`
+	RegistryAuthTimeout time.Duration `envconfig:"PULL_SECRET_REGISTRY_AUTH_TIMEOUT" default:"10s"`
+	// How long the result of logging in with a pull secret is reused before logging in again
+	RegistryAuthCacheTTL time.Duration `envconfig:"PULL_SECRET_REGISTRY_AUTH_CACHE_TTL" default:"10m"`
+	// How long to wait before logging in again after the registries couldn't be reached
+	RegistryAuthRetryInterval time.Duration `envconfig:"PULL_SECRET_REGISTRY_AUTH_RETRY_INTERVAL" default:"30s"`
+}
+
+type PullSecretValidator interface {
+	// RequiresRegistries reports whether the pull secrets must have credentials for some registries
+	RequiresRegistries() bool
+	// MissingRegistries returns the required registries that the pull secret has no credentials for, sorted
+	MissingRegistries(secret string) ([]string, error)
+	// RegistryAuthEnabled reports whether the credentials of the pull secrets are checked against their registries
+	RegistryAuthEnabled() bool
+	/*
+	 * RegistryAuthStatus returns the result of logging in to each registry of the pull secret. The logins run in
+	 * the background so the callers never wait for the registries: checked is false until they complete, and while
+	 * the registries can't be reached, in which case err is the last error and the logins are retried.
+	 */
+	RegistryAuthStatus(secret string) (checked bool, err error)
+}
+
+// The error of a registry that rejected the credentials, other errors are transient and the logins are retried
+type credentialsRejectedError struct {
+	registry string
+}
+
+func (e *credentialsRejectedError) Error() string {
+	return fmt.Sprintf("registry %s rejected the credentials of the pull secret", e.registry)
+}
+
+type registryAuthResult struct {
+	err       error
+	checked   bool
+	checkedAt time.Time
+}
+
+type pullSecretValidator struct {
+	PullSecretValidatorConfig
+	client  *http.Client
+	lock    sync.Mutex
+	cache   map[string]registryAuthResult
+	running map[string]bool
+}
+
+/*
+ * NewPullSecretValidator returns a validator that logs in to the registries with the specified client, or with a
+ * client that times out after the configured timeout if the client is nil. The registries are expected to serve
+ * the v2 API over https.
+ */
+func NewPullSecretValidator(cfg PullSecretValidatorConfig, client *http.Client) PullSecretValidator {
+	if client == nil {
+		client = &http.Client{Timeout: cfg.RegistryAuthTimeout}
+	}
+	return &pullSecretValidator{
+		PullSecretValidatorConfig: cfg,
+		client:                    client,
+		cache:                     make(map[string]registryAuthResult),
+		running:                   make(map[string]bool),
+	}
+}
+
+func (v *pullSecretValidator) RequiresRegistries() bool {
+	for _, registry := range v.RequiredRegistries {
+		if strings.TrimSpace(registry) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *pullSecretValidator) MissingRegistries(secret string) ([]string, error) {
+	creds, err := ParsePullSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	registries := make(map[string]bool)
+	for registry := range creds {
+		registries[registryHost(registry)] = true
+	}
+	missing := make([]string, 0)
+	for _, registry := range v.RequiredRegistries {
+		if registry = strings.TrimSpace(registry); registry != "" && !registries[registryHost(registry)] {
+			missing = append(missing, registry)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+func (v *pullSecretValidator) RegistryAuthEnabled() bool {
+	return v.CheckRegistryAuth
+}
+
+func (v *pullSecretValidator) RegistryAuthStatus(secret string) (bool, error) {
+	sum := sha256.Sum256([]byte(secret))
+	key := hex.EncodeToString(sum[:])
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	result, ok := v.cache[key]
+	if ok && time.Since(result.checkedAt) < v.expiration(result) {
+		return result.checked, result.err
+	}
+	if !v.running[key] {
+		v.running[key] = true
+		go v.checkRegistryAuth(key, secret)
+	}
+	// The expired result is still reported while the logins are refreshed
+	return result.checked, result.err
+}
+
+func (v *pullSecretValidator) expiration(result registryAuthResult) time.Duration {
+	if result.checked {
+		return v.RegistryAuthCacheTTL
+	}
+	return v.RegistryAuthRetryInterval
+}
+
+func (v *pullSecretValidator) checkRegistryAuth(key, secret string) {
+	// Only invalid pull secrets and credentials rejected by the registries fail, anything else is retried
+	_, invalid := ParsePullSecret(secret)
+	err := v.validateRegistryAuth(context.Background(), secret)
+	_, rejected := errors.Cause(err).(*credentialsRejectedError)
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	delete(v.running, key)
+	for k, r := range v.cache {
+		if time.Since(r.checkedAt) >= v.expiration(r) {
+			delete(v.cache, k)
+		}
+	}
+	v.cache[key] = registryAuthResult{err: err, checked: err == nil || rejected || invalid != nil, checkedAt: time.Now()}
+}
+
+func (v *pullSecretValidator) validateRegistryAuth(ctx context.Context, secret string) error {
+	creds, err := ParsePullSecret(secret)
+	if err != nil {
+		return err
+	}
+	registries := make([]string, 0, len(creds))
+	for registry := range creds {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	for _, registry := range registries {
+		if isNonRegistryAuth(registry) {
+			continue
+		}
+		if err = v.login(ctx, creds[registry]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+ * Log in to the registry with the docker registry v2 auth handshake: the registry answers an anonymous request to
+ * /v2/ with a challenge, either for basic auth on the registry itself or for a bearer token issued by its realm.
+ */
+func (v *pullSecretValidator) login(ctx context.Context, creds PullSecretCreds) error {
+	registry := registryHost(creds.Registry)
+	if registry == "docker.io" {
+		registry = dockerHubRegistry
+	}
+	endpoint := fmt.Sprintf("https://%s/v2/", registry)
+	resp, err := v.get(ctx, endpoint, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to reach registry %s", creds.Registry)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return errors.Errorf("registry %s responded with unexpected status %d", creds.Registry, resp.StatusCode)
+	}
+
+	scheme, params := parseAuthChallenge(resp.Header.Get("WWW-Authenticate"))
+	switch strings.ToLower(scheme) {
+	case "basic":
+		resp, err = v.get(ctx, endpoint, &creds)
+	case "bearer":
+		var realm *url.URL
+		if realm, err = url.Parse(params["realm"]); err != nil || realm.Host == "" {
+			return errors.Errorf("registry %s responded with an invalid token realm %q", creds.Registry, params["realm"])
+		}
+		query := realm.Query()
+		if params["service"] != "" {
+			query.Set("service", params["service"])
+		}
+		if params["scope"] != "" {
+			query.Set("scope", params["scope"])
+		}
+		query.Set("account", creds.Username)
+		realm.RawQuery = query.Encode()
+		resp, err = v.get(ctx, realm.String(), &creds)
+	default:
+		return errors.Errorf("registry %s requested unsupported authentication scheme %q", creds.Registry, scheme)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to log in to registry %s", creds.Registry)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &credentialsRejectedError{registry: creds.Registry}
+	default:
+		return errors.Errorf("registry %s responded with unexpected status %d to the login", creds.Registry, resp.StatusCode)
+	}
+}
+
+func (v *pullSecretValidator) get(ctx context.Context, endpoint string, creds *PullSecretCreds) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// Parse a WWW-Authenticate challenge, e.g. Bearer realm="https://auth.example.com/token",service="registry"
+func parseAuthChallenge(header string) (string, map[string]string) {
+	params := make(map[string]string)
+	parts := strings.SplitN(strings.TrimSpace(header), " ", 2)
+	if len(parts) < 2 {
+		return parts[0], params
+	}
+	rest := parts[1]
+	for rest != "" {
+		eq := strings.Index(rest, "=")
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = strings.TrimSpace(rest[eq+1:])
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if comma := strings.Index(rest, ","); comma >= 0 {
+			value, rest = rest[:comma], rest[comma:]
+		} else {
+			value, rest = rest, ""
+		}
+		params[key] = value
+		rest = strings.TrimPrefix(strings.TrimSpace(rest), ",")
+	}
+	return parts[0], params
+}
+
+// The host of the registry of a pull secret entry, the entries of docker.io may be URLs of its index
+func registryHost(registry string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	host = strings.SplitN(host, "/", 2)[0]
+	if host == "index.docker.io" || host == "registry-1.docker.io" {
+		return "docker.io"
+	}
+	return host
+}
+
+func isNonRegistryAuth(registry string) bool {
+	for _, r := range nonRegistryAuths {
+		if registryHost(registry) == r {
+			return true
+		}
+	}
+	return false
+}
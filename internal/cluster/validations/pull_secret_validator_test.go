@@ -0,0 +1,261 @@
+package validations
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// A stand-in for a registry that serves the v2 API and authenticates with the challenged scheme
+type fakeRegistry struct {
+	server      *httptest.Server
+	scheme      string
+	username    string
+	password    string
+	lock        sync.Mutex
+	unavailable bool
+	logins      int
+}
+
+func newFakeRegistry(scheme string) *fakeRegistry {
+	r := &fakeRegistry{scheme: scheme, username: "user", password: "password"}
+	r.server = httptest.NewTLSServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+func (r *fakeRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "https://")
+}
+
+func (r *fakeRegistry) setUnavailable(unavailable bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.unavailable = unavailable
+}
+
+func (r *fakeRegistry) loginCount() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.logins
+}
+
+func (r *fakeRegistry) authorized(req *http.Request) bool {
+	username, password, ok := req.BasicAuth()
+	return ok && username == r.username && password == r.password
+}
+
+func (r *fakeRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	switch {
+	case r.unavailable:
+		w.WriteHeader(http.StatusServiceUnavailable)
+	case req.URL.Path == "/v2/" && r.scheme == "Bearer":
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake-registry"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+	case req.URL.Path == "/v2/" && r.scheme == "Basic":
+		if _, _, ok := req.BasicAuth(); ok {
+			r.logins++
+		}
+		if r.authorized(req) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="fake-registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	case req.URL.Path == "/v2/":
+		w.WriteHeader(http.StatusOK)
+	case req.URL.Path == "/token":
+		r.logins++
+		if req.URL.Query().Get("service") != "fake-registry" || req.URL.Query().Get("account") != r.username {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !r.authorized(req) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"fake-token"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func pullSecret(auths map[string]string) string {
+	entries := make([]string, 0, len(auths))
+	for registry, creds := range auths {
+		entries = append(entries, fmt.Sprintf(`"%s":{"auth":"%s","email":"r@r.com"}`, registry,
+			base64.StdEncoding.EncodeToString([]byte(creds))))
+	}
+	return fmt.Sprintf(`{"auths":{%s}}`, strings.Join(entries, ","))
+}
+
+var _ = Describe("Pull secret registries validation", func() {
+	It("reports the required registries that the pull secret has no credentials for", func() {
+		v := NewPullSecretValidator(PullSecretValidatorConfig{
+			RequiredRegistries: []string{"registry.redhat.io", "quay.io", "docker.io", "registry.example.com"},
+		}, nil)
+		missing, err := v.MissingRegistries(pullSecret(map[string]string{
+			"quay.io":                     "user:password",
+			"https://index.docker.io/v1/": "user:password",
+		}))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(missing).Should(Equal([]string{"registry.example.com", "registry.redhat.io"}))
+		Expect(v.RequiresRegistries()).Should(BeTrue())
+	})
+
+	It("requires no registries by default", func() {
+		v := NewPullSecretValidator(PullSecretValidatorConfig{}, nil)
+		missing, err := v.MissingRegistries(validSecretFormat)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(missing).Should(BeEmpty())
+		Expect(v.RequiresRegistries()).Should(BeFalse())
+		Expect(v.RegistryAuthEnabled()).Should(BeFalse())
+	})
+
+	It("fails to parse an invalid pull secret", func() {
+		v := NewPullSecretValidator(PullSecretValidatorConfig{RequiredRegistries: []string{"quay.io"}}, nil)
+		_, err := v.MissingRegistries(invalidSecretFormat)
+		Expect(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("Pull secret registry auth validation", func() {
+	var (
+		registry *fakeRegistry
+		v        PullSecretValidator
+	)
+
+	newValidator := func(ttl time.Duration) PullSecretValidator {
+		return NewPullSecretValidator(PullSecretValidatorConfig{CheckRegistryAuth: true, RegistryAuthCacheTTL: ttl},
+			registry.server.Client())
+	}
+
+	// Wait for the logins in the background to complete and return their result
+	validate := func(secret string) error {
+		Eventually(func() bool {
+			checked, _ := v.RegistryAuthStatus(secret)
+			return checked
+		}).Should(BeTrue())
+		_, err := v.RegistryAuthStatus(secret)
+		return err
+	}
+
+	AfterEach(func() {
+		registry.server.Close()
+	})
+
+	for _, scheme := range []string{"Bearer", "Basic"} {
+		scheme := scheme
+
+		Context(fmt.Sprintf("%s challenge", scheme), func() {
+			BeforeEach(func() {
+				registry = newFakeRegistry(scheme)
+				v = newValidator(time.Minute)
+			})
+
+			It("accepts valid credentials", func() {
+				Expect(v.RegistryAuthEnabled()).Should(BeTrue())
+				Expect(validate(pullSecret(map[string]string{
+					registry.host():       "user:password",
+					"cloud.openshift.com": "user:password",
+				}))).ShouldNot(HaveOccurred())
+			})
+
+			It("rejects invalid credentials", func() {
+				err := validate(pullSecret(map[string]string{registry.host(): "user:expired"}))
+				Expect(err).Should(MatchError(fmt.Sprintf("registry %s rejected the credentials of the pull secret", registry.host())))
+			})
+		})
+	}
+
+	It("accepts registries that allow anonymous access", func() {
+		registry = newFakeRegistry("")
+		v = newValidator(time.Minute)
+		Expect(validate(pullSecret(map[string]string{registry.host(): "user:expired"}))).ShouldNot(HaveOccurred())
+	})
+
+	It("rejects invalid pull secrets", func() {
+		registry = newFakeRegistry("Bearer")
+		v = newValidator(time.Minute)
+		Expect(validate(invalidSecretFormat)).Should(HaveOccurred())
+	})
+
+	It("doesn't wait for the registries", func() {
+		registry = newFakeRegistry("Bearer")
+		v = newValidator(time.Minute)
+		checked, err := v.RegistryAuthStatus(pullSecret(map[string]string{registry.host(): "user:password"}))
+		Expect(checked).Should(BeFalse())
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("retries unreachable registries", func() {
+		registry = newFakeRegistry("Bearer")
+		v = newValidator(time.Minute)
+		host := registry.host()
+		registry.server.Close()
+		secret := pullSecret(map[string]string{host: "user:password"})
+		Eventually(func() error {
+			_, err := v.RegistryAuthStatus(secret)
+			return err
+		}).Should(HaveOccurred())
+		checked, err := v.RegistryAuthStatus(secret)
+		Expect(checked).Should(BeFalse())
+		Expect(err.Error()).Should(HavePrefix(fmt.Sprintf("failed to reach registry %s", host)))
+	})
+
+	It("retries registries that are unavailable", func() {
+		registry = newFakeRegistry("Bearer")
+		registry.setUnavailable(true)
+		v = newValidator(time.Minute)
+		secret := pullSecret(map[string]string{registry.host(): "user:password"})
+		Eventually(func() error {
+			_, err := v.RegistryAuthStatus(secret)
+			return err
+		}).Should(MatchError(fmt.Sprintf("registry %s responded with unexpected status 503", registry.host())))
+		checked, _ := v.RegistryAuthStatus(secret)
+		Expect(checked).Should(BeFalse())
+
+		registry.setUnavailable(false)
+		Expect(validate(secret)).ShouldNot(HaveOccurred())
+	})
+
+	It("reuses the results until they expire", func() {
+		registry = newFakeRegistry("Bearer")
+		secret := pullSecret(map[string]string{registry.host(): "user:password"})
+
+		v = newValidator(time.Minute)
+		Expect(validate(secret)).ShouldNot(HaveOccurred())
+		Expect(validate(secret)).ShouldNot(HaveOccurred())
+		Expect(registry.loginCount()).Should(Equal(1))
+
+		v = newValidator(0)
+		Expect(validate(secret)).ShouldNot(HaveOccurred())
+		Eventually(registry.loginCount).Should(Equal(3))
+	})
+})
+
+var _ = Describe("Auth challenge parsing", func() {
+	It("parses the scheme and the parameters", func() {
+		scheme, params := parseAuthChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a/b:pull"`)
+		Expect(scheme).Should(Equal("Bearer"))
+		Expect(params).Should(Equal(map[string]string{
+			"realm":   "https://auth.example.com/token",
+			"service": "registry.example.com",
+			"scope":   "repository:a/b:pull",
+		}))
+	})
+
+	It("parses challenges without parameters", func() {
+		scheme, params := parseAuthChallenge("Basic")
+		Expect(scheme).Should(Equal("Basic"))
+		Expect(params).Should(BeEmpty())
+	})
+})
@@ -5,13 +5,16 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
 	"strings"
 
-	"github.com/danielerez/go-dns-client/pkg/dnsproviders"
+	"github.com/openshift/assisted-service/internal/dns"
 )
 
 const clusterNameRegex = "^([a-z]([-a-z0-9]*[a-z0-9])?)*$"
+const noProxyDomainRegex = "^\\.?([a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?\\.)*[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?$"
 
 type imagePullSecret struct {
 	Auths map[string]map[string]interface{} `json:"auths"`
@@ -94,22 +97,8 @@ func ValidatePullSecret(secret string) error {
 	return nil
 }
 
-// ValidateBaseDNS validates the specified base domain name
-func ValidateBaseDNS(dnsDomainName, dnsDomainID, dnsProviderType string) error {
-	var dnsProvider dnsproviders.Provider
-	switch dnsProviderType {
-	case "route53":
-		dnsProvider = dnsproviders.Route53{
-			HostedZoneID: dnsDomainID,
-			SharedCreds:  true,
-		}
-	default:
-		return nil
-	}
-	return validateBaseDNS(dnsDomainName, dnsDomainID, dnsProvider)
-}
-
-func validateBaseDNS(dnsDomainName, dnsDomainID string, dnsProvider dnsproviders.Provider) error {
+// ValidateBaseDNS validates the specified base domain name against the zone managed by the DNS provider
+func ValidateBaseDNS(dnsDomainName string, dnsProvider dns.Provider) error {
 	dnsNameFromService, err := dnsProvider.GetDomainName()
 	if err != nil {
 		return fmt.Errorf("Can't validate base DNS domain: %v", err)
@@ -128,30 +117,13 @@ func validateBaseDNS(dnsDomainName, dnsDomainID string, dnsProvider dnsproviders
 }
 
 // CheckDNSRecordsExistence checks whether that specified record-set names already exist in the DNS service
-func CheckDNSRecordsExistence(names []string, dnsDomainID, dnsProviderType string) error {
-	var dnsProvider dnsproviders.Provider
-	switch dnsProviderType {
-	case "route53":
-		dnsProvider = dnsproviders.Route53{
-			RecordSet: dnsproviders.RecordSet{
-				RecordSetType: "A",
-			},
-			HostedZoneID: dnsDomainID,
-			SharedCreds:  true,
-		}
-	default:
-		return nil
-	}
-	return checkDNSRecordsExistence(names, dnsProvider)
-}
-
-func checkDNSRecordsExistence(names []string, dnsProvider dnsproviders.Provider) error {
+func CheckDNSRecordsExistence(names []string, dnsProvider dns.Provider) error {
 	for _, name := range names {
 		res, err := dnsProvider.GetRecordSet(name)
 		if err != nil {
 			return fmt.Errorf("Can't verify DNS record set existence: %v", err)
 		}
-		if res != "" {
+		if len(res) > 0 {
 			return fmt.Errorf("DNS domain already exists")
 		}
 	}
@@ -167,3 +139,42 @@ func ValidateClusterNameFormat(name string) error {
 	}
 	return nil
 }
+
+// ValidateHTTPProxyFormat validates the format of a http or https proxy URL
+func ValidateHTTPProxyFormat(proxyURL string) error {
+	u, err := url.ParseRequestURI(proxyURL)
+	if err != nil {
+		return fmt.Errorf("Proxy URL format is not valid: '%s'", proxyURL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("The URL scheme must be http or https: '%s'", proxyURL)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("Proxy URL must contain a host: '%s'", proxyURL)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("Proxy URL must not contain a path, a query or a fragment: '%s'", proxyURL)
+	}
+	return nil
+}
+
+// ValidateNoProxyFormat validates the comma-separated list of domain names, IP addresses and CIDRs to exclude from proxying
+func ValidateNoProxyFormat(noProxy string) error {
+	if noProxy == "*" {
+		return nil
+	}
+	for _, entry := range strings.Split(noProxy, ",") {
+		if _, _, err := net.ParseCIDR(entry); err == nil {
+			continue
+		}
+		if net.ParseIP(entry) != nil {
+			continue
+		}
+		if matched, _ := regexp.MatchString(noProxyDomainRegex, entry); !matched {
+			return fmt.Errorf("NO Proxy format is not valid: '%s'. "+
+				"NO Proxy is a comma-separated list of destination domain names, domains, IP addresses or other network CIDRs. "+
+				"A domain can be prefaced with '.' to include all subdomains of that domain.", noProxy)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openshift/assisted-service/internal/cluster/validations"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/sirupsen/logrus"
+)
+
+type validationStatus string
+
+const (
+	ValidationSuccess validationStatus = "success"
+	ValidationFailure validationStatus = "failure"
+	ValidationPending validationStatus = "pending"
+)
+
+func (v validationStatus) String() string {
+	return string(v)
+}
+
+type validationContext struct {
+	ctx     context.Context
+	cluster *common.Cluster
+	// The results of the checks, kept for the formatters
+	missingRegistries []string
+	pullSecretErr     error
+	registryAuthErr   error
+}
+
+type validationConditon func(context *validationContext) validationStatus
+type validationStringFormatter func(context *validationContext, status validationStatus) string
+
+type validation struct {
+	id        validationID
+	condition validationConditon
+	formatter validationStringFormatter
+}
+
+func boolValue(b bool) validationStatus {
+	if b {
+		return ValidationSuccess
+	} else {
+		return ValidationFailure
+	}
+}
+
+type validator struct {
+	log                 logrus.FieldLogger
+	pullSecretValidator validations.PullSecretValidator
+}
+
+func (v *validator) hasRequiredRegistries(c *validationContext) validationStatus {
+	if !v.pullSecretValidator.RequiresRegistries() {
+		return ValidationSuccess
+	}
+	if !c.cluster.PullSecretSet {
+		return ValidationPending
+	}
+	c.missingRegistries, c.pullSecretErr = v.pullSecretValidator.MissingRegistries(c.cluster.PullSecret)
+	return boolValue(c.pullSecretErr == nil && len(c.missingRegistries) == 0)
+}
+
+func (v *validator) printHasRequiredRegistries(c *validationContext, status validationStatus) string {
+	switch status {
+	case ValidationSuccess:
+		return "Pull secret has credentials for the required registries"
+	case ValidationFailure:
+		if c.pullSecretErr != nil {
+			return fmt.Sprintf("Pull secret is invalid: %s", c.pullSecretErr)
+		}
+		return fmt.Sprintf("Pull secret has no credentials for the required registries %s", strings.Join(c.missingRegistries, ", "))
+	case ValidationPending:
+		return "Pull secret is not set"
+	default:
+		return fmt.Sprintf("Unexpected status %s", status)
+	}
+}
+
+func (v *validator) hasValidCredentials(c *validationContext) validationStatus {
+	if !v.pullSecretValidator.RegistryAuthEnabled() {
+		return ValidationSuccess
+	}
+	if !c.cluster.PullSecretSet {
+		return ValidationPending
+	}
+	var checked bool
+	checked, c.registryAuthErr = v.pullSecretValidator.RegistryAuthStatus(c.cluster.PullSecret)
+	if !checked {
+		return ValidationPending
+	}
+	return boolValue(c.registryAuthErr == nil)
+}
+
+func (v *validator) printHasValidCredentials(c *validationContext, status validationStatus) string {
+	switch status {
+	case ValidationSuccess:
+		if !v.pullSecretValidator.RegistryAuthEnabled() {
+			return "Pull secret credentials are not checked against the registries"
+		}
+		return "Pull secret credentials are accepted by the registries"
+	case ValidationFailure:
+		return fmt.Sprintf("Pull secret credentials are not accepted: %s", c.registryAuthErr)
+	case ValidationPending:
+		if !c.cluster.PullSecretSet {
+			return "Pull secret is not set"
+		}
+		if c.registryAuthErr != nil {
+			return fmt.Sprintf("Pull secret credentials could not be checked yet, retrying: %s", c.registryAuthErr)
+		}
+		return "Pull secret credentials are being checked against the registries"
+	default:
+		return fmt.Sprintf("Unexpected status %s", status)
+	}
+}
@@ -6,4 +6,14 @@ type Cluster struct {
 	models.Cluster
 	// The pull secret that obtained from the Pull Secret page on the Red Hat OpenShift Cluster Manager site.
 	PullSecret string `json:"pull_secret" gorm:"type:TEXT"`
+	// Hash of the token the discovery agents of this cluster authenticate with, minted with the discovery image.
+	AgentTokenHash string `json:"-"`
+	// The static network configurations of the hosts that the discovery image was generated with, in JSON.
+	ImageStaticNetworkConfig string `json:"-" gorm:"type:TEXT"`
+	// The proxy settings of the agents that the discovery image was generated with, in JSON.
+	ImageAgentProxy string `json:"-" gorm:"type:TEXT"`
+	// The additional trust bundle of the cluster that the discovery image was generated with.
+	ImageAdditionalTrustBundle string `json:"-" gorm:"type:TEXT"`
+	// The mirror registries configuration of the cluster that the discovery image was generated with.
+	ImageMirrorRegistriesConfig string `json:"-" gorm:"type:TEXT"`
 }
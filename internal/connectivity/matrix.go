@@ -0,0 +1,239 @@
+package connectivity
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+)
+
+// The columns of the CSV format of the matrix, one row per NIC pair
+var matrixCSVHeader = []string{
+	"source_host_id", "source_hostname", "source_nic",
+	"remote_host_id", "remote_hostname", "remote_ip_address", "remote_mac",
+	"l2_status", "l3_status",
+	"host_pair_l2_status", "host_pair_l3_status", "asymmetric",
+}
+
+type nicPairKey struct {
+	nic      string
+	remoteIP string
+}
+
+type hostPairKey struct {
+	source strfmt.UUID
+	remote strfmt.UUID
+}
+
+// BuildMatrix aggregates the connectivity reports of the hosts into the L2 and L3 reachability between
+// each pair of hosts and each pair of NIC and remote address. Reports on hosts that are not in the list are ignored.
+func BuildMatrix(hosts []*models.Host) (*models.ConnectivityMatrix, error) {
+	sorted := make([]*models.Host, len(hosts))
+	copy(sorted, hosts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID.String() < sorted[j].ID.String()
+	})
+
+	matrix := &models.ConnectivityMatrix{
+		Hosts:     make([]*models.ConnectivityMatrixHost, 0, len(sorted)),
+		HostPairs: make([]*models.ConnectivityMatrixHostPair, 0),
+		NicPairs:  make([]*models.ConnectivityMatrixNicPair, 0),
+	}
+	known := make(map[strfmt.UUID]bool, len(sorted))
+	for _, h := range sorted {
+		known[*h.ID] = true
+	}
+
+	nicPairs := make(map[hostPairKey][]*models.ConnectivityMatrixNicPair)
+	for _, h := range sorted {
+		hostname, _ := common.GetCurrentHostName(h)
+		matrix.Hosts = append(matrix.Hosts, &models.ConnectivityMatrixHost{
+			HostID:   *h.ID,
+			Hostname: hostname,
+			Reported: h.Connectivity != "",
+		})
+		if h.Connectivity == "" {
+			continue
+		}
+		var report models.ConnectivityReport
+		if err := json.Unmarshal([]byte(h.Connectivity), &report); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the connectivity report of host %s", h.ID.String())
+		}
+		for _, r := range report.RemoteHosts {
+			if r == nil || r.HostID == *h.ID || !known[r.HostID] {
+				continue
+			}
+			key := hostPairKey{source: *h.ID, remote: r.HostID}
+			nicPairs[key] = append(nicPairs[key], buildNicPairs(*h.ID, r)...)
+		}
+	}
+
+	hostPairs := make(map[hostPairKey]*models.ConnectivityMatrixHostPair)
+	for _, source := range sorted {
+		for _, remote := range sorted {
+			if *source.ID == *remote.ID {
+				continue
+			}
+			key := hostPairKey{source: *source.ID, remote: *remote.ID}
+			pairs := nicPairs[key]
+			hostPair := &models.ConnectivityMatrixHostPair{
+				SourceHostID: *source.ID,
+				RemoteHostID: *remote.ID,
+				L2Status:     aggregateStatus(pairs, func(p *models.ConnectivityMatrixNicPair) models.ConnectivityStatus { return p.L2Status }),
+				L3Status:     aggregateStatus(pairs, func(p *models.ConnectivityMatrixNicPair) models.ConnectivityStatus { return p.L3Status }),
+			}
+			hostPairs[key] = hostPair
+			matrix.HostPairs = append(matrix.HostPairs, hostPair)
+			matrix.NicPairs = append(matrix.NicPairs, pairs...)
+		}
+	}
+
+	for key, hostPair := range hostPairs {
+		reverse := hostPairs[hostPairKey{source: key.remote, remote: key.source}]
+		if isOneWay(hostPair.L2Status, reverse.L2Status) || isOneWay(hostPair.L3Status, reverse.L3Status) {
+			hostPair.Asymmetric = true
+			reverse.Asymmetric = true
+		}
+	}
+	return matrix, nil
+}
+
+// buildNicPairs merges the L2 and L3 results of a remote host by the outgoing NIC and the remote address
+func buildNicPairs(sourceID strfmt.UUID, r *models.ConnectivityRemoteHost) []*models.ConnectivityMatrixNicPair {
+	pairs := make(map[nicPairKey]*models.ConnectivityMatrixNicPair)
+	var keys []nicPairKey
+	get := func(nic, remoteIP string) *models.ConnectivityMatrixNicPair {
+		key := nicPairKey{nic: nic, remoteIP: remoteIP}
+		if p, ok := pairs[key]; ok {
+			return p
+		}
+		p := &models.ConnectivityMatrixNicPair{
+			SourceHostID:    sourceID,
+			SourceNic:       nic,
+			RemoteHostID:    r.HostID,
+			RemoteIPAddress: remoteIP,
+			L2Status:        models.ConnectivityStatusUnknown,
+			L3Status:        models.ConnectivityStatusUnknown,
+		}
+		pairs[key] = p
+		keys = append(keys, key)
+		return p
+	}
+	for _, l2 := range r.L2Connectivity {
+		if l2 == nil {
+			continue
+		}
+		p := get(l2.OutgoingNic, l2.RemoteIPAddress)
+		p.L2Status = mergeStatus(p.L2Status, l2.Successful)
+		if l2.RemoteMac != "" {
+			p.RemoteMac = l2.RemoteMac
+		}
+	}
+	for _, l3 := range r.L3Connectivity {
+		if l3 == nil {
+			continue
+		}
+		p := get(l3.OutgoingNic, l3.RemoteIPAddress)
+		p.L3Status = mergeStatus(p.L3Status, l3.Successful)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].nic != keys[j].nic {
+			return keys[i].nic < keys[j].nic
+		}
+		return keys[i].remoteIP < keys[j].remoteIP
+	})
+	ret := make([]*models.ConnectivityMatrixNicPair, 0, len(keys))
+	for _, key := range keys {
+		ret = append(ret, pairs[key])
+	}
+	return ret
+}
+
+// mergeStatus combines the results of repeated checks of a NIC pair, a single successful check makes it reachable
+func mergeStatus(status models.ConnectivityStatus, successful bool) models.ConnectivityStatus {
+	if successful || status == models.ConnectivityStatusReachable {
+		return models.ConnectivityStatusReachable
+	}
+	return models.ConnectivityStatusUnreachable
+}
+
+// aggregateStatus is reachable when all the checked NIC pairs of the hosts are reachable, partial when only
+// some of them are and unknown when none of them were checked
+func aggregateStatus(pairs []*models.ConnectivityMatrixNicPair, status func(*models.ConnectivityMatrixNicPair) models.ConnectivityStatus) models.ConnectivityStatus {
+	var reachable, unreachable int
+	for _, p := range pairs {
+		switch status(p) {
+		case models.ConnectivityStatusReachable:
+			reachable++
+		case models.ConnectivityStatusUnreachable:
+			unreachable++
+		}
+	}
+	switch {
+	case reachable == 0 && unreachable == 0:
+		return models.ConnectivityStatusUnknown
+	case unreachable == 0:
+		return models.ConnectivityStatusReachable
+	case reachable == 0:
+		return models.ConnectivityStatusUnreachable
+	default:
+		return models.ConnectivityStatusPartial
+	}
+}
+
+func isOneWay(status, reverse models.ConnectivityStatus) bool {
+	reached := func(s models.ConnectivityStatus) bool {
+		return s == models.ConnectivityStatusReachable || s == models.ConnectivityStatusPartial
+	}
+	return (reached(status) && reverse == models.ConnectivityStatusUnreachable) ||
+		(reached(reverse) && status == models.ConnectivityStatusUnreachable)
+}
+
+// FormatMatrixCSV returns the matrix as CSV with a row per NIC pair, pairs of hosts without any checked
+// NIC pair get a single row with empty NIC columns
+func FormatMatrixCSV(matrix *models.ConnectivityMatrix) ([]byte, error) {
+	hostnames := make(map[strfmt.UUID]string, len(matrix.Hosts))
+	for _, h := range matrix.Hosts {
+		hostnames[h.HostID] = h.Hostname
+	}
+	nicPairs := make(map[hostPairKey][]*models.ConnectivityMatrixNicPair)
+	for _, p := range matrix.NicPairs {
+		key := hostPairKey{source: p.SourceHostID, remote: p.RemoteHostID}
+		nicPairs[key] = append(nicPairs[key], p)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(matrixCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, hp := range matrix.HostPairs {
+		pairs := nicPairs[hostPairKey{source: hp.SourceHostID, remote: hp.RemoteHostID}]
+		if len(pairs) == 0 {
+			pairs = []*models.ConnectivityMatrixNicPair{{SourceHostID: hp.SourceHostID, RemoteHostID: hp.RemoteHostID}}
+		}
+		for _, p := range pairs {
+			record := []string{
+				p.SourceHostID.String(), hostnames[p.SourceHostID], p.SourceNic,
+				p.RemoteHostID.String(), hostnames[p.RemoteHostID], p.RemoteIPAddress, p.RemoteMac,
+				string(p.L2Status), string(p.L3Status),
+				string(hp.L2Status), string(hp.L3Status), strconv.FormatBool(hp.Asymmetric),
+			}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
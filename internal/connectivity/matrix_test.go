@@ -0,0 +1,165 @@
+package connectivity
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/models"
+)
+
+var _ = Describe("connectivity matrix", func() {
+	var (
+		ids   []strfmt.UUID
+		hosts []*models.Host
+	)
+
+	newHost := func(id strfmt.UUID, hostname string, remotes ...*models.ConnectivityRemoteHost) *models.Host {
+		h := &models.Host{ID: &id, RequestedHostname: hostname}
+		if remotes != nil {
+			b, err := json.Marshal(&models.ConnectivityReport{RemoteHosts: remotes})
+			Expect(err).ShouldNot(HaveOccurred())
+			h.Connectivity = string(b)
+		}
+		return h
+	}
+
+	remote := func(id strfmt.UUID, ip string, l2, l3 bool) *models.ConnectivityRemoteHost {
+		return &models.ConnectivityRemoteHost{
+			HostID: id,
+			L2Connectivity: []*models.L2Connectivity{
+				{OutgoingNic: "eth0", RemoteIPAddress: ip, RemoteMac: "52:54:00:00:00:" + ip[len(ip)-2:], Successful: l2},
+			},
+			L3Connectivity: []*models.L3Connectivity{
+				{OutgoingNic: "eth0", RemoteIPAddress: ip, Successful: l3},
+			},
+		}
+	}
+
+	hostPair := func(m *models.ConnectivityMatrix, source, remote strfmt.UUID) *models.ConnectivityMatrixHostPair {
+		for _, p := range m.HostPairs {
+			if p.SourceHostID == source && p.RemoteHostID == remote {
+				return p
+			}
+		}
+		return nil
+	}
+
+	BeforeEach(func() {
+		ids = []strfmt.UUID{
+			"11111111-1111-1111-1111-111111111111",
+			"22222222-2222-2222-2222-222222222222",
+			"33333333-3333-3333-3333-333333333333",
+		}
+		hosts = []*models.Host{
+			newHost(ids[0], "h1", remote(ids[1], "10.0.0.12", true, true), remote(ids[2], "10.0.0.13", true, true)),
+			newHost(ids[1], "h2", remote(ids[0], "10.0.0.11", true, true), remote(ids[2], "10.0.0.13", true, true)),
+			newHost(ids[2], "h3", remote(ids[0], "10.0.0.11", true, true), remote(ids[1], "10.0.0.12", true, true)),
+		}
+	})
+
+	It("reports full connectivity between all the pairs of hosts", func() {
+		m, err := BuildMatrix(hosts)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(m.Validate(strfmt.Default)).ShouldNot(HaveOccurred())
+		Expect(m.Hosts).Should(HaveLen(3))
+		Expect(m.Hosts[0]).Should(Equal(&models.ConnectivityMatrixHost{HostID: ids[0], Hostname: "h1", Reported: true}))
+		Expect(m.HostPairs).Should(HaveLen(6))
+		for _, p := range m.HostPairs {
+			Expect(p.L2Status).Should(Equal(models.ConnectivityStatusReachable))
+			Expect(p.L3Status).Should(Equal(models.ConnectivityStatusReachable))
+			Expect(p.Asymmetric).Should(BeFalse())
+		}
+		Expect(m.NicPairs).Should(HaveLen(6))
+		Expect(m.NicPairs[0]).Should(Equal(&models.ConnectivityMatrixNicPair{
+			SourceHostID:    ids[0],
+			SourceNic:       "eth0",
+			RemoteHostID:    ids[1],
+			RemoteIPAddress: "10.0.0.12",
+			RemoteMac:       "52:54:00:00:00:12",
+			L2Status:        models.ConnectivityStatusReachable,
+			L3Status:        models.ConnectivityStatusReachable,
+		}))
+	})
+
+	It("flags connectivity that only works in one direction", func() {
+		hosts[1] = newHost(ids[1], "h2", remote(ids[0], "10.0.0.11", false, false), remote(ids[2], "10.0.0.13", true, true))
+		m, err := BuildMatrix(hosts)
+		Expect(err).ShouldNot(HaveOccurred())
+		forward, reverse := hostPair(m, ids[0], ids[1]), hostPair(m, ids[1], ids[0])
+		Expect(forward.L2Status).Should(Equal(models.ConnectivityStatusReachable))
+		Expect(reverse.L2Status).Should(Equal(models.ConnectivityStatusUnreachable))
+		Expect(reverse.L3Status).Should(Equal(models.ConnectivityStatusUnreachable))
+		Expect(forward.Asymmetric).Should(BeTrue())
+		Expect(reverse.Asymmetric).Should(BeTrue())
+		Expect(hostPair(m, ids[1], ids[2]).Asymmetric).Should(BeFalse())
+	})
+
+	It("reports partial connectivity when only some of the NIC pairs are reachable", func() {
+		r := remote(ids[1], "10.0.0.12", true, true)
+		r.L2Connectivity = append(r.L2Connectivity, &models.L2Connectivity{OutgoingNic: "eth1", RemoteIPAddress: "192.168.0.12"})
+		hosts[0] = newHost(ids[0], "h1", r, remote(ids[2], "10.0.0.13", true, true))
+		m, err := BuildMatrix(hosts)
+		Expect(err).ShouldNot(HaveOccurred())
+		p := hostPair(m, ids[0], ids[1])
+		Expect(p.L2Status).Should(Equal(models.ConnectivityStatusPartial))
+		Expect(p.L3Status).Should(Equal(models.ConnectivityStatusReachable))
+		Expect(p.Asymmetric).Should(BeFalse())
+		Expect(m.NicPairs).Should(ContainElement(&models.ConnectivityMatrixNicPair{
+			SourceHostID:    ids[0],
+			SourceNic:       "eth1",
+			RemoteHostID:    ids[1],
+			RemoteIPAddress: "192.168.0.12",
+			L2Status:        models.ConnectivityStatusUnreachable,
+			L3Status:        models.ConnectivityStatusUnknown,
+		}))
+	})
+
+	It("reports unknown connectivity for hosts without a report", func() {
+		hosts[2] = newHost(ids[2], "h3")
+		m, err := BuildMatrix(hosts)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(m.Hosts[2].Reported).Should(BeFalse())
+		p := hostPair(m, ids[2], ids[0])
+		Expect(p.L2Status).Should(Equal(models.ConnectivityStatusUnknown))
+		Expect(p.L3Status).Should(Equal(models.ConnectivityStatusUnknown))
+		Expect(hostPair(m, ids[0], ids[2]).Asymmetric).Should(BeFalse())
+	})
+
+	It("ignores reports on hosts that are not in the cluster", func() {
+		hosts = hosts[:2]
+		m, err := BuildMatrix(hosts)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(m.HostPairs).Should(HaveLen(2))
+		Expect(m.NicPairs).Should(HaveLen(2))
+	})
+
+	It("fails on an invalid connectivity report", func() {
+		hosts[0].Connectivity = "not a report"
+		_, err := BuildMatrix(hosts)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("formats a row per NIC pair as CSV", func() {
+		hosts[2] = newHost(ids[2], "h3")
+		m, err := BuildMatrix(hosts)
+		Expect(err).ShouldNot(HaveOccurred())
+		b, err := FormatMatrixCSV(m)
+		Expect(err).ShouldNot(HaveOccurred())
+		records, err := csv.NewReader(strings.NewReader(string(b))).ReadAll()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(records[0]).Should(Equal(matrixCSVHeader))
+		// 4 NIC pairs between the reporting hosts and a row for each of the 2 pairs without checks
+		Expect(records).Should(HaveLen(7))
+		Expect(records[1]).Should(Equal([]string{
+			ids[0].String(), "h1", "eth0", ids[1].String(), "h2", "10.0.0.12", "52:54:00:00:00:12",
+			"reachable", "reachable", "reachable", "reachable", "false",
+		}))
+		Expect(records).Should(ContainElement([]string{
+			ids[2].String(), "h3", "", ids[0].String(), "h1", "", "", "", "", "unknown", "unknown", "false",
+		}))
+	})
+})
@@ -0,0 +1,94 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ProviderRoute53 = "route53"
+	ProviderRFC2136 = "rfc2136"
+)
+
+// Provider manages the address records of a single DNS zone
+type Provider interface {
+	// CreateRecordSet sets the value of the address record of the name, replacing any existing value of the record
+	// of the same type, A or AAAA
+	CreateRecordSet(name, value string) error
+	// DeleteRecordSet deletes the value from the address record of the name
+	DeleteRecordSet(name, value string) error
+	// GetRecordSet returns the values of the address record of the name, or nothing if it doesn't exist
+	GetRecordSet(name string) ([]string, error)
+	// GetDomainName returns the name of the managed zone
+	GetDomainName() (string, error)
+}
+
+type Config struct {
+	RecordTTL            int64  `envconfig:"DNS_RECORD_TTL" default:"60"`
+	RFC2136Server        string `envconfig:"DNS_RFC2136_SERVER" default:""` // host:port of the primary name server
+	RFC2136TSIGKeyName   string `envconfig:"DNS_RFC2136_TSIG_KEY_NAME" default:""`
+	RFC2136TSIGSecret    string `envconfig:"DNS_RFC2136_TSIG_SECRET" default:""` // base64 encoded
+	RFC2136TSIGAlgorithm string `envconfig:"DNS_RFC2136_TSIG_ALGORITHM" default:"hmac-sha256"`
+}
+
+// Domain is a base DNS domain whose cluster records are managed by the service
+type Domain struct {
+	Name string
+	// ID identifies the zone at the provider, the hosted zone ID for Route53 and the zone name for RFC 2136
+	ID                string
+	Provider          string
+	APIDomainName     string
+	IngressDomainName string
+}
+
+// GetDomain returns the managed domain of the cluster, or nil if its base DNS domain isn't managed.
+// baseDNSDomains maps each managed base domain to its "<zone ID>/<provider>".
+func GetDomain(baseDNSDomains map[string]string, clusterName, baseDNSDomainName string) (*Domain, error) {
+	val, ok := baseDNSDomains[baseDNSDomainName]
+	if !ok {
+		// No base domains defined in config
+		return nil, nil
+	}
+	s := strings.SplitN(val, "/", 2)
+	if len(s) != 2 {
+		return nil, errors.Errorf("Invalid DNS domain: %s", val)
+	}
+	if s[0] == "" || s[1] == "" {
+		// Specified domain is not defined in config
+		return nil, nil
+	}
+	return &Domain{
+		Name:              baseDNSDomainName,
+		ID:                s[0],
+		Provider:          s[1],
+		APIDomainName:     fmt.Sprintf("%s.%s.%s", "api", clusterName, baseDNSDomainName),
+		IngressDomainName: fmt.Sprintf("*.%s.%s.%s", "apps", clusterName, baseDNSDomainName),
+	}, nil
+}
+
+// NewProvider returns the provider managing the zone of the domain
+func NewProvider(cfg Config, domain *Domain) (Provider, error) {
+	switch domain.Provider {
+	case ProviderRoute53:
+		return NewRoute53(domain.ID, cfg.RecordTTL), nil
+	case ProviderRFC2136:
+		return NewRFC2136(cfg, domain.ID)
+	default:
+		return nil, errors.Errorf("unsupported DNS provider %s for domain %s", domain.Provider, domain.Name)
+	}
+}
+
+// recordType returns the type of the address record holding the IP
+func recordType(value string) (string, error) {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return "", errors.Errorf("invalid IP address %q", value)
+	}
+	if ip.To4() != nil {
+		return "A", nil
+	}
+	return "AAAA", nil
+}
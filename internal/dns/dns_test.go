@@ -0,0 +1,324 @@
+package dns
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	miekgdns "github.com/miekg/dns"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+)
+
+func TestDNS(t *testing.T) {
+	RegisterFailHandler(Fail)
+	common.InitializeDBTest()
+	defer common.TerminateDBTest()
+	RunSpecs(t, "DNS Suite")
+}
+
+var _ = Describe("GetDomain", func() {
+	It("parses the zone and provider of a managed domain", func() {
+		domain, err := GetDomain(map[string]string{"dns.example.com": "abc/route53"}, "test-cluster", "dns.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*domain).Should(Equal(Domain{
+			Name:              "dns.example.com",
+			ID:                "abc",
+			Provider:          ProviderRoute53,
+			APIDomainName:     "api.test-cluster.dns.example.com",
+			IngressDomainName: "*.apps.test-cluster.dns.example.com",
+		}))
+	})
+
+	It("fails on an invalid format", func() {
+		_, err := GetDomain(map[string]string{"dns.example.com": "abc"}, "test-cluster", "dns.example.com")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns nothing for an unmanaged domain", func() {
+		domain, err := GetDomain(map[string]string{"dns.example.com": "abc/route53"}, "test-cluster", "other.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(domain).Should(BeNil())
+	})
+})
+
+var _ = Describe("NewProvider", func() {
+	It("fails for an unsupported provider", func() {
+		_, err := NewProvider(Config{}, &Domain{Name: "dns.example.com", ID: "abc", Provider: "unknown"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails for RFC 2136 without a name server", func() {
+		_, err := NewProvider(Config{}, &Domain{Name: "dns.example.com", ID: "dns.example.com", Provider: ProviderRFC2136})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails for an unsupported TSIG algorithm", func() {
+		_, err := NewRFC2136(Config{RFC2136Server: "127.0.0.1:53", RFC2136TSIGKeyName: "key",
+			RFC2136TSIGAlgorithm: "hmac-sha3"}, "dns.example.com")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+type mockRoute53Client struct {
+	route53iface.Route53API
+	changes []*route53.Change
+}
+
+func (m *mockRoute53Client) ListResourceRecordSets(*route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+	return &route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []*route53.ResourceRecordSet{
+			{
+				Name:            aws.String("\\052.apps.test.example.com."),
+				Type:            aws.String("A"),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("1.2.3.5")}},
+			},
+			{
+				Name:            aws.String("api.test.example.com."),
+				Type:            aws.String("A"),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+			},
+			{
+				Name:            aws.String("api.test.example.com."),
+				Type:            aws.String("AAAA"),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("fe80::1")}},
+			},
+		},
+	}, nil
+}
+
+func (m *mockRoute53Client) GetHostedZone(*route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	return &route53.GetHostedZoneOutput{HostedZone: &route53.HostedZone{Name: aws.String("test.example.com.")}}, nil
+}
+
+func (m *mockRoute53Client) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	m.changes = append(m.changes, input.ChangeBatch.Changes...)
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+var _ = Describe("Route53 provider", func() {
+	var (
+		svc      *mockRoute53Client
+		provider Provider
+	)
+
+	BeforeEach(func() {
+		svc = &mockRoute53Client{}
+		provider = &route53Provider{hostedZoneID: "abc", ttl: 60, svc: svc}
+	})
+
+	It("returns the values of the matching record sets", func() {
+		values, err := provider.GetRecordSet("api.test.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).Should(Equal([]string{"1.2.3.4", "fe80::1"}))
+		values, err = provider.GetRecordSet("*.apps.test.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).Should(Equal([]string{"1.2.3.5"}))
+		values, err = provider.GetRecordSet("api.test2.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).Should(BeEmpty())
+	})
+
+	It("returns the name of the hosted zone", func() {
+		name, err := provider.GetDomainName()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).Should(Equal("test.example.com"))
+	})
+
+	It("upserts and deletes records of the type of the address", func() {
+		Expect(provider.CreateRecordSet("api.test.example.com", "1.2.3.4")).ShouldNot(HaveOccurred())
+		Expect(provider.CreateRecordSet("api.test.example.com", "fe80::1")).ShouldNot(HaveOccurred())
+		Expect(provider.DeleteRecordSet("api.test.example.com", "1.2.3.4")).ShouldNot(HaveOccurred())
+		Expect(svc.changes).Should(HaveLen(3))
+		Expect(*svc.changes[0].Action).Should(Equal(route53.ChangeActionUpsert))
+		Expect(*svc.changes[0].ResourceRecordSet.Type).Should(Equal("A"))
+		Expect(*svc.changes[1].ResourceRecordSet.Type).Should(Equal("AAAA"))
+		Expect(*svc.changes[2].Action).Should(Equal(route53.ChangeActionDelete))
+	})
+
+	It("rejects values that aren't IP addresses", func() {
+		Expect(provider.CreateRecordSet("api.test.example.com", "not-an-ip")).Should(HaveOccurred())
+		Expect(svc.changes).Should(BeEmpty())
+	})
+})
+
+const (
+	testZone      = "test.example.com."
+	testKeyName   = "update-key."
+	testKeySecret = "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3JldA=="
+)
+
+// zoneServer is an in-process authoritative name server of a single zone that accepts TSIG signed
+// RFC 2136 updates
+type zoneServer struct {
+	sync.Mutex
+	records map[string][]miekgdns.RR
+	server  *miekgdns.Server
+}
+
+func newZoneServer() *zoneServer {
+	z := &zoneServer{records: map[string][]miekgdns.RR{}}
+	soa, err := miekgdns.NewRR(testZone + " 3600 IN SOA ns1." + testZone + " admin." + testZone + " 1 7200 3600 1209600 3600")
+	Expect(err).ShouldNot(HaveOccurred())
+	z.records[testZone] = []miekgdns.RR{soa}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ShouldNot(HaveOccurred())
+	started := make(chan struct{})
+	z.server = &miekgdns.Server{
+		Listener:          listener,
+		Handler:           z,
+		TsigSecret:        map[string]string{testKeyName: testKeySecret},
+		NotifyStartedFunc: func() { close(started) },
+		// The default accept function answers updates with NOTIMP
+		MsgAcceptFunc: func(miekgdns.Header) miekgdns.MsgAcceptAction { return miekgdns.MsgAccept },
+	}
+	go func() { _ = z.server.ActivateAndServe() }()
+	<-started
+	return z
+}
+
+func (z *zoneServer) address() string {
+	return z.server.Listener.Addr().String()
+}
+
+func (z *zoneServer) ServeDNS(w miekgdns.ResponseWriter, req *miekgdns.Msg) {
+	z.Lock()
+	defer z.Unlock()
+	m := new(miekgdns.Msg)
+	m.SetReply(req)
+	switch {
+	case req.Opcode == miekgdns.OpcodeUpdate && (req.IsTsig() == nil || w.TsigStatus() != nil):
+		m.Rcode = miekgdns.RcodeRefused
+	case req.Opcode == miekgdns.OpcodeUpdate && req.Question[0].Name != testZone:
+		m.Rcode = miekgdns.RcodeNotAuth
+	case req.Opcode == miekgdns.OpcodeUpdate:
+		for _, rr := range req.Ns {
+			z.apply(rr)
+		}
+	default:
+		q := req.Question[0]
+		name := strings.ToLower(q.Name)
+		if !strings.HasSuffix(name, testZone) {
+			m.Rcode = miekgdns.RcodeRefused
+		} else if _, ok := z.records[name]; !ok {
+			m.Rcode = miekgdns.RcodeNameError
+		}
+		for _, rr := range z.records[name] {
+			if rr.Header().Rrtype == q.Qtype {
+				m.Answer = append(m.Answer, rr)
+			}
+		}
+	}
+	if t := req.IsTsig(); t != nil {
+		m.SetTsig(t.Hdr.Name, t.Algorithm, 300, int64(t.TimeSigned))
+	}
+	_ = w.WriteMsg(m)
+}
+
+func (z *zoneServer) apply(rr miekgdns.RR) {
+	h := rr.Header()
+	name := strings.ToLower(h.Name)
+	var kept []miekgdns.RR
+	switch h.Class {
+	case miekgdns.ClassANY:
+		for _, existing := range z.records[name] {
+			if existing.Header().Rrtype != h.Rrtype {
+				kept = append(kept, existing)
+			}
+		}
+	case miekgdns.ClassNONE:
+		for _, existing := range z.records[name] {
+			e := miekgdns.Copy(existing)
+			e.Header().Class = miekgdns.ClassNONE
+			e.Header().Ttl = 0
+			if !miekgdns.IsDuplicate(e, rr) {
+				kept = append(kept, existing)
+			}
+		}
+	default:
+		kept = append(z.records[name], rr)
+	}
+	if len(kept) == 0 {
+		delete(z.records, name)
+	} else {
+		z.records[name] = kept
+	}
+}
+
+var _ = Describe("RFC 2136 provider", func() {
+	var (
+		zone     *zoneServer
+		cfg      Config
+		provider Provider
+	)
+
+	BeforeEach(func() {
+		zone = newZoneServer()
+		cfg = Config{
+			RecordTTL:            60,
+			RFC2136Server:        zone.address(),
+			RFC2136TSIGKeyName:   "update-key",
+			RFC2136TSIGSecret:    testKeySecret,
+			RFC2136TSIGAlgorithm: "hmac-sha256",
+		}
+		var err error
+		provider, err = NewProvider(cfg, &Domain{Name: "test.example.com", ID: "test.example.com", Provider: ProviderRFC2136})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(zone.server.Shutdown()).ShouldNot(HaveOccurred())
+	})
+
+	It("creates, replaces and deletes records", func() {
+		Expect(provider.CreateRecordSet("api.c1.test.example.com", "1.2.3.4")).ShouldNot(HaveOccurred())
+		Expect(provider.CreateRecordSet("*.apps.c1.test.example.com", "1.2.3.5")).ShouldNot(HaveOccurred())
+		Expect(provider.GetRecordSet("api.c1.test.example.com")).Should(Equal([]string{"1.2.3.4"}))
+		Expect(provider.GetRecordSet("*.apps.c1.test.example.com")).Should(Equal([]string{"1.2.3.5"}))
+
+		Expect(provider.CreateRecordSet("api.c1.test.example.com", "1.2.3.6")).ShouldNot(HaveOccurred())
+		Expect(provider.GetRecordSet("api.c1.test.example.com")).Should(Equal([]string{"1.2.3.6"}))
+
+		Expect(provider.DeleteRecordSet("api.c1.test.example.com", "1.2.3.6")).ShouldNot(HaveOccurred())
+		Expect(provider.GetRecordSet("api.c1.test.example.com")).Should(BeEmpty())
+		Expect(provider.GetRecordSet("*.apps.c1.test.example.com")).Should(Equal([]string{"1.2.3.5"}))
+	})
+
+	It("manages IPv6 records", func() {
+		Expect(provider.CreateRecordSet("api.c1.test.example.com", "fd00::4")).ShouldNot(HaveOccurred())
+		Expect(provider.GetRecordSet("api.c1.test.example.com")).Should(Equal([]string{"fd00::4"}))
+	})
+
+	It("returns the zone name", func() {
+		Expect(provider.GetDomainName()).Should(Equal("test.example.com"))
+	})
+
+	It("fails for a zone the server isn't authoritative for", func() {
+		other, err := NewRFC2136(cfg, "other.example.com")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = other.GetDomainName()
+		Expect(err).Should(HaveOccurred())
+		Expect(other.CreateRecordSet("api.c1.other.example.com", "1.2.3.4")).Should(HaveOccurred())
+	})
+
+	It("fails to update with a wrong TSIG key", func() {
+		cfg.RFC2136TSIGSecret = "d3Jvbmctc2VjcmV0"
+		wrongKey, err := NewRFC2136(cfg, "test.example.com")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(wrongKey.CreateRecordSet("api.c1.test.example.com", "1.2.3.4")).Should(HaveOccurred())
+		Expect(provider.GetRecordSet("api.c1.test.example.com")).Should(BeEmpty())
+	})
+
+	It("fails to update without a TSIG key", func() {
+		cfg.RFC2136TSIGKeyName = ""
+		noKey, err := NewRFC2136(cfg, "test.example.com")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(noKey.CreateRecordSet("api.c1.test.example.com", "1.2.3.4")).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,302 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/jinzhu/gorm"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/events"
+	"github.com/openshift/assisted-service/models"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/openshift/assisted-service/pkg/requestid"
+	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+)
+
+// Record is an address record the service created for a cluster. The records are tracked so that the ones
+// of deleted clusters, or whose deletion failed, can be found and deleted later.
+type Record struct {
+	ClusterID strfmt.UUID `gorm:"primary_key"`
+	Name      string      `gorm:"primary_key"`
+	// BaseDNSDomain is the managed domain the record belongs to
+	BaseDNSDomain string
+	Value         string
+}
+
+// TableName of the tracked records
+func (Record) TableName() string {
+	return "dns_records"
+}
+
+// clusterStatusesWithRecords are the statuses of the clusters whose api and *.apps records should exist,
+// the records are created when the installation starts and deleted when the cluster is reset
+var clusterStatusesWithRecords = []string{
+	models.ClusterStatusPreparingForInstallation,
+	models.ClusterStatusInstalling,
+	models.ClusterStatusFinalizing,
+	models.ClusterStatusInstalled,
+	models.ClusterStatusError,
+}
+
+type Manager struct {
+	log            logrus.FieldLogger
+	db             *gorm.DB
+	eventsHandler  events.Handler
+	baseDNSDomains map[string]string
+	cfg            Config
+	newProvider    func(cfg Config, domain *Domain) (Provider, error)
+}
+
+func NewManager(log logrus.FieldLogger, db *gorm.DB, eventsHandler events.Handler, baseDNSDomains map[string]string,
+	cfg Config) *Manager {
+	return &Manager{
+		log:            log,
+		db:             db,
+		eventsHandler:  eventsHandler,
+		baseDNSDomains: baseDNSDomains,
+		cfg:            cfg,
+		newProvider:    NewProvider,
+	}
+}
+
+// desiredRecords returns the api and *.apps records of the cluster, mapped to their value
+func desiredRecords(domain *Domain, cluster *common.Cluster) map[string]string {
+	return map[string]string{
+		domain.APIDomainName:     cluster.APIVip,
+		domain.IngressDomainName: cluster.IngressVip,
+	}
+}
+
+// CreateRecordSets creates the api and *.apps records of the cluster if its base DNS domain is managed
+func (m *Manager) CreateRecordSets(ctx context.Context, cluster *common.Cluster) error {
+	log := logutil.FromContext(ctx, m.log)
+	domain, provider, err := m.getProvider(cluster)
+	if err != nil || domain == nil {
+		return err
+	}
+	for name, value := range desiredRecords(domain, cluster) {
+		if err = provider.CreateRecordSet(name, value); err != nil {
+			log.WithError(err).Errorf("failed to update DNS record: (%s, %s)", name, value)
+			return err
+		}
+		if err = m.track(cluster.ID, domain.Name, name, value); err != nil {
+			return err
+		}
+	}
+	log.Infof("Successfully created DNS records for base domain: %s", cluster.BaseDNSDomain)
+	return nil
+}
+
+// DeleteRecordSets deletes the api and *.apps records of the cluster if its base DNS domain is managed
+func (m *Manager) DeleteRecordSets(ctx context.Context, cluster *common.Cluster) error {
+	log := logutil.FromContext(ctx, m.log)
+	domain, provider, err := m.getProvider(cluster)
+	if err != nil || domain == nil {
+		return err
+	}
+	for name, value := range desiredRecords(domain, cluster) {
+		if err = provider.DeleteRecordSet(name, value); err != nil {
+			log.WithError(err).Errorf("failed to delete DNS record: (%s, %s)", name, value)
+			return err
+		}
+		if err = m.db.Delete(&Record{ClusterID: *cluster.ID, Name: name}).Error; err != nil {
+			return err
+		}
+	}
+	log.Infof("Successfully deleted DNS records for base domain: %s", cluster.BaseDNSDomain)
+	return nil
+}
+
+func (m *Manager) getProvider(cluster *common.Cluster) (*Domain, Provider, error) {
+	domain, err := GetDomain(m.baseDNSDomains, cluster.Name, cluster.BaseDNSDomain)
+	if err != nil || domain == nil {
+		return nil, nil, err
+	}
+	provider, err := m.newProvider(m.cfg, domain)
+	if err != nil {
+		return nil, nil, err
+	}
+	return domain, provider, nil
+}
+
+func (m *Manager) track(clusterID *strfmt.UUID, baseDNSDomain, name, value string) error {
+	record := Record{ClusterID: *clusterID, Name: name}
+	return m.db.Where(record).Assign(Record{BaseDNSDomain: baseDNSDomain, Value: value}).FirstOrCreate(&record).Error
+}
+
+// ReconcileTask compares the records of every cluster of a managed base DNS domain with the records at the
+// DNS providers. It repairs the records that drifted from the cluster VIPs and deletes the tracked records
+// of the clusters that no longer exist or were reset.
+func (m *Manager) ReconcileTask() {
+	if len(m.baseDNSDomains) == 0 {
+		return
+	}
+	ctx := requestid.ToContext(context.Background(), requestid.NewID())
+	log := logutil.FromContext(ctx, m.log)
+
+	var managedDomains []string
+	for name := range m.baseDNSDomains {
+		managedDomains = append(managedDomains, name)
+	}
+	var clusters []*common.Cluster
+	if err := m.db.Where("base_dns_domain IN (?)", managedDomains).Find(&clusters).Error; err != nil {
+		log.WithError(err).Error("failed to list the clusters of the managed DNS domains")
+		return
+	}
+	var tracked []*Record
+	if err := m.db.Find(&tracked).Error; err != nil {
+		log.WithError(err).Error("failed to list the tracked DNS records")
+		return
+	}
+
+	providers := map[string]Provider{}
+	// desired holds the names of the records each cluster should have, desiredNames the names of all of them
+	desired := map[strfmt.UUID]map[string]bool{}
+	desiredNames := map[string]bool{}
+	clusterIDs := map[strfmt.UUID]bool{}
+	for _, cluster := range clusters {
+		clusterIDs[*cluster.ID] = true
+		if !funk.ContainsString(clusterStatusesWithRecords, swag.StringValue(cluster.Status)) {
+			continue
+		}
+		domain, err := GetDomain(m.baseDNSDomains, cluster.Name, cluster.BaseDNSDomain)
+		if err != nil || domain == nil {
+			continue
+		}
+		provider, err := m.cachedProvider(providers, domain)
+		if err != nil {
+			log.WithError(err).Errorf("failed to get DNS provider of domain %s", domain.Name)
+			continue
+		}
+		desired[*cluster.ID] = map[string]bool{}
+		for name, value := range desiredRecords(domain, cluster) {
+			desired[*cluster.ID][name] = true
+			desiredNames[name] = true
+			if value == "" {
+				continue
+			}
+			m.reconcileRecord(ctx, log, provider, cluster, domain, name, value)
+		}
+	}
+
+	for _, record := range tracked {
+		switch {
+		case desired[record.ClusterID][record.Name]:
+			continue
+		case desiredNames[record.Name]:
+			// The name was taken over by another cluster, only its tracking is stale
+			m.untrack(log, record)
+		default:
+			m.deleteStaleRecord(ctx, log, providers, record, clusterIDs[record.ClusterID])
+		}
+	}
+}
+
+func (m *Manager) cachedProvider(providers map[string]Provider, domain *Domain) (Provider, error) {
+	if provider, ok := providers[domain.Name]; ok {
+		return provider, nil
+	}
+	provider, err := m.newProvider(m.cfg, domain)
+	if err != nil {
+		return nil, err
+	}
+	providers[domain.Name] = provider
+	return provider, nil
+}
+
+func (m *Manager) reconcileRecord(ctx context.Context, log logrus.FieldLogger, provider Provider, cluster *common.Cluster,
+	domain *Domain, name, value string) {
+	values, err := provider.GetRecordSet(name)
+	if err != nil {
+		log.WithError(err).Errorf("failed to get DNS record %s", name)
+		return
+	}
+	valueType, err := recordType(value)
+	if err != nil {
+		log.WithError(err).Errorf("failed to repair DNS record: (%s, %s)", name, value)
+		return
+	}
+	// Creating the record replaces the values of the same type only, the values of the other type are left
+	// behind when the VIP changes its address family and have to be deleted
+	var replaced, removed []string
+	for _, v := range values {
+		if v == value {
+			continue
+		}
+		if vType, _ := recordType(v); vType == valueType {
+			replaced = append(replaced, v)
+		} else {
+			removed = append(removed, v)
+		}
+	}
+	if len(values) != 1 || values[0] != value {
+		if !funk.ContainsString(values, value) || len(replaced) > 0 {
+			if err = provider.CreateRecordSet(name, value); err != nil {
+				log.WithError(err).Errorf("failed to repair DNS record: (%s, %s)", name, value)
+				return
+			}
+		}
+		for _, v := range removed {
+			if err = provider.DeleteRecordSet(name, v); err != nil {
+				log.WithError(err).Errorf("failed to delete DNS record: (%s, %s)", name, v)
+				return
+			}
+		}
+		var msg string
+		if len(values) == 0 {
+			msg = fmt.Sprintf("Created missing DNS record %s with value %s", name, value)
+		} else {
+			msg = fmt.Sprintf("Repaired DNS record %s, changed value from %v to %s", name, values, value)
+		}
+		log.Info(msg)
+		m.eventsHandler.AddEvent(ctx, cluster.ID.String(), models.EventSeverityWarning, msg, time.Now())
+	}
+	if err = m.track(cluster.ID, domain.Name, name, value); err != nil {
+		log.WithError(err).Errorf("failed to track DNS record %s", name)
+	}
+}
+
+func (m *Manager) deleteStaleRecord(ctx context.Context, log logrus.FieldLogger, providers map[string]Provider,
+	record *Record, clusterExists bool) {
+	if _, ok := m.baseDNSDomains[record.BaseDNSDomain]; ok {
+		domain, err := GetDomain(m.baseDNSDomains, "", record.BaseDNSDomain)
+		if err != nil || domain == nil {
+			log.WithError(err).Errorf("failed to get DNS domain %s", record.BaseDNSDomain)
+			return
+		}
+		provider, err := m.cachedProvider(providers, domain)
+		if err != nil {
+			log.WithError(err).Errorf("failed to get DNS provider of domain %s", domain.Name)
+			return
+		}
+		values, err := provider.GetRecordSet(record.Name)
+		if err != nil {
+			log.WithError(err).Errorf("failed to get DNS record %s", record.Name)
+			return
+		}
+		if funk.ContainsString(values, record.Value) {
+			if err = provider.DeleteRecordSet(record.Name, record.Value); err != nil {
+				log.WithError(err).Errorf("failed to delete DNS record: (%s, %s)", record.Name, record.Value)
+				return
+			}
+			reason := "the cluster was deleted"
+			if clusterExists {
+				reason = "the cluster isn't installed"
+			}
+			msg := fmt.Sprintf("Deleted stale DNS record %s with value %s, %s", record.Name, record.Value, reason)
+			log.Info(msg)
+			m.eventsHandler.AddEvent(ctx, record.ClusterID.String(), models.EventSeverityWarning, msg, time.Now())
+		}
+	}
+	m.untrack(log, record)
+}
+
+func (m *Manager) untrack(log logrus.FieldLogger, record *Record) {
+	if err := m.db.Delete(record).Error; err != nil {
+		log.WithError(err).Errorf("failed to untrack DNS record %s", record.Name)
+	}
+}
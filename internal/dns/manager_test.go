@@ -0,0 +1,241 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/events"
+	"github.com/openshift/assisted-service/models"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeProvider keeps the records of all the zones in memory, the A and AAAA values of a name in one list
+type fakeProvider struct {
+	records map[string][]string
+}
+
+func (f *fakeProvider) CreateRecordSet(name, value string) error {
+	valueType, err := recordType(value)
+	if err != nil {
+		return err
+	}
+	values := []string{value}
+	for _, v := range f.records[name] {
+		if vType, _ := recordType(v); vType != valueType {
+			values = append(values, v)
+		}
+	}
+	f.records[name] = values
+	return nil
+}
+
+func (f *fakeProvider) DeleteRecordSet(name, value string) error {
+	var values []string
+	for _, v := range f.records[name] {
+		if v != value {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		delete(f.records, name)
+	} else {
+		f.records[name] = values
+	}
+	return nil
+}
+
+func (f *fakeProvider) GetRecordSet(name string) ([]string, error) {
+	return f.records[name], nil
+}
+
+func (f *fakeProvider) GetDomainName() (string, error) {
+	return "dns.example.com", nil
+}
+
+var _ = Describe("Manager", func() {
+	var (
+		ctx        = context.Background()
+		db         *gorm.DB
+		dbName     = "dns_manager"
+		ctrl       *gomock.Controller
+		mockEvents *events.MockHandler
+		provider   *fakeProvider
+		manager    *Manager
+	)
+
+	BeforeEach(func() {
+		db = common.PrepareTestDB(dbName, &Record{})
+		ctrl = gomock.NewController(GinkgoT())
+		mockEvents = events.NewMockHandler(ctrl)
+		provider = &fakeProvider{records: map[string][]string{}}
+		manager = NewManager(logrus.New(), db, mockEvents, map[string]string{"dns.example.com": "abc/route53"}, Config{})
+		manager.newProvider = func(Config, *Domain) (Provider, error) {
+			return provider, nil
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		common.DeleteTestDB(db, dbName)
+	})
+
+	createCluster := func(name, status string) *common.Cluster {
+		id := strfmt.UUID(uuid.New().String())
+		cluster := &common.Cluster{Cluster: models.Cluster{
+			ID:            &id,
+			Name:          name,
+			BaseDNSDomain: "dns.example.com",
+			APIVip:        "1.2.3.4",
+			IngressVip:    "1.2.3.5",
+			Status:        swag.String(status),
+		}}
+		Expect(db.Create(cluster).Error).ShouldNot(HaveOccurred())
+		return cluster
+	}
+
+	countRecords := func() int {
+		var count int
+		Expect(db.Model(&Record{}).Count(&count).Error).ShouldNot(HaveOccurred())
+		return count
+	}
+
+	expectEvent := func(clusterID *strfmt.UUID, msg string) {
+		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterID.String(), models.EventSeverityWarning, msg, gomock.Any()).Times(1)
+	}
+
+	It("creates and tracks the records of the cluster", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalling)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		Expect(provider.records).Should(Equal(map[string][]string{
+			"api.test-cluster.dns.example.com":    {"1.2.3.4"},
+			"*.apps.test-cluster.dns.example.com": {"1.2.3.5"},
+		}))
+		Expect(countRecords()).Should(Equal(2))
+
+		Expect(manager.DeleteRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		Expect(provider.records).Should(BeEmpty())
+		Expect(countRecords()).Should(Equal(0))
+	})
+
+	It("ignores clusters of unmanaged domains", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalling)
+		cluster.BaseDNSDomain = "other.example.com"
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		Expect(provider.records).Should(BeEmpty())
+		Expect(countRecords()).Should(Equal(0))
+	})
+
+	It("leaves records that didn't drift alone", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalled)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		manager.ReconcileTask()
+		Expect(provider.records).Should(HaveLen(2))
+		Expect(countRecords()).Should(Equal(2))
+	})
+
+	It("repairs a drifted record", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalled)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		provider.records["api.test-cluster.dns.example.com"] = []string{"1.1.1.1"}
+		expectEvent(cluster.ID, "Repaired DNS record api.test-cluster.dns.example.com, changed value from [1.1.1.1] to 1.2.3.4")
+		manager.ReconcileTask()
+		Expect(provider.records["api.test-cluster.dns.example.com"]).Should(Equal([]string{"1.2.3.4"}))
+	})
+
+	It("repairs a record whose VIP changed its address family once", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalled)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		Expect(db.Model(cluster).Update("api_vip", "fd00::4").Error).ShouldNot(HaveOccurred())
+		expectEvent(cluster.ID, "Repaired DNS record api.test-cluster.dns.example.com, changed value from [1.2.3.4] to fd00::4")
+		manager.ReconcileTask()
+		Expect(provider.records["api.test-cluster.dns.example.com"]).Should(Equal([]string{"fd00::4"}))
+
+		// The record doesn't drift anymore
+		manager.ReconcileTask()
+		Expect(provider.records["api.test-cluster.dns.example.com"]).Should(Equal([]string{"fd00::4"}))
+	})
+
+	It("deletes the value of the other address family", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalled)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		provider.records["api.test-cluster.dns.example.com"] = []string{"1.2.3.4", "fd00::4"}
+		expectEvent(cluster.ID, "Repaired DNS record api.test-cluster.dns.example.com, changed value from [1.2.3.4 fd00::4] to 1.2.3.4")
+		manager.ReconcileTask()
+		Expect(provider.records["api.test-cluster.dns.example.com"]).Should(Equal([]string{"1.2.3.4"}))
+	})
+
+	It("creates a missing record", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalling)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		delete(provider.records, "*.apps.test-cluster.dns.example.com")
+		expectEvent(cluster.ID, "Created missing DNS record *.apps.test-cluster.dns.example.com with value 1.2.3.5")
+		manager.ReconcileTask()
+		Expect(provider.records["*.apps.test-cluster.dns.example.com"]).Should(Equal([]string{"1.2.3.5"}))
+		Expect(countRecords()).Should(Equal(2))
+	})
+
+	It("doesn't create the records of clusters that aren't installing", func() {
+		createCluster("test-cluster", models.ClusterStatusReady)
+		manager.ReconcileTask()
+		Expect(provider.records).Should(BeEmpty())
+		Expect(countRecords()).Should(Equal(0))
+	})
+
+	It("deletes the records of a deleted cluster", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalled)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		Expect(db.Delete(cluster).Error).ShouldNot(HaveOccurred())
+		for name, value := range map[string]string{
+			"api.test-cluster.dns.example.com":    "1.2.3.4",
+			"*.apps.test-cluster.dns.example.com": "1.2.3.5",
+		} {
+			expectEvent(cluster.ID, fmt.Sprintf("Deleted stale DNS record %s with value %s, the cluster was deleted", name, value))
+		}
+		manager.ReconcileTask()
+		Expect(provider.records).Should(BeEmpty())
+		Expect(countRecords()).Should(Equal(0))
+	})
+
+	It("deletes the records of a cluster that was reset", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalling)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		Expect(db.Model(cluster).Update("status", models.ClusterStatusInsufficient).Error).ShouldNot(HaveOccurred())
+		mockEvents.EXPECT().AddEvent(gomock.Any(), cluster.ID.String(), models.EventSeverityWarning,
+			gomock.Any(), gomock.Any()).Times(2)
+		manager.ReconcileTask()
+		Expect(provider.records).Should(BeEmpty())
+		Expect(countRecords()).Should(Equal(0))
+	})
+
+	It("only untracks records that were already deleted", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalled)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		Expect(db.Delete(cluster).Error).ShouldNot(HaveOccurred())
+		provider.records = map[string][]string{}
+		manager.ReconcileTask()
+		Expect(countRecords()).Should(Equal(0))
+	})
+
+	It("keeps the records taken over by another cluster", func() {
+		cluster := createCluster("test-cluster", models.ClusterStatusInstalled)
+		Expect(manager.CreateRecordSets(ctx, cluster)).ShouldNot(HaveOccurred())
+		Expect(db.Delete(cluster).Error).ShouldNot(HaveOccurred())
+		other := createCluster("test-cluster", models.ClusterStatusInstalled)
+		manager.ReconcileTask()
+		Expect(provider.records).Should(HaveLen(2))
+		var records []*Record
+		Expect(db.Find(&records).Error).ShouldNot(HaveOccurred())
+		Expect(records).Should(HaveLen(2))
+		for _, record := range records {
+			Expect(record.ClusterID).Should(Equal(*other.ID))
+		}
+	})
+})
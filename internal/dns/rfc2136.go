@@ -0,0 +1,153 @@
+package dns
+
+import (
+	"strings"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+	"github.com/pkg/errors"
+)
+
+const rfc2136Timeout = 10 * time.Second
+
+var tsigAlgorithms = map[string]string{
+	"hmac-md5":    miekgdns.HmacMD5,
+	"hmac-sha1":   miekgdns.HmacSHA1,
+	"hmac-sha256": miekgdns.HmacSHA256,
+	"hmac-sha512": miekgdns.HmacSHA512,
+}
+
+type rfc2136Provider struct {
+	server   string
+	zone     string
+	ttl      uint32
+	keyName  string
+	keyAlg   string
+	keyValue string
+}
+
+// NewRFC2136 returns a provider for a zone of a name server that accepts RFC 2136 dynamic updates, such
+// as BIND or PowerDNS. The updates and the queries are signed with the configured TSIG key, if any.
+func NewRFC2136(cfg Config, zone string) (Provider, error) {
+	if cfg.RFC2136Server == "" {
+		return nil, errors.New("RFC 2136 name server isn't configured")
+	}
+	p := &rfc2136Provider{
+		server: cfg.RFC2136Server,
+		zone:   miekgdns.Fqdn(strings.ToLower(zone)),
+		ttl:    uint32(cfg.RecordTTL),
+	}
+	if cfg.RFC2136TSIGKeyName != "" {
+		alg, ok := tsigAlgorithms[strings.ToLower(strings.TrimSuffix(cfg.RFC2136TSIGAlgorithm, "."))]
+		if !ok {
+			return nil, errors.Errorf("unsupported TSIG algorithm %s", cfg.RFC2136TSIGAlgorithm)
+		}
+		p.keyName = miekgdns.Fqdn(strings.ToLower(cfg.RFC2136TSIGKeyName))
+		p.keyAlg = alg
+		p.keyValue = cfg.RFC2136TSIGSecret
+	}
+	return p, nil
+}
+
+func (p *rfc2136Provider) exchange(m *miekgdns.Msg) (*miekgdns.Msg, error) {
+	c := &miekgdns.Client{Net: "tcp", Timeout: rfc2136Timeout}
+	if p.keyName != "" {
+		c.TsigSecret = map[string]string{p.keyName: p.keyValue}
+		m.SetTsig(p.keyName, p.keyAlg, 300, time.Now().Unix())
+	}
+	r, _, err := c.Exchange(m, p.server)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to reach name server %s", p.server)
+	}
+	return r, nil
+}
+
+func (p *rfc2136Provider) update(m *miekgdns.Msg) error {
+	r, err := p.exchange(m)
+	if err != nil {
+		return err
+	}
+	if r.Rcode != miekgdns.RcodeSuccess {
+		return errors.Errorf("name server %s refused the update of zone %s: %s",
+			p.server, p.zone, miekgdns.RcodeToString[r.Rcode])
+	}
+	return nil
+}
+
+func (p *rfc2136Provider) newRR(name, value string) (miekgdns.RR, error) {
+	rtype, err := recordType(value)
+	if err != nil {
+		return nil, err
+	}
+	return miekgdns.NewRR(strings.Join([]string{miekgdns.Fqdn(name), "IN", rtype, value}, " "))
+}
+
+func (p *rfc2136Provider) CreateRecordSet(name, value string) error {
+	rr, err := p.newRR(name, value)
+	if err != nil {
+		return err
+	}
+	rr.Header().Ttl = p.ttl
+	m := new(miekgdns.Msg)
+	m.SetUpdate(p.zone)
+	m.RemoveRRset([]miekgdns.RR{rr})
+	m.Insert([]miekgdns.RR{rr})
+	return p.update(m)
+}
+
+func (p *rfc2136Provider) DeleteRecordSet(name, value string) error {
+	rr, err := p.newRR(name, value)
+	if err != nil {
+		return err
+	}
+	m := new(miekgdns.Msg)
+	m.SetUpdate(p.zone)
+	m.Remove([]miekgdns.RR{rr})
+	return p.update(m)
+}
+
+func (p *rfc2136Provider) query(name string, qtype uint16) (*miekgdns.Msg, error) {
+	m := new(miekgdns.Msg)
+	m.SetQuestion(miekgdns.Fqdn(name), qtype)
+	r, err := p.exchange(m)
+	if err != nil {
+		return nil, err
+	}
+	if r.Rcode != miekgdns.RcodeSuccess && r.Rcode != miekgdns.RcodeNameError {
+		return nil, errors.Errorf("failed to query %s %s: %s", miekgdns.TypeToString[qtype], name,
+			miekgdns.RcodeToString[r.Rcode])
+	}
+	return r, nil
+}
+
+func (p *rfc2136Provider) GetRecordSet(name string) ([]string, error) {
+	var values []string
+	for _, qtype := range []uint16{miekgdns.TypeA, miekgdns.TypeAAAA} {
+		r, err := p.query(name, qtype)
+		if err != nil {
+			return nil, err
+		}
+		for _, rr := range r.Answer {
+			switch v := rr.(type) {
+			case *miekgdns.A:
+				values = append(values, v.A.String())
+			case *miekgdns.AAAA:
+				values = append(values, v.AAAA.String())
+			}
+		}
+	}
+	return values, nil
+}
+
+func (p *rfc2136Provider) GetDomainName() (string, error) {
+	r, err := p.query(p.zone, miekgdns.TypeSOA)
+	if err != nil {
+		return "", err
+	}
+	for _, rr := range r.Answer {
+		if _, ok := rr.(*miekgdns.SOA); ok && strings.EqualFold(rr.Header().Name, p.zone) {
+			return strings.TrimSuffix(p.zone, "."), nil
+		}
+	}
+	return "", errors.Errorf("name server %s isn't authoritative for zone %s", p.server, p.zone)
+}
@@ -0,0 +1,117 @@
+package dns
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/pkg/errors"
+)
+
+type route53Provider struct {
+	hostedZoneID string
+	ttl          int64
+	svc          route53iface.Route53API
+}
+
+// NewRoute53 returns a provider for the Route53 hosted zone, it uses the credentials of the "route53"
+// profile of the shared AWS credentials file
+func NewRoute53(hostedZoneID string, ttl int64) Provider {
+	return &route53Provider{hostedZoneID: hostedZoneID, ttl: ttl}
+}
+
+func (r *route53Provider) getService() (route53iface.Route53API, error) {
+	if r.svc == nil {
+		sess, err := session.NewSession(&aws.Config{
+			Credentials: credentials.NewSharedCredentials("", "route53"),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create AWS session")
+		}
+		r.svc = route53.New(sess)
+	}
+	return r.svc, nil
+}
+
+func (r *route53Provider) CreateRecordSet(name, value string) error {
+	return r.changeRecordSet(route53.ChangeActionUpsert, name, value)
+}
+
+func (r *route53Provider) DeleteRecordSet(name, value string) error {
+	return r.changeRecordSet(route53.ChangeActionDelete, name, value)
+}
+
+func (r *route53Provider) changeRecordSet(action, name, value string) error {
+	rtype, err := recordType(value)
+	if err != nil {
+		return err
+	}
+	svc, err := r.getService()
+	if err != nil {
+		return err
+	}
+	_, err = svc.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{
+				{
+					Action: aws.String(action),
+					ResourceRecordSet: &route53.ResourceRecordSet{
+						Name:            aws.String(name),
+						ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(value)}},
+						TTL:             aws.Int64(r.ttl),
+						Type:            aws.String(rtype),
+					},
+				},
+			},
+		},
+		HostedZoneId: aws.String(r.hostedZoneID),
+	})
+	return errors.Wrapf(err, "failed to %s record %s %s", strings.ToLower(action), rtype, name)
+}
+
+func (r *route53Provider) GetRecordSet(name string) ([]string, error) {
+	svc, err := r.getService()
+	if err != nil {
+		return nil, err
+	}
+	// Record sets are listed in name and type order, so the A record set of the name comes right before
+	// its AAAA one
+	out, err := svc.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(r.hostedZoneID),
+		MaxItems:        aws.String("2"),
+		StartRecordName: aws.String(name),
+		StartRecordType: aws.String(route53.RRTypeA),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list record sets of %s", name)
+	}
+
+	// Route53 returns the names fully qualified and with the wildcard escaped
+	awsName := strings.Replace(name, "*", "\\052", 1) + "."
+	var values []string
+	for _, rs := range out.ResourceRecordSets {
+		if aws.StringValue(rs.Name) != awsName ||
+			(aws.StringValue(rs.Type) != route53.RRTypeA && aws.StringValue(rs.Type) != route53.RRTypeAaaa) {
+			continue
+		}
+		for _, rr := range rs.ResourceRecords {
+			values = append(values, aws.StringValue(rr.Value))
+		}
+	}
+	return values, nil
+}
+
+func (r *route53Provider) GetDomainName() (string, error) {
+	svc, err := r.getService()
+	if err != nil {
+		return "", err
+	}
+	out, err := svc.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(r.hostedZoneID)})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get hosted zone %s", r.hostedZoneID)
+	}
+	return strings.TrimSuffix(aws.StringValue(out.HostedZone.Name), "."), nil
+}
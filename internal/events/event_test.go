@@ -10,6 +10,8 @@ import (
 	"github.com/openshift/assisted-service/pkg/requestid"
 	"github.com/pborman/uuid"
 
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
@@ -18,6 +20,8 @@ import (
 	"github.com/onsi/gomega/types"
 	"github.com/openshift/assisted-service/internal/events"
 	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/auth"
+	eventsapi "github.com/openshift/assisted-service/restapi/operations/events"
 	"github.com/sirupsen/logrus"
 )
 
@@ -136,6 +140,62 @@ var _ = Describe("Events library", func() {
 
 })
 
+var _ = Describe("Events api", func() {
+	var (
+		db        *gorm.DB
+		api       *events.Api
+		dbName    = "events_api_test"
+		clusterID strfmt.UUID
+		hostID    strfmt.UUID
+	)
+
+	userCtx := func(orgID, role string) context.Context {
+		return auth.IdentityToContext(context.Background(), &auth.Identity{UserID: "user", OrgID: orgID, Role: role})
+	}
+
+	BeforeEach(func() {
+		db = common.PrepareTestDB(dbName, &events.Event{})
+		theEvents := events.New(db, logrus.WithField("pkg", "events"))
+		api = events.NewApi(theEvents, db, logrus.WithField("pkg", "eventsApi"))
+		clusterID = strfmt.UUID(uuid.NewRandom().String())
+		hostID = strfmt.UUID(uuid.NewRandom().String())
+		Expect(db.Create(&common.Cluster{Cluster: models.Cluster{ID: &clusterID, OrgID: "org1"}}).Error).
+			ShouldNot(HaveOccurred())
+		Expect(db.Create(&models.Host{ID: &hostID, ClusterID: clusterID}).Error).ShouldNot(HaveOccurred())
+		theEvents.AddEvent(context.TODO(), hostID.String(), models.EventSeverityInfo, "event1", time.Now(), clusterID.String())
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	listEvents := func(ctx context.Context, entityID strfmt.UUID) middleware.Responder {
+		return api.ListEvents(ctx, eventsapi.ListEventsParams{EntityID: entityID})
+	}
+
+	It("lists the events of the caller's clusters and hosts", func() {
+		for _, id := range []strfmt.UUID{clusterID, hostID} {
+			reply := listEvents(userCtx("org1", "user"), id)
+			Expect(reply).Should(BeAssignableToTypeOf(eventsapi.NewListEventsOK()))
+			Expect(reply.(*eventsapi.ListEventsOK).Payload).Should(HaveLen(1))
+		}
+	})
+
+	It("hides the events of other orgs", func() {
+		for _, id := range []strfmt.UUID{clusterID, hostID} {
+			Expect(listEvents(userCtx("org2", "user"), id)).Should(BeAssignableToTypeOf(eventsapi.NewListEventsNotFound()))
+		}
+	})
+
+	It("lists the events of any org for admins and support", func() {
+		for _, role := range []string{auth.AdminUserRole, auth.SupportUserRole} {
+			for _, id := range []strfmt.UUID{clusterID, hostID} {
+				Expect(listEvents(userCtx("org2", role), id)).Should(BeAssignableToTypeOf(eventsapi.NewListEventsOK()))
+			}
+		}
+	})
+})
+
 func WithRequestID(requestID string) types.GomegaMatcher {
 	return WithTransform(func(e *events.Event) string {
 		return e.RequestID.String()
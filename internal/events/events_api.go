@@ -2,11 +2,14 @@ package events
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/openshift/assisted-service/models"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/gorm"
 	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/identity"
 	logutil "github.com/openshift/assisted-service/pkg/log"
 	"github.com/openshift/assisted-service/restapi"
 	"github.com/openshift/assisted-service/restapi/operations/events"
@@ -17,18 +20,30 @@ var _ restapi.EventsAPI = &Api{}
 
 type Api struct {
 	handler Handler
+	db      *gorm.DB
 	log     logrus.FieldLogger
 }
 
-func NewApi(handler Handler, log logrus.FieldLogger) *Api {
+func NewApi(handler Handler, db *gorm.DB, log logrus.FieldLogger) *Api {
 	return &Api{
 		handler: handler,
+		db:      db,
 		log:     log,
 	}
 }
 
 func (a *Api) ListEvents(ctx context.Context, params events.ListEventsParams) middleware.Responder {
 	log := logutil.FromContext(ctx, a.log)
+	accessible, err := a.isEntityAccessible(ctx, params.EntityID.String())
+	if err != nil {
+		log.WithError(err).Errorf("failed to get entity %s", params.EntityID.String())
+		return events.NewListEventsInternalServerError().
+			WithPayload(common.GenerateInternalFromError(err))
+	}
+	if !accessible {
+		return events.NewListEventsNotFound().
+			WithPayload(common.GenerateError(http.StatusNotFound, gorm.ErrRecordNotFound))
+	}
 	evs, err := a.handler.GetEvents(params.EntityID.String())
 	if err != nil {
 		log.Errorf("failed to get events for id %s ", params.EntityID.String())
@@ -45,5 +60,25 @@ func (a *Api) ListEvents(ctx context.Context, params events.ListEventsParams) mi
 		}
 	}
 	return events.NewListEventsOK().WithPayload(ret)
+}
 
+// isEntityAccessible checks that the entity is a cluster, or a host of a cluster, that the caller is allowed
+// to access. Admins and support can access the events of any entity, including deleted ones.
+func (a *Api) isEntityAccessible(ctx context.Context, entityID string) (bool, error) {
+	if identity.IsAdmin(ctx) || identity.IsSupport(ctx) {
+		return true, nil
+	}
+	var count int
+	if err := identity.AddUserFilter(ctx, a.db).Model(&common.Cluster{}).
+		Where("id = ?", entityID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	if err := identity.AddHostUserFilter(ctx, a.db).Model(&models.Host{}).
+		Where("id = ?", entityID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
 }
@@ -47,3 +47,17 @@ func (mr *MockValidatorMockRecorder) GetHostValidDisks(host interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostValidDisks", reflect.TypeOf((*MockValidator)(nil).GetHostValidDisks), host)
 }
+
+// GetDiskEligibility mocks base method
+func (m *MockValidator) GetDiskEligibility(disk *models.Disk) *models.DiskInstallationEligibility {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiskEligibility", disk)
+	ret0, _ := ret[0].(*models.DiskInstallationEligibility)
+	return ret0
+}
+
+// GetDiskEligibility indicates an expected call of GetDiskEligibility
+func (mr *MockValidatorMockRecorder) GetDiskEligibility(disk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiskEligibility", reflect.TypeOf((*MockValidator)(nil).GetDiskEligibility), disk)
+}
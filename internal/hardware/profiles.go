@@ -0,0 +1,201 @@
+package hardware
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+	"gopkg.in/yaml.v2"
+)
+
+// Aliases of the CPU flags that a requirement can use instead of listing the alternatives
+var cpuFlagAliases = map[string][]string{
+	"virtualization": {"vmx", "svm"},
+}
+
+// Requirements are the hardware requirements of the hosts of a role, zero values aren't checked. The minimums
+// are checked in addition to the minimums of the service, lower ones have no effect.
+type Requirements struct {
+	MinCPUCores int64 `yaml:"min_cpu_cores"`
+	MinRAMGib   int64 `yaml:"min_ram_gib"`
+	// Minimal size of the installation disk and of the disks that count for the minimal disk count
+	MinDiskSizeGb int64 `yaml:"min_disk_size_gb"`
+	// CPU flags that the hosts must have, an entry can list alternatives separated by "|", e.g. "vmx|svm"
+	CPUFlags        []string `yaml:"cpu_flags"`
+	MinNICSpeedMbps int64    `yaml:"min_nic_speed_mbps"`
+	// Drive types of the installation disk and of the disks that count for the minimal disk count, e.g. SSD
+	DiskTypes    []string `yaml:"disk_types"`
+	MinDiskCount int64    `yaml:"min_disk_count"`
+	// System manufacturers of the hosts, the denied ones are rejected even if they are allowed
+	AllowedVendors []string `yaml:"allowed_vendors"`
+	DeniedVendors  []string `yaml:"denied_vendors"`
+}
+
+// Profile is a named set of hardware requirements for the masters and the workers of a cluster
+type Profile struct {
+	Name   string       `yaml:"name"`
+	Master Requirements `yaml:"master"`
+	Worker Requirements `yaml:"worker"`
+}
+
+// Profiles are the hardware profiles that the clusters can select, and the profiles that the clusters
+// that don't select one get by their OpenShift version or by default
+type Profiles struct {
+	Profiles []*Profile        `yaml:"profiles"`
+	Versions map[string]string `yaml:"versions"`
+	Default  string            `yaml:"default"`
+}
+
+// LoadProfiles reads the hardware profiles from a YAML file, an empty path means that there are no profiles
+func LoadProfiles(path string) (*Profiles, error) {
+	if path == "" {
+		return &Profiles{}, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read hardware profiles file %s", path)
+	}
+	profiles, err := ParseProfiles(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid hardware profiles file %s", path)
+	}
+	return profiles, nil
+}
+
+// ParseProfiles parses and validates YAML hardware profiles
+func ParseProfiles(content []byte) (*Profiles, error) {
+	var profiles Profiles
+	if err := yaml.UnmarshalStrict(content, &profiles); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(profiles.Profiles))
+	for _, p := range profiles.Profiles {
+		if p == nil || p.Name == "" {
+			return nil, errors.New("hardware profiles must have a name")
+		}
+		if names[p.Name] {
+			return nil, errors.Errorf("hardware profile %s is defined more than once", p.Name)
+		}
+		names[p.Name] = true
+		for _, r := range []Requirements{p.Master, p.Worker} {
+			for _, t := range r.DiskTypes {
+				if !funk.ContainsString([]string{"HDD", "SSD"}, t) {
+					return nil, errors.Errorf("hardware profile %s has an unsupported disk type %s", p.Name, t)
+				}
+			}
+			if r.MinCPUCores < 0 || r.MinRAMGib < 0 || r.MinDiskSizeGb < 0 || r.MinNICSpeedMbps < 0 || r.MinDiskCount < 0 {
+				return nil, errors.Errorf("hardware profile %s has a negative minimum", p.Name)
+			}
+		}
+	}
+	for version, name := range profiles.Versions {
+		if !names[name] {
+			return nil, errors.Errorf("OpenShift version %s has an undefined hardware profile %s", version, name)
+		}
+	}
+	if profiles.Default != "" && !names[profiles.Default] {
+		return nil, errors.Errorf("the default hardware profile %s is not defined", profiles.Default)
+	}
+	return &profiles, nil
+}
+
+// Get returns the profile with the name, or nil when there is no such profile
+func (p *Profiles) Get(name string) *Profile {
+	if p == nil {
+		return nil
+	}
+	for _, profile := range p.Profiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+	return nil
+}
+
+// ForCluster returns the profile that the hosts of the cluster are validated against, the profile that the
+// cluster selected, or the profile of its OpenShift version, or the default one. Nil means no profile.
+func (p *Profiles) ForCluster(cluster *models.Cluster) (*Profile, error) {
+	if cluster.HardwareProfile != "" {
+		profile := p.Get(cluster.HardwareProfile)
+		if profile == nil {
+			return nil, errors.Errorf("hardware profile %s is not defined", cluster.HardwareProfile)
+		}
+		return profile, nil
+	}
+	if p == nil {
+		return nil, nil
+	}
+	if name, ok := p.Versions[cluster.OpenshiftVersion]; ok {
+		return p.Get(name), nil
+	}
+	return p.Get(p.Default), nil
+}
+
+// ForRole returns the requirements of the role, or nil for hosts without a role
+func (p *Profile) ForRole(role models.HostRole) *Requirements {
+	switch role {
+	case models.HostRoleMaster:
+		return &p.Master
+	case models.HostRoleWorker:
+		return &p.Worker
+	default:
+		return nil
+	}
+}
+
+// MissingCPUFlags returns the required CPU flags that the CPU doesn't have
+func (r *Requirements) MissingCPUFlags(cpu *models.CPU) []string {
+	var missing []string
+	for _, required := range r.CPUFlags {
+		alternatives := strings.Split(required, "|")
+		if aliased, ok := cpuFlagAliases[required]; ok {
+			alternatives = aliased
+		}
+		found := false
+		for _, flag := range alternatives {
+			if funk.ContainsString(cpu.Flags, flag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, required)
+		}
+	}
+	return missing
+}
+
+// CountDisks returns the number of disks of the required types that are at least of the minimal size
+func (r *Requirements) CountDisks(inventory *models.Inventory, minSizeRequiredInBytes int64) int64 {
+	var count int64
+	for _, disk := range ListValidDisks(inventory, minSizeRequiredInBytes) {
+		if r.IsDiskAllowed(disk) {
+			count++
+		}
+	}
+	return count
+}
+
+// IsDiskAllowed reports whether the disk is of the required types and of the minimal size of the requirements
+func (r *Requirements) IsDiskAllowed(disk *models.Disk) bool {
+	return (len(r.DiskTypes) == 0 || funk.ContainsString(r.DiskTypes, disk.DriveType)) &&
+		disk.SizeBytes >= GbToBytes(r.MinDiskSizeGb)
+}
+
+// IsVendorAllowed reports whether the manufacturer is allowed, the names are compared case insensitively
+func (r *Requirements) IsVendorAllowed(manufacturer string) bool {
+	contains := func(vendors []string) bool {
+		for _, v := range vendors {
+			if strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(manufacturer)) {
+				return true
+			}
+		}
+		return false
+	}
+	if contains(r.DeniedVendors) {
+		return false
+	}
+	return len(r.AllowedVendors) == 0 || contains(r.AllowedVendors)
+}
@@ -0,0 +1,160 @@
+package hardware
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/alecthomas/units"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/models"
+)
+
+const testProfiles = `
+profiles:
+  - name: edge
+    master:
+      cpu_flags: [virtualization]
+      min_nic_speed_mbps: 1000
+      min_cpu_cores: 8
+      min_ram_gib: 32
+      min_disk_size_gb: 200
+    worker:
+      cpu_flags: [virtualization]
+  - name: storage-heavy
+    master:
+      disk_types: [SSD]
+    worker:
+      disk_types: [SSD, HDD]
+      min_disk_count: 3
+      allowed_vendors: [Dell Inc., HPE]
+      denied_vendors: [QEMU]
+versions:
+  "4.6": edge
+default: storage-heavy
+`
+
+var _ = Describe("hardware profiles", func() {
+	It("loads no profiles without a file", func() {
+		profiles, err := LoadProfiles("")
+		Expect(err).ShouldNot(HaveOccurred())
+		profile, err := profiles.ForCluster(&models.Cluster{OpenshiftVersion: "4.6"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(profile).Should(BeNil())
+	})
+
+	It("loads the profiles from a file", func() {
+		dir, err := ioutil.TempDir("", "profiles")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "profiles.yaml")
+		Expect(ioutil.WriteFile(path, []byte(testProfiles), 0600)).ShouldNot(HaveOccurred())
+
+		profiles, err := LoadProfiles(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(profiles.Profiles).Should(HaveLen(2))
+		Expect(profiles.Get("storage-heavy").Worker.MinDiskCount).Should(Equal(int64(3)))
+		Expect(profiles.Get("standard")).Should(BeNil())
+
+		_, err = LoadProfiles(filepath.Join(dir, "missing.yaml"))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("selects the profile of the cluster, of its version or the default one", func() {
+		profiles, err := ParseProfiles([]byte(testProfiles))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		profile, err := profiles.ForCluster(&models.Cluster{HardwareProfile: "storage-heavy", OpenshiftVersion: "4.6"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(profile.Name).Should(Equal("storage-heavy"))
+
+		profile, err = profiles.ForCluster(&models.Cluster{OpenshiftVersion: "4.6"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(profile.Name).Should(Equal("edge"))
+
+		profile, err = profiles.ForCluster(&models.Cluster{OpenshiftVersion: "4.7"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(profile.Name).Should(Equal("storage-heavy"))
+
+		_, err = profiles.ForCluster(&models.Cluster{HardwareProfile: "standard"})
+		Expect(err).Should(MatchError("hardware profile standard is not defined"))
+
+		var none *Profiles
+		_, err = none.ForCluster(&models.Cluster{HardwareProfile: "edge"})
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("returns the requirements of the role", func() {
+		profiles, err := ParseProfiles([]byte(testProfiles))
+		Expect(err).ShouldNot(HaveOccurred())
+		profile := profiles.Get("edge")
+		Expect(profile.ForRole(models.HostRoleMaster).MinNICSpeedMbps).Should(Equal(int64(1000)))
+		Expect(profile.ForRole(models.HostRoleMaster).MinCPUCores).Should(Equal(int64(8)))
+		Expect(profile.ForRole(models.HostRoleMaster).MinRAMGib).Should(Equal(int64(32)))
+		Expect(profile.ForRole(models.HostRoleMaster).MinDiskSizeGb).Should(Equal(int64(200)))
+		Expect(profile.ForRole(models.HostRoleWorker).MinNICSpeedMbps).Should(BeZero())
+		Expect(profile.ForRole("")).Should(BeNil())
+	})
+
+	for _, t := range []struct {
+		name    string
+		content string
+	}{
+		{"a profile without a name", "profiles: [{master: {min_disk_count: 1}}]"},
+		{"duplicate profiles", "profiles: [{name: edge}, {name: edge}]"},
+		{"an unsupported disk type", "profiles: [{name: edge, master: {disk_types: [NVMe]}}]"},
+		{"a negative minimum", "profiles: [{name: edge, worker: {min_disk_count: -1}}]"},
+		{"a negative minimal size", "profiles: [{name: edge, master: {min_disk_size_gb: -1}}]"},
+		{"a version with an undefined profile", "profiles: [{name: edge}]\nversions: {\"4.6\": standard}"},
+		{"an undefined default profile", "profiles: [{name: edge}]\ndefault: standard"},
+		{"unknown fields", "profiles: [{name: edge, master: {min_gpu_count: 1}}]"},
+	} {
+		t := t
+		It("rejects "+t.name, func() {
+			_, err := ParseProfiles([]byte(t.content))
+			Expect(err).Should(HaveOccurred())
+		})
+	}
+})
+
+var _ = Describe("hardware requirements", func() {
+	It("reports the missing CPU flags", func() {
+		r := &Requirements{CPUFlags: []string{"virtualization", "avx|avx2", "aes"}}
+		Expect(r.MissingCPUFlags(&models.CPU{Flags: []string{"svm", "avx2", "aes"}})).Should(BeEmpty())
+		Expect(r.MissingCPUFlags(&models.CPU{Flags: []string{"vmx"}})).Should(Equal([]string{"avx|avx2", "aes"}))
+		Expect((&Requirements{}).MissingCPUFlags(&models.CPU{})).Should(BeEmpty())
+	})
+
+	It("counts the disks of the required types", func() {
+		inventory := &models.Inventory{Disks: []*models.Disk{
+			{Name: "sda", DriveType: "SSD", SizeBytes: 200 * int64(units.GB)},
+			{Name: "sdb", DriveType: "HDD", SizeBytes: 200 * int64(units.GB)},
+			{Name: "sdc", DriveType: "SSD", SizeBytes: 20 * int64(units.GB)},
+			{Name: "sr0", DriveType: "ODD", SizeBytes: 200 * int64(units.GB)},
+		}}
+		Expect((&Requirements{DiskTypes: []string{"SSD"}}).CountDisks(inventory, 100*int64(units.GB))).Should(Equal(int64(1)))
+		Expect((&Requirements{}).CountDisks(inventory, 100*int64(units.GB))).Should(Equal(int64(2)))
+		Expect((&Requirements{DiskTypes: []string{"SSD"}}).CountDisks(inventory, 10*int64(units.GB))).Should(Equal(int64(2)))
+	})
+
+	It("allows the disks of the required types and size", func() {
+		r := &Requirements{DiskTypes: []string{"SSD"}, MinDiskSizeGb: 150}
+		Expect(r.IsDiskAllowed(&models.Disk{DriveType: "SSD", SizeBytes: 200 * int64(units.GB)})).Should(BeTrue())
+		Expect(r.IsDiskAllowed(&models.Disk{DriveType: "HDD", SizeBytes: 200 * int64(units.GB)})).Should(BeFalse())
+		Expect(r.IsDiskAllowed(&models.Disk{DriveType: "SSD", SizeBytes: 100 * int64(units.GB)})).Should(BeFalse())
+		Expect((&Requirements{}).IsDiskAllowed(&models.Disk{DriveType: "HDD", SizeBytes: 1})).Should(BeTrue())
+	})
+
+	It("allows the vendors that are allowed and not denied", func() {
+		r := &Requirements{AllowedVendors: []string{"Dell Inc.", "HPE"}, DeniedVendors: []string{"HPE"}}
+		Expect(r.IsVendorAllowed("dell inc.")).Should(BeTrue())
+		Expect(r.IsVendorAllowed("HPE")).Should(BeFalse())
+		Expect(r.IsVendorAllowed("Lenovo")).Should(BeFalse())
+
+		r = &Requirements{DeniedVendors: []string{"QEMU"}}
+		Expect(r.IsVendorAllowed("Lenovo")).Should(BeTrue())
+		Expect(r.IsVendorAllowed("QEMU")).Should(BeFalse())
+		Expect((&Requirements{}).IsVendorAllowed("")).Should(BeTrue())
+	})
+})
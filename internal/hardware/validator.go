@@ -6,8 +6,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/thoas/go-funk"
-
 	"github.com/sirupsen/logrus"
 
 	"github.com/alecthomas/units"
@@ -17,6 +15,7 @@ import (
 //go:generate mockgen -source=validator.go -package=hardware -destination=mock_validator.go
 type Validator interface {
 	GetHostValidDisks(host *models.Host) ([]*models.Disk, error)
+	GetDiskEligibility(disk *models.Disk) *models.DiskInstallationEligibility
 }
 
 func NewValidator(log logrus.FieldLogger, cfg ValidatorCfg) Validator {
@@ -34,6 +33,9 @@ type ValidatorCfg struct {
 	MinRamGibWorker   int64 `envconfig:"HW_VALIDATOR_MIN_RAM_GIB_WORKER" default:"8"`
 	MinRamGibMaster   int64 `envconfig:"HW_VALIDATOR_MIN_RAM_GIB_MASTER" default:"16"`
 	MinDiskSizeGb     int64 `envconfig:"HW_VALIDATOR_MIN_DISK_SIZE_GIB" default:"120"` // Env variable is GIB to not break infra
+	// YAML file of the named hardware profiles that the clusters can select
+	ProfilesFile string    `envconfig:"HW_VALIDATOR_PROFILES_FILE" default:""`
+	Profiles     *Profiles `ignored:"true"` // Loaded from ProfilesFile
 }
 
 type validator struct {
@@ -46,25 +48,81 @@ func (v *validator) GetHostValidDisks(host *models.Host) ([]*models.Disk, error)
 	if err := json.Unmarshal([]byte(host.Inventory), &inventory); err != nil {
 		return nil, err
 	}
-	disks := ListValidDisks(&inventory, gbToBytes(v.MinDiskSizeGb))
+	disks := ListValidDisks(&inventory, GbToBytes(v.MinDiskSizeGb))
 	if len(disks) == 0 {
 		return nil, fmt.Errorf("host %s doesn't have valid disks", host.ID)
 	}
 	return disks, nil
 }
 
-func gbToBytes(gb int64) int64 {
+func (v *validator) GetDiskEligibility(disk *models.Disk) *models.DiskInstallationEligibility {
+	reasons := DiskEligibilityReasons(disk, GbToBytes(v.MinDiskSizeGb))
+	return &models.DiskInstallationEligibility{
+		Eligible:           len(reasons) == 0,
+		NotEligibleReasons: reasons,
+	}
+}
+
+// FindDisk returns the disk that the ID identifies by its by-path, WWN or serial number, or nil when there is no such disk
+func FindDisk(disks []*models.Disk, id string) *models.Disk {
+	if id == "" {
+		return nil
+	}
+	for _, disk := range disks {
+		if id == disk.ByPath || id == disk.Wwn || id == disk.Serial {
+			return disk
+		}
+	}
+	return nil
+}
+
+// GbToBytes converts the disk sizes, which are in GB like the disk sizes that are reported to the users
+func GbToBytes(gb int64) int64 {
 	return gb * int64(units.GB)
 }
 
+func bytesToGb(bytes int64) int64 {
+	return bytes / int64(units.GB)
+}
+
 func isNvme(name string) bool {
 	return strings.HasPrefix(name, "nvme")
 }
 
+// DiskEligibilityReasons returns the reasons that the host can't be installed on the disk, none when it can
+func DiskEligibilityReasons(disk *models.Disk, minSizeRequiredInBytes int64) []string {
+	var reasons []string
+	switch disk.DriveType {
+	case "HDD", "SSD":
+	case "ODD":
+		reasons = append(reasons, "Disk is an optical disk drive")
+	default:
+		reasons = append(reasons, fmt.Sprintf("Drive type %q is not supported", disk.DriveType))
+	}
+	if disk.SizeBytes < minSizeRequiredInBytes {
+		reasons = append(reasons, fmt.Sprintf("Disk is too small, only %d GB", bytesToGb(disk.SizeBytes)))
+	}
+	if disk.IsInstallationMedia {
+		reasons = append(reasons, "Disk is the installation medium")
+	} else if disk.Removable {
+		reasons = append(reasons, "Disk is removable")
+	}
+	if disk.ReadOnly {
+		reasons = append(reasons, "Disk is read-only")
+	}
+	if disk.HasPartitions || disk.HasLvm {
+		reasons = append(reasons, "Disk has existing partitions or LVM")
+	}
+	if disk.MultipathMember {
+		reasons = append(reasons, "Disk is a multipath member")
+	}
+	return reasons
+}
+
 func ListValidDisks(inventory *models.Inventory, minSizeRequiredInBytes int64) []*models.Disk {
 	var disks []*models.Disk
 	for _, disk := range inventory.Disks {
-		if disk.SizeBytes >= minSizeRequiredInBytes && funk.ContainsString([]string{"HDD", "SSD"}, disk.DriveType) {
+		if len(DiskEligibilityReasons(disk, minSizeRequiredInBytes)) == 0 {
 			disks = append(disks, disk)
 		}
 	}
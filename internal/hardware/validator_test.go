@@ -90,6 +90,102 @@ var _ = Describe("hardware_validator", func() {
 		Expect(disks[4].DriveType).To(Equal("SSD"))
 		Expect(disks[4].Name).To(HavePrefix("nvme"))
 	})
+
+	It("doesn't list the disks that are not eligible", func() {
+		inventory.Disks = []*models.Disk{
+			{DriveType: "HDD", Name: "sda", SizeBytes: validDiskSize, Removable: true},
+			{DriveType: "HDD", Name: "sdb", SizeBytes: validDiskSize, HasPartitions: true},
+			{DriveType: "SSD", Name: "sdc", SizeBytes: validDiskSize, MultipathMember: true},
+			{DriveType: "SSD", Name: "sdd", SizeBytes: validDiskSize},
+		}
+		hw, err := json.Marshal(&inventory)
+		Expect(err).NotTo(HaveOccurred())
+		host1.Inventory = string(hw)
+		disks, err := hwvalidator.GetHostValidDisks(host1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(disks).Should(Equal([]*models.Disk{inventory.Disks[3]}))
+	})
+
+	It("returns the eligibility of a disk", func() {
+		Expect(hwvalidator.GetDiskEligibility(&models.Disk{DriveType: "SSD", SizeBytes: validDiskSize})).Should(Equal(
+			&models.DiskInstallationEligibility{Eligible: true}))
+		Expect(hwvalidator.GetDiskEligibility(&models.Disk{DriveType: "SSD", SizeBytes: validDiskSize, ReadOnly: true})).Should(Equal(
+			&models.DiskInstallationEligibility{NotEligibleReasons: []string{"Disk is read-only"}}))
+	})
+})
+
+var _ = Describe("DiskEligibilityReasons", func() {
+	minSize := int64(120 * units.GiB)
+
+	for _, t := range []struct {
+		name    string
+		disk    models.Disk
+		reasons []string
+	}{
+		{
+			name: "eligible disk",
+			disk: models.Disk{DriveType: "HDD", SizeBytes: minSize},
+		},
+		{
+			name:    "too small",
+			disk:    models.Disk{DriveType: "SSD", SizeBytes: 20 * int64(units.GiB)},
+			reasons: []string{"Disk is too small, only 21 GB"},
+		},
+		{
+			name:    "optical disk drive",
+			disk:    models.Disk{DriveType: "ODD", SizeBytes: minSize},
+			reasons: []string{"Disk is an optical disk drive"},
+		},
+		{
+			name:    "unsupported drive type",
+			disk:    models.Disk{DriveType: "FDD", SizeBytes: minSize},
+			reasons: []string{`Drive type "FDD" is not supported`},
+		},
+		{
+			name:    "removable read-only disk",
+			disk:    models.Disk{DriveType: "HDD", SizeBytes: minSize, Removable: true, ReadOnly: true},
+			reasons: []string{"Disk is removable", "Disk is read-only"},
+		},
+		{
+			name:    "USB install medium",
+			disk:    models.Disk{DriveType: "HDD", SizeBytes: minSize, Removable: true, IsInstallationMedia: true},
+			reasons: []string{"Disk is the installation medium"},
+		},
+		{
+			name:    "existing LVM",
+			disk:    models.Disk{DriveType: "HDD", SizeBytes: minSize, HasLvm: true},
+			reasons: []string{"Disk has existing partitions or LVM"},
+		},
+		{
+			name:    "multipath member with partitions",
+			disk:    models.Disk{DriveType: "SSD", SizeBytes: minSize, HasPartitions: true, MultipathMember: true},
+			reasons: []string{"Disk has existing partitions or LVM", "Disk is a multipath member"},
+		},
+	} {
+		t := t
+		It(t.name, func() {
+			Expect(DiskEligibilityReasons(&t.disk, minSize)).Should(Equal(t.reasons))
+		})
+	}
+})
+
+var _ = Describe("FindDisk", func() {
+	disks := []*models.Disk{
+		{Name: "sda", ByPath: "/dev/disk/by-path/pci-0000:00:06.0", Wwn: "0x5000c500a1b2c3d4", Serial: "S1"},
+		{Name: "nvme0n1", ByPath: "/dev/disk/by-path/pci-0000:00:07.0", Wwn: "eui.0025385b71b0a1b2", Serial: "S2"},
+	}
+
+	It("finds a disk by its by-path, WWN or serial number", func() {
+		Expect(FindDisk(disks, "/dev/disk/by-path/pci-0000:00:07.0")).Should(Equal(disks[1]))
+		Expect(FindDisk(disks, "0x5000c500a1b2c3d4")).Should(Equal(disks[0]))
+		Expect(FindDisk(disks, "S2")).Should(Equal(disks[1]))
+	})
+
+	It("doesn't find unknown or empty IDs", func() {
+		Expect(FindDisk(disks, "S3")).Should(BeNil())
+		Expect(FindDisk(disks, "sda")).Should(BeNil())
+		Expect(FindDisk([]*models.Disk{{Name: "sdb"}}, "")).Should(BeNil())
+	})
 })
 
 func isBlockDeviceNameInlist(disks []*models.Disk, name string) bool {
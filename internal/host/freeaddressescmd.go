@@ -32,13 +32,18 @@ func (f *freeAddressesCmd) prepareParam(host *models.Host) (string, error) {
 	}
 	m := make(map[string]struct{})
 	for _, intf := range inventory.Interfaces {
-		for _, ipv4 := range intf.IPV4Addresses {
+		for _, addr := range append(append([]string{}, intf.IPV4Addresses...), intf.IPV6Addresses...) {
+			var ip net.IP
 			var cidr *net.IPNet
-			_, cidr, err = net.ParseCIDR(ipv4)
+			ip, cidr, err = net.ParseCIDR(addr)
 			if err != nil {
 				f.log.WithError(err).Warn("Cidr parse")
 				return "", err
 			}
+			// The VIPs can't be link-local addresses
+			if ip.IsLinkLocalUnicast() {
+				continue
+			}
 			m[cidr.String()] = struct{}{}
 		}
 	}
@@ -2,6 +2,7 @@ package host
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/openshift/assisted-service/internal/common"
 
@@ -41,6 +42,22 @@ var _ = Describe("inventory", func() {
 		Expect(stepErr).ShouldNot(HaveOccurred())
 	})
 
+	It("scans the IPv4 and IPv6 networks", func() {
+		inventory := models.Inventory{Interfaces: []*models.Interface{{
+			IPV4Addresses: []string{"192.168.1.5/24"},
+			IPV6Addresses: []string{"fd00::5/120", "fe80::5054:ff:fe12:3456/64"},
+		}}}
+		b, err := json.Marshal(&inventory)
+		Expect(err).ShouldNot(HaveOccurred())
+		host.Inventory = string(b)
+		stepReply, stepErr = fCmd.GetStep(ctx, &host)
+		Expect(stepErr).ShouldNot(HaveOccurred())
+		var request models.FreeAddressesRequest
+		Expect(json.Unmarshal([]byte(stepReply.Args[len(stepReply.Args)-1]), &request)).ShouldNot(HaveOccurred())
+		Expect(request).Should(ConsistOf("192.168.1.0/24", "fd00::/120"))
+		Expect(request.Validate(strfmt.Default)).ShouldNot(HaveOccurred())
+	})
+
 	It("Illegal inventory", func() {
 		host.Inventory = "blah"
 		stepReply, stepErr = fCmd.GetStep(ctx, &host)
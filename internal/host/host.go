@@ -2,6 +2,7 @@ package host
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -74,6 +75,8 @@ type API interface {
 	HostMonitoring()
 	UpdateRole(ctx context.Context, h *models.Host, role models.HostRole, db *gorm.DB) error
 	UpdateHostname(ctx context.Context, h *models.Host, hostname string, db *gorm.DB) error
+	// Set the disk that the host is installed on, empty to let the service choose the disk
+	UpdateInstallationDisk(ctx context.Context, h *models.Host, installationDiskID string, db *gorm.DB) error
 	CancelInstallation(ctx context.Context, h *models.Host, reason string, db *gorm.DB) *common.ApiErrorResponse
 	IsRequireUserActionReset(h *models.Host) bool
 	ResetHost(ctx context.Context, h *models.Host, reason string, db *gorm.DB) *common.ApiErrorResponse
@@ -162,8 +165,20 @@ func (m *Manager) UpdateInventory(ctx context.Context, h *models.Host, inventory
 			errors.Errorf("Host is in %s state, host can be updated only in one of %s states",
 				hostStatus, allowedStatuses))
 	}
-	h.Inventory = inventory
-	return m.db.Model(h).Update("inventory", inventory).Error
+
+	var inv models.Inventory
+	if err := json.Unmarshal([]byte(inventory), &inv); err != nil {
+		return common.NewApiError(http.StatusBadRequest, errors.Wrapf(err, "Failed to parse the inventory of host %s", h.ID))
+	}
+	for _, disk := range inv.Disks {
+		disk.InstallationEligibility = m.hwValidator.GetDiskEligibility(disk)
+	}
+	b, err := json.Marshal(&inv)
+	if err != nil {
+		return err
+	}
+	h.Inventory = string(b)
+	return m.db.Model(h).Update("inventory", h.Inventory).Error
 }
 
 func (m *Manager) RefreshStatus(ctx context.Context, h *models.Host, db *gorm.DB) error {
@@ -320,6 +335,31 @@ func (m *Manager) UpdateHostname(ctx context.Context, h *models.Host, hostname s
 	return cdb.Model(h).Update("requested_hostname", hostname).Error
 }
 
+func (m *Manager) UpdateInstallationDisk(ctx context.Context, h *models.Host, installationDiskID string, db *gorm.DB) error {
+	hostStatus := swag.StringValue(h.Status)
+	allowedStatuses := []string{HostStatusKnown, HostStatusInsufficient, HostStatusPendingForInput}
+	if !funk.ContainsString(allowedStatuses, hostStatus) {
+		return common.NewApiError(http.StatusBadRequest,
+			errors.Errorf("Host is in %s state, installation disk can be set only in one of %s states",
+				hostStatus, allowedStatuses))
+	}
+
+	if installationDiskID != "" {
+		disks, err := m.hwValidator.GetHostValidDisks(h)
+		if err != nil || hardware.FindDisk(disks, installationDiskID) == nil {
+			return common.NewApiError(http.StatusBadRequest,
+				errors.Errorf("Disk %s is not a valid installation disk of host %s", installationDiskID, h.ID))
+		}
+	}
+
+	h.InstallationDiskID = installationDiskID
+	cdb := m.db
+	if db != nil {
+		cdb = db
+	}
+	return cdb.Model(h).Update("installation_disk_id", installationDiskID).Error
+}
+
 func (m *Manager) CancelInstallation(ctx context.Context, h *models.Host, reason string, db *gorm.DB) *common.ApiErrorResponse {
 	eventSeverity := models.EventSeverityInfo
 	eventInfo := fmt.Sprintf("Installation canceled for host %s", common.GetHostnameForMsg(h))
@@ -11,6 +11,7 @@ import (
 
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/events"
+	"github.com/openshift/assisted-service/internal/hardware"
 	"github.com/openshift/assisted-service/internal/metrics"
 	"github.com/openshift/assisted-service/models"
 
@@ -707,7 +708,8 @@ var _ = Describe("UpdateInventory", func() {
 
 	BeforeEach(func() {
 		db = common.PrepareTestDB(dbName, &events.Event{})
-		hapi = NewManager(getTestLog(), db, nil, nil, nil, createValidatorCfg(), nil)
+		hapi = NewManager(getTestLog(), db, nil, hardware.NewValidator(getTestLog(), *createValidatorCfg()), nil,
+			createValidatorCfg(), nil)
 		hostId = strfmt.UUID(uuid.New().String())
 		clusterId = strfmt.UUID(uuid.New().String())
 	})
@@ -716,8 +718,40 @@ var _ = Describe("UpdateInventory", func() {
 		common.DeleteTestDB(db, dbName)
 	})
 
+	It("sets the installation eligibility of the disks", func() {
+		host = getTestHost(hostId, clusterId, models.HostStatusKnown)
+		Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+		inventory := models.Inventory{Disks: []*models.Disk{
+			{Name: "sda", DriveType: "HDD", SizeBytes: 128849018880},
+			{Name: "sdb", DriveType: "SSD", SizeBytes: 128849018880, Removable: true, HasLvm: true},
+			{Name: "sr0", DriveType: "ODD", SizeBytes: 1073741824},
+		}}
+		b, err := json.Marshal(&inventory)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(hapi.UpdateInventory(ctx, &host, string(b))).ShouldNot(HaveOccurred())
+
+		Expect(json.Unmarshal([]byte(getHost(hostId, clusterId, db).Inventory), &inventory)).ShouldNot(HaveOccurred())
+		Expect(inventory.Disks[0].InstallationEligibility).Should(Equal(&models.DiskInstallationEligibility{Eligible: true}))
+		Expect(inventory.Disks[1].InstallationEligibility).Should(Equal(&models.DiskInstallationEligibility{
+			NotEligibleReasons: []string{"Disk is removable", "Disk has existing partitions or LVM"},
+		}))
+		Expect(inventory.Disks[2].InstallationEligibility).Should(Equal(&models.DiskInstallationEligibility{
+			NotEligibleReasons: []string{"Disk is an optical disk drive", "Disk is too small, only 1 GB"},
+		}))
+	})
+
+	It("rejects an invalid inventory", func() {
+		host = getTestHost(hostId, clusterId, models.HostStatusKnown)
+		host.Inventory = defaultInventoryS
+		Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+		err := hapi.UpdateInventory(ctx, &host, "new inventory stuff")
+		Expect(err).Should(HaveOccurred())
+		Expect(err.(*common.ApiErrorResponse).StatusCode()).Should(Equal(int32(http.StatusBadRequest)))
+		Expect(getHost(hostId, clusterId, db).Inventory).To(Equal(defaultInventoryS))
+	})
+
 	Context("enable host", func() {
-		newInventory := "new inventory stuff"
+		newInventory := `{"disks":null,"hostname":"new-hostname","interfaces":null}`
 		success := func(reply error) {
 			Expect(reply).To(BeNil())
 			h := getHost(hostId, clusterId, db)
@@ -922,6 +956,67 @@ var _ = Describe("Update hostname", func() {
 	})
 })
 
+var _ = Describe("Update installation disk", func() {
+	var (
+		ctx               = context.Background()
+		hapi              API
+		db                *gorm.DB
+		hostId, clusterId strfmt.UUID
+		host              models.Host
+		dbName            = "update_installation_disk"
+	)
+
+	BeforeEach(func() {
+		db = common.PrepareTestDB(dbName, &events.Event{})
+		hapi = NewManager(getTestLog(), db, nil, hardware.NewValidator(getTestLog(), *createValidatorCfg()), nil,
+			createValidatorCfg(), nil)
+		hostId = strfmt.UUID(uuid.New().String())
+		clusterId = strfmt.UUID(uuid.New().String())
+	})
+
+	AfterEach(func() {
+		common.DeleteTestDB(db, dbName)
+	})
+
+	createHost := func(srcState string) {
+		host = getTestHost(hostId, clusterId, srcState)
+		inventory := models.Inventory{Disks: []*models.Disk{
+			{Name: "sda", DriveType: "HDD", SizeBytes: 128849018880, ByPath: "/dev/disk/by-path/pci-0000:00:06.0"},
+			{Name: "nvme0n1", DriveType: "SSD", SizeBytes: 128849018880, Wwn: "eui.0025385b71b0a1b2"},
+			{Name: "sdb", DriveType: "HDD", SizeBytes: 1073741824, Serial: "S1"},
+		}}
+		b, err := json.Marshal(&inventory)
+		Expect(err).ShouldNot(HaveOccurred())
+		host.Inventory = string(b)
+		Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+	}
+
+	It("sets a valid disk", func() {
+		createHost(models.HostStatusKnown)
+		Expect(hapi.UpdateInstallationDisk(ctx, &host, "eui.0025385b71b0a1b2", db)).ShouldNot(HaveOccurred())
+		Expect(getHost(hostId, clusterId, db).InstallationDiskID).To(Equal("eui.0025385b71b0a1b2"))
+
+		Expect(hapi.UpdateInstallationDisk(ctx, &host, "", db)).ShouldNot(HaveOccurred())
+		Expect(getHost(hostId, clusterId, db).InstallationDiskID).To(Equal(""))
+	})
+
+	It("rejects disks that are not valid disks of the host", func() {
+		createHost(models.HostStatusInsufficient)
+		for _, id := range []string{"S1", "S2"} {
+			err := hapi.UpdateInstallationDisk(ctx, &host, id, db)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.(*common.ApiErrorResponse).StatusCode()).Should(Equal(int32(http.StatusBadRequest)))
+		}
+		Expect(getHost(hostId, clusterId, db).InstallationDiskID).To(Equal(""))
+	})
+
+	It("rejects hosts that are installing", func() {
+		createHost(models.HostStatusInstalling)
+		Expect(hapi.UpdateInstallationDisk(ctx, &host, "/dev/disk/by-path/pci-0000:00:06.0", db)).Should(HaveOccurred())
+		Expect(getHost(hostId, clusterId, db).InstallationDiskID).To(Equal(""))
+	})
+})
+
 var _ = Describe("SetBootstrap", func() {
 	var (
 		ctx               = context.Background()
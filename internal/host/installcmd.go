@@ -13,6 +13,8 @@ import (
 
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/hardware"
+	"github.com/openshift/assisted-service/internal/mirrorregistries"
+	"github.com/openshift/assisted-service/internal/versions"
 	"github.com/openshift/assisted-service/models"
 )
 
@@ -21,14 +23,17 @@ type installCmd struct {
 	db                *gorm.DB
 	hwValidator       hardware.Validator
 	instructionConfig InstructionConfig
+	versionsHandler   versions.Handler
 }
 
-func NewInstallCmd(log logrus.FieldLogger, db *gorm.DB, hwValidator hardware.Validator, instructionConfig InstructionConfig) *installCmd {
+func NewInstallCmd(log logrus.FieldLogger, db *gorm.DB, hwValidator hardware.Validator, instructionConfig InstructionConfig,
+	versionsHandler versions.Handler) *installCmd {
 	return &installCmd{
 		baseCmd:           baseCmd{log: log},
 		db:                db,
 		hwValidator:       hwValidator,
 		instructionConfig: instructionConfig,
+		versionsHandler:   versionsHandler,
 	}
 }
 
@@ -43,16 +48,29 @@ func (i *installCmd) GetStep(ctx context.Context, host *models.Host) (*models.St
 		i.log.Errorf("failed to get cluster %s", host.ClusterID)
 		return nil, err
 	}
+	openshiftVersion, err := i.versionsHandler.GetOpenshiftVersion(cluster.OpenshiftVersion)
+	if err != nil {
+		i.log.WithError(err).Errorf("failed to get OpenShift version of cluster %s", host.ClusterID)
+		return nil, err
+	}
+
+	mirrorRegistries, err := mirrorregistries.ParseConfig(cluster.MirrorRegistriesConfig)
+	if err != nil {
+		i.log.WithError(err).Errorf("failed to parse the mirror registries of cluster %s", host.ClusterID)
+		return nil, err
+	}
 
 	var role = host.Role
 	if host.Bootstrap {
 		role = models.HostRoleBootstrap
 	}
 
+	// The installer sends the agent token of the cluster along with its requests and stores it in the secret of
+	// the controller, which calls the service with it once the cluster is up
 	cmdArgsTmpl := "podman run -v /dev:/dev:rw -v /opt:/opt:rw -v /run/systemd/journal/socket:/run/systemd/journal/socket --privileged --pid=host --net=host " +
-		"-v /var/log:/var/log:rw --env PULL_SECRET_TOKEN --name assisted-installer {{.INSTALLER}} --role {{.ROLE}} --cluster-id {{.CLUSTER_ID}} --host {{.HOST}} " +
+		"-v /var/log:/var/log:rw --env PULL_SECRET_TOKEN --env AGENT_TOKEN --name assisted-installer {{.INSTALLER}} --role {{.ROLE}} --cluster-id {{.CLUSTER_ID}} --host {{.HOST}} " +
 		"--port {{.PORT}} --boot-device {{.BOOT_DEVICE}} --host-id {{.HOST_ID}} --openshift-version {{.OPENSHIFT_VERSION}} " +
-		"--controller-image {{.CONTROLLER_IMAGE}}"
+		"--controller-image {{.CONTROLLER_IMAGE}} --agent-token-env AGENT_TOKEN"
 
 	data := map[string]string{
 		"HOST":              strings.TrimSpace(i.instructionConfig.ServiceURL),
@@ -60,8 +78,8 @@ func (i *installCmd) GetStep(ctx context.Context, host *models.Host) (*models.St
 		"CLUSTER_ID":        string(host.ClusterID),
 		"HOST_ID":           string(*host.ID),
 		"ROLE":              string(role),
-		"INSTALLER":         i.instructionConfig.InstallerImage,
-		"CONTROLLER_IMAGE":  i.instructionConfig.ControllerImage,
+		"INSTALLER":         mirrorregistries.RewriteImage(openshiftVersion.InstallerImage, mirrorRegistries),
+		"CONTROLLER_IMAGE":  mirrorregistries.RewriteImage(openshiftVersion.ControllerImage, mirrorRegistries),
 		"BOOT_DEVICE":       "",
 		"OPENSHIFT_VERSION": cluster.OpenshiftVersion,
 	}
@@ -90,7 +108,7 @@ func (i *installCmd) GetStep(ctx context.Context, host *models.Host) (*models.St
 	step.Args = []string{"-c", buf.String()}
 
 	if err := i.db.Model(&models.Host{}).Where("id = ?", host.ID.String()).
-		Update("installer_version", i.instructionConfig.InstallerImage).Error; err != nil {
+		Update("installer_version", openshiftVersion.InstallerImage).Error; err != nil {
 		return nil, err
 	}
 
@@ -104,5 +122,13 @@ func getBootDevice(log logrus.FieldLogger, hwValidator hardware.Validator, host
 		log.Errorf("Failed to get valid disks on host with id %s", host.ID)
 		return "", err
 	}
-	return fmt.Sprintf("/dev/%s", disks[0].Name), nil
+	if host.InstallationDiskID == "" {
+		return fmt.Sprintf("/dev/%s", disks[0].Name), nil
+	}
+	disk := hardware.FindDisk(disks, host.InstallationDiskID)
+	if disk == nil {
+		log.Errorf("Installation disk %s is not a valid disk of host %s", host.InstallationDiskID, host.ID)
+		return "", fmt.Errorf("Installation disk %s is not a valid disk of host %s", host.InstallationDiskID, host.ID)
+	}
+	return fmt.Sprintf("/dev/%s", disk.Name), nil
 }
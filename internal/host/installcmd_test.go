@@ -14,13 +14,19 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/hardware"
+	"github.com/openshift/assisted-service/internal/versions"
 	"github.com/openshift/assisted-service/models"
 	"github.com/pkg/errors"
 )
 
 var defaultInstructionConfig = InstructionConfig{
-	ServiceURL:      "10.35.59.36",
-	ServicePort:     "30485",
+	ServiceURL:  "10.35.59.36",
+	ServicePort: "30485",
+}
+
+var defaultOpenshiftVersion = &models.OpenshiftVersion{
+	DisplayName:     swag.String("4.5"),
+	ReleaseImage:    swag.String("quay.io/openshift-release-dev/ocp-release:4.5.0-x86_64"),
 	InstallerImage:  "quay.io/ocpmetal/assisted-installer:latest",
 	ControllerImage: "quay.io/ocpmetal/assisted-installer-controller:latest",
 }
@@ -37,6 +43,7 @@ var _ = Describe("installcmd", func() {
 		stepErr           error
 		ctrl              *gomock.Controller
 		mockValidator     *hardware.MockValidator
+		mockVersions      *versions.MockHandler
 		instructionConfig InstructionConfig
 		disks             []*models.Disk
 		dbName            = "install_cmd"
@@ -45,8 +52,9 @@ var _ = Describe("installcmd", func() {
 		db = common.PrepareTestDB(dbName)
 		ctrl = gomock.NewController(GinkgoT())
 		mockValidator = hardware.NewMockValidator(ctrl)
+		mockVersions = versions.NewMockHandler(ctrl)
 		instructionConfig = defaultInstructionConfig
-		installCmd = NewInstallCmd(getTestLog(), db, mockValidator, instructionConfig)
+		installCmd = NewInstallCmd(getTestLog(), db, mockValidator, instructionConfig, mockVersions)
 		cluster = createClusterInDb(db)
 		clusterId = *cluster.ID
 		host = createHostInDb(db, clusterId, models.HostRoleMaster, false, "")
@@ -60,12 +68,14 @@ var _ = Describe("installcmd", func() {
 	})
 
 	It("get_step_one_master", func() {
+		mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(defaultOpenshiftVersion, nil).Times(1)
 		mockValidator.EXPECT().GetHostValidDisks(gomock.Any()).Return(nil, errors.New("error")).Times(1)
 		stepReply, stepErr = installCmd.GetStep(ctx, &host)
 		postvalidation(true, true, stepReply, stepErr, "")
 	})
 
 	It("get_step_one_master_no_disks", func() {
+		mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(defaultOpenshiftVersion, nil).Times(1)
 		var emptydisks []*models.Disk
 		mockValidator.EXPECT().GetHostValidDisks(gomock.Any()).Return(emptydisks, nil).Times(1)
 		stepReply, stepErr = installCmd.GetStep(ctx, &host)
@@ -73,18 +83,70 @@ var _ = Describe("installcmd", func() {
 	})
 
 	It("get_step_one_master_success", func() {
+		mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(defaultOpenshiftVersion, nil).Times(1)
 		mockValidator.EXPECT().GetHostValidDisks(gomock.Any()).Return(disks, nil).Times(1)
 		stepReply, stepErr = installCmd.GetStep(ctx, &host)
 		postvalidation(false, false, stepReply, stepErr, models.HostRoleMaster)
 		validateInstallCommand(stepReply, models.HostRoleMaster, string(clusterId), string(*host.ID), "")
 		Expect(getHost(*host.ID, clusterId, db).InstallerVersion).
-			To(Equal(defaultInstructionConfig.InstallerImage))
+			To(Equal(defaultOpenshiftVersion.InstallerImage))
+	})
+
+	It("get_step_installation_disk", func() {
+		disks[2].ByPath = "/dev/disk/by-path/pci-0000:00:06.0"
+		host.InstallationDiskID = disks[2].ByPath
+		mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(defaultOpenshiftVersion, nil).Times(1)
+		mockValidator.EXPECT().GetHostValidDisks(gomock.Any()).Return(disks, nil).Times(1)
+		stepReply, stepErr = installCmd.GetStep(ctx, &host)
+		postvalidation(false, false, stepReply, stepErr, models.HostRoleMaster)
+		Expect(stepReply.Args[1]).Should(ContainSubstring("--boot-device /dev/sdh "))
+	})
+
+	It("get_step_invalid_installation_disk", func() {
+		host.InstallationDiskID = "not-a-disk"
+		mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(defaultOpenshiftVersion, nil).Times(1)
+		mockValidator.EXPECT().GetHostValidDisks(gomock.Any()).Return(disks, nil).Times(1)
+		stepReply, stepErr = installCmd.GetStep(ctx, &host)
+		postvalidation(true, true, stepReply, stepErr, "")
+	})
+
+	It("get_step_version_images", func() {
+		openshiftVersion := *defaultOpenshiftVersion
+		openshiftVersion.InstallerImage = "quay.io/example/assisted-installer:4.5"
+		openshiftVersion.ControllerImage = "quay.io/example/assisted-installer-controller:4.5"
+		mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(&openshiftVersion, nil).Times(1)
+		mockValidator.EXPECT().GetHostValidDisks(gomock.Any()).Return(disks, nil).Times(1)
+		stepReply, stepErr = installCmd.GetStep(ctx, &host)
+		postvalidation(false, false, stepReply, stepErr, models.HostRoleMaster)
+		Expect(stepReply.Args[1]).Should(ContainSubstring("--name assisted-installer quay.io/example/assisted-installer:4.5 "))
+		Expect(stepReply.Args[1]).Should(ContainSubstring("--controller-image quay.io/example/assisted-installer-controller:4.5"))
+		Expect(getHost(*host.ID, clusterId, db).InstallerVersion).To(Equal(openshiftVersion.InstallerImage))
+	})
+
+	It("get_step_mirror_registries_images", func() {
+		Expect(db.Model(&common.Cluster{}).Where("id = ?", clusterId).Update("mirror_registries_config",
+			`{"image_content_sources":[{"source":"quay.io/ocpmetal","mirrors":["mirror.example.com:5000/ocpmetal"]}]}`).Error).
+			ShouldNot(HaveOccurred())
+		mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(defaultOpenshiftVersion, nil).Times(1)
+		mockValidator.EXPECT().GetHostValidDisks(gomock.Any()).Return(disks, nil).Times(1)
+		stepReply, stepErr = installCmd.GetStep(ctx, &host)
+		postvalidation(false, false, stepReply, stepErr, models.HostRoleMaster)
+		Expect(stepReply.Args[1]).Should(ContainSubstring("--name assisted-installer mirror.example.com:5000/ocpmetal/assisted-installer:latest "))
+		Expect(stepReply.Args[1]).Should(ContainSubstring("--controller-image mirror.example.com:5000/ocpmetal/assisted-installer-controller:latest"))
+		Expect(getHost(*host.ID, clusterId, db).InstallerVersion).To(Equal(defaultOpenshiftVersion.InstallerImage))
+	})
+
+	It("get_step_unsupported_version", func() {
+		mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(nil, errors.New("OpenShift version 4.5 is not supported")).Times(1)
+		stepReply, stepErr = installCmd.GetStep(ctx, &host)
+		postvalidation(true, true, stepReply, stepErr, "")
 	})
 
 	It("get_step_three_master_success", func() {
 
 		host2 := createHostInDb(db, clusterId, models.HostRoleMaster, false, "")
 		host3 := createHostInDb(db, clusterId, models.HostRoleMaster, true, "some_hostname")
+		mockVersions.EXPECT().GetOpenshiftVersion("4.5").Return(defaultOpenshiftVersion, nil).Times(3)
 		mockValidator.EXPECT().GetHostValidDisks(gomock.Any()).Return(disks, nil).Times(3)
 		stepReply, stepErr = installCmd.GetStep(ctx, &host)
 		postvalidation(false, false, stepReply, stepErr, models.HostRoleMaster)
@@ -150,25 +212,25 @@ func validateInstallCommand(reply *models.Step, role models.HostRole, clusterId
 	if hostname != "" {
 		installCommand := "podman run -v /dev:/dev:rw -v /opt:/opt:rw -v /run/systemd/journal/socket:/run/systemd/journal/socket " +
 			"--privileged --pid=host " +
-			"--net=host -v /var/log:/var/log:rw --env PULL_SECRET_TOKEN " +
+			"--net=host -v /var/log:/var/log:rw --env PULL_SECRET_TOKEN --env AGENT_TOKEN " +
 			"--name assisted-installer quay.io/ocpmetal/assisted-installer:latest --role %s " +
 			"--cluster-id %s --host %s --port %s " +
 			"--boot-device /dev/sdb --host-id %s --openshift-version 4.5 " +
-			"--controller-image %s --host-name %s"
+			"--controller-image %s --agent-token-env AGENT_TOKEN --host-name %s"
 		ExpectWithOffset(1, reply.Args[1]).Should(Equal(fmt.Sprintf(installCommand, role, clusterId,
 			defaultInstructionConfig.ServiceURL, defaultInstructionConfig.ServicePort, hostId,
-			defaultInstructionConfig.ControllerImage, hostname)))
+			defaultOpenshiftVersion.ControllerImage, hostname)))
 	} else {
 		installCommand := "podman run -v /dev:/dev:rw -v /opt:/opt:rw -v /run/systemd/journal/socket:/run/systemd/journal/socket " +
 			"--privileged --pid=host " +
-			"--net=host -v /var/log:/var/log:rw --env PULL_SECRET_TOKEN " +
+			"--net=host -v /var/log:/var/log:rw --env PULL_SECRET_TOKEN --env AGENT_TOKEN " +
 			"--name assisted-installer quay.io/ocpmetal/assisted-installer:latest --role %s " +
 			"--cluster-id %s --host %s --port %s " +
 			"--boot-device /dev/sdb --host-id %s --openshift-version 4.5 " +
-			"--controller-image %s"
+			"--controller-image %s --agent-token-env AGENT_TOKEN"
 		ExpectWithOffset(1, reply.Args[1]).Should(Equal(fmt.Sprintf(installCommand, role, clusterId,
 			defaultInstructionConfig.ServiceURL, defaultInstructionConfig.ServicePort, hostId,
-			defaultInstructionConfig.ControllerImage)))
+			defaultOpenshiftVersion.ControllerImage)))
 	}
 	ExpectWithOffset(1, reply.StepType).To(Equal(models.StepTypeInstall))
 }
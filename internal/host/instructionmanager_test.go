@@ -13,6 +13,7 @@ import (
 	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/internal/events"
 	"github.com/openshift/assisted-service/internal/hardware"
+	"github.com/openshift/assisted-service/internal/versions"
 	"github.com/openshift/assisted-service/models"
 )
 
@@ -28,6 +29,7 @@ var _ = Describe("instructionmanager", func() {
 		instMng           *InstructionManager
 		ctrl              *gomock.Controller
 		hwValidator       *hardware.MockValidator
+		mockVersions      *versions.MockHandler
 		instructionConfig InstructionConfig
 		dbName            = "instructionmanager"
 	)
@@ -37,7 +39,9 @@ var _ = Describe("instructionmanager", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockEvents = events.NewMockHandler(ctrl)
 		hwValidator = hardware.NewMockValidator(ctrl)
-		instMng = NewInstructionManager(getTestLog(), db, hwValidator, instructionConfig, nil)
+		mockVersions = versions.NewMockHandler(ctrl)
+		mockVersions.EXPECT().GetOpenshiftVersion(gomock.Any()).Return(defaultOpenshiftVersion, nil).AnyTimes()
+		instMng = NewInstructionManager(getTestLog(), db, hwValidator, instructionConfig, nil, mockVersions)
 		hostId = strfmt.UUID(uuid.New().String())
 		clusterId = strfmt.UUID(uuid.New().String())
 		cluster := common.Cluster{Cluster: models.Cluster{ID: &clusterId}}
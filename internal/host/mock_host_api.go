@@ -175,6 +175,20 @@ func (mr *MockAPIMockRecorder) UpdateHostname(ctx, h, hostname, db interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHostname", reflect.TypeOf((*MockAPI)(nil).UpdateHostname), ctx, h, hostname, db)
 }
 
+// UpdateInstallationDisk mocks base method
+func (m *MockAPI) UpdateInstallationDisk(ctx context.Context, h *models.Host, installationDiskID string, db *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstallationDisk", ctx, h, installationDiskID, db)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInstallationDisk indicates an expected call of UpdateInstallationDisk
+func (mr *MockAPIMockRecorder) UpdateInstallationDisk(ctx, h, installationDiskID, db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstallationDisk", reflect.TypeOf((*MockAPI)(nil).UpdateInstallationDisk), ctx, h, installationDiskID, db)
+}
+
 // CancelInstallation mocks base method
 func (m *MockAPI) CancelInstallation(ctx context.Context, h *models.Host, reason string, db *gorm.DB) *common.ApiErrorResponse {
 	m.ctrl.T.Helper()
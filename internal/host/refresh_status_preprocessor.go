@@ -75,6 +75,11 @@ func newValidations(log logrus.FieldLogger, hwValidatorCfg *hardware.ValidatorCf
 			condition: v.hasMinValidDisks,
 			formatter: v.printHasMinValidDisks,
 		},
+		{
+			id:        IsInstallationDiskValid,
+			condition: v.isInstallationDiskValid,
+			formatter: v.printIsInstallationDiskValid,
+		},
 		{
 			id:        IsMachineCidrDefined,
 			condition: v.isMachineCidrDefined,
@@ -110,6 +115,31 @@ func newValidations(log logrus.FieldLogger, hwValidatorCfg *hardware.ValidatorCf
 			condition: v.isHostnameValid,
 			formatter: v.printHostnameValid,
 		},
+		{
+			id:        BelongsToMajority,
+			condition: v.belongsToMajority,
+			formatter: v.printBelongsToMajority,
+		},
+		{
+			id:        HasRequiredCPUFlags,
+			condition: v.hasRequiredCPUFlags,
+			formatter: v.printHasRequiredCPUFlags,
+		},
+		{
+			id:        HasMinNicSpeed,
+			condition: v.hasMinNicSpeed,
+			formatter: v.printHasMinNicSpeed,
+		},
+		{
+			id:        HasRequiredDisks,
+			condition: v.hasRequiredDisks,
+			formatter: v.printHasRequiredDisks,
+		},
+		{
+			id:        HasAllowedVendor,
+			condition: v.hasAllowedVendor,
+			formatter: v.printHasAllowedVendor,
+		},
 	}
 	return ret
 }
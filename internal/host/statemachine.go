@@ -220,7 +220,8 @@ func NewHostStateMachine(th *transitionHandler) stateswitch.StateMachine {
 	var requiredInputFieldsExist = stateswitch.And(If(IsMachineCidrDefined), If(IsRoleDefined))
 
 	var isSufficientForInstall = stateswitch.And(If(HasMemoryForRole), If(HasCPUCoresForRole), If(BelongsToMachineCidr),
-		If(IsHostnameUnique), If(IsHostnameValid))
+		If(IsHostnameUnique), If(IsHostnameValid), If(BelongsToMajority), If(HasRequiredCPUFlags), If(HasMinNicSpeed),
+		If(HasRequiredDisks), If(HasAllowedVendor), If(IsInstallationDiskValid))
 
 	// In order for this transition to be fired at least one of the validations in minRequiredHardwareValidations must fail.
 	// This transition handles the case that a host does not pass minimum hardware requirements for any of the roles
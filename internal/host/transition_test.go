@@ -1101,7 +1101,7 @@ var _ = Describe("Refresh Host", func() {
 					HasInventory:         {status: ValidationSuccess, messagePattern: "Valid inventory exists for the host"},
 					HasMinCPUCores:       {status: ValidationSuccess, messagePattern: "Sufficient CPU cores"},
 					HasMinMemory:         {status: ValidationFailure, messagePattern: "Require at least 8 GiB RAM, found only 0 GiB"},
-					HasMinValidDisks:     {status: ValidationFailure, messagePattern: "Require a disk of at least 120 GB"},
+					HasMinValidDisks:     {status: ValidationFailure, messagePattern: "Require a disk of at least 120 GB, found no eligible disk:  \\(Disk is too small, only 0 GB\\)$"},
 					IsMachineCidrDefined: {status: ValidationFailure, messagePattern: "Machine network CIDR is undefined"},
 					IsRoleDefined:        {status: ValidationFailure, messagePattern: "Role is undefined"},
 					HasCPUCoresForRole:   {status: ValidationPending, messagePattern: "Missing inventory or role"},
@@ -2030,6 +2030,400 @@ var _ = Describe("Refresh Host", func() {
 			})
 		}
 	})
+	Context("Majority connectivity", func() {
+		var peerIDs []strfmt.UUID
+
+		BeforeEach(func() {
+			peerIDs = nil
+			for i := 0; i < 4; i++ {
+				peerIDs = append(peerIDs, strfmt.UUID(uuid.New().String()))
+			}
+		})
+
+		connectivityReport := func(reachable []int, remoteIPAddress string) string {
+			var report models.ConnectivityReport
+			for _, i := range reachable {
+				report.RemoteHosts = append(report.RemoteHosts, &models.ConnectivityRemoteHost{
+					HostID: peerIDs[i],
+					L2Connectivity: []*models.L2Connectivity{
+						{RemoteIPAddress: remoteIPAddress, Successful: false},
+					},
+					L3Connectivity: []*models.L3Connectivity{
+						{RemoteIPAddress: remoteIPAddress, Successful: true},
+					},
+				})
+			}
+			b, err := json.Marshal(&report)
+			Expect(err).ToNot(HaveOccurred())
+			return string(b)
+		}
+
+		tests := []struct {
+			name              string
+			peers             int
+			noReport          bool
+			reachable         []int
+			remoteIPAddress   string
+			dstState          string
+			statusInfoChecker statusInfoChecker
+			validation        validationCheckResult
+		}{
+			{
+				name:              "too few hosts to require a majority",
+				peers:             1,
+				noReport:          true,
+				dstState:          HostStatusKnown,
+				statusInfoChecker: makeValueChecker(""),
+				validation: validationCheckResult{status: ValidationSuccess,
+					messagePattern: "Connectivity is not checked for less than 3 hosts in the machine network"},
+			},
+			{
+				name:              "missing connectivity report",
+				peers:             2,
+				noReport:          true,
+				dstState:          HostStatusInsufficient,
+				statusInfoChecker: makeValueChecker(statusInfoNotReadyForInstall),
+				validation:        validationCheckResult{status: ValidationPending, messagePattern: "Missing connectivity report"},
+			},
+			{
+				name:              "connectivity to all the hosts",
+				peers:             2,
+				reachable:         []int{0, 1},
+				remoteIPAddress:   "1.2.3.5",
+				dstState:          HostStatusKnown,
+				statusInfoChecker: makeValueChecker(""),
+				validation: validationCheckResult{status: ValidationSuccess,
+					messagePattern: "Host has connectivity to all the hosts in the machine network"},
+			},
+			{
+				name:              "connectivity to the majority of the hosts",
+				peers:             4,
+				reachable:         []int{1, 3},
+				remoteIPAddress:   "1.2.3.5",
+				dstState:          HostStatusKnown,
+				statusInfoChecker: makeValueChecker(""),
+				validation: validationCheckResult{status: ValidationSuccess,
+					messagePattern: "Host has connectivity to the majority of hosts in the machine network, unreachable hosts: peer-0, peer-2$"},
+			},
+			{
+				name:              "no connectivity to the majority of the hosts",
+				peers:             4,
+				reachable:         []int{2},
+				remoteIPAddress:   "1.2.3.5",
+				dstState:          HostStatusInsufficient,
+				statusInfoChecker: makeValueChecker(statusInfoNotReadyForInstall),
+				validation: validationCheckResult{status: ValidationFailure,
+					messagePattern: "Host has no connectivity to the majority of hosts in the machine network, unreachable hosts: peer-0, peer-1, peer-3$"},
+			},
+			{
+				name:              "connectivity outside of the machine network",
+				peers:             2,
+				reachable:         []int{0, 1},
+				remoteIPAddress:   "10.0.0.5",
+				dstState:          HostStatusInsufficient,
+				statusInfoChecker: makeValueChecker(statusInfoNotReadyForInstall),
+				validation: validationCheckResult{status: ValidationFailure,
+					messagePattern: "unreachable hosts: peer-0, peer-1$"},
+			},
+		}
+
+		for i := range tests {
+			t := tests[i]
+			It(t.name, func() {
+				host = getTestHost(hostId, clusterId, HostStatusInsufficient)
+				host.Inventory = masterInventoryWithHostname("host")
+				host.Role = models.HostRoleMaster
+				if !t.noReport {
+					host.Connectivity = connectivityReport(t.reachable, t.remoteIPAddress)
+				}
+				Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+				for i := 0; i < t.peers; i++ {
+					peer := getTestHost(peerIDs[i], clusterId, HostStatusKnown)
+					peer.Inventory = masterInventoryWithHostname(fmt.Sprintf("peer-%d", i))
+					peer.Role = models.HostRoleMaster
+					Expect(db.Create(&peer).Error).ShouldNot(HaveOccurred())
+				}
+				cluster = getTestCluster(clusterId, "1.2.3.0/24")
+				Expect(db.Create(&cluster).Error).ToNot(HaveOccurred())
+				if t.dstState != HostStatusInsufficient {
+					mockEvents.EXPECT().AddEvent(gomock.Any(), hostId.String(), models.EventSeverityInfo,
+						gomock.Any(), gomock.Any(), clusterId.String())
+				}
+
+				Expect(hapi.RefreshStatus(ctx, &host, db)).ToNot(HaveOccurred())
+				var resultHost models.Host
+				Expect(db.Take(&resultHost, "id = ? and cluster_id = ?", hostId.String(), clusterId.String()).Error).ToNot(HaveOccurred())
+				Expect(resultHost.Status).To(Equal(&t.dstState))
+				t.statusInfoChecker.check(resultHost.StatusInfo)
+				makeJsonChecker(map[validationID]validationCheckResult{BelongsToMajority: t.validation}).check(resultHost.ValidationsInfo)
+			})
+		}
+	})
+	Context("Hardware profiles", func() {
+		const profiles = `
+profiles:
+  - name: edge
+    master:
+      cpu_flags: [virtualization, avx]
+      min_nic_speed_mbps: 1000
+  - name: storage-heavy
+    master:
+      disk_types: [SSD]
+      min_disk_count: 2
+      denied_vendors: [QEMU]
+  - name: large
+    master:
+      min_cpu_cores: 32
+      min_ram_gib: 64
+      min_disk_size_gb: 500
+versions:
+  "4.6": edge
+`
+
+		BeforeEach(func() {
+			cfg := createValidatorCfg()
+			var err error
+			cfg.Profiles, err = hardware.ParseProfiles([]byte(profiles))
+			Expect(err).ShouldNot(HaveOccurred())
+			hapi = NewManager(getTestLog(), db, mockEvents, nil, nil, cfg, nil)
+		})
+
+		inventory := func(update func(inventory *models.Inventory)) string {
+			var inv models.Inventory
+			Expect(json.Unmarshal([]byte(masterInventory()), &inv)).ShouldNot(HaveOccurred())
+			inv.CPU.Flags = []string{"vmx", "avx"}
+			inv.Interfaces[0].SpeedMbps = 10000
+			inv.SystemVendor = &models.SystemVendor{Manufacturer: "Dell Inc."}
+			inv.Disks = []*models.Disk{
+				{Name: "sda", SizeBytes: 128849018880, DriveType: "SSD"},
+				{Name: "sdb", SizeBytes: 128849018880, DriveType: "SSD"},
+			}
+			if update != nil {
+				update(&inv)
+			}
+			b, err := json.Marshal(&inv)
+			Expect(err).ShouldNot(HaveOccurred())
+			return string(b)
+		}
+
+		tests := []struct {
+			name               string
+			hardwareProfile    string
+			openshiftVersion   string
+			installationDiskID string
+			update             func(inventory *models.Inventory)
+			dstState           string
+			validations        map[validationID]validationCheckResult
+		}{
+			{
+				name:     "no profile",
+				update:   func(inventory *models.Inventory) { inventory.CPU.Flags = nil },
+				dstState: HostStatusKnown,
+				validations: map[validationID]validationCheckResult{
+					HasRequiredCPUFlags: {status: ValidationSuccess, messagePattern: "No hardware profile is selected for the cluster"},
+					HasAllowedVendor:    {status: ValidationSuccess, messagePattern: "No hardware profile is selected for the cluster"},
+				},
+			},
+			{
+				name:             "profile of the OpenShift version",
+				openshiftVersion: "4.6",
+				dstState:         HostStatusKnown,
+				validations: map[validationID]validationCheckResult{
+					HasRequiredCPUFlags: {status: ValidationSuccess, messagePattern: "Host has the CPU flags of hardware profile edge"},
+					HasMinNicSpeed:      {status: ValidationSuccess, messagePattern: "Host has the NIC speed of hardware profile edge"},
+				},
+			},
+			{
+				name:             "missing CPU flag",
+				openshiftVersion: "4.6",
+				update:           func(inventory *models.Inventory) { inventory.CPU.Flags = []string{"svm"} },
+				dstState:         HostStatusInsufficient,
+				validations: map[validationID]validationCheckResult{
+					HasRequiredCPUFlags: {status: ValidationFailure,
+						messagePattern: "Hardware profile edge requires the CPU flags virtualization, avx for master role, missing avx"},
+				},
+			},
+			{
+				name:             "slow NIC",
+				openshiftVersion: "4.6",
+				update:           func(inventory *models.Inventory) { inventory.Interfaces[0].SpeedMbps = 100 },
+				dstState:         HostStatusInsufficient,
+				validations: map[validationID]validationCheckResult{
+					HasMinNicSpeed: {status: ValidationFailure,
+						messagePattern: "Hardware profile edge requires a NIC of at least 1000 Mbps for master role, found only 100 Mbps"},
+				},
+			},
+			{
+				name:             "selected profile instead of the profile of the OpenShift version",
+				hardwareProfile:  "storage-heavy",
+				openshiftVersion: "4.6",
+				update:           func(inventory *models.Inventory) { inventory.CPU.Flags = nil },
+				dstState:         HostStatusKnown,
+				validations: map[validationID]validationCheckResult{
+					HasRequiredCPUFlags: {status: ValidationSuccess, messagePattern: "Host has the CPU flags of hardware profile storage-heavy"},
+					HasRequiredDisks:    {status: ValidationSuccess, messagePattern: "Host has the disks of hardware profile storage-heavy"},
+					HasAllowedVendor:    {status: ValidationSuccess, messagePattern: "Host vendor is allowed by hardware profile storage-heavy"},
+				},
+			},
+			{
+				name:            "missing SSD",
+				hardwareProfile: "storage-heavy",
+				update:          func(inventory *models.Inventory) { inventory.Disks[1].DriveType = "HDD" },
+				dstState:        HostStatusInsufficient,
+				validations: map[validationID]validationCheckResult{
+					HasRequiredDisks: {status: ValidationFailure,
+						messagePattern: "Hardware profile storage-heavy requires at least 2 SSD disks of at least 120 GB for master role, found only 1"},
+					IsInstallationDiskValid: {status: ValidationFailure,
+						messagePattern: "Hardware profile storage-heavy requires a SSD installation disk of at least 120 GB for master role, select another disk than sdb"},
+				},
+			},
+			{
+				name:            "HDD installation disk chosen by the service",
+				hardwareProfile: "storage-heavy",
+				update: func(inventory *models.Inventory) {
+					inventory.Disks = append(inventory.Disks, &models.Disk{Name: "sdc", SizeBytes: 128849018880, DriveType: "HDD"})
+				},
+				dstState: HostStatusInsufficient,
+				validations: map[validationID]validationCheckResult{
+					HasRequiredDisks: {status: ValidationSuccess, messagePattern: "Host has the disks of hardware profile storage-heavy"},
+					IsInstallationDiskValid: {status: ValidationFailure,
+						messagePattern: "Hardware profile storage-heavy requires a SSD installation disk of at least 120 GB for master role, select another disk than sdc"},
+				},
+			},
+			{
+				name:               "SSD installation disk selected by the user",
+				hardwareProfile:    "storage-heavy",
+				installationDiskID: "S-SDA",
+				update: func(inventory *models.Inventory) {
+					inventory.Disks[0].Serial = "S-SDA"
+					inventory.Disks = append(inventory.Disks, &models.Disk{Name: "sdc", SizeBytes: 128849018880, DriveType: "HDD"})
+				},
+				dstState: HostStatusKnown,
+				validations: map[validationID]validationCheckResult{
+					IsInstallationDiskValid: {status: ValidationSuccess, messagePattern: "Installation disk S-SDA is a valid disk"},
+				},
+			},
+			{
+				name:            "minimums of the profile",
+				hardwareProfile: "large",
+				dstState:        HostStatusInsufficient,
+				validations: map[validationID]validationCheckResult{
+					HasCPUCoresForRole: {status: ValidationFailure, messagePattern: "Require at least 32 CPU cores for master role, found only 8"},
+					HasMemoryForRole:   {status: ValidationFailure, messagePattern: "Require at least 64 GiB RAM role master, found only 16"},
+					IsInstallationDiskValid: {status: ValidationFailure,
+						messagePattern: "Hardware profile large requires a HDD or SSD installation disk of at least 500 GB for master role, select another disk than sd[ab]"},
+				},
+			},
+			{
+				name:            "denied vendor",
+				hardwareProfile: "storage-heavy",
+				update:          func(inventory *models.Inventory) { inventory.SystemVendor.Manufacturer = "QEMU" },
+				dstState:        HostStatusInsufficient,
+				validations: map[validationID]validationCheckResult{
+					HasAllowedVendor: {status: ValidationFailure,
+						messagePattern: "Hardware profile storage-heavy doesn't allow hosts of vendor \"QEMU\" for master role"},
+				},
+			},
+			{
+				name:            "undefined profile",
+				hardwareProfile: "standard",
+				dstState:        HostStatusInsufficient,
+				validations: map[validationID]validationCheckResult{
+					HasRequiredCPUFlags: {status: ValidationFailure, messagePattern: "Hardware profile standard is not defined"},
+					HasRequiredDisks:    {status: ValidationFailure, messagePattern: "Hardware profile standard is not defined"},
+				},
+			},
+		}
+
+		for i := range tests {
+			t := tests[i]
+			It(t.name, func() {
+				host = getTestHost(hostId, clusterId, HostStatusInsufficient)
+				host.Inventory = inventory(t.update)
+				host.Role = models.HostRoleMaster
+				host.InstallationDiskID = t.installationDiskID
+				Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+				cluster = getTestCluster(clusterId, "1.2.3.0/24")
+				cluster.HardwareProfile = t.hardwareProfile
+				cluster.OpenshiftVersion = t.openshiftVersion
+				Expect(db.Create(&cluster).Error).ToNot(HaveOccurred())
+				if t.dstState != HostStatusInsufficient {
+					mockEvents.EXPECT().AddEvent(gomock.Any(), hostId.String(), models.EventSeverityInfo,
+						gomock.Any(), gomock.Any(), clusterId.String())
+				}
+
+				Expect(hapi.RefreshStatus(ctx, &host, db)).ToNot(HaveOccurred())
+				var resultHost models.Host
+				Expect(db.Take(&resultHost, "id = ? and cluster_id = ?", hostId.String(), clusterId.String()).Error).ToNot(HaveOccurred())
+				Expect(resultHost.Status).To(Equal(&t.dstState))
+				makeJsonChecker(t.validations).check(resultHost.ValidationsInfo)
+			})
+		}
+	})
+	Context("Installation disk", func() {
+		tests := []struct {
+			name               string
+			installationDiskID string
+			dstState           string
+			validation         validationCheckResult
+		}{
+			{
+				name:       "disk chosen by the service",
+				dstState:   HostStatusKnown,
+				validation: validationCheckResult{status: ValidationSuccess, messagePattern: "Installation disk is chosen by the service"},
+			},
+			{
+				name:               "valid disk",
+				installationDiskID: "/dev/disk/by-path/pci-0000:00:06.0",
+				dstState:           HostStatusKnown,
+				validation: validationCheckResult{status: ValidationSuccess,
+					messagePattern: "Installation disk /dev/disk/by-path/pci-0000:00:06.0 is a valid disk"},
+			},
+			{
+				name:               "disk that is too small",
+				installationDiskID: "S1",
+				dstState:           HostStatusInsufficient,
+				validation: validationCheckResult{status: ValidationFailure,
+					messagePattern: "Installation disk S1 is not a disk of the host of at least 120 GB"},
+			},
+			{
+				name:               "disk that the host doesn't have",
+				installationDiskID: "S2",
+				dstState:           HostStatusInsufficient,
+				validation: validationCheckResult{status: ValidationFailure,
+					messagePattern: "Installation disk S2 is not a disk of the host of at least 120 GB"},
+			},
+		}
+
+		for i := range tests {
+			t := tests[i]
+			It(t.name, func() {
+				var inventory models.Inventory
+				Expect(json.Unmarshal([]byte(masterInventory()), &inventory)).ShouldNot(HaveOccurred())
+				inventory.Disks[0].ByPath = "/dev/disk/by-path/pci-0000:00:06.0"
+				inventory.Disks = append(inventory.Disks, &models.Disk{Name: "sdb", DriveType: "SSD", SizeBytes: 1073741824, Serial: "S1"})
+				b, err := json.Marshal(&inventory)
+				Expect(err).ShouldNot(HaveOccurred())
+				host = getTestHost(hostId, clusterId, HostStatusInsufficient)
+				host.Inventory = string(b)
+				host.Role = models.HostRoleMaster
+				host.InstallationDiskID = t.installationDiskID
+				Expect(db.Create(&host).Error).ShouldNot(HaveOccurred())
+				cluster = getTestCluster(clusterId, "1.2.3.0/24")
+				Expect(db.Create(&cluster).Error).ToNot(HaveOccurred())
+				if t.dstState != HostStatusInsufficient {
+					mockEvents.EXPECT().AddEvent(gomock.Any(), hostId.String(), models.EventSeverityInfo,
+						gomock.Any(), gomock.Any(), clusterId.String())
+				}
+
+				Expect(hapi.RefreshStatus(ctx, &host, db)).ToNot(HaveOccurred())
+				var resultHost models.Host
+				Expect(db.Take(&resultHost, "id = ? and cluster_id = ?", hostId.String(), clusterId.String()).Error).ToNot(HaveOccurred())
+				Expect(resultHost.Status).To(Equal(&t.dstState))
+				makeJsonChecker(map[validationID]validationCheckResult{IsInstallationDiskValid: t.validation}).check(resultHost.ValidationsInfo)
+			})
+		}
+	})
 	Context("Cluster Errors", func() {
 		for _, srcState := range []string{
 			models.HostStatusInstalling,
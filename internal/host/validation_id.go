@@ -12,26 +12,33 @@ import (
 type validationID models.HostValidationID
 
 const (
-	IsConnected          = validationID(models.HostValidationIDConnected)
-	HasInventory         = validationID(models.HostValidationIDHasInventory)
-	IsMachineCidrDefined = validationID(models.HostValidationIDMachineCidrDefined)
-	BelongsToMachineCidr = validationID(models.HostValidationIDBelongsToMachineCidr)
-	HasMinCPUCores       = validationID(models.HostValidationIDHasMinCPUCores)
-	HasMinValidDisks     = validationID(models.HostValidationIDHasMinValidDisks)
-	HasMinMemory         = validationID(models.HostValidationIDHasMinMemory)
-	HasCPUCoresForRole   = validationID(models.HostValidationIDHasCPUCoresForRole)
-	HasMemoryForRole     = validationID(models.HostValidationIDHasMemoryForRole)
-	IsHostnameUnique     = validationID(models.HostValidationIDHostnameUnique)
-	IsRoleDefined        = validationID(models.HostValidationIDRoleDefined)
-	IsHostnameValid      = validationID(models.HostValidationIDHostnameValid)
+	IsConnected             = validationID(models.HostValidationIDConnected)
+	HasInventory            = validationID(models.HostValidationIDHasInventory)
+	IsMachineCidrDefined    = validationID(models.HostValidationIDMachineCidrDefined)
+	BelongsToMachineCidr    = validationID(models.HostValidationIDBelongsToMachineCidr)
+	BelongsToMajority       = validationID(models.HostValidationIDBelongsToMajorityGroup)
+	HasRequiredCPUFlags     = validationID(models.HostValidationIDHasRequiredCPUFlags)
+	HasMinNicSpeed          = validationID(models.HostValidationIDHasMinNicSpeed)
+	HasRequiredDisks        = validationID(models.HostValidationIDHasRequiredDisks)
+	HasAllowedVendor        = validationID(models.HostValidationIDHasAllowedVendor)
+	HasMinCPUCores          = validationID(models.HostValidationIDHasMinCPUCores)
+	HasMinValidDisks        = validationID(models.HostValidationIDHasMinValidDisks)
+	IsInstallationDiskValid = validationID(models.HostValidationIDValidInstallationDisk)
+	HasMinMemory            = validationID(models.HostValidationIDHasMinMemory)
+	HasCPUCoresForRole      = validationID(models.HostValidationIDHasCPUCoresForRole)
+	HasMemoryForRole        = validationID(models.HostValidationIDHasMemoryForRole)
+	IsHostnameUnique        = validationID(models.HostValidationIDHostnameUnique)
+	IsRoleDefined           = validationID(models.HostValidationIDRoleDefined)
+	IsHostnameValid         = validationID(models.HostValidationIDHostnameValid)
 )
 
 func (v validationID) category() (string, error) {
 	switch v {
-	case IsConnected, IsMachineCidrDefined, BelongsToMachineCidr:
+	case IsConnected, IsMachineCidrDefined, BelongsToMachineCidr, BelongsToMajority:
 		return "network", nil
 	case HasInventory, HasMinCPUCores, HasMinValidDisks, HasMinMemory,
-		HasCPUCoresForRole, HasMemoryForRole, IsHostnameUnique, IsHostnameValid:
+		HasCPUCoresForRole, HasMemoryForRole, IsHostnameUnique, IsHostnameValid,
+		HasRequiredCPUFlags, HasMinNicSpeed, HasRequiredDisks, HasAllowedVendor, IsInstallationDiskValid:
 		return "hardware", nil
 	case IsRoleDefined:
 		return "role", nil
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/thoas/go-funk"
 
 	"github.com/alecthomas/units"
@@ -44,6 +47,15 @@ type validationContext struct {
 	cluster   *common.Cluster
 	inventory *models.Inventory
 	db        *gorm.DB
+	// The hardware profile of the cluster, resolved on first use
+	profile       *hardware.Profile
+	profileErr    error
+	profileLoaded bool
+	// The peers of the host in the machine network and the ones it cannot reach, resolved on first use
+	peers              []*models.Host
+	unreachablePeers   []*models.Host
+	connectivityReport *models.ConnectivityReport
+	peersLoaded        bool
 }
 
 type validationConditon func(context *validationContext) validationStatus
@@ -213,7 +225,7 @@ func (v *validator) hasMinValidDisks(c *validationContext) validationStatus {
 	if c.inventory == nil {
 		return ValidationPending
 	}
-	disks := hardware.ListValidDisks(c.inventory, gibToBytes(v.hwValidatorCfg.MinDiskSizeGb))
+	disks := hardware.ListValidDisks(c.inventory, hardware.GbToBytes(v.hwValidatorCfg.MinDiskSizeGb))
 	return boolValue(len(disks) > 0)
 }
 
@@ -222,7 +234,77 @@ func (v *validator) printHasMinValidDisks(c *validationContext, status validatio
 	case ValidationSuccess:
 		return "Sufficient disk capacity"
 	case ValidationFailure:
-		return fmt.Sprintf("Require a disk of at least %d GB", v.hwValidatorCfg.MinDiskSizeGb)
+		return fmt.Sprintf("Require a disk of at least %d GB, %s", v.hwValidatorCfg.MinDiskSizeGb,
+			describeIneligibleDisks(c.inventory, hardware.GbToBytes(v.hwValidatorCfg.MinDiskSizeGb)))
+	case ValidationPending:
+		return "Missing inventory"
+	default:
+		return fmt.Sprintf("Unexpected status %s", status)
+	}
+}
+
+// describeIneligibleDisks lists the disks of the inventory with the reasons that the host can't be installed on them
+func describeIneligibleDisks(inventory *models.Inventory, minSizeRequiredInBytes int64) string {
+	if len(inventory.Disks) == 0 {
+		return "found no disks"
+	}
+	var disks []string
+	for _, disk := range inventory.Disks {
+		disks = append(disks, fmt.Sprintf("%s (%s)", disk.Name,
+			strings.Join(hardware.DiskEligibilityReasons(disk, minSizeRequiredInBytes), "; ")))
+	}
+	return fmt.Sprintf("found no eligible disk: %s", strings.Join(disks, ", "))
+}
+
+// getInstallationDisk returns the disk that the host is installed on, the one selected by the user or else the one
+// that the service chooses, nil when there is no such valid disk
+func (v *validator) getInstallationDisk(c *validationContext) *models.Disk {
+	disks := hardware.ListValidDisks(c.inventory, hardware.GbToBytes(v.hwValidatorCfg.MinDiskSizeGb))
+	if c.host.InstallationDiskID == "" {
+		if len(disks) == 0 {
+			return nil
+		}
+		return disks[0]
+	}
+	return hardware.FindDisk(disks, c.host.InstallationDiskID)
+}
+
+func (v *validator) isInstallationDiskValid(c *validationContext) validationStatus {
+	_, requirements, _ := v.getProfileRequirements(c)
+	if c.host.InstallationDiskID == "" && requirements == nil {
+		return ValidationSuccess
+	}
+	if c.inventory == nil {
+		return ValidationPending
+	}
+	disk := v.getInstallationDisk(c)
+	if disk == nil {
+		// Hosts without any valid disk fail the validation of the disks
+		return boolValue(c.host.InstallationDiskID == "")
+	}
+	return boolValue(requirements == nil || requirements.IsDiskAllowed(disk))
+}
+
+func (v *validator) printIsInstallationDiskValid(c *validationContext, status validationStatus) string {
+	switch status {
+	case ValidationSuccess:
+		if c.host.InstallationDiskID == "" {
+			return "Installation disk is chosen by the service"
+		}
+		return fmt.Sprintf("Installation disk %s is a valid disk", c.host.InstallationDiskID)
+	case ValidationFailure:
+		disk := v.getInstallationDisk(c)
+		if disk == nil {
+			return fmt.Sprintf("Installation disk %s is not a disk of the host of at least %d GB", c.host.InstallationDiskID,
+				v.hwValidatorCfg.MinDiskSizeGb)
+		}
+		profile, requirements, _ := v.getProfileRequirements(c)
+		diskTypes := "HDD or SSD"
+		if len(requirements.DiskTypes) > 0 {
+			diskTypes = strings.Join(requirements.DiskTypes, " or ")
+		}
+		return fmt.Sprintf("Hardware profile %s requires a %s installation disk of at least %d GB for %s role, select another disk than %s",
+			profile.Name, diskTypes, v.getMinDiskSizeGb(c), c.host.Role, disk.Name)
 	case ValidationPending:
 		return "Missing inventory"
 	default:
@@ -265,25 +347,29 @@ func (v *validator) hasCpuCoresForRole(c *validationContext) validationStatus {
 		return ValidationPending
 	}
 	switch c.host.Role {
-	case models.HostRoleMaster:
-		return boolValue(c.inventory.CPU.Count >= v.hwValidatorCfg.MinCPUCoresMaster)
-	case models.HostRoleWorker:
-		return boolValue(c.inventory.CPU.Count >= v.hwValidatorCfg.MinCPUCoresWorker)
+	case models.HostRoleMaster, models.HostRoleWorker:
+		return boolValue(c.inventory.CPU.Count >= v.getCpuCountForRole(c))
 	default:
 		v.log.Errorf("Unexpected role %s", c.host.Role)
 		return ValidationError
 	}
 }
 
-func (v *validator) getCpuCountForRole(role models.HostRole) int64 {
-	switch role {
+// getCpuCountForRole returns the CPU cores that the role requires, the hardware profile of the cluster can require more
+func (v *validator) getCpuCountForRole(c *validationContext) int64 {
+	var count int64
+	switch c.host.Role {
 	case models.HostRoleMaster:
-		return v.hwValidatorCfg.MinCPUCoresMaster
+		count = v.hwValidatorCfg.MinCPUCoresMaster
 	case models.HostRoleWorker:
-		return v.hwValidatorCfg.MinCPUCoresWorker
+		count = v.hwValidatorCfg.MinCPUCoresWorker
 	default:
 		return v.hwValidatorCfg.MinCPUCores
 	}
+	if _, requirements, _ := v.getProfileRequirements(c); requirements != nil && requirements.MinCPUCores > count {
+		count = requirements.MinCPUCores
+	}
+	return count
 }
 
 func (v *validator) printHasCpuCoresForRole(c *validationContext, status validationStatus) string {
@@ -292,7 +378,7 @@ func (v *validator) printHasCpuCoresForRole(c *validationContext, status validat
 		return fmt.Sprintf("Sufficient CPU cores for role %s", c.host.Role)
 	case ValidationFailure:
 		return fmt.Sprintf("Require at least %d CPU cores for %s role, found only %d",
-			v.getCpuCountForRole(c.host.Role), c.host.Role, c.inventory.CPU.Count)
+			v.getCpuCountForRole(c), c.host.Role, c.inventory.CPU.Count)
 	case ValidationPending:
 		return "Missing inventory or role"
 	default:
@@ -305,25 +391,38 @@ func (v *validator) hasMemoryForRole(c *validationContext) validationStatus {
 		return ValidationPending
 	}
 	switch c.host.Role {
-	case models.HostRoleMaster:
-		return boolValue(c.inventory.Memory.PhysicalBytes >= gibToBytes(v.hwValidatorCfg.MinRamGibMaster))
-	case models.HostRoleWorker:
-		return boolValue(c.inventory.Memory.PhysicalBytes >= gibToBytes(v.hwValidatorCfg.MinRamGibWorker))
+	case models.HostRoleMaster, models.HostRoleWorker:
+		return boolValue(c.inventory.Memory.PhysicalBytes >= gibToBytes(v.getMemoryForRole(c)))
 	default:
 		v.log.Errorf("Unexpected role %s", c.host.Role)
 		return ValidationError
 	}
 }
 
-func (v *validator) getMemoryForRole(role models.HostRole) int64 {
-	switch role {
+// getMemoryForRole returns the GiB of RAM that the role requires, the hardware profile of the cluster can require more
+func (v *validator) getMemoryForRole(c *validationContext) int64 {
+	var ramGib int64
+	switch c.host.Role {
 	case models.HostRoleMaster:
-		return v.hwValidatorCfg.MinRamGibMaster
+		ramGib = v.hwValidatorCfg.MinRamGibMaster
 	case models.HostRoleWorker:
-		return v.hwValidatorCfg.MinRamGibWorker
+		ramGib = v.hwValidatorCfg.MinRamGibWorker
 	default:
 		return v.hwValidatorCfg.MinRamGib
 	}
+	if _, requirements, _ := v.getProfileRequirements(c); requirements != nil && requirements.MinRAMGib > ramGib {
+		ramGib = requirements.MinRAMGib
+	}
+	return ramGib
+}
+
+// getMinDiskSizeGb returns the minimal size of the disks of the host, the hardware profile of the cluster can require more
+func (v *validator) getMinDiskSizeGb(c *validationContext) int64 {
+	sizeGb := v.hwValidatorCfg.MinDiskSizeGb
+	if _, requirements, _ := v.getProfileRequirements(c); requirements != nil && requirements.MinDiskSizeGb > sizeGb {
+		sizeGb = requirements.MinDiskSizeGb
+	}
+	return sizeGb
 }
 
 func (v *validator) printHasMemoryForRole(c *validationContext, status validationStatus) string {
@@ -332,7 +431,7 @@ func (v *validator) printHasMemoryForRole(c *validationContext, status validatio
 		return fmt.Sprintf("Sufficient RAM for role %s", c.host.Role)
 	case ValidationFailure:
 		return fmt.Sprintf("Require at least %d GiB RAM role %s, found only %d",
-			v.getMemoryForRole(c.host.Role), c.host.Role, bytesToGiB(c.inventory.Memory.PhysicalBytes))
+			v.getMemoryForRole(c), c.host.Role, bytesToGiB(c.inventory.Memory.PhysicalBytes))
 	case ValidationPending:
 		return "Missing inventory or role"
 	default:
@@ -340,6 +439,154 @@ func (v *validator) printHasMemoryForRole(c *validationContext, status validatio
 	}
 }
 
+// getProfileRequirements returns the hardware profile of the cluster and its requirements for the role of the host,
+// a nil profile means that the cluster has no profile and nil requirements mean that the host has no role
+func (v *validator) getProfileRequirements(c *validationContext) (*hardware.Profile, *hardware.Requirements, error) {
+	if !c.profileLoaded {
+		if v.hwValidatorCfg != nil {
+			c.profile, c.profileErr = v.hwValidatorCfg.Profiles.ForCluster(&c.cluster.Cluster)
+		}
+		c.profileLoaded = true
+	}
+	if c.profileErr != nil || c.profile == nil {
+		return nil, nil, c.profileErr
+	}
+	return c.profile, c.profile.ForRole(c.host.Role), nil
+}
+
+// checkProfile checks the host against the requirements of the hardware profile of the cluster for its role
+func (v *validator) checkProfile(c *validationContext, check func(requirements *hardware.Requirements) bool) validationStatus {
+	profile, requirements, err := v.getProfileRequirements(c)
+	switch {
+	case err != nil:
+		return ValidationFailure
+	case profile == nil:
+		return ValidationSuccess
+	case c.inventory == nil || requirements == nil:
+		return ValidationPending
+	default:
+		return boolValue(check(requirements))
+	}
+}
+
+// printProfileStatus formats the statuses that are the same for all the hardware profile validations
+func (v *validator) printProfileStatus(c *validationContext, status validationStatus) (string, bool) {
+	profile, _, err := v.getProfileRequirements(c)
+	switch {
+	case status == ValidationFailure && err != nil:
+		return fmt.Sprintf("Hardware profile %s is not defined", c.cluster.HardwareProfile), true
+	case status == ValidationSuccess && profile == nil:
+		return "No hardware profile is selected for the cluster", true
+	case status == ValidationPending:
+		return "Missing inventory or role", true
+	case status != ValidationSuccess && status != ValidationFailure:
+		return fmt.Sprintf("Unexpected status %s", status), true
+	}
+	return "", false
+}
+
+func (v *validator) hasRequiredCPUFlags(c *validationContext) validationStatus {
+	return v.checkProfile(c, func(requirements *hardware.Requirements) bool {
+		return len(requirements.MissingCPUFlags(c.inventory.CPU)) == 0
+	})
+}
+
+func (v *validator) printHasRequiredCPUFlags(c *validationContext, status validationStatus) string {
+	if message, ok := v.printProfileStatus(c, status); ok {
+		return message
+	}
+	profile, requirements, _ := v.getProfileRequirements(c)
+	if status == ValidationSuccess {
+		return fmt.Sprintf("Host has the CPU flags of hardware profile %s", profile.Name)
+	}
+	return fmt.Sprintf("Hardware profile %s requires the CPU flags %s for %s role, missing %s", profile.Name,
+		strings.Join(requirements.CPUFlags, ", "), c.host.Role, strings.Join(requirements.MissingCPUFlags(c.inventory.CPU), ", "))
+}
+
+func getMaxNicSpeedMbps(inventory *models.Inventory) int64 {
+	var speed int64
+	for _, intf := range inventory.Interfaces {
+		if intf.SpeedMbps > speed {
+			speed = intf.SpeedMbps
+		}
+	}
+	return speed
+}
+
+func (v *validator) hasMinNicSpeed(c *validationContext) validationStatus {
+	return v.checkProfile(c, func(requirements *hardware.Requirements) bool {
+		return getMaxNicSpeedMbps(c.inventory) >= requirements.MinNICSpeedMbps
+	})
+}
+
+func (v *validator) printHasMinNicSpeed(c *validationContext, status validationStatus) string {
+	if message, ok := v.printProfileStatus(c, status); ok {
+		return message
+	}
+	profile, requirements, _ := v.getProfileRequirements(c)
+	if status == ValidationSuccess {
+		return fmt.Sprintf("Host has the NIC speed of hardware profile %s", profile.Name)
+	}
+	return fmt.Sprintf("Hardware profile %s requires a NIC of at least %d Mbps for %s role, found only %d Mbps", profile.Name,
+		requirements.MinNICSpeedMbps, c.host.Role, getMaxNicSpeedMbps(c.inventory))
+}
+
+// getMinDiskCount returns the number of disks that the requirements need, a disk of the required types when only the types are set
+func getMinDiskCount(requirements *hardware.Requirements) int64 {
+	if requirements.MinDiskCount == 0 && len(requirements.DiskTypes) > 0 {
+		return 1
+	}
+	return requirements.MinDiskCount
+}
+
+func (v *validator) hasRequiredDisks(c *validationContext) validationStatus {
+	return v.checkProfile(c, func(requirements *hardware.Requirements) bool {
+		return requirements.CountDisks(c.inventory, hardware.GbToBytes(v.getMinDiskSizeGb(c))) >= getMinDiskCount(requirements)
+	})
+}
+
+func (v *validator) printHasRequiredDisks(c *validationContext, status validationStatus) string {
+	if message, ok := v.printProfileStatus(c, status); ok {
+		return message
+	}
+	profile, requirements, _ := v.getProfileRequirements(c)
+	if status == ValidationSuccess {
+		return fmt.Sprintf("Host has the disks of hardware profile %s", profile.Name)
+	}
+	diskTypes := "HDD or SSD"
+	if len(requirements.DiskTypes) > 0 {
+		diskTypes = strings.Join(requirements.DiskTypes, " or ")
+	}
+	return fmt.Sprintf("Hardware profile %s requires at least %d %s disks of at least %d GB for %s role, found only %d", profile.Name,
+		getMinDiskCount(requirements), diskTypes, v.getMinDiskSizeGb(c), c.host.Role,
+		requirements.CountDisks(c.inventory, hardware.GbToBytes(v.getMinDiskSizeGb(c))))
+}
+
+func getManufacturer(inventory *models.Inventory) string {
+	if inventory.SystemVendor == nil {
+		return ""
+	}
+	return inventory.SystemVendor.Manufacturer
+}
+
+func (v *validator) hasAllowedVendor(c *validationContext) validationStatus {
+	return v.checkProfile(c, func(requirements *hardware.Requirements) bool {
+		return requirements.IsVendorAllowed(getManufacturer(c.inventory))
+	})
+}
+
+func (v *validator) printHasAllowedVendor(c *validationContext, status validationStatus) string {
+	if message, ok := v.printProfileStatus(c, status); ok {
+		return message
+	}
+	profile, _, _ := v.getProfileRequirements(c)
+	if status == ValidationSuccess {
+		return fmt.Sprintf("Host vendor is allowed by hardware profile %s", profile.Name)
+	}
+	return fmt.Sprintf("Hardware profile %s doesn't allow hosts of vendor %q for %s role", profile.Name,
+		getManufacturer(c.inventory), c.host.Role)
+}
+
 func (v *validator) belongsToMachineCidr(c *validationContext) validationStatus {
 	if c.inventory == nil || c.cluster.MachineNetworkCidr == "" {
 		return ValidationPending
@@ -420,3 +667,122 @@ func (v *validator) printHostnameValid(c *validationContext, status validationSt
 		return fmt.Sprintf("Unexpected status %s", status)
 	}
 }
+
+// The smallest number of hosts in the machine network, including the host itself, that a majority is required of
+const minHostsForMajority = 3
+
+// getUnreachablePeers returns the peers of the host and the unreachable ones among them, computing them only once per
+// validation context so that the condition and the formatter of the validation agree
+func (v *validator) getUnreachablePeers(c *validationContext) ([]*models.Host, []*models.Host, *models.ConnectivityReport) {
+	if !c.peersLoaded {
+		c.peers, c.unreachablePeers, c.connectivityReport = v.computeUnreachablePeers(c)
+		c.peersLoaded = true
+	}
+	return c.peers, c.unreachablePeers, c.connectivityReport
+}
+
+/*
+ * The other hosts of the machine network and the ones among them that the host has no L2 or L3 connectivity to over
+ * the machine network, according to its connectivity report. The report is nil until the host sends it.
+ */
+func (v *validator) computeUnreachablePeers(c *validationContext) (peers []*models.Host, unreachable []*models.Host, report *models.ConnectivityReport) {
+	machineHosts, err := network.GetMachineCIDRHosts(v.log, c.cluster)
+	if err != nil {
+		v.log.WithError(err).Warnf("Failed to get the machine network hosts of cluster %s", c.cluster.ID.String())
+		return nil, nil, nil
+	}
+	for _, h := range machineHosts {
+		if h.ID.String() != c.host.ID.String() {
+			peers = append(peers, h)
+		}
+	}
+	if c.host.Connectivity == "" {
+		return peers, nil, nil
+	}
+	report = &models.ConnectivityReport{}
+	if err = json.Unmarshal([]byte(c.host.Connectivity), report); err != nil {
+		v.log.WithError(err).Warnf("Illegal connectivity report for host %s", c.host.ID.String())
+		return peers, nil, nil
+	}
+	var machineNets []*net.IPNet
+	for _, cidr := range []string{c.cluster.MachineNetworkCidr, c.cluster.SecondaryMachineNetworkCidr} {
+		if _, ipnet, err := net.ParseCIDR(cidr); err == nil {
+			machineNets = append(machineNets, ipnet)
+		}
+	}
+	inMachineNetwork := func(address string) bool {
+		ip := net.ParseIP(address)
+		for _, ipnet := range machineNets {
+			if ip != nil && ipnet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	reachable := make(map[strfmt.UUID]bool)
+	for _, remote := range report.RemoteHosts {
+		for _, l2 := range remote.L2Connectivity {
+			if l2.Successful && inMachineNetwork(l2.RemoteIPAddress) {
+				reachable[remote.HostID] = true
+			}
+		}
+		for _, l3 := range remote.L3Connectivity {
+			if l3.Successful && inMachineNetwork(l3.RemoteIPAddress) {
+				reachable[remote.HostID] = true
+			}
+		}
+	}
+	for _, h := range peers {
+		if !reachable[*h.ID] {
+			unreachable = append(unreachable, h)
+		}
+	}
+	return peers, unreachable, report
+}
+
+func (v *validator) belongsToMajority(c *validationContext) validationStatus {
+	if c.inventory == nil || c.cluster.MachineNetworkCidr == "" {
+		return ValidationPending
+	}
+	peers, unreachable, report := v.getUnreachablePeers(c)
+	if len(peers)+1 < minHostsForMajority {
+		return ValidationSuccess
+	}
+	if report == nil {
+		return ValidationPending
+	}
+	// The host and the peers it reaches must be more than half of the hosts of the machine network
+	return boolValue((len(peers)-len(unreachable)+1)*2 > len(peers)+1)
+}
+
+func getHostNames(hosts []*models.Host) []string {
+	names := funk.Map(hosts, common.GetHostnameForMsg).([]string)
+	sort.Strings(names)
+	return names
+}
+
+func (v *validator) printBelongsToMajority(c *validationContext, status validationStatus) string {
+	switch status {
+	case ValidationSuccess:
+		peers, unreachable, _ := v.getUnreachablePeers(c)
+		if len(peers)+1 < minHostsForMajority {
+			return fmt.Sprintf("Connectivity is not checked for less than %d hosts in the machine network", minHostsForMajority)
+		}
+		if len(unreachable) == 0 {
+			return "Host has connectivity to all the hosts in the machine network"
+		}
+		return fmt.Sprintf("Host has connectivity to the majority of hosts in the machine network, unreachable hosts: %s",
+			strings.Join(getHostNames(unreachable), ", "))
+	case ValidationFailure:
+		_, unreachable, _ := v.getUnreachablePeers(c)
+		return fmt.Sprintf("Host has no connectivity to the majority of hosts in the machine network, unreachable hosts: %s",
+			strings.Join(getHostNames(unreachable), ", "))
+	case ValidationPending:
+		if c.inventory == nil || c.cluster.MachineNetworkCidr == "" {
+			return "Missing inventory or machine network CIDR"
+		}
+		return "Missing connectivity report"
+	default:
+		return fmt.Sprintf("Unexpected status %s", status)
+	}
+}
@@ -2,8 +2,9 @@ package identity
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/jinzhu/gorm"
+	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/pkg/auth"
 )
 
@@ -11,11 +12,26 @@ func IsAdmin(ctx context.Context) bool {
 	return auth.UserRoleFromContext(ctx) == auth.AdminUserRole
 }
 
-func GetUserIDFilter(ctx context.Context) string {
-	query := ""
-	if !IsAdmin(ctx) {
-		user_id := auth.UserIDFromContext(ctx)
-		query = fmt.Sprintf("user_id = '%s'", user_id)
+// IsSupport returns whether the caller has the support role, which may read some resources, such as the
+// events, of every org
+func IsSupport(ctx context.Context) bool {
+	return auth.UserRoleFromContext(ctx) == auth.SupportUserRole
+}
+
+// AddUserFilter limits a query on clusters to the clusters of the caller's org, admins aren't limited
+func AddUserFilter(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if IsAdmin(ctx) {
+		return db
+	}
+	return db.Where("org_id = ?", auth.OrgIDFromContext(ctx))
+}
+
+// AddHostUserFilter limits a query on hosts to the hosts of the clusters of the caller's org, admins
+// aren't limited
+func AddHostUserFilter(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if IsAdmin(ctx) {
+		return db
 	}
-	return query
+	clusters := db.New().Model(&common.Cluster{}).Select("id").Where("org_id = ?", auth.OrgIDFromContext(ctx))
+	return db.Where("cluster_id IN (?)", clusters.QueryExpr())
 }
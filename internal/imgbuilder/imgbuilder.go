@@ -0,0 +1,33 @@
+package imgbuilder
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+)
+
+const (
+	// BackendJob builds the images with a k8s job running the image builder container
+	BackendJob = "job"
+	// BackendISO builds the images in-process from a local base RHCOS ISO
+	BackendISO = "iso"
+)
+
+//go:generate mockgen -source=imgbuilder.go -package=imgbuilder -destination=mock_imgbuilder.go
+type ImageBuilder interface {
+	// Build generates the discovery image of the cluster with the given ignition config and uploads it as imgName,
+	// the image is generated from the RHCOS image of the OpenShift version of the cluster, or from the default
+	// base image if the version doesn't have one
+	Build(ctx context.Context, clusterID strfmt.UUID, createdAt time.Time, imgName, ignitionConfig, rhcosImage string) error
+	// Cancel stops the generation of the image that was requested at createdAt, in case it's still running
+	Cancel(ctx context.Context, clusterID strfmt.UUID, createdAt time.Time) error
+	// Version identifies the images the builder generates from the RHCOS image, images of another version must be
+	// regenerated
+	Version(rhcosImage string) string
+}
+
+type Config struct {
+	Backend     string `envconfig:"IMAGE_BUILDER_BACKEND" default:""`         // job or iso, defaults to job when running with k8s
+	BaseISOPath string `envconfig:"BASE_ISO_PATH" default:"/data/livecd.iso"` // used for the versions without an RHCOS image
+}
@@ -0,0 +1,199 @@
+package imgbuilder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/openshift/assisted-service/pkg/s3Client"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// The RHCOS live ISO describes its ignition embed area with a header at the end of the
+	// ISO9660 system area: the magic, followed by the offset and the length of the area as
+	// little-endian uint64 values.
+	embedHeaderMagic  = "coreiso+"
+	embedHeaderOffset = 32768 - 24
+	embedHeaderSize   = 24
+
+	ignitionFileName = "config.ign"
+)
+
+// isoBuilder generates the images in-process, it embeds the ignition config in a base RHCOS
+// live ISO taken from local storage and uploads the result to S3. The RHCOS image of a version
+// is the path of its live ISO, relative paths are relative to the directory of the default one.
+type isoBuilder struct {
+	log         logrus.FieldLogger
+	s3Client    s3Client.S3Client
+	s3Bucket    string
+	baseISOPath string
+	buildsMux   sync.Mutex
+	builds      map[string]context.CancelFunc
+}
+
+func NewISOBuilder(log logrus.FieldLogger, s3Client s3Client.S3Client, s3Bucket, baseISOPath string) *isoBuilder {
+	return &isoBuilder{
+		log:         log,
+		s3Client:    s3Client,
+		s3Bucket:    s3Bucket,
+		baseISOPath: baseISOPath,
+		builds:      make(map[string]context.CancelFunc),
+	}
+}
+
+func getBuildKey(clusterID strfmt.UUID, createdAt time.Time) string {
+	return fmt.Sprintf("%s-%d", clusterID, createdAt.UnixNano())
+}
+
+// getBaseISOPath returns the path of the live ISO of the RHCOS image, the default one if there is no image
+func (i *isoBuilder) getBaseISOPath(rhcosImage string) string {
+	if rhcosImage == "" {
+		return i.baseISOPath
+	}
+	if filepath.IsAbs(rhcosImage) {
+		return rhcosImage
+	}
+	return filepath.Join(filepath.Dir(i.baseISOPath), rhcosImage)
+}
+
+// Version changes whenever the base ISO is replaced
+func (i *isoBuilder) Version(rhcosImage string) string {
+	baseISOPath := i.getBaseISOPath(rhcosImage)
+	info, err := os.Stat(baseISOPath)
+	if err != nil {
+		return fmt.Sprintf("iso:%s", baseISOPath)
+	}
+	return fmt.Sprintf("iso:%s:%d:%d", baseISOPath, info.Size(), info.ModTime().Unix())
+}
+
+// Cancel stops the upload of the image in case it's still running in this process
+func (i *isoBuilder) Cancel(ctx context.Context, clusterID strfmt.UUID, createdAt time.Time) error {
+	i.buildsMux.Lock()
+	defer i.buildsMux.Unlock()
+	if cancel, ok := i.builds[getBuildKey(clusterID, createdAt)]; ok {
+		logutil.FromContext(ctx, i.log).Infof("Canceling the generation of the image of cluster %s", clusterID)
+		cancel()
+	}
+	return nil
+}
+
+func (i *isoBuilder) Build(ctx context.Context, clusterID strfmt.UUID, createdAt time.Time, imgName, ignitionConfig, rhcosImage string) error {
+	log := logutil.FromContext(ctx, i.log)
+	key := getBuildKey(clusterID, createdAt)
+	ctx, cancel := context.WithCancel(ctx)
+	i.buildsMux.Lock()
+	i.builds[key] = cancel
+	i.buildsMux.Unlock()
+	defer func() {
+		i.buildsMux.Lock()
+		delete(i.builds, key)
+		i.buildsMux.Unlock()
+		cancel()
+	}()
+
+	baseISOPath := i.getBaseISOPath(rhcosImage)
+	iso, err := os.Open(baseISOPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open base ISO %s", baseISOPath)
+	}
+	defer iso.Close()
+	info, err := iso.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat base ISO %s", baseISOPath)
+	}
+
+	offset, length, err := getEmbedArea(iso, info.Size())
+	if err != nil {
+		return err
+	}
+	embedArea, err := makeIgnitionArchive(ignitionConfig)
+	if err != nil {
+		return err
+	}
+	if int64(len(embedArea)) > length {
+		return errors.Errorf("ignition config of %d bytes doesn't fit the %d bytes embed area of the base ISO", len(embedArea), length)
+	}
+	// The remainder of the area must be zeroed, it may hold the ignition config of a previous embed
+	embedArea = append(embedArea, make([]byte, length-int64(len(embedArea)))...)
+
+	reader := io.MultiReader(
+		io.NewSectionReader(iso, 0, offset),
+		bytes.NewReader(embedArea),
+		io.NewSectionReader(iso, offset+length, info.Size()-offset-length))
+	log.Infof("Uploading image %s generated from base ISO %s", imgName, baseISOPath)
+	if err = i.s3Client.UploadStream(ctx, reader, info.Size(), imgName, i.s3Bucket); err != nil {
+		return err
+	}
+	// The image expirer deletes the image according to this tag
+	if _, err = i.s3Client.UpdateObjectTag(ctx, imgName, i.s3Bucket, "create_sec_since_epoch", strconv.FormatInt(createdAt.Unix(), 10)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getEmbedArea returns the offset and the length of the ignition embed area of the ISO
+func getEmbedArea(iso io.ReaderAt, isoSize int64) (int64, int64, error) {
+	header := make([]byte, embedHeaderSize)
+	if _, err := iso.ReadAt(header, embedHeaderOffset); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read the embed area header of the base ISO")
+	}
+	if string(header[:len(embedHeaderMagic)]) != embedHeaderMagic {
+		return 0, 0, errors.New("the base ISO has no ignition embed area")
+	}
+	offset := int64(binary.LittleEndian.Uint64(header[8:16]))
+	length := int64(binary.LittleEndian.Uint64(header[16:24]))
+	if offset < embedHeaderOffset+embedHeaderSize || length <= 0 || offset+length > isoSize {
+		return 0, 0, errors.Errorf("invalid ignition embed area of %d bytes at offset %d", length, offset)
+	}
+	return offset, length, nil
+}
+
+// makeIgnitionArchive creates the gzipped newc cpio archive holding the ignition config,
+// the format the RHCOS initramfs expects to find in the embed area
+func makeIgnitionArchive(ignitionConfig string) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := writeCpioEntry(gz, ignitionFileName, 0100644, []byte(ignitionConfig)); err != nil {
+		return nil, err
+	}
+	if err := writeCpioEntry(gz, "TRAILER!!!", 0, nil); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func cpioPadding(n int) []byte {
+	return make([]byte, (4-n%4)%4)
+}
+
+func writeCpioEntry(w io.Writer, name string, mode uint32, data []byte) error {
+	var nlink uint32
+	if mode != 0 {
+		nlink = 1
+	}
+	// magic, ino, mode, uid, gid, nlink, mtime, filesize, devmajor, devminor, rdevmajor, rdevminor, namesize, check
+	header := fmt.Sprintf("070701%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X",
+		0, mode, 0, 0, nlink, 0, len(data), 0, 0, 0, 0, len(name)+1, 0)
+	entry := append([]byte(header), name...)
+	entry = append(entry, 0)
+	entry = append(entry, cpioPadding(len(entry))...)
+	entry = append(entry, data...)
+	entry = append(entry, cpioPadding(len(data))...)
+	_, err := w.Write(entry)
+	return err
+}
@@ -0,0 +1,155 @@
+package imgbuilder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/pkg/s3Client"
+	"github.com/sirupsen/logrus"
+)
+
+func TestImageBuilder(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Image builder tests Suite")
+}
+
+var _ = Describe("iso_builder", func() {
+	const (
+		isoSize    = 64 * 1024
+		areaOffset = 40 * 1024
+		areaLength = 8 * 1024
+		bucket     = "test"
+		imgName    = "discovery-image-d183c403-d27b-42e1-b0a4-1274ea1a5d77"
+		ignition   = `{"ignition": {"version": "2.2.0"}}`
+	)
+	var (
+		ctx          = context.Background()
+		clusterID    = strfmt.UUID("d183c403-d27b-42e1-b0a4-1274ea1a5d77")
+		createdAt    = time.Unix(1600000000, 0)
+		ctrl         *gomock.Controller
+		mockS3Client *s3Client.MockS3Client
+		dir          string
+		isoPath      string
+		baseISO      []byte
+		builder      *isoBuilder
+	)
+
+	writeISO := func(withHeader bool) {
+		baseISO = make([]byte, isoSize)
+		for i := range baseISO {
+			baseISO[i] = byte(i % 251)
+		}
+		if withHeader {
+			copy(baseISO[embedHeaderOffset:], embedHeaderMagic)
+			binary.LittleEndian.PutUint64(baseISO[embedHeaderOffset+8:], areaOffset)
+			binary.LittleEndian.PutUint64(baseISO[embedHeaderOffset+16:], areaLength)
+		}
+		Expect(ioutil.WriteFile(isoPath, baseISO, 0600)).ShouldNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		ctrl = gomock.NewController(GinkgoT())
+		mockS3Client = s3Client.NewMockS3Client(ctrl)
+		dir, err = ioutil.TempDir("", "isobuilder")
+		Expect(err).ShouldNot(HaveOccurred())
+		isoPath = filepath.Join(dir, "livecd.iso")
+		builder = NewISOBuilder(logrus.New(), mockS3Client, bucket, isoPath)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		os.RemoveAll(dir)
+	})
+
+	It("embeds the ignition config and uploads the image", func() {
+		writeISO(true)
+		var uploaded []byte
+		mockS3Client.EXPECT().UploadStream(gomock.Any(), gomock.Any(), int64(isoSize), imgName, bucket).
+			DoAndReturn(func(_ context.Context, reader io.Reader, _ int64, _, _ string) error {
+				var err error
+				uploaded, err = ioutil.ReadAll(reader)
+				return err
+			}).Times(1)
+		mockS3Client.EXPECT().UpdateObjectTag(gomock.Any(), imgName, bucket, "create_sec_since_epoch", "1600000000").
+			Return(true, nil).Times(1)
+		Expect(builder.Build(ctx, clusterID, createdAt, imgName, ignition, "")).ShouldNot(HaveOccurred())
+
+		Expect(uploaded).Should(HaveLen(isoSize))
+		Expect(uploaded[:areaOffset]).Should(Equal(baseISO[:areaOffset]))
+		Expect(uploaded[areaOffset+areaLength:]).Should(Equal(baseISO[areaOffset+areaLength:]))
+
+		gz, err := gzip.NewReader(bytes.NewReader(uploaded[areaOffset : areaOffset+areaLength]))
+		Expect(err).ShouldNot(HaveOccurred())
+		gz.Multistream(false)
+		archive, err := ioutil.ReadAll(gz)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(archive[:6])).Should(Equal("070701"))
+		Expect(string(archive)).Should(ContainSubstring("config.ign\x00"))
+		Expect(string(archive)).Should(ContainSubstring(ignition))
+		Expect(string(archive)).Should(ContainSubstring("TRAILER!!!"))
+		Expect(len(archive) % 4).Should(Equal(0))
+	})
+
+	It("stops the upload of a canceled build", func() {
+		writeISO(true)
+		mockS3Client.EXPECT().UploadStream(gomock.Any(), gomock.Any(), int64(isoSize), imgName, bucket).
+			DoAndReturn(func(uploadCtx context.Context, _ io.Reader, _ int64, _, _ string) error {
+				Expect(builder.Cancel(ctx, clusterID, createdAt)).ShouldNot(HaveOccurred())
+				<-uploadCtx.Done()
+				return uploadCtx.Err()
+			}).Times(1)
+		Expect(builder.Build(ctx, clusterID, createdAt, imgName, ignition, "")).Should(MatchError(context.Canceled))
+		Expect(builder.builds).Should(BeEmpty())
+	})
+
+	It("fails when the base ISO has no embed area", func() {
+		writeISO(false)
+		Expect(builder.Build(ctx, clusterID, createdAt, imgName, ignition, "")).Should(HaveOccurred())
+	})
+
+	It("fails when the base ISO is missing", func() {
+		Expect(builder.Build(ctx, clusterID, createdAt, imgName, ignition, "")).Should(HaveOccurred())
+	})
+
+	It("fails when the ignition config doesn't fit the embed area", func() {
+		writeISO(true)
+		// random data doesn't compress
+		random := make([]byte, 2*areaLength)
+		rand.New(rand.NewSource(1)).Read(random)
+		err := builder.Build(ctx, clusterID, createdAt, imgName, string(random), "")
+		Expect(err).Should(MatchError(ContainSubstring("doesn't fit")))
+	})
+
+	It("builds the image from the RHCOS image of the version", func() {
+		writeISO(true)
+		Expect(os.Rename(isoPath, filepath.Join(dir, "rhcos-4.6.iso"))).ShouldNot(HaveOccurred())
+		mockS3Client.EXPECT().UploadStream(gomock.Any(), gomock.Any(), int64(isoSize), imgName, bucket).
+			Return(nil).Times(1)
+		mockS3Client.EXPECT().UpdateObjectTag(gomock.Any(), imgName, bucket, "create_sec_since_epoch", "1600000000").
+			Return(true, nil).Times(1)
+		Expect(builder.Build(ctx, clusterID, createdAt, imgName, ignition, "rhcos-4.6.iso")).ShouldNot(HaveOccurred())
+		Expect(builder.Build(ctx, clusterID, createdAt, imgName, ignition, "")).Should(HaveOccurred())
+		Expect(builder.Version("rhcos-4.6.iso")).Should(HavePrefix("iso:" + filepath.Join(dir, "rhcos-4.6.iso") + ":"))
+	})
+
+	It("changes the version when the base ISO is replaced", func() {
+		writeISO(true)
+		version := builder.Version("")
+		Expect(os.Chtimes(isoPath, createdAt, createdAt)).ShouldNot(HaveOccurred())
+		Expect(builder.Version("")).ShouldNot(Equal(version))
+	})
+})
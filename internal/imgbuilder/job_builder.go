@@ -0,0 +1,182 @@
+package imgbuilder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/pkg/job"
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/openshift/assisted-service/pkg/requestid"
+	"github.com/sirupsen/logrus"
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobConfig holds the settings of the image generation jobs
+type JobConfig struct {
+	Image              string
+	Namespace          string
+	S3EndpointURL      string
+	S3Bucket           string
+	AwsAccessKeyID     string
+	AwsSecretAccessKey string
+	CPULimit           string
+	MemoryLimit        string
+	CPURequests        string
+	MemoryRequests     string
+}
+
+// jobBuilder generates the images with a k8s job running the image builder container
+type jobBuilder struct {
+	JobConfig
+	log logrus.FieldLogger
+	job job.API
+}
+
+func NewJobBuilder(log logrus.FieldLogger, jobApi job.API, cfg JobConfig) *jobBuilder {
+	return &jobBuilder{
+		JobConfig: cfg,
+		log:       log,
+		job:       jobApi,
+	}
+}
+
+func (j *jobBuilder) Version(rhcosImage string) string {
+	if rhcosImage == "" {
+		return j.Image
+	}
+	return fmt.Sprintf("%s:%s", j.Image, rhcosImage)
+}
+
+// This job name is exactly 63 characters which is the maximum for a job - be careful if modifying
+func getJobName(clusterID strfmt.UUID, createdAt time.Time) string {
+	return fmt.Sprintf("createimage-%s-%s", clusterID, createdAt.Format("20060102150405"))
+}
+
+func (j *jobBuilder) Build(ctx context.Context, clusterID strfmt.UUID, createdAt time.Time, imgName, ignitionConfig, rhcosImage string) error {
+	log := logutil.FromContext(ctx, j.log)
+	jobName := getJobName(clusterID, createdAt)
+	log.Infof("Creating job %s", jobName)
+	if err := j.job.Create(ctx, j.createImageJob(jobName, imgName, ignitionConfig, rhcosImage, true)); err != nil {
+		log.WithError(err).Error("failed to create image job")
+		return err
+	}
+	if err := j.job.Monitor(ctx, jobName, j.Namespace); err != nil {
+		log.WithError(err).Error("image creation failed")
+		return err
+	}
+	return nil
+}
+
+// Kill the previous job in case it's still running
+func (j *jobBuilder) Cancel(ctx context.Context, clusterID strfmt.UUID, createdAt time.Time) error {
+	log := logutil.FromContext(ctx, j.log)
+	jobName := getJobName(clusterID, createdAt)
+	log.Infof("Attempting to delete job %s", jobName)
+	return j.job.Delete(ctx, jobName, j.Namespace)
+}
+
+// GenerateDummyImage runs a dummy job that only pulls the image builder container, so
+// that the first real image generation will take less time.
+func (j *jobBuilder) GenerateDummyImage() {
+	var (
+		dummyId   = "00000000-0000-0000-0000-000000000000"
+		jobName   = fmt.Sprintf("dummyimage-%s-%s", dummyId, time.Now().Format("20060102150405"))
+		imgName   = fmt.Sprintf("discovery-image-%s", dummyId)
+		requestID = requestid.NewID()
+		log       = requestid.RequestIDLogger(j.log, requestID)
+	)
+	// create dummy job without uploading to s3, we just need to pull the image
+	if err := j.job.Create(requestid.ToContext(context.Background(), requestID),
+		j.createImageJob(jobName, imgName, "Dummy", "", false)); err != nil {
+		log.WithError(err).Errorf("failed to generate dummy ISO image")
+	}
+}
+
+func getQuantity(s string) resource.Quantity {
+	reply, _ := resource.ParseQuantity(s)
+	return reply
+}
+
+// create discovery image generation job, the image builder uses its own base ISO unless RHCOS_IMAGE is set
+func (j *jobBuilder) createImageJob(jobName, imgName, ignitionConfig, rhcosImage string, performUpload bool) *batch.Job {
+	var command []string
+	if !performUpload {
+		command = []string{"echo", "pass"}
+	}
+	env := []core.EnvVar{
+		{
+			Name:  "S3_ENDPOINT_URL",
+			Value: j.S3EndpointURL,
+		},
+		{
+			Name:  "IGNITION_CONFIG",
+			Value: ignitionConfig,
+		},
+		{
+			Name:  "IMAGE_NAME",
+			Value: imgName,
+		},
+		{
+			Name:  "S3_BUCKET",
+			Value: j.S3Bucket,
+		},
+		{
+			Name:  "aws_access_key_id",
+			Value: j.AwsAccessKeyID,
+		},
+		{
+			Name:  "aws_secret_access_key",
+			Value: j.AwsSecretAccessKey,
+		},
+	}
+	if rhcosImage != "" {
+		env = append(env, core.EnvVar{Name: "RHCOS_IMAGE", Value: rhcosImage})
+	}
+	return &batch.Job{
+		TypeMeta: meta.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: meta.ObjectMeta{
+			Name:      jobName,
+			Namespace: j.Namespace,
+		},
+		Spec: batch.JobSpec{
+			BackoffLimit: swag.Int32(2),
+			Template: core.PodTemplateSpec{
+				ObjectMeta: meta.ObjectMeta{
+					Name:      jobName,
+					Namespace: j.Namespace,
+				},
+				Spec: core.PodSpec{
+					Containers: []core.Container{
+						{
+							Resources: core.ResourceRequirements{
+								Limits: core.ResourceList{
+									"cpu":    getQuantity(j.CPULimit),
+									"memory": getQuantity(j.MemoryLimit),
+								},
+								Requests: core.ResourceList{
+									"cpu":    getQuantity(j.CPURequests),
+									"memory": getQuantity(j.MemoryRequests),
+								},
+							},
+							Command:         command,
+							Name:            "image-creator",
+							Image:           j.Image,
+							ImagePullPolicy: "IfNotPresent",
+							Env:             env,
+						},
+					},
+					RestartPolicy: "Never",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package imgbuilder
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/pkg/job"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+)
+
+var _ = Describe("job_builder", func() {
+	var (
+		ctx       = context.Background()
+		clusterID = strfmt.UUID("d183c403-d27b-42e1-b0a4-1274ea1a5d77")
+		createdAt = time.Date(2020, 9, 1, 10, 20, 30, 0, time.UTC)
+		jobName   = "createimage-d183c403-d27b-42e1-b0a4-1274ea1a5d77-20200901102030"
+		ctrl      *gomock.Controller
+		mockJob   *job.MockAPI
+		builder   *jobBuilder
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockJob = job.NewMockAPI(ctrl)
+		builder = NewJobBuilder(logrus.New(), mockJob, JobConfig{Image: "builder:v1", Namespace: "ns"})
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("runs the image generation job", func() {
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, obj *batch.Job) error {
+				Expect(obj.Name).Should(Equal(jobName))
+				Expect(obj.Spec.Template.Spec.Containers[0].Image).Should(Equal("builder:v1"))
+				return nil
+			}).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), jobName, "ns").Return(nil).Times(1)
+		Expect(builder.Build(ctx, clusterID, createdAt, "img", "{}", "")).ShouldNot(HaveOccurred())
+		Expect(builder.Version("")).Should(Equal("builder:v1"))
+	})
+
+	It("passes the RHCOS image of the version to the job", func() {
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, obj *batch.Job) error {
+				Expect(obj.Spec.Template.Spec.Containers[0].Env).Should(ContainElement(
+					core.EnvVar{Name: "RHCOS_IMAGE", Value: "https://mirror/rhcos-4.6.iso"}))
+				return nil
+			}).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), jobName, "ns").Return(nil).Times(1)
+		Expect(builder.Build(ctx, clusterID, createdAt, "img", "{}", "https://mirror/rhcos-4.6.iso")).ShouldNot(HaveOccurred())
+		Expect(builder.Version("https://mirror/rhcos-4.6.iso")).Should(Equal("builder:v1:https://mirror/rhcos-4.6.iso"))
+	})
+
+	It("fails when the job fails", func() {
+		mockJob.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockJob.EXPECT().Monitor(gomock.Any(), jobName, "ns").Return(errors.New("failed")).Times(1)
+		Expect(builder.Build(ctx, clusterID, createdAt, "img", "{}", "")).Should(HaveOccurred())
+	})
+
+	It("deletes the job of the canceled generation", func() {
+		mockJob.EXPECT().Delete(gomock.Any(), jobName, "ns").Return(nil).Times(1)
+		Expect(builder.Cancel(ctx, clusterID, createdAt)).ShouldNot(HaveOccurred())
+	})
+})
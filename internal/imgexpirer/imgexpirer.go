@@ -5,12 +5,11 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/openshift/assisted-service/internal/events"
 	"github.com/openshift/assisted-service/models"
 	logutil "github.com/openshift/assisted-service/pkg/log"
 	"github.com/openshift/assisted-service/pkg/requestid"
+	"github.com/openshift/assisted-service/pkg/s3Client"
 	"github.com/sirupsen/logrus"
 )
 
@@ -20,13 +19,13 @@ const dummyImage = "discovery-image-00000000-0000-0000-0000-000000000000"
 
 type Manager struct {
 	log           logrus.FieldLogger
-	s3Client      s3iface.S3API
+	s3Client      s3Client.S3Client
 	s3Bucket      string
 	deleteTime    time.Duration
 	eventsHandler events.Handler
 }
 
-func NewManager(log logrus.FieldLogger, s3Client s3iface.S3API, s3Bucket string, deleteTime time.Duration, eventsHandler events.Handler) *Manager {
+func NewManager(log logrus.FieldLogger, s3Client s3Client.S3Client, s3Bucket string, deleteTime time.Duration, eventsHandler events.Handler) *Manager {
 	return &Manager{
 		log:           log,
 		s3Client:      s3Client,
@@ -43,22 +42,19 @@ func (m *Manager) ExpirationTask() {
 	prefix := imagePrefix
 
 	log.Info("Image expiration monitor woke up, checking for expired images...")
-	err := m.s3Client.ListObjectsPages(&s3.ListObjectsInput{Bucket: &m.s3Bucket, Prefix: &prefix},
-		func(page *s3.ListObjectsOutput, lastPage bool) bool {
-			for _, object := range page.Contents {
-				m.handleObject(ctx, log, object, now)
-			}
-			return !lastPage
-		})
+	objects, err := m.s3Client.ListObjectsByPrefix(ctx, prefix, m.s3Bucket)
 	if err != nil {
 		log.WithError(err).Error("Error listing objects")
 		return
 	}
+	for _, object := range objects {
+		m.handleObject(ctx, log, object, now)
+	}
 }
 
-func (m *Manager) handleObject(ctx context.Context, log logrus.FieldLogger, object *s3.Object, now time.Time) {
+func (m *Manager) handleObject(ctx context.Context, log logrus.FieldLogger, object s3Client.ObjectInfo, now time.Time) {
 	// Delete dummy objects right away, they just take up space
-	if *object.Key == dummyImage {
+	if object.Key == dummyImage {
 		m.deleteObject(ctx, log, object)
 		return
 	}
@@ -67,30 +63,28 @@ func (m *Manager) handleObject(ctx context.Context, log logrus.FieldLogger, obje
 	if now.Before(object.LastModified.Add(m.deleteTime)) {
 		return
 	}
-	objectTags, err := m.s3Client.GetObjectTagging(&s3.GetObjectTaggingInput{Bucket: &m.s3Bucket, Key: object.Key})
+	objectTags, err := m.s3Client.GetObjectTags(ctx, object.Key, m.s3Bucket)
 	if err != nil {
-		log.WithError(err).Errorf("Error getting tags for object %s", *object.Key)
+		log.WithError(err).Errorf("Error getting tags for object %s", object.Key)
 		return
 	}
-	for _, tag := range objectTags.TagSet {
-		if *tag.Key == "create_sec_since_epoch" {
-			objTime, _ := strconv.ParseInt(*tag.Value, 10, 64)
-			if now.After(time.Unix(objTime, 0).Add(m.deleteTime)) {
-				m.deleteObject(ctx, log, object)
-			}
+	if value, ok := objectTags["create_sec_since_epoch"]; ok {
+		objTime, _ := strconv.ParseInt(value, 10, 64)
+		if now.After(time.Unix(objTime, 0).Add(m.deleteTime)) {
+			m.deleteObject(ctx, log, object)
 		}
 	}
 }
 
-func (m *Manager) deleteObject(ctx context.Context, log logrus.FieldLogger, object *s3.Object) {
-	_, err := m.s3Client.DeleteObject(&s3.DeleteObjectInput{Bucket: &m.s3Bucket, Key: object.Key})
+func (m *Manager) deleteObject(ctx context.Context, log logrus.FieldLogger, object s3Client.ObjectInfo) {
+	err := m.s3Client.DeleteFileFromS3(ctx, object.Key, m.s3Bucket)
 	if err != nil {
-		log.WithError(err).Errorf("Error deleting object %s", *object.Key)
+		log.WithError(err).Errorf("Error deleting object %s", object.Key)
 		return
 	}
 	eventMsg := "Deleted image from backend because it expired. It may be generated again at any time."
-	m.eventsHandler.AddEvent(ctx, clusterIDFromImageName(*object.Key), models.EventSeverityInfo, eventMsg, time.Now())
-	log.Infof("Deleted expired image %s", *object.Key)
+	m.eventsHandler.AddEvent(ctx, clusterIDFromImageName(object.Key), models.EventSeverityInfo, eventMsg, time.Now())
+	log.Infof("Deleted expired image %s", object.Key)
 }
 
 func clusterIDFromImageName(imgName string) string {
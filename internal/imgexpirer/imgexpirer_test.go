@@ -7,17 +7,15 @@ import (
 	"testing"
 	"time"
 
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/openshift/assisted-service/internal/events"
 	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/s3Client"
 	"github.com/sirupsen/logrus"
 )
 
-//go:generate mockgen -package imgexpirer -destination mock_s3iface.go github.com/aws/aws-sdk-go/service/s3/s3iface S3API
-
 func TestExpirer(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Image expirer tests Suite")
@@ -29,7 +27,7 @@ var _ = Describe("image_expirer", func() {
 		log        = logrus.New()
 		ctrl       *gomock.Controller
 		deleteTime time.Duration
-		mockAPI    *MockS3API
+		mockAPI    *s3Client.MockS3Client
 		mockEvents *events.MockHandler
 		bucket     string
 		mgr        *Manager
@@ -42,7 +40,7 @@ var _ = Describe("image_expirer", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		log.SetOutput(ioutil.Discard)
 		bucket = "test"
-		mockAPI = NewMockS3API(ctrl)
+		mockAPI = s3Client.NewMockS3Client(ctrl)
 		mockEvents = events.NewMockHandler(ctrl)
 		deleteTime, _ = time.ParseDuration("60m")
 		mgr = NewManager(log, mockAPI, bucket, deleteTime, mockEvents)
@@ -50,62 +48,56 @@ var _ = Describe("image_expirer", func() {
 	})
 	It("not_expired_image_not_reused", func() {
 		imgCreatedAt, _ := time.Parse(time.RFC3339, "2020-01-01T09:30:00+00:00") // 30 minutes ago
-		obj := s3.Object{Key: &objKey, LastModified: &imgCreatedAt}
-		mgr.handleObject(ctx, log, &obj, now)
+		obj := s3Client.ObjectInfo{Key: objKey, LastModified: imgCreatedAt}
+		mgr.handleObject(ctx, log, obj, now)
 	})
 	It("expired_image_not_reused", func() {
 		imgCreatedAt, _ := time.Parse(time.RFC3339, "2020-01-01T08:00:00+00:00") // Two hours ago
 		unixTime := imgCreatedAt.Unix()                                          // Tag is also two hours ago
-		obj := s3.Object{Key: &objKey, LastModified: &imgCreatedAt}
-		taggingInput := s3.GetObjectTaggingInput{Bucket: &bucket, Key: &objKey}
-		tagValue := strconv.Itoa(int(unixTime))
-		tag := s3.Tag{Key: &tagKey, Value: &tagValue}
-		tagSet := []*s3.Tag{&tag}
-		taggingOutput := s3.GetObjectTaggingOutput{TagSet: tagSet}
-		mockAPI.EXPECT().GetObjectTagging(&taggingInput).Return(&taggingOutput, nil)
-		deleteInput := s3.DeleteObjectInput{Bucket: &bucket, Key: &objKey}
-		mockAPI.EXPECT().DeleteObject(&deleteInput).Return(nil, nil)
+		obj := s3Client.ObjectInfo{Key: objKey, LastModified: imgCreatedAt}
+		tags := map[string]string{tagKey: strconv.Itoa(int(unixTime))}
+		mockAPI.EXPECT().GetObjectTags(ctx, objKey, bucket).Return(tags, nil)
+		mockAPI.EXPECT().DeleteFileFromS3(ctx, objKey, bucket).Return(nil)
 		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId, models.EventSeverityInfo, "Deleted image from backend because it expired. It may be generated again at any time.", gomock.Any())
-		mgr.handleObject(ctx, log, &obj, now)
+		mgr.handleObject(ctx, log, obj, now)
 	})
 	It("not_expired_image_reused", func() {
 		imgCreatedAt, _ := time.Parse(time.RFC3339, "2020-01-01T08:00:00+00:00") // Two hours ago
 		durationToAdd, _ := time.ParseDuration("90m")
 		unixTime := imgCreatedAt.Add(durationToAdd).Unix() // Tag is now half an hour ago
-		obj := s3.Object{Key: &objKey, LastModified: &imgCreatedAt}
-		taggingInput := s3.GetObjectTaggingInput{Bucket: &bucket, Key: &objKey}
-		tagValue := strconv.Itoa(int(unixTime))
-		tag := s3.Tag{Key: &tagKey, Value: &tagValue}
-		tagSet := []*s3.Tag{&tag}
-		taggingOutput := s3.GetObjectTaggingOutput{TagSet: tagSet}
-		mockAPI.EXPECT().GetObjectTagging(&taggingInput).Return(&taggingOutput, nil)
-		mgr.handleObject(ctx, log, &obj, now)
+		obj := s3Client.ObjectInfo{Key: objKey, LastModified: imgCreatedAt}
+		tags := map[string]string{tagKey: strconv.Itoa(int(unixTime))}
+		mockAPI.EXPECT().GetObjectTags(ctx, objKey, bucket).Return(tags, nil)
+		mgr.handleObject(ctx, log, obj, now)
 	})
 	It("expired_image_reused", func() {
 		imgCreatedAt, _ := time.Parse(time.RFC3339, "2020-01-01T07:00:00+00:00") // Three hours ago
 		durationToAdd, _ := time.ParseDuration("90m")
 		unixTime := imgCreatedAt.Add(durationToAdd).Unix() // Tag is now 1.5 hours ago
-		obj := s3.Object{Key: &objKey, LastModified: &imgCreatedAt}
-		taggingInput := s3.GetObjectTaggingInput{Bucket: &bucket, Key: &objKey}
-		tagValue := strconv.Itoa(int(unixTime))
-		tag := s3.Tag{Key: &tagKey, Value: &tagValue}
-		tagSet := []*s3.Tag{&tag}
-		taggingOutput := s3.GetObjectTaggingOutput{TagSet: tagSet}
-		mockAPI.EXPECT().GetObjectTagging(&taggingInput).Return(&taggingOutput, nil)
-		deleteInput := s3.DeleteObjectInput{Bucket: &bucket, Key: &objKey}
-		mockAPI.EXPECT().DeleteObject(&deleteInput).Return(nil, nil)
+		obj := s3Client.ObjectInfo{Key: objKey, LastModified: imgCreatedAt}
+		tags := map[string]string{tagKey: strconv.Itoa(int(unixTime))}
+		mockAPI.EXPECT().GetObjectTags(ctx, objKey, bucket).Return(tags, nil)
+		mockAPI.EXPECT().DeleteFileFromS3(ctx, objKey, bucket).Return(nil)
 		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId, models.EventSeverityInfo, "Deleted image from backend because it expired. It may be generated again at any time.", gomock.Any())
-		mgr.handleObject(ctx, log, &obj, now)
+		mgr.handleObject(ctx, log, obj, now)
 	})
 	It("dummy_image_expires_immediately", func() {
 		clusterId = "00000000-0000-0000-0000-000000000000"
 		objKey = "discovery-image-00000000-0000-0000-0000-000000000000"
 		imgCreatedAt, _ := time.Parse(time.RFC3339, "2020-01-01T08:00:00+00:00") // Two hours ago
-		obj := s3.Object{Key: &objKey, LastModified: &imgCreatedAt}
-		deleteInput := s3.DeleteObjectInput{Bucket: &bucket, Key: &objKey}
-		mockAPI.EXPECT().DeleteObject(&deleteInput).Return(nil, nil)
+		obj := s3Client.ObjectInfo{Key: objKey, LastModified: imgCreatedAt}
+		mockAPI.EXPECT().DeleteFileFromS3(ctx, objKey, bucket).Return(nil)
 		mockEvents.EXPECT().AddEvent(gomock.Any(), clusterId, models.EventSeverityInfo, "Deleted image from backend because it expired. It may be generated again at any time.", gomock.Any())
-		mgr.handleObject(ctx, log, &obj, now)
+		mgr.handleObject(ctx, log, obj, now)
+	})
+	It("expiration_task_handles_listed_images", func() {
+		imgCreatedAt, _ := time.Parse(time.RFC3339, "2020-01-01T08:00:00+00:00") // Two hours ago
+		dummyImage := "discovery-image-00000000-0000-0000-0000-000000000000"
+		mockAPI.EXPECT().ListObjectsByPrefix(gomock.Any(), "discovery-image-", bucket).
+			Return([]s3Client.ObjectInfo{{Key: dummyImage, LastModified: imgCreatedAt}}, nil)
+		mockAPI.EXPECT().DeleteFileFromS3(gomock.Any(), dummyImage, bucket).Return(nil)
+		mockEvents.EXPECT().AddEvent(gomock.Any(), "00000000-0000-0000-0000-000000000000", models.EventSeverityInfo, "Deleted image from backend because it expired. It may be generated again at any time.", gomock.Any())
+		mgr.ExpirationTask()
 	})
 
 	AfterEach(func() {
@@ -1,27 +1,31 @@
 package installcfg
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
+	"net/url"
+	"strings"
 
 	"github.com/go-openapi/swag"
 	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/mirrorregistries"
 	"github.com/openshift/assisted-service/models"
-
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
 
 type bmc struct {
-	Address  string `yaml:"address"`
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
+	Address  string `yaml:"address,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
 }
 
 type host struct {
 	Name            string `yaml:"name"`
 	Role            string `yaml:"role"`
-	Bmc             bmc    `yaml:"bmc"`
+	Bmc             bmc    `yaml:"bmc,omitempty"`
 	BootMACAddress  string `yaml:"bootMACAddress"`
 	BootMode        string `yaml:"bootMode"`
 	HardwareProfile string `yaml:"hardwareProfile"`
@@ -35,8 +39,22 @@ type baremetal struct {
 	Hosts                        []host `yaml:"hosts"`
 }
 
+type platformNone struct{}
+
 type platform struct {
-	Baremetal baremetal `yaml:"baremetal"`
+	Baremetal *baremetal    `yaml:"baremetal,omitempty"`
+	None      *platformNone `yaml:"none,omitempty"`
+}
+
+type imageContentSource struct {
+	Mirrors []string `yaml:"mirrors" json:"mirrors"`
+	Source  string   `yaml:"source" json:"source"`
+}
+
+type proxy struct {
+	HTTPProxy  string `yaml:"httpProxy,omitempty"`
+	HTTPSProxy string `yaml:"httpsProxy,omitempty"`
+	NoProxy    string `yaml:"noProxy,omitempty"`
 }
 
 type InstallerConfigBaremetal struct {
@@ -64,9 +82,13 @@ type InstallerConfigBaremetal struct {
 		Name     string `yaml:"name"`
 		Replicas int    `yaml:"replicas"`
 	} `yaml:"controlPlane"`
-	Platform   platform `yaml:"platform"`
-	PullSecret string   `yaml:"pullSecret"`
-	SSHKey     string   `yaml:"sshKey"`
+	Platform              platform             `yaml:"platform"`
+	PullSecret            string               `yaml:"pullSecret"`
+	SSHKey                string               `yaml:"sshKey"`
+	FIPS                  bool                 `yaml:"fips,omitempty"`
+	Proxy                 *proxy               `yaml:"proxy,omitempty"`
+	AdditionalTrustBundle string               `yaml:"additionalTrustBundle,omitempty"`
+	ImageContentSources   []imageContentSource `yaml:"imageContentSources,omitempty"`
 }
 
 func countHostsByRole(cluster *common.Cluster, role models.HostRole) int {
@@ -80,7 +102,11 @@ func countHostsByRole(cluster *common.Cluster, role models.HostRole) int {
 }
 
 func getBasicInstallConfig(cluster *common.Cluster) *InstallerConfigBaremetal {
-	return &InstallerConfigBaremetal{
+	networkType := cluster.NetworkType
+	if networkType == "" {
+		networkType = models.ClusterNetworkTypeOpenShiftSDN
+	}
+	cfg := &InstallerConfigBaremetal{
 		APIVersion: "v1",
 		BaseDomain: cluster.BaseDNSDomain,
 		Networking: struct {
@@ -94,7 +120,7 @@ func getBasicInstallConfig(cluster *common.Cluster) *InstallerConfigBaremetal {
 			} `yaml:"machineNetwork"`
 			ServiceNetwork []string `yaml:"serviceNetwork"`
 		}{
-			NetworkType: "OpenShiftSDN",
+			NetworkType: networkType,
 			ClusterNetwork: []struct {
 				Cidr       string `yaml:"cidr"`
 				HostPrefix int    `yaml:"hostPrefix"`
@@ -129,62 +155,172 @@ func getBasicInstallConfig(cluster *common.Cluster) *InstallerConfigBaremetal {
 			Name:     string(models.HostRoleMaster),
 			Replicas: countHostsByRole(cluster, models.HostRoleMaster),
 		},
-		PullSecret: cluster.PullSecret,
-		SSHKey:     cluster.SSHPublicKey,
+		PullSecret:            cluster.PullSecret,
+		SSHKey:                cluster.SSHPublicKey,
+		AdditionalTrustBundle: cluster.AdditionalTrustBundle,
 	}
+	setDualStackNetworks(cluster, cfg)
+	setProxy(cluster, cfg)
+	return cfg
 }
 
-// [TODO] - remove once we decide to use specific values from the hosts of the cluster
-func getDummyMAC(log logrus.FieldLogger, dummyMAC string, count int) (string, error) {
-	hwMac, err := net.ParseMAC(dummyMAC)
-	if err != nil {
-		log.Warn("Failed to parse dummyMac")
-		return "", err
+// setDualStackNetworks adds the secondary networks of a dual-stack cluster after the primary ones
+func setDualStackNetworks(cluster *common.Cluster, cfg *InstallerConfigBaremetal) {
+	if cluster.SecondaryClusterNetworkCidr != "" {
+		cfg.Networking.ClusterNetwork = append(cfg.Networking.ClusterNetwork, struct {
+			Cidr       string `yaml:"cidr"`
+			HostPrefix int    `yaml:"hostPrefix"`
+		}{Cidr: cluster.SecondaryClusterNetworkCidr, HostPrefix: int(cluster.SecondaryClusterNetworkHostPrefix)})
+	}
+	if cluster.SecondaryMachineNetworkCidr != "" {
+		cfg.Networking.MachineNetwork = append(cfg.Networking.MachineNetwork, struct {
+			Cidr string `yaml:"cidr"`
+		}{Cidr: cluster.SecondaryMachineNetworkCidr})
+	}
+	if cluster.SecondaryServiceNetworkCidr != "" {
+		cfg.Networking.ServiceNetwork = append(cfg.Networking.ServiceNetwork, cluster.SecondaryServiceNetworkCidr)
 	}
-	hwMac[len(hwMac)-1] = hwMac[len(hwMac)-1] + byte(count)
-	return hwMac.String(), nil
 }
 
-func setBMPlatformInstallconfig(log logrus.FieldLogger, cluster *common.Cluster, cfg *InstallerConfigBaremetal) error {
-	// set hosts
-	numMasters := countHostsByRole(cluster, models.HostRoleMaster)
-	numWorkers := countHostsByRole(cluster, models.HostRoleWorker)
-	masterCount := 0
-	workerCount := 0
-	hosts := make([]host, numWorkers+numMasters)
-
-	// dummy MAC and port, once we start using real BMH, those values should be set from cluster
-	dummyMAC := "00:aa:39:b3:51:10"
-	dummyPort := 6230
-
-	for i := range hosts {
-		log.Infof("Setting master, host %d, master count %d", i, masterCount)
-		if i >= numMasters {
-			hosts[i].Name = fmt.Sprintf("openshift-worker-%d", workerCount)
-			hosts[i].Role = string(models.HostRoleWorker)
-			workerCount += 1
-		} else {
-			hosts[i].Name = fmt.Sprintf("openshift-master-%d", masterCount)
-			hosts[i].Role = string(models.HostRoleMaster)
-			masterCount += 1
+// setProxy configures the cluster-wide proxy of the installed cluster, the installer adds the cluster networks to its noProxy
+func setProxy(cluster *common.Cluster, cfg *InstallerConfigBaremetal) {
+	if cluster.HTTPProxy == "" && cluster.HTTPSProxy == "" {
+		return
+	}
+	cfg.Proxy = &proxy{
+		HTTPProxy:  cluster.HTTPProxy,
+		HTTPSProxy: cluster.HTTPSProxy,
+		NoProxy:    cluster.NoProxy,
+	}
+}
+
+// setMirrorRegistries sets the image content sources of the mirror registries and trusts their CA
+func setMirrorRegistries(cluster *common.Cluster, cfg *InstallerConfigBaremetal) error {
+	config, err := mirrorregistries.ParseConfig(cluster.MirrorRegistriesConfig)
+	if err != nil || config == nil {
+		return err
+	}
+	for _, source := range config.ImageContentSources {
+		cfg.ImageContentSources = append(cfg.ImageContentSources, imageContentSource{
+			Mirrors: source.Mirrors,
+			Source:  swag.StringValue(source.Source),
+		})
+	}
+	cfg.AdditionalTrustBundle = appendTrustBundle(cfg.AdditionalTrustBundle, config.CaCertificate)
+	return nil
+}
+
+// appendTrustBundle returns the bundle followed by the certificates of the other bundle
+func appendTrustBundle(bundle, other string) string {
+	if other == "" {
+		return bundle
+	}
+	if bundle != "" && !strings.HasSuffix(bundle, "\n") {
+		bundle += "\n"
+	}
+	return bundle + other
+}
+
+// getBootInterface returns the interface of the host that has an address in the machine network
+func getBootInterface(inventory *models.Inventory, machineIpnet *net.IPNet) *models.Interface {
+	for _, intf := range inventory.Interfaces {
+		for _, addr := range append(append([]string{}, intf.IPV4Addresses...), intf.IPV6Addresses...) {
+			ip, _, err := net.ParseCIDR(addr)
+			if err == nil && machineIpnet.Contains(ip) {
+				return intf
+			}
 		}
-		hosts[i].Bmc = bmc{
-			Address:  fmt.Sprintf("ipmi://192.168.111.1:%d", dummyPort+i),
-			Username: "admin",
-			Password: "rackattack",
+	}
+	return nil
+}
+
+// getBootMode translates the boot mode the agent reported to the one of the baremetal platform
+func getBootMode(inventory *models.Inventory) string {
+	if inventory.Boot != nil && strings.EqualFold(inventory.Boot.CurrentBootMode, "bios") {
+		return "legacy"
+	}
+	return "UEFI"
+}
+
+// getBmcAddress returns the BMC address of the host as an IPMI URL, the agent reports an unspecified address when the
+// host has no BMC and then no address is returned. A bare IP or hostname is turned into an IPMI URL, only malformed
+// URLs are rejected.
+func getBmcAddress(inventory *models.Inventory) (string, error) {
+	if inventory.BmcAddress == "" {
+		return "", nil
+	}
+	address := inventory.BmcAddress
+	if !strings.Contains(address, "://") {
+		ip := net.ParseIP(address)
+		switch {
+		case ip == nil:
+			address = fmt.Sprintf("ipmi://%s", address)
+		case ip.IsUnspecified():
+			return "", nil
+		case ip.To4() == nil:
+			return fmt.Sprintf("ipmi://[%s]", ip), nil
+		default:
+			return fmt.Sprintf("ipmi://%s", ip), nil
 		}
-		hwMac, err := getDummyMAC(log, dummyMAC, i)
-		if err != nil {
-			log.Warn("Failed to parse dummyMac")
-			return err
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid BMC address %s", inventory.BmcAddress)
+	}
+	if ip := net.ParseIP(u.Hostname()); u.Hostname() == "" || ip != nil && ip.IsUnspecified() {
+		return "", errors.Errorf("BMC address %s has no host", inventory.BmcAddress)
+	}
+	return address, nil
+}
+
+func setBMPlatformInstallconfig(log logrus.FieldLogger, cluster *common.Cluster, cfg *InstallerConfigBaremetal) error {
+	_, machineIpnet, err := net.ParseCIDR(cluster.MachineNetworkCidr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse machine network CIDR %q", cluster.MachineNetworkCidr)
+	}
+
+	// the masters are listed before the workers
+	var hosts []host
+	var provisioningInterface string
+	for _, role := range []models.HostRole{models.HostRoleMaster, models.HostRoleWorker} {
+		for _, h := range cluster.Hosts {
+			if swag.StringValue(h.Status) == models.HostStatusDisabled || h.Role != role {
+				continue
+			}
+			var inventory models.Inventory
+			if err = json.Unmarshal([]byte(h.Inventory), &inventory); err != nil {
+				log.WithError(err).Warnf("failed to unmarshal inventory of host %s", h.ID)
+				return errors.Wrapf(err, "failed to unmarshal inventory of host %s", h.ID)
+			}
+			name, err := common.GetCurrentHostName(h)
+			if err != nil {
+				return err
+			}
+			bmcAddress, err := getBmcAddress(&inventory)
+			if err != nil {
+				return errors.Wrapf(err, "host %s", name)
+			}
+			bootInterface := getBootInterface(&inventory, machineIpnet)
+			if bootInterface == nil {
+				return errors.Errorf("host %s has no interface in machine network CIDR %s", name, cluster.MachineNetworkCidr)
+			}
+			if provisioningInterface == "" {
+				provisioningInterface = bootInterface.Name
+			}
+			log.Infof("Setting %s %s, boot MAC %s", role, name, bootInterface.MacAddress)
+			hosts = append(hosts, host{
+				Name:            name,
+				Role:            string(role),
+				Bmc:             bmc{Address: bmcAddress},
+				BootMACAddress:  bootInterface.MacAddress,
+				BootMode:        getBootMode(&inventory),
+				HardwareProfile: "unknown",
+			})
 		}
-		hosts[i].BootMACAddress = hwMac
-		hosts[i].BootMode = "UEFI"
-		hosts[i].HardwareProfile = "unknown"
 	}
 	cfg.Platform = platform{
-		Baremetal: baremetal{
-			ProvisioningNetworkInterface: "ens4",
+		Baremetal: &baremetal{
+			ProvisioningNetworkInterface: provisioningInterface,
 			APIVIP:                       cluster.APIVip,
 			IngressVIP:                   cluster.IngressVip,
 			DNSVIP:                       cluster.APIVip,
@@ -194,11 +330,29 @@ func setBMPlatformInstallconfig(log logrus.FieldLogger, cluster *common.Cluster,
 	return nil
 }
 
+func setPlatformInstallconfig(log logrus.FieldLogger, cluster *common.Cluster, cfg *InstallerConfigBaremetal) error {
+	switch cluster.Platform {
+	case models.ClusterPlatformNone:
+		cfg.Platform = platform{None: &platformNone{}}
+		return nil
+	case "", models.ClusterPlatformBaremetal:
+		return setBMPlatformInstallconfig(log, cluster, cfg)
+	default:
+		return errors.Errorf("unsupported platform %s", cluster.Platform)
+	}
+}
+
 func GetInstallConfig(log logrus.FieldLogger, cluster *common.Cluster) ([]byte, error) {
 	cfg := getBasicInstallConfig(cluster)
-	err := setBMPlatformInstallconfig(log, cluster, cfg)
+	err := setPlatformInstallconfig(log, cluster, cfg)
 	if err != nil {
 		return nil, err
 	}
+	if err = setMirrorRegistries(cluster, cfg); err != nil {
+		return nil, err
+	}
+	if err = applyInstallConfigOverrides(cluster, cfg); err != nil {
+		return nil, err
+	}
 	return yaml.Marshal(*cfg)
 }
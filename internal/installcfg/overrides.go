@@ -0,0 +1,218 @@
+package installcfg
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"sort"
+	"strings"
+
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/internal/network"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+)
+
+var overridableNetworkTypes = []string{"OpenShiftSDN", "OVNKubernetes"}
+
+// installConfigOverrides are the install config fields a user is allowed to override, any other field is rejected
+type installConfigOverrides struct {
+	Networking *struct {
+		NetworkType *string `json:"networkType"`
+	} `json:"networking"`
+	AdditionalTrustBundle *string               `json:"additionalTrustBundle"`
+	ImageContentSources   *[]imageContentSource `json:"imageContentSources"`
+	FIPS                  *bool                 `json:"fips"`
+}
+
+// serviceManagedFields are the install config fields that the service generates from the cluster, mapped to the
+// cluster fields that set them
+var serviceManagedFields = map[string]string{
+	"baseDomain":                "base_dns_domain",
+	"metadata":                  "name",
+	"pullSecret":                "pull_secret",
+	"sshKey":                    "ssh_public_key",
+	"proxy":                     "http_proxy, https_proxy and no_proxy",
+	"platform":                  "platform, api_vip and ingress_vip",
+	"controlPlane":              "host roles",
+	"compute":                   "host roles",
+	"networking.clusterNetwork": "cluster_network_cidr and cluster_network_host_prefix",
+	"networking.serviceNetwork": "service_network_cidr",
+	"networking.machineNetwork": "machine_network_cidr",
+}
+
+// verifyNoServiceManagedFields rejects the overrides of the fields that the service generates, with an error that
+// points to the cluster fields that set them
+func verifyNoServiceManagedFields(overrides string) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(overrides), &fields); err != nil {
+		// The strict parsing reports the error
+		return nil
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	var networking map[string]json.RawMessage
+	if err := json.Unmarshal(fields["networking"], &networking); err == nil {
+		for key := range networking {
+			keys = append(keys, "networking."+key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if clusterFields, ok := serviceManagedFields[key]; ok {
+			return errors.Errorf("install config field %s is managed by the service, set the cluster %s instead", key, clusterFields)
+		}
+	}
+	return nil
+}
+
+func parseInstallConfigOverrides(overrides string) (*installConfigOverrides, error) {
+	if err := verifyNoServiceManagedFields(overrides); err != nil {
+		return nil, err
+	}
+	var ret installConfigOverrides
+	dec := json.NewDecoder(strings.NewReader(overrides))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&ret); err != nil {
+		return nil, errors.Wrap(err, "invalid install config overrides")
+	}
+	if dec.More() {
+		return nil, errors.New("invalid install config overrides: unexpected data after the JSON object")
+	}
+	return &ret, nil
+}
+
+// ValidateTrustBundle verifies that the bundle is a PEM encoded list of X.509 certificates
+func ValidateTrustBundle(bundle string) error {
+	rest := []byte(bundle)
+	var count int
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return errors.Errorf("additionalTrustBundle contains a PEM block of type %s, only certificates are allowed", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return errors.Wrap(err, "additionalTrustBundle contains an invalid certificate")
+		}
+		count++
+	}
+	if count == 0 || len(bytes.TrimSpace(rest)) != 0 {
+		return errors.New("additionalTrustBundle must be a PEM encoded list of certificates")
+	}
+	return nil
+}
+
+// ValidateInstallConfigOverrides verifies that the overrides are a JSON object with only the overridable
+// install config fields, that their values are valid and that they are consistent with the cluster
+func ValidateInstallConfigOverrides(cluster *common.Cluster, overrides string) error {
+	if overrides == "" {
+		return nil
+	}
+	o, err := parseInstallConfigOverrides(overrides)
+	if err != nil {
+		return err
+	}
+	if err = o.validate(); err != nil {
+		return err
+	}
+	return o.validateForCluster(cluster)
+}
+
+func (o *installConfigOverrides) validate() error {
+	if o.Networking != nil && o.Networking.NetworkType != nil &&
+		!funk.ContainsString(overridableNetworkTypes, *o.Networking.NetworkType) {
+		return errors.Errorf("networking.networkType must be one of %s", strings.Join(overridableNetworkTypes, ", "))
+	}
+	if o.AdditionalTrustBundle != nil && *o.AdditionalTrustBundle != "" {
+		if err := ValidateTrustBundle(*o.AdditionalTrustBundle); err != nil {
+			return err
+		}
+	}
+	if o.ImageContentSources != nil {
+		for _, source := range *o.ImageContentSources {
+			if source.Source == "" {
+				return errors.New("imageContentSources entries must have a source")
+			}
+			if len(source.Mirrors) == 0 || funk.ContainsString(source.Mirrors, "") {
+				return errors.Errorf("imageContentSources entry of %s must have non empty mirrors", source.Source)
+			}
+		}
+	}
+	return nil
+}
+
+// validateForCluster verifies that the cluster networks are still valid with the overridden network type
+func (o *installConfigOverrides) validateForCluster(cluster *common.Cluster) error {
+	if o.Networking == nil || o.Networking.NetworkType == nil {
+		return nil
+	}
+	merged := cluster.Cluster
+	merged.NetworkType = *o.Networking.NetworkType
+	if err := network.VerifyClusterNetworks(&merged); err != nil {
+		return errors.Wrap(err, "install config overrides conflict with the cluster networks")
+	}
+	return nil
+}
+
+// applyInstallConfigOverrides merges the overrides into the generated install config. The overridden network type
+// and fips replace the generated values, the overridden trust bundle and image content sources are added to the
+// ones that the service generates from the cluster, so the user can't drop them by mistake
+func applyInstallConfigOverrides(cluster *common.Cluster, cfg *InstallerConfigBaremetal) error {
+	if cluster.InstallConfigOverrides == "" {
+		return nil
+	}
+	o, err := parseInstallConfigOverrides(cluster.InstallConfigOverrides)
+	if err != nil {
+		return err
+	}
+	if err = o.validate(); err != nil {
+		return err
+	}
+	if err = o.validateForCluster(cluster); err != nil {
+		return err
+	}
+	if o.Networking != nil && o.Networking.NetworkType != nil {
+		cfg.Networking.NetworkType = *o.Networking.NetworkType
+	}
+	if o.AdditionalTrustBundle != nil {
+		cfg.AdditionalTrustBundle = appendTrustBundle(cfg.AdditionalTrustBundle, *o.AdditionalTrustBundle)
+	}
+	if o.ImageContentSources != nil {
+		cfg.ImageContentSources = mergeImageContentSources(cfg.ImageContentSources, *o.ImageContentSources)
+	}
+	if o.FIPS != nil {
+		cfg.FIPS = *o.FIPS
+	}
+	return nil
+}
+
+// mergeImageContentSources adds the mirrors of the other sources to the sources, a source that already appears
+// gets the mirrors it doesn't have yet appended to its own
+func mergeImageContentSources(sources, other []imageContentSource) []imageContentSource {
+	for _, o := range other {
+		merged := false
+		for i := range sources {
+			if sources[i].Source != o.Source {
+				continue
+			}
+			for _, mirror := range o.Mirrors {
+				if !funk.ContainsString(sources[i].Mirrors, mirror) {
+					sources[i].Mirrors = append(sources[i].Mirrors, mirror)
+				}
+			}
+			merged = true
+			break
+		}
+		if !merged {
+			sources = append(sources, imageContentSource{Source: o.Source, Mirrors: append([]string{}, o.Mirrors...)})
+		}
+	}
+	return sources
+}
@@ -0,0 +1,143 @@
+package mirrorregistries
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/swag"
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+)
+
+// A repository of images, the host of its registry with an optional port followed by an optional path
+const repositoryRegex = `^[a-zA-Z0-9]([-a-zA-Z0-9.]*[a-zA-Z0-9])?(:[0-9]+)?(/[a-z0-9]+([._-]+[a-z0-9]+)*)*$`
+
+var repositoryRegexp = regexp.MustCompile(repositoryRegex)
+
+// ParseConfig parses the JSON encoded mirror registries configuration of a cluster, it is nil if the cluster has none
+func ParseConfig(config string) (*models.MirrorRegistriesConfig, error) {
+	if config == "" {
+		return nil, nil
+	}
+	var ret models.MirrorRegistriesConfig
+	if err := json.Unmarshal([]byte(config), &ret); err != nil {
+		return nil, errors.Wrap(err, "invalid mirror registries configuration")
+	}
+	return &ret, nil
+}
+
+// FormatConfig returns the JSON encoded mirror registries configuration, or an empty string if the configuration is empty
+func FormatConfig(config *models.MirrorRegistriesConfig) (string, error) {
+	if config == nil || (len(config.ImageContentSources) == 0 && config.CaCertificate == "") {
+		return "", nil
+	}
+	ret, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+/*
+ * Validate the image content sources of the mirror registries configuration, each source repository may only appear
+ * once and must be mirrored to at least one repository. The CA certificate is validated by the caller.
+ */
+func ValidateConfig(config *models.MirrorRegistriesConfig) error {
+	if config == nil {
+		return nil
+	}
+	sources := make(map[string]bool)
+	for _, source := range config.ImageContentSources {
+		if source == nil || swag.StringValue(source.Source) == "" {
+			return errors.New("Image content sources must have a source")
+		}
+		name := swag.StringValue(source.Source)
+		if !repositoryRegexp.MatchString(name) {
+			return errors.Errorf("Source %s is not a repository of images", name)
+		}
+		if sources[name] {
+			return errors.Errorf("Source %s appears in more than one image content source", name)
+		}
+		sources[name] = true
+		if len(source.Mirrors) == 0 {
+			return errors.Errorf("Source %s must have at least one mirror", name)
+		}
+		for _, mirror := range source.Mirrors {
+			if !repositoryRegexp.MatchString(mirror) {
+				return errors.Errorf("Mirror %s of source %s is not a repository of images", mirror, name)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+ * RewriteImage returns the reference of the image in the first mirror of its source. The image is matched against
+ * the source with the longest repository, images that have no source are returned as is.
+ */
+func RewriteImage(image string, config *models.MirrorRegistriesConfig) string {
+	if config == nil {
+		return image
+	}
+	var match *models.ImageContentSource
+	for _, source := range config.ImageContentSources {
+		name := swag.StringValue(source.Source)
+		if !strings.HasPrefix(image, name) || len(source.Mirrors) == 0 {
+			continue
+		}
+		// The source must be the whole repository of the image or one of its parent paths
+		if rest := image[len(name):]; rest != "" && !strings.ContainsAny(rest[:1], "/:@") {
+			continue
+		}
+		if match == nil || len(name) > len(swag.StringValue(match.Source)) {
+			match = source
+		}
+	}
+	if match == nil {
+		return image
+	}
+	return match.Mirrors[0] + image[len(swag.StringValue(match.Source)):]
+}
+
+/*
+ * GenerateRegistriesConf returns the containers-registries.conf(5) of the hosts, the images of the sources are pulled
+ * from their mirrors. The mirrors are used for references by tag as well, as the agent and installer images are
+ * usually referenced by tag.
+ */
+func GenerateRegistriesConf(config *models.MirrorRegistriesConfig) string {
+	var sb strings.Builder
+	sb.WriteString("unqualified-search-registries = [\"registry.access.redhat.com\", \"docker.io\"]\n")
+	if config == nil {
+		return sb.String()
+	}
+	for _, source := range config.ImageContentSources {
+		fmt.Fprintf(&sb, "\n[[registry]]\n  prefix = \"\"\n  location = %q\n  mirror-by-digest-only = false\n", swag.StringValue(source.Source))
+		for _, mirror := range source.Mirrors {
+			fmt.Fprintf(&sb, "\n  [[registry.mirror]]\n    location = %q\n", mirror)
+		}
+	}
+	return sb.String()
+}
+
+// GetMirrorRegistries returns the registries of the mirrors, with their ports, sorted
+func GetMirrorRegistries(config *models.MirrorRegistriesConfig) []string {
+	var ret []string
+	if config == nil {
+		return ret
+	}
+	registries := make(map[string]bool)
+	for _, source := range config.ImageContentSources {
+		for _, mirror := range source.Mirrors {
+			registry := strings.SplitN(mirror, "/", 2)[0]
+			if !registries[registry] {
+				registries[registry] = true
+				ret = append(ret, registry)
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
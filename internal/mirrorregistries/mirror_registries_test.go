@@ -0,0 +1,133 @@
+package mirrorregistries
+
+import (
+	"testing"
+
+	"github.com/go-openapi/swag"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/models"
+)
+
+var _ = Describe("mirror registries", func() {
+	source := func(name string, mirrors ...string) *models.ImageContentSource {
+		return &models.ImageContentSource{Source: swag.String(name), Mirrors: mirrors}
+	}
+	config := &models.MirrorRegistriesConfig{ImageContentSources: []*models.ImageContentSource{
+		source("quay.io/ocpmetal", "mirror.example.com:5000/ocpmetal"),
+		source("quay.io/openshift-release-dev/ocp-release", "mirror.example.com:5000/ocp/release", "other.example.com/ocp/release"),
+		source("quay.io/openshift-release-dev", "mirror.example.com:5000/openshift-release-dev"),
+	}}
+
+	Context("config", func() {
+		It("formats and parses the config", func() {
+			formatted, err := FormatConfig(config)
+			Expect(err).ShouldNot(HaveOccurred())
+			parsed, err := ParseConfig(formatted)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed).Should(Equal(config))
+		})
+
+		It("formats an empty config as an empty string", func() {
+			for _, c := range []*models.MirrorRegistriesConfig{nil, {}} {
+				formatted, err := FormatConfig(c)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(formatted).Should(BeEmpty())
+			}
+			parsed, err := ParseConfig("")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed).Should(BeNil())
+		})
+	})
+
+	Context("validation", func() {
+		It("accepts valid configs", func() {
+			Expect(ValidateConfig(config)).ShouldNot(HaveOccurred())
+			Expect(ValidateConfig(nil)).ShouldNot(HaveOccurred())
+		})
+
+		tests := []struct {
+			name   string
+			source *models.ImageContentSource
+			err    string
+		}{
+			{
+				name:   "missing source",
+				source: &models.ImageContentSource{Mirrors: []string{"mirror.example.com/ocp"}},
+				err:    "Image content sources must have a source",
+			},
+			{
+				name:   "source with a tag",
+				source: source("quay.io/ocpmetal/agent:latest", "mirror.example.com/ocp"),
+				err:    "Source quay.io/ocpmetal/agent:latest is not a repository of images",
+			},
+			{
+				name:   "duplicate source",
+				source: source("quay.io/ocpmetal", "other.example.com/ocpmetal"),
+				err:    "Source quay.io/ocpmetal appears in more than one image content source",
+			},
+			{
+				name:   "no mirrors",
+				source: source("quay.io/other"),
+				err:    "Source quay.io/other must have at least one mirror",
+			},
+			{
+				name:   "mirror with a scheme",
+				source: source("quay.io/other", "https://mirror.example.com/other"),
+				err:    "Mirror https://mirror.example.com/other of source quay.io/other is not a repository of images",
+			},
+		}
+
+		for i := range tests {
+			t := tests[i]
+			It(t.name, func() {
+				c := &models.MirrorRegistriesConfig{ImageContentSources: append(append([]*models.ImageContentSource{},
+					config.ImageContentSources...), t.source)}
+				Expect(ValidateConfig(c)).Should(MatchError(t.err))
+			})
+		}
+	})
+
+	Context("rewrite", func() {
+		It("rewrites the images of the sources to their first mirror", func() {
+			Expect(RewriteImage("quay.io/ocpmetal/agent:latest", config)).Should(Equal("mirror.example.com:5000/ocpmetal/agent:latest"))
+			Expect(RewriteImage("quay.io/openshift-release-dev/ocp-release@sha256:eab93b", config)).
+				Should(Equal("mirror.example.com:5000/ocp/release@sha256:eab93b"))
+			Expect(RewriteImage("quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:eab93b", config)).
+				Should(Equal("mirror.example.com:5000/openshift-release-dev/ocp-v4.0-art-dev@sha256:eab93b"))
+		})
+
+		It("keeps the images without a source", func() {
+			Expect(RewriteImage("quay.io/ocpmetal-other/agent:latest", config)).Should(Equal("quay.io/ocpmetal-other/agent:latest"))
+			Expect(RewriteImage("registry.redhat.io/ubi8/ubi:latest", config)).Should(Equal("registry.redhat.io/ubi8/ubi:latest"))
+			Expect(RewriteImage("quay.io/ocpmetal/agent:latest", nil)).Should(Equal("quay.io/ocpmetal/agent:latest"))
+		})
+	})
+
+	It("generates the registries.conf of the sources", func() {
+		Expect(GenerateRegistriesConf(&models.MirrorRegistriesConfig{ImageContentSources: config.ImageContentSources[1:2]})).
+			Should(Equal(`unqualified-search-registries = ["registry.access.redhat.com", "docker.io"]
+
+[[registry]]
+  prefix = ""
+  location = "quay.io/openshift-release-dev/ocp-release"
+  mirror-by-digest-only = false
+
+  [[registry.mirror]]
+    location = "mirror.example.com:5000/ocp/release"
+
+  [[registry.mirror]]
+    location = "other.example.com/ocp/release"
+`))
+	})
+
+	It("returns the registries of the mirrors", func() {
+		Expect(GetMirrorRegistries(config)).Should(Equal([]string{"mirror.example.com:5000", "other.example.com"}))
+		Expect(GetMirrorRegistries(nil)).Should(BeEmpty())
+	})
+})
+
+func TestMirrorRegistries(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "mirror registries tests")
+}
@@ -0,0 +1,122 @@
+package network
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/openshift/assisted-service/models"
+)
+
+// IsIPv6CIDR checks if the given CIDR is an IPv6 one
+func IsIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
+
+// IsIPv6Addr checks if the given address is an IPv6 one
+func IsIPv6Addr(addr string) bool {
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.To4() == nil
+}
+
+func parseCidr(name, cidr string) (*net.IPNet, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("%s <%s> is not a valid CIDR", name, cidr)
+	}
+	return ipnet, nil
+}
+
+func verifyHostPrefix(name string, hostPrefix int64, clusterNetwork *net.IPNet) error {
+	ones, bits := clusterNetwork.Mask.Size()
+	if hostPrefix < int64(ones) || hostPrefix > int64(bits) {
+		return fmt.Errorf("%s %d must be between %d and %d for cluster network <%s>", name, hostPrefix, ones, bits, clusterNetwork)
+	}
+	return nil
+}
+
+func verifySameFamily(name1, cidr1, name2, cidr2 string) error {
+	if cidr1 != "" && cidr2 != "" && IsIPv6CIDR(cidr1) != IsIPv6CIDR(cidr2) {
+		return fmt.Errorf("%s <%s> and %s <%s> must be of the same IP family", name1, cidr1, name2, cidr2)
+	}
+	return nil
+}
+
+func verifyOtherFamily(name1, cidr1, name2, cidr2 string) error {
+	if cidr1 != "" && cidr2 != "" && IsIPv6CIDR(cidr1) == IsIPv6CIDR(cidr2) {
+		return fmt.Errorf("%s <%s> and %s <%s> must be of different IP families", name1, cidr1, name2, cidr2)
+	}
+	return nil
+}
+
+/*
+ * Verify the networks of the cluster are consistent with each other and with the network type.
+ * The primary cluster, service and machine networks must all be of the same IP family. A dual-stack
+ * cluster sets the secondary networks too, and these must be of the other IP family.
+ * OpenShiftSDN supports only IPv4, so any IPv6 network requires OVNKubernetes.
+ */
+func VerifyClusterNetworks(cluster *models.Cluster) error {
+	cidrs := []struct{ name, cidr string }{
+		{"cluster-network-cidr", cluster.ClusterNetworkCidr},
+		{"service-network-cidr", cluster.ServiceNetworkCidr},
+		{"machine-network-cidr", cluster.MachineNetworkCidr},
+		{"secondary-cluster-network-cidr", cluster.SecondaryClusterNetworkCidr},
+		{"secondary-service-network-cidr", cluster.SecondaryServiceNetworkCidr},
+		{"secondary-machine-network-cidr", cluster.SecondaryMachineNetworkCidr},
+	}
+	hasIPv6 := false
+	for _, c := range cidrs {
+		if c.cidr == "" {
+			continue
+		}
+		if _, err := parseCidr(c.name, c.cidr); err != nil {
+			return err
+		}
+		hasIPv6 = hasIPv6 || IsIPv6CIDR(c.cidr)
+	}
+
+	if err := verifySameFamily("cluster-network-cidr", cluster.ClusterNetworkCidr, "service-network-cidr", cluster.ServiceNetworkCidr); err != nil {
+		return err
+	}
+	if err := verifySameFamily("cluster-network-cidr", cluster.ClusterNetworkCidr, "machine-network-cidr", cluster.MachineNetworkCidr); err != nil {
+		return err
+	}
+	if cluster.ClusterNetworkCidr != "" {
+		clusterNetwork, _ := parseCidr("cluster-network-cidr", cluster.ClusterNetworkCidr)
+		if err := verifyHostPrefix("cluster-network-host-prefix", cluster.ClusterNetworkHostPrefix, clusterNetwork); err != nil {
+			return err
+		}
+	}
+
+	dualStack := cluster.SecondaryClusterNetworkCidr != "" || cluster.SecondaryServiceNetworkCidr != ""
+	if dualStack {
+		if cluster.SecondaryClusterNetworkCidr == "" || cluster.SecondaryServiceNetworkCidr == "" {
+			return fmt.Errorf("A dual-stack cluster requires both secondary-cluster-network-cidr and secondary-service-network-cidr")
+		}
+		if err := verifyOtherFamily("cluster-network-cidr", cluster.ClusterNetworkCidr, "secondary-cluster-network-cidr", cluster.SecondaryClusterNetworkCidr); err != nil {
+			return err
+		}
+		if err := verifySameFamily("secondary-cluster-network-cidr", cluster.SecondaryClusterNetworkCidr, "secondary-service-network-cidr", cluster.SecondaryServiceNetworkCidr); err != nil {
+			return err
+		}
+		if err := verifySameFamily("secondary-cluster-network-cidr", cluster.SecondaryClusterNetworkCidr, "secondary-machine-network-cidr", cluster.SecondaryMachineNetworkCidr); err != nil {
+			return err
+		}
+		secondaryClusterNetwork, _ := parseCidr("secondary-cluster-network-cidr", cluster.SecondaryClusterNetworkCidr)
+		if err := verifyHostPrefix("secondary-cluster-network-host-prefix", cluster.SecondaryClusterNetworkHostPrefix, secondaryClusterNetwork); err != nil {
+			return err
+		}
+	} else if cluster.SecondaryMachineNetworkCidr != "" {
+		return fmt.Errorf("secondary-machine-network-cidr <%s> is set, but the cluster isn't dual-stack", cluster.SecondaryMachineNetworkCidr)
+	}
+
+	networkType := cluster.NetworkType
+	if networkType == "" {
+		networkType = models.ClusterNetworkTypeOpenShiftSDN
+	}
+	if networkType == models.ClusterNetworkTypeOpenShiftSDN && hasIPv6 {
+		return fmt.Errorf("The %s network type supports only IPv4, use %s for IPv6 and dual-stack networks",
+			models.ClusterNetworkTypeOpenShiftSDN, models.ClusterNetworkTypeOVNKubernetes)
+	}
+	return nil
+}
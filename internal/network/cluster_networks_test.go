@@ -0,0 +1,125 @@
+package network
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/models"
+)
+
+var _ = Describe("VerifyClusterNetworks", func() {
+	var cluster *models.Cluster
+
+	BeforeEach(func() {
+		cluster = &models.Cluster{
+			NetworkType:              models.ClusterNetworkTypeOpenShiftSDN,
+			ClusterNetworkCidr:       "10.128.0.0/14",
+			ClusterNetworkHostPrefix: 23,
+			ServiceNetworkCidr:       "172.30.0.0/16",
+			MachineNetworkCidr:       "192.168.126.0/24",
+		}
+	})
+
+	setIPv6 := func() {
+		cluster.NetworkType = models.ClusterNetworkTypeOVNKubernetes
+		cluster.ClusterNetworkCidr = "fd01::/48"
+		cluster.ClusterNetworkHostPrefix = 64
+		cluster.ServiceNetworkCidr = "fd02::/112"
+		cluster.MachineNetworkCidr = "fd00::/64"
+	}
+
+	setDualStack := func() {
+		cluster.NetworkType = models.ClusterNetworkTypeOVNKubernetes
+		cluster.SecondaryClusterNetworkCidr = "fd01::/48"
+		cluster.SecondaryClusterNetworkHostPrefix = 64
+		cluster.SecondaryServiceNetworkCidr = "fd02::/112"
+		cluster.SecondaryMachineNetworkCidr = "fd00::/64"
+	}
+
+	It("IPv4", func() {
+		Expect(VerifyClusterNetworks(cluster)).ToNot(HaveOccurred())
+	})
+
+	It("IPv6", func() {
+		setIPv6()
+		Expect(VerifyClusterNetworks(cluster)).ToNot(HaveOccurred())
+	})
+
+	It("Dual stack", func() {
+		setDualStack()
+		Expect(VerifyClusterNetworks(cluster)).ToNot(HaveOccurred())
+	})
+
+	It("Dual stack without a machine network", func() {
+		setDualStack()
+		cluster.MachineNetworkCidr = ""
+		cluster.SecondaryMachineNetworkCidr = ""
+		Expect(VerifyClusterNetworks(cluster)).ToNot(HaveOccurred())
+	})
+
+	It("Empty network type is OpenShiftSDN", func() {
+		setIPv6()
+		cluster.NetworkType = ""
+		Expect(VerifyClusterNetworks(cluster)).To(HaveOccurred())
+	})
+
+	It("OpenShiftSDN with IPv6", func() {
+		setIPv6()
+		cluster.NetworkType = models.ClusterNetworkTypeOpenShiftSDN
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("supports only IPv4")))
+	})
+
+	It("OpenShiftSDN with dual stack", func() {
+		setDualStack()
+		cluster.NetworkType = models.ClusterNetworkTypeOpenShiftSDN
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("supports only IPv4")))
+	})
+
+	It("Invalid CIDR", func() {
+		cluster.ServiceNetworkCidr = "172.30.0.0/33"
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("is not a valid CIDR")))
+	})
+
+	It("Mixed primary families", func() {
+		cluster.NetworkType = models.ClusterNetworkTypeOVNKubernetes
+		cluster.ServiceNetworkCidr = "fd02::/112"
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("must be of the same IP family")))
+	})
+
+	It("Machine network of the other family", func() {
+		cluster.NetworkType = models.ClusterNetworkTypeOVNKubernetes
+		cluster.MachineNetworkCidr = "fd00::/64"
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("must be of the same IP family")))
+	})
+
+	It("Host prefix out of range", func() {
+		cluster.ClusterNetworkHostPrefix = 12
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("must be between 14 and 32")))
+		cluster.ClusterNetworkHostPrefix = 33
+		Expect(VerifyClusterNetworks(cluster)).To(HaveOccurred())
+	})
+
+	It("Secondary networks of the same family", func() {
+		setDualStack()
+		cluster.SecondaryClusterNetworkCidr = "10.132.0.0/14"
+		cluster.SecondaryClusterNetworkHostPrefix = 23
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("must be of different IP families")))
+	})
+
+	It("Partial dual stack", func() {
+		setDualStack()
+		cluster.SecondaryServiceNetworkCidr = ""
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("requires both")))
+	})
+
+	It("Missing secondary host prefix", func() {
+		setDualStack()
+		cluster.SecondaryClusterNetworkHostPrefix = 0
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("secondary-cluster-network-host-prefix")))
+	})
+
+	It("Secondary machine network without dual stack", func() {
+		cluster.NetworkType = models.ClusterNetworkTypeOVNKubernetes
+		cluster.SecondaryMachineNetworkCidr = "fd00::/64"
+		Expect(VerifyClusterNetworks(cluster)).To(MatchError(ContainSubstring("isn't dual-stack")))
+	})
+})
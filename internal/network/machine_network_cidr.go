@@ -1,6 +1,7 @@
 package network
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -8,7 +9,6 @@ import (
 
 	"github.com/go-openapi/swag"
 
-	"github.com/go-openapi/strfmt"
 	"github.com/pkg/errors"
 
 	"github.com/openshift/assisted-service/internal/common"
@@ -46,8 +46,8 @@ func CalculateMachineNetworkCIDR(apiVip string, ingressVip string, hosts []*mode
 			continue
 		}
 		for _, intf := range inventory.Interfaces {
-			for _, ipv4addr := range intf.IPV4Addresses {
-				_, ipnet, err := net.ParseCIDR(ipv4addr)
+			for _, addr := range interfaceAddresses(intf) {
+				_, ipnet, err := net.ParseCIDR(addr)
 				if err != nil {
 					continue
 				}
@@ -60,6 +60,13 @@ func CalculateMachineNetworkCIDR(apiVip string, ingressVip string, hosts []*mode
 	return "", fmt.Errorf("No suitable matching CIDR found for VIP %s", ip)
 }
 
+// interfaceAddresses returns both the IPv4 and the IPv6 addresses of the interface, in CIDR notation
+func interfaceAddresses(intf *models.Interface) []string {
+	ret := make([]string, 0, len(intf.IPV4Addresses)+len(intf.IPV6Addresses))
+	ret = append(ret, intf.IPV4Addresses...)
+	return append(ret, intf.IPV6Addresses...)
+}
+
 func ipInCidr(ipStr, cidrStr string) bool {
 	ip := net.ParseIP(ipStr)
 	if ip == nil {
@@ -111,10 +118,10 @@ func belongsToNetwork(log logrus.FieldLogger, h *models.Host, machineIpnet *net.
 		return false
 	}
 	for _, intf := range inventory.Interfaces {
-		for _, ipv4addr := range intf.IPV4Addresses {
-			ip, _, err := net.ParseCIDR(ipv4addr)
+		for _, addr := range interfaceAddresses(intf) {
+			ip, _, err := net.ParseCIDR(addr)
 			if err != nil {
-				log.WithError(err).Warnf("Could not parse cidr %s", ipv4addr)
+				log.WithError(err).Warnf("Could not parse cidr %s", addr)
 				continue
 			}
 			if machineIpnet.Contains(ip) {
@@ -142,17 +149,32 @@ func GetMachineCIDRHosts(log logrus.FieldLogger, cluster *common.Cluster) ([]*mo
 	return ret, nil
 }
 
+// IsHostInMachineNetCidr checks the host has an address in the machine network of the cluster.
+// In a dual-stack cluster the host must have an address in the secondary machine network as well.
 func IsHostInMachineNetCidr(log logrus.FieldLogger, cluster *common.Cluster, host *models.Host) bool {
-	_, machineIpnet, err := net.ParseCIDR(cluster.MachineNetworkCidr)
-	if err != nil {
-		return false
+	cidrs := []string{cluster.MachineNetworkCidr}
+	if cluster.SecondaryMachineNetworkCidr != "" {
+		cidrs = append(cidrs, cluster.SecondaryMachineNetworkCidr)
+	}
+	for _, cidr := range cidrs {
+		_, machineIpnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return false
+		}
+		if !belongsToNetwork(log, host, machineIpnet) {
+			return false
+		}
 	}
-	return belongsToNetwork(log, host, machineIpnet)
+	return true
 }
 
-type IPSet map[strfmt.IPv4]struct{}
+// IPSet holds IPv4 and IPv6 addresses in their canonical text form
+type IPSet map[string]struct{}
 
-func (s IPSet) Add(str strfmt.IPv4) {
+func (s IPSet) Add(str string) {
+	if ip := net.ParseIP(str); ip != nil {
+		str = ip.String()
+	}
 	s[str] = struct{}{}
 }
 
@@ -173,10 +195,10 @@ func freeAddressesUnmarshal(network, freeAddressesStr string, prefix *string) (I
 		return nil, err
 	}
 	for _, f := range unmarshaled {
-		if f.Network == network {
+		if sameCidr(f.Network, network) {
 			ret := make(IPSet)
 			for _, a := range f.FreeAddresses {
-				if prefix == nil || strings.HasPrefix(a.String(), *prefix) {
+				if prefix == nil || strings.HasPrefix(a, *prefix) {
 					ret.Add(a)
 				}
 			}
@@ -227,7 +249,34 @@ func IpInFreeList(hosts []*models.Host, vipIPStr, network string, log logrus.Fie
 	isFree := true
 	freeSet := MakeFreeAddressesSet(hosts, network, nil, log)
 	if len(freeSet) > 0 {
-		_, isFree = freeSet[strfmt.IPv4(vipIPStr)]
+		if ip := net.ParseIP(vipIPStr); ip != nil {
+			vipIPStr = ip.String()
+		}
+		_, isFree = freeSet[vipIPStr]
 	}
 	return isFree
 }
+
+// sameCidr compares two CIDRs regardless of their textual representation, e.g. 2001:db8::/64 and 2001:0db8:0::/64
+func sameCidr(cidr1, cidr2 string) bool {
+	_, ipnet1, err1 := net.ParseCIDR(cidr1)
+	_, ipnet2, err2 := net.ParseCIDR(cidr2)
+	if err1 != nil || err2 != nil {
+		return cidr1 == cidr2
+	}
+	return ipnet1.String() == ipnet2.String()
+}
+
+// CompareIPs orders IP addresses numerically, IPv4 addresses before IPv6 ones.
+// Addresses that can't be parsed are ordered first.
+func CompareIPs(ipStr1, ipStr2 string) int {
+	ip1, ip2 := net.ParseIP(ipStr1), net.ParseIP(ipStr2)
+	v4_1, v4_2 := ip1 != nil && ip1.To4() != nil, ip2 != nil && ip2.To4() != nil
+	if v4_1 != v4_2 && ip1 != nil && ip2 != nil {
+		if v4_1 {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(ip1.To16(), ip2.To16())
+}
@@ -23,6 +23,12 @@ var _ = Describe("inventory", func() {
 		}
 	}
 
+	createIPv6Interface := func(ipv6Addresses ...string) *models.Interface {
+		return &models.Interface{
+			IPV6Addresses: append([]string{}, ipv6Addresses...),
+		}
+	}
+
 	createInventory := func(interfaces ...*models.Interface) string {
 		inventory := models.Inventory{Interfaces: interfaces}
 		ret, _ := json.Marshal(&inventory)
@@ -104,6 +110,37 @@ var _ = Describe("inventory", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(cidr).To(Equal("1.2.4.0/23"))
 		})
+		It("IPv6", func() {
+			cluster := createCluster("fd00::10", "",
+				createInventory(createInterface("1.2.5.7/23"), createIPv6Interface("fe80::1/64", "fd00::5/64")))
+			cidr, err := CalculateMachineNetworkCIDR(cluster.APIVip, cluster.IngressVip, cluster.Hosts)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(cidr).To(Equal("fd00::/64"))
+		})
+	})
+	Context("IsHostInMachineNetCidr", func() {
+		It("Single stack", func() {
+			cluster := createCluster("fd00::10", "fd00::/64",
+				createInventory(createIPv6Interface("fd00::5/64")))
+			Expect(IsHostInMachineNetCidr(logrus.New(), cluster, cluster.Hosts[0])).To(BeTrue())
+		})
+		It("Dual stack", func() {
+			cluster := createCluster("1.2.5.6", "1.2.4.0/23",
+				createInventory(createInterface("1.2.5.7/23"), createIPv6Interface("fd00::5/64")),
+				createInventory(createInterface("1.2.5.8/23")))
+			cluster.SecondaryMachineNetworkCidr = "fd00::/64"
+			Expect(IsHostInMachineNetCidr(logrus.New(), cluster, cluster.Hosts[0])).To(BeTrue())
+			Expect(IsHostInMachineNetCidr(logrus.New(), cluster, cluster.Hosts[1])).To(BeFalse())
+		})
+	})
+	Context("CompareIPs", func() {
+		It("Orders IPv4 before IPv6 numerically", func() {
+			Expect(CompareIPs("1.2.3.10", "1.2.3.9")).To(Equal(1))
+			Expect(CompareIPs("1.2.3.9", "1.2.3.9")).To(Equal(0))
+			Expect(CompareIPs("fd00::a", "fd00::10")).To(Equal(-1))
+			Expect(CompareIPs("fd00::1", "10.0.0.1")).To(Equal(1))
+			Expect(CompareIPs("bad", "10.0.0.1")).To(Equal(-1))
+		})
 	})
 	Context("GetMachineCIDRHosts", func() {
 		It("No Machine CIDR", func() {
@@ -183,6 +220,31 @@ var _ = Describe("inventory", func() {
 			err = VerifyVips(cluster.Hosts, cluster.MachineNetworkCidr, cluster.APIVip, cluster.IngressVip, true, log)
 			Expect(err).To(HaveOccurred())
 		})
+		It("IPv6 not free", func() {
+			cluster := createCluster("fd00::6", "fd00::/120",
+				createInventory(createIPv6Interface("fd00::7/120")))
+			cluster.IngressVip = "fd00::8"
+			cluster.Hosts = []*models.Host{
+				{
+					FreeAddresses: "[{\"network\":\"fd00::/120\",\"free_addresses\":[\"fd00::8\",\"fd00::9\"]}]",
+				},
+			}
+			err := VerifyVips(cluster.Hosts, cluster.MachineNetworkCidr, cluster.APIVip, cluster.IngressVip, false, log)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("api-vip <fd00::6> is already in use"))
+		})
+		It("IPv6 free in another notation", func() {
+			cluster := createCluster("fd00:0:0::6", "fd00:0::/120",
+				createInventory(createIPv6Interface("fd00::7/120")))
+			cluster.IngressVip = "fd00::0:8"
+			cluster.Hosts = []*models.Host{
+				{
+					FreeAddresses: "[{\"network\":\"fd00::/120\",\"free_addresses\":[\"fd00:0::6\",\"fd00::8\"]}]",
+				},
+			}
+			err := VerifyVips(cluster.Hosts, cluster.MachineNetworkCidr, cluster.APIVip, cluster.IngressVip, true, log)
+			Expect(err).ToNot(HaveOccurred())
+		})
 		It("Disabled", func() {
 			cluster := createCluster("1.2.5.6", "1.2.4.0/23",
 				createInventory(createInterface("1.2.5.7/23")))
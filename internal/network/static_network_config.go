@@ -0,0 +1,236 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	"github.com/openshift/assisted-service/models"
+	"github.com/pkg/errors"
+)
+
+// The interface name of the bonds, each host has at most one bond so the hosts can share it
+const staticNetworkBondName = "bond0"
+
+// StaticNetworkKeyfile is a NetworkManager keyfile connection profile of a static network configuration
+type StaticNetworkKeyfile struct {
+	// The name of the file in /etc/NetworkManager/system-connections
+	Name     string
+	Contents string
+}
+
+/*
+ * Validate the static network configurations of the hosts, each one on its own and against each other.
+ * An interface may only be configured once and an IP address may only be assigned to one host.
+ */
+func ValidateStaticNetworkConfig(configs []*models.HostStaticNetworkConfig) error {
+	macs := make(map[string]bool)
+	ips := make(map[string]bool)
+	for _, config := range configs {
+		if config == nil {
+			return errors.New("Static network configuration must not be empty")
+		}
+		hostMACs, err := staticNetworkMACs(config)
+		if err != nil {
+			return err
+		}
+		for _, mac := range hostMACs {
+			if macs[mac] {
+				return errors.Errorf("MAC address %s is configured more than once", mac)
+			}
+			macs[mac] = true
+		}
+		if len(config.BondMacAddresses) > 0 && config.BondMode == "" {
+			return errors.Errorf("Bond mode of MAC address %s must be set with its bond MAC addresses", hostMACs[0])
+		}
+
+		ipnet, err := staticNetworkIPNet(config)
+		if err != nil {
+			return err
+		}
+		if ips[ipnet.IP.String()] {
+			return errors.Errorf("IP address %s is assigned to more than one host", ipnet.IP)
+		}
+		ips[ipnet.IP.String()] = true
+
+		if config.Gateway != "" {
+			gateway := net.ParseIP(config.Gateway)
+			if gateway == nil {
+				return errors.Errorf("Could not parse gateway %s", config.Gateway)
+			}
+			if !ipnet.Contains(gateway) || isIPv4(gateway) != isIPv4(ipnet.IP) {
+				network := net.IPNet{IP: ipnet.IP.Mask(ipnet.Mask), Mask: ipnet.Mask}
+				return errors.Errorf("Gateway %s is not on the network %s of IP address %s", gateway, &network, ipnet.IP)
+			}
+			if gateway.Equal(ipnet.IP) {
+				return errors.Errorf("Gateway %s must not be the IP address of the host", gateway)
+			}
+		}
+		for _, server := range config.DNSServers {
+			ip := net.ParseIP(server)
+			if ip == nil {
+				return errors.Errorf("Could not parse DNS server %s", server)
+			}
+			if isIPv4(ip) != isIPv4(ipnet.IP) {
+				return errors.Errorf("DNS server %s is not of the IP family of IP address %s", ip, ipnet.IP)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+ * Generate the NetworkManager keyfiles of validated static network configurations. The connection profiles
+ * match the interfaces by their MAC addresses, so the keyfiles of all the hosts can be shipped to every host.
+ */
+func GenerateStaticNetworkKeyfiles(configs []*models.HostStaticNetworkConfig) []StaticNetworkKeyfile {
+	var keyfiles []StaticNetworkKeyfile
+	for _, config := range configs {
+		macs, _ := staticNetworkMACs(config)
+		ipnet, _ := staticNetworkIPNet(config)
+		id := fmt.Sprintf("static-%s", strings.ReplaceAll(macs[0], ":", ""))
+
+		// The connection that the IP address is configured on, or the parent of its VLAN
+		var parent *keyfile
+		if config.BondMode != "" {
+			parent = newKeyfile(id+"-bond", "bond")
+			parent.set("connection", "interface-name", staticNetworkBondName)
+			// The bond is activated by its ports, the bonds of the other hosts have none
+			parent.set("connection", "autoconnect", "false")
+			parent.set("connection", "autoconnect-slaves", "1")
+			parent.set("bond", "mode", config.BondMode)
+			for _, mac := range macs {
+				port := newKeyfile(fmt.Sprintf("%s-port-%s", id, strings.ReplaceAll(mac, ":", "")), "ethernet")
+				port.set("connection", "master", parent.uuid)
+				port.set("connection", "slave-type", "bond")
+				port.set("ethernet", "mac-address", mac)
+				keyfiles = append(keyfiles, port.render())
+			}
+		} else {
+			parent = newKeyfile(id, "ethernet")
+			parent.set("ethernet", "mac-address", macs[0])
+		}
+
+		addressed := parent
+		if config.VlanID != 0 {
+			parent.disableIP()
+			addressed = newKeyfile(fmt.Sprintf("%s-vlan%d", id, config.VlanID), "vlan")
+			addressed.set("vlan", "id", fmt.Sprint(config.VlanID))
+			addressed.set("vlan", "parent", parent.uuid)
+			keyfiles = append(keyfiles, parent.render())
+		}
+		addressed.setIP(ipnet, config.Gateway, config.DNSServers)
+		keyfiles = append(keyfiles, addressed.render())
+	}
+	sort.Slice(keyfiles, func(i, j int) bool { return keyfiles[i].Name < keyfiles[j].Name })
+	return keyfiles
+}
+
+// staticNetworkMACs returns the normalized MAC addresses of the host, the MAC address of the config first
+func staticNetworkMACs(config *models.HostStaticNetworkConfig) ([]string, error) {
+	var macs []string
+	for _, mac := range append([]string{swag.StringValue(config.MacAddress)}, config.BondMacAddresses...) {
+		hw, err := net.ParseMAC(mac)
+		if err != nil || len(hw) != 6 {
+			return nil, errors.Errorf("Could not parse MAC address %s", mac)
+		}
+		macs = append(macs, hw.String())
+	}
+	return macs, nil
+}
+
+// staticNetworkIPNet returns the IP address of the host on its network
+func staticNetworkIPNet(config *models.HostStaticNetworkConfig) (*net.IPNet, error) {
+	ip := net.ParseIP(swag.StringValue(config.IPAddress))
+	if ip == nil {
+		return nil, errors.Errorf("Could not parse IP address %s", swag.StringValue(config.IPAddress))
+	}
+	bits := net.IPv6len * 8
+	if isIPv4(ip) {
+		ip = ip.To4()
+		bits = net.IPv4len * 8
+	}
+	prefixLength := int(swag.Int64Value(config.PrefixLength))
+	if prefixLength < 1 || prefixLength > bits {
+		return nil, errors.Errorf("Prefix length %d of IP address %s is out of range", prefixLength, ip)
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(prefixLength, bits)}, nil
+}
+
+func isIPv4(ip net.IP) bool {
+	return ip.To4() != nil
+}
+
+// keyfile is a connection profile in the NetworkManager keyfile format
+type keyfile struct {
+	id       string
+	uuid     string
+	sections map[string][][2]string
+}
+
+// The sections of a connection profile in the order they are written
+var keyfileSections = []string{"connection", "ethernet", "bond", "vlan", "ipv4", "ipv6"}
+
+func newKeyfile(id, connectionType string) *keyfile {
+	k := &keyfile{
+		id: id,
+		// The UUIDs are derived from the IDs, so the profiles can refer to each other and are the same in every image
+		uuid:     uuid.NewSHA1(uuid.NameSpaceOID, []byte("assisted-service/static-network/"+id)).String(),
+		sections: make(map[string][][2]string),
+	}
+	k.set("connection", "id", id)
+	k.set("connection", "uuid", k.uuid)
+	k.set("connection", "type", connectionType)
+	return k
+}
+
+func (k *keyfile) set(section, key, value string) {
+	k.sections[section] = append(k.sections[section], [2]string{key, value})
+}
+
+func (k *keyfile) disableIP() {
+	k.set("ipv4", "method", "disabled")
+	k.set("ipv6", "method", "ignore")
+}
+
+func (k *keyfile) setIP(ipnet *net.IPNet, gateway string, dnsServers []string) {
+	family, other := "ipv4", "ipv6"
+	if !isIPv4(ipnet.IP) {
+		family, other = "ipv6", "ipv4"
+	}
+	prefixLength, _ := ipnet.Mask.Size()
+	k.set(family, "method", "manual")
+	k.set(family, "address1", fmt.Sprintf("%s/%d", ipnet.IP, prefixLength))
+	if gateway != "" {
+		k.set(family, "gateway", gateway)
+	}
+	if len(dnsServers) > 0 {
+		k.set(family, "dns", strings.Join(dnsServers, ";")+";")
+	}
+	if other == "ipv4" {
+		k.set(other, "method", "disabled")
+	} else {
+		k.set(other, "method", "ignore")
+	}
+}
+
+func (k *keyfile) render() StaticNetworkKeyfile {
+	var sb strings.Builder
+	for _, section := range keyfileSections {
+		entries, ok := k.sections[section]
+		if !ok {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "[%s]\n", section)
+		for _, entry := range entries {
+			fmt.Fprintf(&sb, "%s=%s\n", entry[0], entry[1])
+		}
+	}
+	return StaticNetworkKeyfile{Name: k.id + ".nmconnection", Contents: sb.String()}
+}
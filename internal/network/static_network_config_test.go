@@ -0,0 +1,177 @@
+package network
+
+import (
+	"github.com/go-openapi/swag"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/models"
+)
+
+var _ = Describe("static network config", func() {
+	config := func(mac, ip string, prefixLength int64) *models.HostStaticNetworkConfig {
+		return &models.HostStaticNetworkConfig{
+			MacAddress:   swag.String(mac),
+			IPAddress:    swag.String(ip),
+			PrefixLength: swag.Int64(prefixLength),
+		}
+	}
+
+	Context("validation", func() {
+		It("accepts the configs of several hosts", func() {
+			first := config("52:54:00:aa:bb:01", "10.0.0.11", 24)
+			first.Gateway = "10.0.0.1"
+			first.DNSServers = []string{"10.0.0.2", "10.0.0.3"}
+			second := config("52:54:00:aa:bb:02", "10.0.0.12", 24)
+			second.VlanID = 100
+			second.BondMode = models.HostStaticNetworkConfigBondModeActiveBackup
+			second.BondMacAddresses = []string{"52:54:00:aa:bb:03"}
+			third := config("52:54:00:aa:bb:04", "2001:db8::12", 64)
+			third.Gateway = "2001:db8::1"
+			Expect(ValidateStaticNetworkConfig([]*models.HostStaticNetworkConfig{first, second, third})).ShouldNot(HaveOccurred())
+		})
+
+		It("accepts no configs", func() {
+			Expect(ValidateStaticNetworkConfig(nil)).ShouldNot(HaveOccurred())
+		})
+
+		tests := []struct {
+			name    string
+			configs func() []*models.HostStaticNetworkConfig
+			err     string
+		}{
+			{
+				name: "duplicate MAC address",
+				configs: func() []*models.HostStaticNetworkConfig {
+					return []*models.HostStaticNetworkConfig{config("52:54:00:aa:bb:01", "10.0.0.11", 24),
+						config("52:54:00:AA:BB:01", "10.0.0.12", 24)}
+				},
+				err: "MAC address 52:54:00:aa:bb:01 is configured more than once",
+			},
+			{
+				name: "MAC address of another bond",
+				configs: func() []*models.HostStaticNetworkConfig {
+					bonded := config("52:54:00:aa:bb:01", "10.0.0.11", 24)
+					bonded.BondMode = models.HostStaticNetworkConfigBondModeNr8023ad
+					bonded.BondMacAddresses = []string{"52:54:00:aa:bb:02"}
+					return []*models.HostStaticNetworkConfig{bonded, config("52:54:00:aa:bb:02", "10.0.0.12", 24)}
+				},
+				err: "MAC address 52:54:00:aa:bb:02 is configured more than once",
+			},
+			{
+				name: "duplicate IP address",
+				configs: func() []*models.HostStaticNetworkConfig {
+					return []*models.HostStaticNetworkConfig{config("52:54:00:aa:bb:01", "2001:db8::12", 64),
+						config("52:54:00:aa:bb:02", "2001:db8:0::12", 64)}
+				},
+				err: "IP address 2001:db8::12 is assigned to more than one host",
+			},
+			{
+				name: "invalid IP address",
+				configs: func() []*models.HostStaticNetworkConfig {
+					return []*models.HostStaticNetworkConfig{config("52:54:00:aa:bb:01", "10.0.0.300", 24)}
+				},
+				err: "Could not parse IP address 10.0.0.300",
+			},
+			{
+				name: "prefix length out of range",
+				configs: func() []*models.HostStaticNetworkConfig {
+					return []*models.HostStaticNetworkConfig{config("52:54:00:aa:bb:01", "10.0.0.11", 33)}
+				},
+				err: "Prefix length 33 of IP address 10.0.0.11 is out of range",
+			},
+			{
+				name: "gateway on another network",
+				configs: func() []*models.HostStaticNetworkConfig {
+					c := config("52:54:00:aa:bb:01", "10.0.0.11", 24)
+					c.Gateway = "10.0.1.1"
+					return []*models.HostStaticNetworkConfig{c}
+				},
+				err: "Gateway 10.0.1.1 is not on the network 10.0.0.0/24 of IP address 10.0.0.11",
+			},
+			{
+				name: "DNS server of another IP family",
+				configs: func() []*models.HostStaticNetworkConfig {
+					c := config("52:54:00:aa:bb:01", "10.0.0.11", 24)
+					c.DNSServers = []string{"2001:db8::53"}
+					return []*models.HostStaticNetworkConfig{c}
+				},
+				err: "DNS server 2001:db8::53 is not of the IP family of IP address 10.0.0.11",
+			},
+			{
+				name: "bond MAC addresses without a bond mode",
+				configs: func() []*models.HostStaticNetworkConfig {
+					c := config("52:54:00:aa:bb:01", "10.0.0.11", 24)
+					c.BondMacAddresses = []string{"52:54:00:aa:bb:02"}
+					return []*models.HostStaticNetworkConfig{c}
+				},
+				err: "Bond mode of MAC address 52:54:00:aa:bb:01 must be set with its bond MAC addresses",
+			},
+		}
+
+		for i := range tests {
+			t := tests[i]
+			It(t.name, func() {
+				Expect(ValidateStaticNetworkConfig(t.configs())).Should(MatchError(t.err))
+			})
+		}
+	})
+
+	Context("keyfiles", func() {
+		It("configures the address on the interface", func() {
+			c := config("52:54:00:AA:BB:01", "10.0.0.11", 24)
+			c.Gateway = "10.0.0.1"
+			c.DNSServers = []string{"10.0.0.2", "10.0.0.3"}
+			keyfiles := GenerateStaticNetworkKeyfiles([]*models.HostStaticNetworkConfig{c})
+			Expect(keyfiles).Should(HaveLen(1))
+			Expect(keyfiles[0].Name).Should(Equal("static-525400aabb01.nmconnection"))
+			Expect(keyfiles[0].Contents).Should(Equal(`[connection]
+id=static-525400aabb01
+uuid=` + newKeyfile("static-525400aabb01", "ethernet").uuid + `
+type=ethernet
+
+[ethernet]
+mac-address=52:54:00:aa:bb:01
+
+[ipv4]
+method=manual
+address1=10.0.0.11/24
+gateway=10.0.0.1
+dns=10.0.0.2;10.0.0.3;
+
+[ipv6]
+method=ignore
+`))
+		})
+
+		It("configures an IPv6 address", func() {
+			keyfiles := GenerateStaticNetworkKeyfiles([]*models.HostStaticNetworkConfig{config("52:54:00:aa:bb:01", "2001:db8::12", 64)})
+			Expect(keyfiles).Should(HaveLen(1))
+			Expect(keyfiles[0].Contents).Should(ContainSubstring("[ipv4]\nmethod=disabled\n"))
+			Expect(keyfiles[0].Contents).Should(ContainSubstring("[ipv6]\nmethod=manual\naddress1=2001:db8::12/64\n"))
+		})
+
+		It("configures the address on a VLAN of a bond", func() {
+			c := config("52:54:00:aa:bb:01", "10.0.0.11", 24)
+			c.VlanID = 100
+			c.BondMode = models.HostStaticNetworkConfigBondModeActiveBackup
+			c.BondMacAddresses = []string{"52:54:00:aa:bb:02"}
+			keyfiles := GenerateStaticNetworkKeyfiles([]*models.HostStaticNetworkConfig{c})
+			bondUUID := newKeyfile("static-525400aabb01-bond", "bond").uuid
+
+			Expect(keyfiles).Should(HaveLen(4))
+			Expect(keyfiles[0].Name).Should(Equal("static-525400aabb01-bond.nmconnection"))
+			Expect(keyfiles[0].Contents).Should(ContainSubstring("interface-name=bond0\nautoconnect=false\n"))
+			Expect(keyfiles[0].Contents).Should(ContainSubstring("[bond]\nmode=active-backup\n"))
+			Expect(keyfiles[0].Contents).Should(ContainSubstring("[ipv4]\nmethod=disabled\n"))
+			for i, mac := range []string{"52:54:00:aa:bb:01", "52:54:00:aa:bb:02"} {
+				port := keyfiles[i+1]
+				Expect(port.Contents).Should(ContainSubstring("master=" + bondUUID + "\nslave-type=bond\n"))
+				Expect(port.Contents).Should(ContainSubstring("mac-address=" + mac + "\n"))
+				Expect(port.Contents).ShouldNot(ContainSubstring("[ipv4]"))
+			}
+			Expect(keyfiles[3].Name).Should(Equal("static-525400aabb01-vlan100.nmconnection"))
+			Expect(keyfiles[3].Contents).Should(ContainSubstring("[vlan]\nid=100\nparent=" + bondUUID + "\n"))
+			Expect(keyfiles[3].Contents).Should(ContainSubstring("address1=10.0.0.11/24\n"))
+		})
+	})
+})
@@ -2,13 +2,21 @@ package versions
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/openshift/assisted-service/models"
 	"github.com/openshift/assisted-service/restapi"
 	operations "github.com/openshift/assisted-service/restapi/operations/versions"
+	"github.com/pkg/errors"
 )
 
+// DefaultOpenshiftVersion is the version the service installs when no versions file is configured
+const DefaultOpenshiftVersion = "4.5"
+
 type Versions struct {
 	SelfVersion         string `envconfig:"SELF_VERSION" default:"quay.io/ocpmetal/installer-image-build:latest"`
 	ImageBuilder        string `envconfig:"IMAGE_BUILDER" default:"quay.io/ocpmetal/installer-image-build:latest"`
@@ -17,16 +25,72 @@ type Versions struct {
 	InstallerImage      string `envconfig:"INSTALLER_IMAGE" default:"quay.io/ocpmetal/assisted-installer:latest"`
 	ControllerImage     string `envconfig:"CONTROLLER_IMAGE" default:"quay.io/ocpmetal/assisted-installer-controller:latest"`
 	ReleaseTag          string `envconfig:"RELEASE_TAG" default:""`
+	//[TODO] -  change the default of Releae image to "", once everyine wll update their environment
+	ReleaseImage          string `envconfig:"OPENSHIFT_INSTALL_RELEASE_IMAGE" default:"quay.io/openshift-release-dev/ocp-release@sha256:eab93b4591699a5a4ff50ad3517892653f04fb840127895bb3609b3cc68f98f3"`
+	OpenshiftVersionsFile string `envconfig:"OPENSHIFT_VERSIONS_FILE" default:""` // JSON catalog of the supported versions, the hardware profiles set their minimums
+}
+
+//go:generate mockgen -source=versions.go -package=versions -destination=mock_versions.go
+type Handler interface {
+	restapi.VersionsAPI
+	// GetOpenshiftVersion returns the catalog entry of the version, or an error if the service doesn't support it
+	GetOpenshiftVersion(openshiftVersion string) (*models.OpenshiftVersion, error)
+}
+
+// LoadOpenshiftVersions reads the catalog of the supported OpenShift versions from a JSON file,
+// an empty path means that only the default version is supported
+func LoadOpenshiftVersions(path string) (models.OpenshiftVersions, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read OpenShift versions file %s", path)
+	}
+	var openshiftVersions models.OpenshiftVersions
+	if err = json.Unmarshal(content, &openshiftVersions); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse OpenShift versions file %s", path)
+	}
+	if len(openshiftVersions) == 0 {
+		return nil, errors.Errorf("OpenShift versions file %s has no versions", path)
+	}
+	if err = openshiftVersions.Validate(strfmt.Default); err != nil {
+		return nil, errors.Wrapf(err, "invalid OpenShift versions file %s", path)
+	}
+	return openshiftVersions, nil
 }
 
-func NewHandler(versions Versions) *handler {
-	return &handler{versions: versions}
+func NewHandler(versions Versions, openshiftVersions models.OpenshiftVersions) *handler {
+	if len(openshiftVersions) == 0 {
+		openshiftVersions = models.OpenshiftVersions{
+			DefaultOpenshiftVersion: models.OpenshiftVersion{
+				DisplayName:  swag.String(DefaultOpenshiftVersion),
+				ReleaseImage: swag.String(versions.ReleaseImage),
+			},
+		}
+	}
+	// Versions that don't specify their own images are installed with the service wide ones
+	catalog := make(models.OpenshiftVersions, len(openshiftVersions))
+	for key, version := range openshiftVersions {
+		if version.InstallerImage == "" {
+			version.InstallerImage = versions.InstallerImage
+		}
+		if version.ControllerImage == "" {
+			version.ControllerImage = versions.ControllerImage
+		}
+		if version.KubeconfigGeneratorImage == "" {
+			version.KubeconfigGeneratorImage = versions.KubeconfigGenerator
+		}
+		catalog[key] = version
+	}
+	return &handler{versions: versions, openshiftVersions: catalog}
 }
 
-var _ restapi.VersionsAPI = (*handler)(nil)
+var _ Handler = (*handler)(nil)
 
 type handler struct {
-	versions Versions
+	versions          Versions
+	openshiftVersions models.OpenshiftVersions
 }
 
 func (h *handler) ListComponentVersions(ctx context.Context, params operations.ListComponentVersionsParams) middleware.Responder {
@@ -43,3 +107,15 @@ func (h *handler) ListComponentVersions(ctx context.Context, params operations.L
 			ReleaseTag: h.versions.ReleaseTag,
 		})
 }
+
+func (h *handler) ListSupportedOpenshiftVersions(ctx context.Context, params operations.ListSupportedOpenshiftVersionsParams) middleware.Responder {
+	return operations.NewListSupportedOpenshiftVersionsOK().WithPayload(h.openshiftVersions)
+}
+
+func (h *handler) GetOpenshiftVersion(openshiftVersion string) (*models.OpenshiftVersion, error) {
+	version, ok := h.openshiftVersions[openshiftVersion]
+	if !ok {
+		return nil, errors.Errorf("OpenShift version %s is not supported", openshiftVersion)
+	}
+	return &version, nil
+}
@@ -2,13 +2,16 @@ package versions
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/kelseyhightower/envconfig"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/models"
 	operations "github.com/openshift/assisted-service/restapi/operations/versions"
 )
 
@@ -24,7 +27,7 @@ var _ = Describe("list versions", func() {
 	)
 	It("default values", func() {
 		Expect(envconfig.Process("test", &versions)).ShouldNot(HaveOccurred())
-		h = NewHandler(versions)
+		h = NewHandler(versions, nil)
 		reply := h.ListComponentVersions(context.Background(), operations.ListComponentVersionsParams{})
 		Expect(reply).Should(BeAssignableToTypeOf(operations.NewListComponentVersionsOK()))
 		val, _ := reply.(*operations.ListComponentVersionsOK)
@@ -45,7 +48,7 @@ var _ = Describe("list versions", func() {
 		os.Setenv("INSTALLER_IMAGE", "installer-image")
 		os.Setenv("CONTROLLER_IMAGE", "controller-image")
 		Expect(envconfig.Process("test", &versions)).ShouldNot(HaveOccurred())
-		h = NewHandler(versions)
+		h = NewHandler(versions, nil)
 		reply := h.ListComponentVersions(context.Background(), operations.ListComponentVersionsParams{})
 		Expect(reply).Should(BeAssignableToTypeOf(operations.NewListComponentVersionsOK()))
 		val, _ := reply.(*operations.ListComponentVersionsOK)
@@ -59,3 +62,95 @@ var _ = Describe("list versions", func() {
 		Expect(val.Payload.ReleaseTag).Should(Equal(""))
 	})
 })
+
+var _ = Describe("openshift versions", func() {
+	var (
+		versions Versions
+		dir      string
+	)
+
+	BeforeEach(func() {
+		var err error
+		Expect(envconfig.Process("test", &versions)).ShouldNot(HaveOccurred())
+		dir, err = ioutil.TempDir("", "versions")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	writeVersionsFile := func(content string) string {
+		path := filepath.Join(dir, "openshift-versions.json")
+		Expect(ioutil.WriteFile(path, []byte(content), 0600)).ShouldNot(HaveOccurred())
+		return path
+	}
+
+	It("default version", func() {
+		h := NewHandler(versions, nil)
+		reply := h.ListSupportedOpenshiftVersions(context.Background(), operations.ListSupportedOpenshiftVersionsParams{})
+		Expect(reply).Should(BeAssignableToTypeOf(operations.NewListSupportedOpenshiftVersionsOK()))
+		val, _ := reply.(*operations.ListSupportedOpenshiftVersionsOK)
+		Expect(val.Payload).Should(HaveLen(1))
+		Expect(*val.Payload[DefaultOpenshiftVersion].ReleaseImage).Should(Equal(versions.ReleaseImage))
+
+		version, err := h.GetOpenshiftVersion(DefaultOpenshiftVersion)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(version.InstallerImage).Should(Equal(versions.InstallerImage))
+		Expect(version.ControllerImage).Should(Equal(versions.ControllerImage))
+		Expect(version.KubeconfigGeneratorImage).Should(Equal(versions.KubeconfigGenerator))
+	})
+
+	It("versions from file", func() {
+		openshiftVersions, err := LoadOpenshiftVersions(writeVersionsFile(`{
+			"4.5": {"display_name": "4.5.13", "release_image": "quay.io/openshift-release-dev/ocp-release:4.5.13-x86_64"},
+			"4.6": {"display_name": "4.6.1", "release_image": "quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64",
+				"installer_image": "quay.io/ocpmetal/assisted-installer:4.6", "rhcos_image": "rhcos-4.6.iso"}
+		}`))
+		Expect(err).ShouldNot(HaveOccurred())
+		h := NewHandler(versions, openshiftVersions)
+
+		version, err := h.GetOpenshiftVersion("4.6")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*version.ReleaseImage).Should(Equal("quay.io/openshift-release-dev/ocp-release:4.6.1-x86_64"))
+		Expect(version.InstallerImage).Should(Equal("quay.io/ocpmetal/assisted-installer:4.6"))
+		Expect(version.ControllerImage).Should(Equal(versions.ControllerImage))
+		Expect(version.RhcosImage).Should(Equal("rhcos-4.6.iso"))
+
+		version, err = h.GetOpenshiftVersion("4.5")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(version.InstallerImage).Should(Equal(versions.InstallerImage))
+
+		_, err = h.GetOpenshiftVersion("4.4")
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("returned versions can't modify the catalog", func() {
+		h := NewHandler(versions, models.OpenshiftVersions{})
+		version, err := h.GetOpenshiftVersion(DefaultOpenshiftVersion)
+		Expect(err).ShouldNot(HaveOccurred())
+		version.InstallerImage = "changed"
+		version, err = h.GetOpenshiftVersion(DefaultOpenshiftVersion)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(version.InstallerImage).Should(Equal(versions.InstallerImage))
+	})
+
+	It("no versions file", func() {
+		openshiftVersions, err := LoadOpenshiftVersions("")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(openshiftVersions).Should(BeEmpty())
+	})
+
+	It("invalid versions files", func() {
+		for _, content := range []string{
+			`not json`,
+			`{}`,
+			`{"4.6": {"display_name": "4.6.1"}}`,
+		} {
+			_, err := LoadOpenshiftVersions(writeVersionsFile(content))
+			Expect(err).Should(HaveOccurred(), content)
+		}
+		_, err := LoadOpenshiftVersions(filepath.Join(dir, "missing.json"))
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -21,18 +21,21 @@ import (
 type Cluster struct {
 
 	// Virtual IP used to reach the OpenShift cluster API.
-	// Pattern: ^(([0-9]{1,3}\.){3}[0-9]{1,3})?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$
 	APIVip string `json:"api_vip,omitempty"`
 
+	// PEM-encoded X.509 certificate bundle. The discovered hosts and the installed cluster trust these certificates in addition to the ones of the system, e.g. of a TLS-intercepting proxy or of a mirror registry.
+	AdditionalTrustBundle string `json:"additional_trust_bundle,omitempty" gorm:"type:TEXT"`
+
 	// Base domain of the cluster. All DNS records must be sub-domains of this base and include the cluster name.
 	BaseDNSDomain string `json:"base_dns_domain,omitempty"`
 
 	// IP address block from which Pod IPs are allocated This block must not overlap with existing physical networks. These IP addresses are used for the Pod network, and if you need to access the Pods from an external network, configure load balancers and routers to manage the traffic.
-	// Pattern: ^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
 	ClusterNetworkCidr string `json:"cluster_network_cidr,omitempty"`
 
-	// The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.
-	// Maximum: 32
+	// The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. For IPv6 cluster networks the prefix length may be up to 128. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.
+	// Maximum: 128
 	// Minimum: 1
 	ClusterNetworkHostPrefix int64 `json:"cluster_network_host_prefix,omitempty"`
 
@@ -40,6 +43,15 @@ type Cluster struct {
 	// Format: date-time
 	CreatedAt strfmt.DateTime `json:"created_at,omitempty" gorm:"type:timestamp with time zone"`
 
+	// A proxy URL to use for creating HTTP connections outside the cluster. http://\<username\>:\<pswd\>@\<ip\>:\<port\>
+	HTTPProxy string `json:"http_proxy,omitempty"`
+
+	// A proxy URL to use for creating HTTPS connections outside the cluster. http://\<username\>:\<pswd\>@\<ip\>:\<port\>
+	HTTPSProxy string `json:"https_proxy,omitempty"`
+
+	// The hardware profile that the hosts of the cluster are validated against. When empty, the profile of the OpenShift version or the default profile of the service is used.
+	HardwareProfile string `json:"hardware_profile,omitempty"`
+
 	// List of host networks to be filled during query.
 	HostNetworks []*HostNetwork `json:"host_networks" gorm:"-"`
 
@@ -63,13 +75,16 @@ type Cluster struct {
 	ImageInfo *ImageInfo `json:"image_info" gorm:"embedded;embedded_prefix:image_"`
 
 	// Virtual IP used for cluster ingress traffic.
-	// Pattern: ^(([0-9]{1,3}\.){3}[0-9]{1,3})?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$
 	IngressVip string `json:"ingress_vip,omitempty"`
 
 	// The time that this cluster completed installation.
 	// Format: date-time
 	InstallCompletedAt strfmt.DateTime `json:"install_completed_at,omitempty" gorm:"type:timestamp with time zone;default:'2000-01-01 00:00:00z'"`
 
+	// JSON encoded partial install config that is merged over the generated one.
+	InstallConfigOverrides string `json:"install_config_overrides,omitempty" gorm:"type:text"`
+
 	// The time that this cluster began installation.
 	// Format: date-time
 	InstallStartedAt strfmt.DateTime `json:"install_started_at,omitempty" gorm:"type:timestamp with time zone;default:'2000-01-01 00:00:00z'"`
@@ -80,24 +95,54 @@ type Cluster struct {
 	Kind *string `json:"kind"`
 
 	// A CIDR that all hosts belonging to the cluster should have an interfaces with IP address that belongs to this CIDR. The api_vip belongs to this CIDR.
-	// Pattern: ^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
 	MachineNetworkCidr string `json:"machine_network_cidr,omitempty"`
 
+	// JSON encoded mirror-registries-config, the mirror registries that the images are pulled from instead of their source registries.
+	MirrorRegistriesConfig string `json:"mirror_registries_config,omitempty" gorm:"type:text"`
+
 	// Name of the OpenShift cluster.
 	Name string `json:"name,omitempty"`
 
-	// Version of the OpenShift cluster.
-	// Enum: [4.5]
+	// The cluster network provider. OpenShiftSDN supports only IPv4, IPv6 and dual-stack networks require OVNKubernetes.
+	// Enum: [OpenShiftSDN OVNKubernetes]
+	NetworkType string `json:"network_type,omitempty" gorm:"default:'OpenShiftSDN'"`
+
+	// A comma-separated list of destination domain names, domains, IP addresses or other network CIDRs to exclude proxying.
+	NoProxy string `json:"no_proxy,omitempty"`
+
+	// Version of the OpenShift cluster, one of the versions the service supports.
 	OpenshiftVersion string `json:"openshift_version,omitempty"`
 
 	// org id
 	OrgID string `json:"org_id,omitempty"`
 
+	// Platform of the cluster, the hosts are provisioned by the baremetal operator or by nothing.
+	// Enum: [baremetal none]
+	Platform string `json:"platform,omitempty" gorm:"default:'baremetal'"`
+
 	// True if the pull-secret has been added to the cluster
 	PullSecretSet bool `json:"pull_secret_set,omitempty"`
 
+	// An additional IP address block from which Pod IPs are allocated in a dual-stack cluster. Must be of the other IP family than cluster_network_cidr.
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
+	SecondaryClusterNetworkCidr string `json:"secondary_cluster_network_cidr,omitempty"`
+
+	// The subnet prefix length to assign to each individual node out of secondary_cluster_network_cidr.
+	// Maximum: 128
+	// Minimum: 1
+	SecondaryClusterNetworkHostPrefix int64 `json:"secondary_cluster_network_host_prefix,omitempty"`
+
+	// An additional CIDR that the hosts of a dual-stack cluster have addresses in. Must be of the other IP family than machine_network_cidr.
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
+	SecondaryMachineNetworkCidr string `json:"secondary_machine_network_cidr,omitempty"`
+
+	// An additional IP address pool to use for service IP addresses in a dual-stack cluster. Must be of the other IP family than service_network_cidr.
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
+	SecondaryServiceNetworkCidr string `json:"secondary_service_network_cidr,omitempty"`
+
 	// The IP address pool to use for service IP addresses. You can enter only one IP address pool. If you need to access the services from an external network, configure load balancers and routers to manage the traffic.
-	// Pattern: ^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
 	ServiceNetworkCidr string `json:"service_network_cidr,omitempty"`
 
 	// SSH public key for debugging OpenShift nodes.
@@ -122,6 +167,9 @@ type Cluster struct {
 
 	// user id
 	UserID string `json:"user_id,omitempty"`
+
+	// Json formatted string containing the validations results for each validation id grouped by category (pull-secret, etc.)
+	ValidationsInfo string `json:"validations_info,omitempty" gorm:"type:text"`
 }
 
 // Validate validates this cluster
@@ -184,7 +232,27 @@ func (m *Cluster) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
-	if err := m.validateOpenshiftVersion(formats); err != nil {
+	if err := m.validateNetworkType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePlatform(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryClusterNetworkCidr(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryClusterNetworkHostPrefix(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryMachineNetworkCidr(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryServiceNetworkCidr(formats); err != nil {
 		res = append(res, err)
 	}
 
@@ -220,7 +288,7 @@ func (m *Cluster) validateAPIVip(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if err := validate.Pattern("api_vip", "body", string(m.APIVip), `^(([0-9]{1,3}\.){3}[0-9]{1,3})?$`); err != nil {
+	if err := validate.Pattern("api_vip", "body", string(m.APIVip), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$`); err != nil {
 		return err
 	}
 
@@ -233,7 +301,7 @@ func (m *Cluster) validateClusterNetworkCidr(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if err := validate.Pattern("cluster_network_cidr", "body", string(m.ClusterNetworkCidr), `^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$`); err != nil {
+	if err := validate.Pattern("cluster_network_cidr", "body", string(m.ClusterNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -250,7 +318,7 @@ func (m *Cluster) validateClusterNetworkHostPrefix(formats strfmt.Registry) erro
 		return err
 	}
 
-	if err := validate.MaximumInt("cluster_network_host_prefix", "body", int64(m.ClusterNetworkHostPrefix), 32, false); err != nil {
+	if err := validate.MaximumInt("cluster_network_host_prefix", "body", int64(m.ClusterNetworkHostPrefix), 128, false); err != nil {
 		return err
 	}
 
@@ -366,7 +434,7 @@ func (m *Cluster) validateIngressVip(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if err := validate.Pattern("ingress_vip", "body", string(m.IngressVip), `^(([0-9]{1,3}\.){3}[0-9]{1,3})?$`); err != nil {
+	if err := validate.Pattern("ingress_vip", "body", string(m.IngressVip), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$`); err != nil {
 		return err
 	}
 
@@ -445,47 +513,149 @@ func (m *Cluster) validateMachineNetworkCidr(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if err := validate.Pattern("machine_network_cidr", "body", string(m.MachineNetworkCidr), `^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$`); err != nil {
+	if err := validate.Pattern("machine_network_cidr", "body", string(m.MachineNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-var clusterTypeOpenshiftVersionPropEnum []interface{}
+var clusterTypeNetworkTypePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["4.5"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["OpenShiftSDN","OVNKubernetes"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
-		clusterTypeOpenshiftVersionPropEnum = append(clusterTypeOpenshiftVersionPropEnum, v)
+		clusterTypeNetworkTypePropEnum = append(clusterTypeNetworkTypePropEnum, v)
 	}
 }
 
 const (
 
-	// ClusterOpenshiftVersionNr45 captures enum value "4.5"
-	ClusterOpenshiftVersionNr45 string = "4.5"
+	// ClusterNetworkTypeOpenShiftSDN captures enum value "OpenShiftSDN"
+	ClusterNetworkTypeOpenShiftSDN string = "OpenShiftSDN"
+
+	// ClusterNetworkTypeOVNKubernetes captures enum value "OVNKubernetes"
+	ClusterNetworkTypeOVNKubernetes string = "OVNKubernetes"
 )
 
 // prop value enum
-func (m *Cluster) validateOpenshiftVersionEnum(path, location string, value string) error {
-	if err := validate.EnumCase(path, location, value, clusterTypeOpenshiftVersionPropEnum, true); err != nil {
+func (m *Cluster) validateNetworkTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, clusterTypeNetworkTypePropEnum, true); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (m *Cluster) validateOpenshiftVersion(formats strfmt.Registry) error {
+func (m *Cluster) validateNetworkType(formats strfmt.Registry) error {
 
-	if swag.IsZero(m.OpenshiftVersion) { // not required
+	if swag.IsZero(m.NetworkType) { // not required
 		return nil
 	}
 
 	// value enum
-	if err := m.validateOpenshiftVersionEnum("openshift_version", "body", m.OpenshiftVersion); err != nil {
+	if err := m.validateNetworkTypeEnum("network_type", "body", m.NetworkType); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var clusterTypePlatformPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["baremetal","none"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		clusterTypePlatformPropEnum = append(clusterTypePlatformPropEnum, v)
+	}
+}
+
+const (
+
+	// ClusterPlatformBaremetal captures enum value "baremetal"
+	ClusterPlatformBaremetal string = "baremetal"
+
+	// ClusterPlatformNone captures enum value "none"
+	ClusterPlatformNone string = "none"
+)
+
+// prop value enum
+func (m *Cluster) validatePlatformEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, clusterTypePlatformPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Cluster) validatePlatform(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Platform) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validatePlatformEnum("platform", "body", m.Platform); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Cluster) validateSecondaryClusterNetworkCidr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryClusterNetworkCidr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("secondary_cluster_network_cidr", "body", string(m.SecondaryClusterNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Cluster) validateSecondaryClusterNetworkHostPrefix(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryClusterNetworkHostPrefix) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("secondary_cluster_network_host_prefix", "body", int64(m.SecondaryClusterNetworkHostPrefix), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("secondary_cluster_network_host_prefix", "body", int64(m.SecondaryClusterNetworkHostPrefix), 128, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Cluster) validateSecondaryMachineNetworkCidr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryMachineNetworkCidr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("secondary_machine_network_cidr", "body", string(m.SecondaryMachineNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Cluster) validateSecondaryServiceNetworkCidr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryServiceNetworkCidr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("secondary_service_network_cidr", "body", string(m.SecondaryServiceNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -498,7 +668,7 @@ func (m *Cluster) validateServiceNetworkCidr(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if err := validate.Pattern("service_network_cidr", "body", string(m.ServiceNetworkCidr), `^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$`); err != nil {
+	if err := validate.Pattern("service_network_cidr", "body", string(m.ServiceNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -19,36 +19,74 @@ import (
 // swagger:model cluster-create-params
 type ClusterCreateParams struct {
 
+	// PEM-encoded X.509 certificate bundle. The discovered hosts and the installed cluster trust these certificates in addition to the ones of the system, e.g. of a TLS-intercepting proxy or of a mirror registry.
+	AdditionalTrustBundle string `json:"additional_trust_bundle,omitempty"`
+
 	// Base domain of the cluster. All DNS records must be sub-domains of this base and include the cluster name.
 	BaseDNSDomain string `json:"base_dns_domain,omitempty"`
 
 	// IP address block from which Pod IPs are allocated This block must not overlap with existing physical networks. These IP addresses are used for the Pod network, and if you need to access the Pods from an external network, configure load balancers and routers to manage the traffic.
-	// Pattern: ^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
 	ClusterNetworkCidr *string `json:"cluster_network_cidr,omitempty"`
 
-	// The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.
-	// Maximum: 32
+	// The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. For IPv6 cluster networks the prefix length may be up to 128. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.
+	// Maximum: 128
 	// Minimum: 1
 	ClusterNetworkHostPrefix int64 `json:"cluster_network_host_prefix,omitempty"`
 
+	// A proxy URL to use for creating HTTP connections outside the cluster. http://\<username\>:\<pswd\>@\<ip\>:\<port\>
+	HTTPProxy string `json:"http_proxy,omitempty"`
+
+	// A proxy URL to use for creating HTTPS connections outside the cluster. http://\<username\>:\<pswd\>@\<ip\>:\<port\>
+	HTTPSProxy string `json:"https_proxy,omitempty"`
+
+	// The hardware profile that the hosts of the cluster are validated against. When empty, the profile of the OpenShift version or the default profile of the service is used.
+	HardwareProfile string `json:"hardware_profile,omitempty"`
+
 	// Virtual IP used for cluster ingress traffic.
-	// Pattern: ^(([0-9]{1,3}\.){3}[0-9]{1,3})?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$
 	IngressVip string `json:"ingress_vip,omitempty"`
 
+	// The mirror registries that the images are pulled from instead of their source registries, for disconnected installations.
+	MirrorRegistries *MirrorRegistriesConfig `json:"mirror_registries,omitempty"`
+
 	// Name of the OpenShift cluster.
 	// Required: true
 	Name *string `json:"name"`
 
-	// Version of the OpenShift cluster.
+	// The cluster network provider. OpenShiftSDN supports only IPv4, IPv6 and dual-stack networks require OVNKubernetes.
+	// Enum: [OpenShiftSDN OVNKubernetes]
+	NetworkType *string `json:"network_type,omitempty"`
+
+	// A comma-separated list of destination domain names, domains, IP addresses or other network CIDRs to exclude proxying.
+	NoProxy string `json:"no_proxy,omitempty"`
+
+	// Version of the OpenShift cluster, one of the versions the service supports.
 	// Required: true
-	// Enum: [4.5]
 	OpenshiftVersion *string `json:"openshift_version"`
 
+	// Platform of the cluster, the hosts are provisioned by the baremetal operator or by nothing.
+	// Enum: [baremetal none]
+	Platform *string `json:"platform,omitempty"`
+
 	// The pull secret that obtained from the Pull Secret page on the Red Hat OpenShift Cluster Manager site.
 	PullSecret string `json:"pull_secret,omitempty"`
 
+	// An additional IP address block from which Pod IPs are allocated in a dual-stack cluster. Must be of the other IP family than cluster_network_cidr.
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
+	SecondaryClusterNetworkCidr *string `json:"secondary_cluster_network_cidr,omitempty"`
+
+	// The subnet prefix length to assign to each individual node out of secondary_cluster_network_cidr.
+	// Maximum: 128
+	// Minimum: 1
+	SecondaryClusterNetworkHostPrefix *int64 `json:"secondary_cluster_network_host_prefix,omitempty"`
+
+	// An additional IP address pool to use for service IP addresses in a dual-stack cluster. Must be of the other IP family than service_network_cidr.
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
+	SecondaryServiceNetworkCidr *string `json:"secondary_service_network_cidr,omitempty"`
+
 	// The IP address pool to use for service IP addresses. You can enter only one IP address pool. If you need to access the services from an external network, configure load balancers and routers to manage the traffic.
-	// Pattern: ^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
 	ServiceNetworkCidr *string `json:"service_network_cidr,omitempty"`
 
 	// SSH public key for debugging OpenShift nodes.
@@ -71,14 +109,38 @@ func (m *ClusterCreateParams) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateMirrorRegistries(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateName(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateNetworkType(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateOpenshiftVersion(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validatePlatform(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryClusterNetworkCidr(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryClusterNetworkHostPrefix(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryServiceNetworkCidr(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateServiceNetworkCidr(formats); err != nil {
 		res = append(res, err)
 	}
@@ -95,7 +157,7 @@ func (m *ClusterCreateParams) validateClusterNetworkCidr(formats strfmt.Registry
 		return nil
 	}
 
-	if err := validate.Pattern("cluster_network_cidr", "body", string(*m.ClusterNetworkCidr), `^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$`); err != nil {
+	if err := validate.Pattern("cluster_network_cidr", "body", string(*m.ClusterNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -112,7 +174,7 @@ func (m *ClusterCreateParams) validateClusterNetworkHostPrefix(formats strfmt.Re
 		return err
 	}
 
-	if err := validate.MaximumInt("cluster_network_host_prefix", "body", int64(m.ClusterNetworkHostPrefix), 32, false); err != nil {
+	if err := validate.MaximumInt("cluster_network_host_prefix", "body", int64(m.ClusterNetworkHostPrefix), 128, false); err != nil {
 		return err
 	}
 
@@ -125,13 +187,31 @@ func (m *ClusterCreateParams) validateIngressVip(formats strfmt.Registry) error
 		return nil
 	}
 
-	if err := validate.Pattern("ingress_vip", "body", string(m.IngressVip), `^(([0-9]{1,3}\.){3}[0-9]{1,3})?$`); err != nil {
+	if err := validate.Pattern("ingress_vip", "body", string(m.IngressVip), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$`); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (m *ClusterCreateParams) validateMirrorRegistries(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.MirrorRegistries) { // not required
+		return nil
+	}
+
+	if m.MirrorRegistries != nil {
+		if err := m.MirrorRegistries.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("mirror_registries")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *ClusterCreateParams) validateName(formats strfmt.Registry) error {
 
 	if err := validate.Required("name", "body", m.Name); err != nil {
@@ -141,29 +221,46 @@ func (m *ClusterCreateParams) validateName(formats strfmt.Registry) error {
 	return nil
 }
 
-var clusterCreateParamsTypeOpenshiftVersionPropEnum []interface{}
+var clusterCreateParamsTypeNetworkTypePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["4.5"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["OpenShiftSDN","OVNKubernetes"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
-		clusterCreateParamsTypeOpenshiftVersionPropEnum = append(clusterCreateParamsTypeOpenshiftVersionPropEnum, v)
+		clusterCreateParamsTypeNetworkTypePropEnum = append(clusterCreateParamsTypeNetworkTypePropEnum, v)
 	}
 }
 
 const (
 
-	// ClusterCreateParamsOpenshiftVersionNr45 captures enum value "4.5"
-	ClusterCreateParamsOpenshiftVersionNr45 string = "4.5"
+	// ClusterCreateParamsNetworkTypeOpenShiftSDN captures enum value "OpenShiftSDN"
+	ClusterCreateParamsNetworkTypeOpenShiftSDN string = "OpenShiftSDN"
+
+	// ClusterCreateParamsNetworkTypeOVNKubernetes captures enum value "OVNKubernetes"
+	ClusterCreateParamsNetworkTypeOVNKubernetes string = "OVNKubernetes"
 )
 
 // prop value enum
-func (m *ClusterCreateParams) validateOpenshiftVersionEnum(path, location string, value string) error {
-	if err := validate.EnumCase(path, location, value, clusterCreateParamsTypeOpenshiftVersionPropEnum, true); err != nil {
+func (m *ClusterCreateParams) validateNetworkTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, clusterCreateParamsTypeNetworkTypePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ClusterCreateParams) validateNetworkType(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NetworkType) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateNetworkTypeEnum("network_type", "body", *m.NetworkType); err != nil {
 		return err
 	}
+
 	return nil
 }
 
@@ -173,8 +270,89 @@ func (m *ClusterCreateParams) validateOpenshiftVersion(formats strfmt.Registry)
 		return err
 	}
 
+	return nil
+}
+
+var clusterCreateParamsTypePlatformPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["baremetal","none"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		clusterCreateParamsTypePlatformPropEnum = append(clusterCreateParamsTypePlatformPropEnum, v)
+	}
+}
+
+const (
+
+	// ClusterCreateParamsPlatformBaremetal captures enum value "baremetal"
+	ClusterCreateParamsPlatformBaremetal string = "baremetal"
+
+	// ClusterCreateParamsPlatformNone captures enum value "none"
+	ClusterCreateParamsPlatformNone string = "none"
+)
+
+// prop value enum
+func (m *ClusterCreateParams) validatePlatformEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, clusterCreateParamsTypePlatformPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ClusterCreateParams) validatePlatform(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Platform) { // not required
+		return nil
+	}
+
 	// value enum
-	if err := m.validateOpenshiftVersionEnum("openshift_version", "body", *m.OpenshiftVersion); err != nil {
+	if err := m.validatePlatformEnum("platform", "body", *m.Platform); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ClusterCreateParams) validateSecondaryClusterNetworkCidr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryClusterNetworkCidr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("secondary_cluster_network_cidr", "body", string(*m.SecondaryClusterNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ClusterCreateParams) validateSecondaryClusterNetworkHostPrefix(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryClusterNetworkHostPrefix) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("secondary_cluster_network_host_prefix", "body", int64(*m.SecondaryClusterNetworkHostPrefix), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("secondary_cluster_network_host_prefix", "body", int64(*m.SecondaryClusterNetworkHostPrefix), 128, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ClusterCreateParams) validateSecondaryServiceNetworkCidr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryServiceNetworkCidr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("secondary_service_network_cidr", "body", string(*m.SecondaryServiceNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -187,7 +365,7 @@ func (m *ClusterCreateParams) validateServiceNetworkCidr(formats strfmt.Registry
 		return nil
 	}
 
-	if err := validate.Pattern("service_network_cidr", "body", string(*m.ServiceNetworkCidr), `^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$`); err != nil {
+	if err := validate.Pattern("service_network_cidr", "body", string(*m.ServiceNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -6,6 +6,7 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
 	"strconv"
 
 	"github.com/go-openapi/errors"
@@ -20,21 +21,36 @@ import (
 type ClusterUpdateParams struct {
 
 	// Virtual IP used to reach the OpenShift cluster API.
-	// Pattern: ^(([0-9]{1,3}\.){3}[0-9]{1,3})?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$
 	APIVip *string `json:"api_vip,omitempty"`
 
+	// PEM-encoded X.509 certificate bundle. The discovered hosts and the installed cluster trust these certificates in addition to the ones of the system, e.g. of a TLS-intercepting proxy or of a mirror registry.
+	AdditionalTrustBundle *string `json:"additional_trust_bundle,omitempty"`
+
 	// Base domain of the cluster. All DNS records must be sub-domains of this base and include the cluster name.
 	BaseDNSDomain *string `json:"base_dns_domain,omitempty"`
 
 	// IP address block from which Pod IPs are allocated This block must not overlap with existing physical networks. These IP addresses are used for the Pod network, and if you need to access the Pods from an external network, configure load balancers and routers to manage the traffic.
-	// Pattern: ^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
 	ClusterNetworkCidr *string `json:"cluster_network_cidr,omitempty"`
 
-	// The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.
-	// Maximum: 32
+	// The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. For IPv6 cluster networks the prefix length may be up to 128. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.
+	// Maximum: 128
 	// Minimum: 1
 	ClusterNetworkHostPrefix *int64 `json:"cluster_network_host_prefix,omitempty"`
 
+	// A proxy URL to use for creating HTTP connections outside the cluster. http://\<username\>:\<pswd\>@\<ip\>:\<port\>
+	HTTPProxy *string `json:"http_proxy,omitempty"`
+
+	// A proxy URL to use for creating HTTPS connections outside the cluster. http://\<username\>:\<pswd\>@\<ip\>:\<port\>
+	HTTPSProxy *string `json:"https_proxy,omitempty"`
+
+	// The hardware profile that the hosts of the cluster are validated against. When empty, the profile of the OpenShift version or the default profile of the service is used.
+	HardwareProfile *string `json:"hardware_profile,omitempty"`
+
+	// The desired installation disk for hosts associated with the cluster.
+	HostsInstallationDisks []*ClusterUpdateParamsHostsInstallationDisksItems0 `json:"hosts_installation_disks" gorm:"type:varchar(64)[]"`
+
 	// The desired hostname for hosts associated with the cluster.
 	HostsNames []*ClusterUpdateParamsHostsNamesItems0 `json:"hosts_names" gorm:"type:varchar(64)[]"`
 
@@ -42,17 +58,48 @@ type ClusterUpdateParams struct {
 	HostsRoles []*ClusterUpdateParamsHostsRolesItems0 `json:"hosts_roles" gorm:"type:varchar(64)[]"`
 
 	// Virtual IP used for cluster ingress traffic.
-	// Pattern: ^(([0-9]{1,3}\.){3}[0-9]{1,3})?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$
 	IngressVip *string `json:"ingress_vip,omitempty"`
 
+	// The mirror registries that the images are pulled from instead of their source registries, for disconnected installations.
+	MirrorRegistries *MirrorRegistriesConfig `json:"mirror_registries,omitempty"`
+
 	// OpenShift cluster name
 	Name *string `json:"name,omitempty"`
 
+	// The cluster network provider. OpenShiftSDN supports only IPv4, IPv6 and dual-stack networks require OVNKubernetes.
+	// Enum: [OpenShiftSDN OVNKubernetes]
+	NetworkType *string `json:"network_type,omitempty"`
+
+	// A comma-separated list of destination domain names, domains, IP addresses or other network CIDRs to exclude proxying.
+	NoProxy *string `json:"no_proxy,omitempty"`
+
+	// Platform of the cluster, the hosts are provisioned by the baremetal operator or by nothing.
+	// Enum: [baremetal none]
+	Platform *string `json:"platform,omitempty"`
+
 	// The pull secret that obtained from the Pull Secret page on the Red Hat OpenShift Cluster Manager site.
 	PullSecret *string `json:"pull_secret,omitempty"`
 
+	// An additional IP address block from which Pod IPs are allocated in a dual-stack cluster. Must be of the other IP family than cluster_network_cidr.
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
+	SecondaryClusterNetworkCidr *string `json:"secondary_cluster_network_cidr,omitempty"`
+
+	// The subnet prefix length to assign to each individual node out of secondary_cluster_network_cidr.
+	// Maximum: 128
+	// Minimum: 1
+	SecondaryClusterNetworkHostPrefix *int64 `json:"secondary_cluster_network_host_prefix,omitempty"`
+
+	// An additional CIDR that the hosts of a dual-stack cluster have addresses in. Must be of the other IP family than machine_network_cidr.
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
+	SecondaryMachineNetworkCidr *string `json:"secondary_machine_network_cidr,omitempty"`
+
+	// An additional IP address pool to use for service IP addresses in a dual-stack cluster. Must be of the other IP family than service_network_cidr.
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
+	SecondaryServiceNetworkCidr *string `json:"secondary_service_network_cidr,omitempty"`
+
 	// The IP address pool to use for service IP addresses. You can enter only one IP address pool. If you need to access the services from an external network, configure load balancers and routers to manage the traffic.
-	// Pattern: ^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
 	ServiceNetworkCidr *string `json:"service_network_cidr,omitempty"`
 
 	// SSH public key for debugging OpenShift nodes.
@@ -75,6 +122,10 @@ func (m *ClusterUpdateParams) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateHostsInstallationDisks(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateHostsNames(formats); err != nil {
 		res = append(res, err)
 	}
@@ -87,6 +138,34 @@ func (m *ClusterUpdateParams) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateMirrorRegistries(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNetworkType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePlatform(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryClusterNetworkCidr(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryClusterNetworkHostPrefix(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryMachineNetworkCidr(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSecondaryServiceNetworkCidr(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateServiceNetworkCidr(formats); err != nil {
 		res = append(res, err)
 	}
@@ -103,7 +182,7 @@ func (m *ClusterUpdateParams) validateAPIVip(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if err := validate.Pattern("api_vip", "body", string(*m.APIVip), `^(([0-9]{1,3}\.){3}[0-9]{1,3})?$`); err != nil {
+	if err := validate.Pattern("api_vip", "body", string(*m.APIVip), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$`); err != nil {
 		return err
 	}
 
@@ -116,7 +195,7 @@ func (m *ClusterUpdateParams) validateClusterNetworkCidr(formats strfmt.Registry
 		return nil
 	}
 
-	if err := validate.Pattern("cluster_network_cidr", "body", string(*m.ClusterNetworkCidr), `^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$`); err != nil {
+	if err := validate.Pattern("cluster_network_cidr", "body", string(*m.ClusterNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -140,6 +219,31 @@ func (m *ClusterUpdateParams) validateClusterNetworkHostPrefix(formats strfmt.Re
 	return nil
 }
 
+func (m *ClusterUpdateParams) validateHostsInstallationDisks(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.HostsInstallationDisks) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.HostsInstallationDisks); i++ {
+		if swag.IsZero(m.HostsInstallationDisks[i]) { // not required
+			continue
+		}
+
+		if m.HostsInstallationDisks[i] != nil {
+			if err := m.HostsInstallationDisks[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("hosts_installation_disks" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *ClusterUpdateParams) validateHostsNames(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.HostsNames) { // not required
@@ -196,7 +300,167 @@ func (m *ClusterUpdateParams) validateIngressVip(formats strfmt.Registry) error
 		return nil
 	}
 
-	if err := validate.Pattern("ingress_vip", "body", string(*m.IngressVip), `^(([0-9]{1,3}\.){3}[0-9]{1,3})?$`); err != nil {
+	if err := validate.Pattern("ingress_vip", "body", string(*m.IngressVip), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ClusterUpdateParams) validateMirrorRegistries(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.MirrorRegistries) { // not required
+		return nil
+	}
+
+	if m.MirrorRegistries != nil {
+		if err := m.MirrorRegistries.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("mirror_registries")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+var clusterUpdateParamsTypeNetworkTypePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["OpenShiftSDN","OVNKubernetes"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		clusterUpdateParamsTypeNetworkTypePropEnum = append(clusterUpdateParamsTypeNetworkTypePropEnum, v)
+	}
+}
+
+const (
+
+	// ClusterUpdateParamsNetworkTypeOpenShiftSDN captures enum value "OpenShiftSDN"
+	ClusterUpdateParamsNetworkTypeOpenShiftSDN string = "OpenShiftSDN"
+
+	// ClusterUpdateParamsNetworkTypeOVNKubernetes captures enum value "OVNKubernetes"
+	ClusterUpdateParamsNetworkTypeOVNKubernetes string = "OVNKubernetes"
+)
+
+// prop value enum
+func (m *ClusterUpdateParams) validateNetworkTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, clusterUpdateParamsTypeNetworkTypePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ClusterUpdateParams) validateNetworkType(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NetworkType) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateNetworkTypeEnum("network_type", "body", *m.NetworkType); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var clusterUpdateParamsTypePlatformPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["baremetal","none"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		clusterUpdateParamsTypePlatformPropEnum = append(clusterUpdateParamsTypePlatformPropEnum, v)
+	}
+}
+
+const (
+
+	// ClusterUpdateParamsPlatformBaremetal captures enum value "baremetal"
+	ClusterUpdateParamsPlatformBaremetal string = "baremetal"
+
+	// ClusterUpdateParamsPlatformNone captures enum value "none"
+	ClusterUpdateParamsPlatformNone string = "none"
+)
+
+// prop value enum
+func (m *ClusterUpdateParams) validatePlatformEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, clusterUpdateParamsTypePlatformPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ClusterUpdateParams) validatePlatform(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Platform) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validatePlatformEnum("platform", "body", *m.Platform); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ClusterUpdateParams) validateSecondaryClusterNetworkCidr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryClusterNetworkCidr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("secondary_cluster_network_cidr", "body", string(*m.SecondaryClusterNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ClusterUpdateParams) validateSecondaryClusterNetworkHostPrefix(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryClusterNetworkHostPrefix) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("secondary_cluster_network_host_prefix", "body", int64(*m.SecondaryClusterNetworkHostPrefix), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("secondary_cluster_network_host_prefix", "body", int64(*m.SecondaryClusterNetworkHostPrefix), 128, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ClusterUpdateParams) validateSecondaryMachineNetworkCidr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryMachineNetworkCidr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("secondary_machine_network_cidr", "body", string(*m.SecondaryMachineNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ClusterUpdateParams) validateSecondaryServiceNetworkCidr(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SecondaryServiceNetworkCidr) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("secondary_service_network_cidr", "body", string(*m.SecondaryServiceNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -209,7 +473,7 @@ func (m *ClusterUpdateParams) validateServiceNetworkCidr(formats strfmt.Registry
 		return nil
 	}
 
-	if err := validate.Pattern("service_network_cidr", "body", string(*m.ServiceNetworkCidr), `^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$`); err != nil {
+	if err := validate.Pattern("service_network_cidr", "body", string(*m.ServiceNetworkCidr), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -234,6 +498,64 @@ func (m *ClusterUpdateParams) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// ClusterUpdateParamsHostsInstallationDisksItems0 cluster update params hosts installation disks items0
+//
+// swagger:model ClusterUpdateParamsHostsInstallationDisksItems0
+type ClusterUpdateParamsHostsInstallationDisksItems0 struct {
+
+	// id
+	// Format: uuid
+	ID strfmt.UUID `json:"id,omitempty"`
+
+	// The by-path, WWN or serial number of a valid disk of the host, empty to let the service choose the disk.
+	InstallationDiskID string `json:"installation_disk_id,omitempty"`
+}
+
+// Validate validates this cluster update params hosts installation disks items0
+func (m *ClusterUpdateParamsHostsInstallationDisksItems0) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ClusterUpdateParamsHostsInstallationDisksItems0) validateID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ID) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("id", "body", "uuid", m.ID.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ClusterUpdateParamsHostsInstallationDisksItems0) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ClusterUpdateParamsHostsInstallationDisksItems0) UnmarshalBinary(b []byte) error {
+	var res ClusterUpdateParamsHostsInstallationDisksItems0
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
 // ClusterUpdateParamsHostsNamesItems0 cluster update params hosts names items0
 //
 // swagger:model ClusterUpdateParamsHostsNamesItems0
@@ -21,18 +21,39 @@ type Disk struct {
 	// drive type
 	DriveType string `json:"drive_type,omitempty"`
 
+	// The disk or one of its partitions is an LVM physical volume.
+	HasLvm bool `json:"has_lvm,omitempty"`
+
+	// The disk has existing partitions.
+	HasPartitions bool `json:"has_partitions,omitempty"`
+
 	// hctl
 	Hctl string `json:"hctl,omitempty"`
 
+	// installation eligibility
+	InstallationEligibility *DiskInstallationEligibility `json:"installation_eligibility,omitempty"`
+
+	// The disk is the medium that the host booted the discovery image from, e.g. a USB drive.
+	IsInstallationMedia bool `json:"is_installation_media,omitempty"`
+
 	// model
 	Model string `json:"model,omitempty"`
 
+	// The disk is one of the paths of a multipath device.
+	MultipathMember bool `json:"multipath_member,omitempty"`
+
 	// name
 	Name string `json:"name,omitempty"`
 
 	// path
 	Path string `json:"path,omitempty"`
 
+	// read only
+	ReadOnly bool `json:"read_only,omitempty"`
+
+	// The disk is removable, e.g. a USB drive or an SD card.
+	Removable bool `json:"removable,omitempty"`
+
 	// serial
 	Serial string `json:"serial,omitempty"`
 
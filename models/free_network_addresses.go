@@ -20,10 +20,10 @@ import (
 type FreeNetworkAddresses struct {
 
 	// free addresses
-	FreeAddresses []strfmt.IPv4 `json:"free_addresses"`
+	FreeAddresses []string `json:"free_addresses"`
 
 	// network
-	// Pattern: ^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$
+	// Pattern: ^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$
 	Network string `json:"network,omitempty"`
 }
 
@@ -53,7 +53,7 @@ func (m *FreeNetworkAddresses) validateFreeAddresses(formats strfmt.Registry) er
 
 	for i := 0; i < len(m.FreeAddresses); i++ {
 
-		if err := validate.FormatOf("free_addresses"+"."+strconv.Itoa(i), "body", "ipv4", m.FreeAddresses[i].String(), formats); err != nil {
+		if err := validate.Pattern("free_addresses"+"."+strconv.Itoa(i), "body", string(m.FreeAddresses[i]), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))$`); err != nil {
 			return err
 		}
 
@@ -68,7 +68,7 @@ func (m *FreeNetworkAddresses) validateNetwork(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if err := validate.Pattern("network", "body", string(m.Network), `^([0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]|[1-2][0-9]|3[0-2]?$`); err != nil {
+	if err := validate.Pattern("network", "body", string(m.Network), `^(?:(?:(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$`); err != nil {
 		return err
 	}
 
@@ -53,6 +53,9 @@ type Host struct {
 	// Format: uuid
 	ID *strfmt.UUID `json:"id" gorm:"primary_key"`
 
+	// The by-path, WWN or serial number of the disk that the host is installed on, selected by the user. When empty, the service chooses the disk.
+	InstallationDiskID string `json:"installation_disk_id,omitempty"`
+
 	// Installer version
 	InstallerVersion string `json:"installer_version,omitempty"`
 
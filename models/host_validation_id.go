@@ -55,6 +55,24 @@ const (
 
 	// HostValidationIDBelongsToMachineCidr captures enum value "belongs-to-machine-cidr"
 	HostValidationIDBelongsToMachineCidr HostValidationID = "belongs-to-machine-cidr"
+
+	// HostValidationIDBelongsToMajorityGroup captures enum value "belongs-to-majority-group"
+	HostValidationIDBelongsToMajorityGroup HostValidationID = "belongs-to-majority-group"
+
+	// HostValidationIDHasRequiredCPUFlags captures enum value "has-required-cpu-flags"
+	HostValidationIDHasRequiredCPUFlags HostValidationID = "has-required-cpu-flags"
+
+	// HostValidationIDHasMinNicSpeed captures enum value "has-min-nic-speed"
+	HostValidationIDHasMinNicSpeed HostValidationID = "has-min-nic-speed"
+
+	// HostValidationIDHasRequiredDisks captures enum value "has-required-disks"
+	HostValidationIDHasRequiredDisks HostValidationID = "has-required-disks"
+
+	// HostValidationIDHasAllowedVendor captures enum value "has-allowed-vendor"
+	HostValidationIDHasAllowedVendor HostValidationID = "has-allowed-vendor"
+
+	// HostValidationIDValidInstallationDisk captures enum value "valid-installation-disk"
+	HostValidationIDValidInstallationDisk HostValidationID = "valid-installation-disk"
 )
 
 // for schema
@@ -62,7 +80,7 @@ var hostValidationIdEnum []interface{}
 
 func init() {
 	var res []HostValidationID
-	if err := json.Unmarshal([]byte(`["connected","has-inventory","has-min-cpu-cores","has-min-valid-disks","has-min-memory","machine-cidr-defined","role-defined","has-cpu-cores-for-role","has-memory-for-role","hostname-unique","hostname-valid","belongs-to-machine-cidr"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["connected","has-inventory","has-min-cpu-cores","has-min-valid-disks","has-min-memory","machine-cidr-defined","role-defined","has-cpu-cores-for-role","has-memory-for-role","hostname-unique","hostname-valid","belongs-to-machine-cidr","belongs-to-majority-group","has-required-cpu-flags","has-min-nic-speed","has-required-disks","has-allowed-vendor","valid-installation-disk"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -6,6 +6,9 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -22,10 +25,47 @@ type ImageCreateParams struct {
 
 	// SSH public key for debugging the installation.
 	SSHPublicKey string `json:"ssh_public_key,omitempty"`
+
+	// Static network configurations of the hosts that have no DHCP on the machine network.
+	StaticNetworkConfig []*HostStaticNetworkConfig `json:"static_network_config"`
 }
 
 // Validate validates this image create params
 func (m *ImageCreateParams) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateStaticNetworkConfig(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ImageCreateParams) validateStaticNetworkConfig(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.StaticNetworkConfig) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.StaticNetworkConfig); i++ {
+		if swag.IsZero(m.StaticNetworkConfig[i]) { // not required
+			continue
+		}
+
+		if m.StaticNetworkConfig[i] != nil {
+			if err := m.StaticNetworkConfig[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("static_network_config" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -6,6 +6,7 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -21,6 +22,9 @@ type ImageInfo struct {
 	// Format: date-time
 	CreatedAt strfmt.DateTime `json:"created_at,omitempty" gorm:"type:timestamp with time zone"`
 
+	// The reason the generation of the image failed.
+	ErrorMessage string `json:"error_message,omitempty"`
+
 	// Image generator version
 	GeneratorVersion string `json:"generator_version,omitempty"`
 
@@ -31,6 +35,10 @@ type ImageInfo struct {
 
 	// SSH public key for debugging the installation
 	SSHPublicKey string `json:"ssh_public_key,omitempty" gorm:"type:varchar(1024)"`
+
+	// The state of the generation of the image.
+	// Enum: [pending building ready failed]
+	State string `json:"state,omitempty"`
 }
 
 // Validate validates this image info
@@ -41,6 +49,10 @@ func (m *ImageInfo) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateState(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -60,6 +72,55 @@ func (m *ImageInfo) validateCreatedAt(formats strfmt.Registry) error {
 	return nil
 }
 
+var imageInfoTypeStatePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["pending","building","ready","failed"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		imageInfoTypeStatePropEnum = append(imageInfoTypeStatePropEnum, v)
+	}
+}
+
+const (
+
+	// ImageInfoStatePending captures enum value "pending"
+	ImageInfoStatePending string = "pending"
+
+	// ImageInfoStateBuilding captures enum value "building"
+	ImageInfoStateBuilding string = "building"
+
+	// ImageInfoStateReady captures enum value "ready"
+	ImageInfoStateReady string = "ready"
+
+	// ImageInfoStateFailed captures enum value "failed"
+	ImageInfoStateFailed string = "failed"
+)
+
+// prop value enum
+func (m *ImageInfo) validateStateEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, imageInfoTypeStatePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ImageInfo) validateState(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.State) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateStateEnum("state", "body", m.State); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *ImageInfo) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const AgentRole = "agent"
+
+// AgentTokenHeader is the header the discovery agent passes its cluster token in
+const AgentTokenHeader = "X-Agent-Token"
+
+const agentTokenBytes = 32
+
+// agentOperations are the operations called by the discovery agent running on the hosts, they can only be
+// called with the agent token of the cluster in the request path
+var agentOperations = map[string]bool{
+	"RegisterHost":              true,
+	"GetNextSteps":              true,
+	"PostStepReply":             true,
+	"UpdateHostInstallProgress": true,
+}
+
+// installerOperations are the operations called by the installer running on the hosts and by the controller
+// running on the installed cluster, they are called either by users or with the agent token of the cluster in
+// the request path
+var installerOperations = map[string]bool{
+	"GetCluster":               true,
+	"ListHosts":                true,
+	"DownloadClusterFiles":     true,
+	"UploadClusterIngressCert": true,
+	"CompleteInstallation":     true,
+}
+
+func IsAgentOperation(operationID string) bool {
+	return agentOperations[operationID]
+}
+
+func IsInstallerOperation(operationID string) bool {
+	return installerOperations[operationID]
+}
+
+// GenerateAgentToken returns a new random agent token along with the hash to store for verifying it
+func GenerateAgentToken() (string, string, error) {
+	buf := make([]byte, agentTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", errors.Wrap(err, "failed to generate agent token")
+	}
+	token := hex.EncodeToString(buf)
+	return token, HashAgentToken(token), nil
+}
+
+func HashAgentToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type agentAuthenticator struct {
+	log      logrus.FieldLogger
+	clusters ClusterStore
+}
+
+// NewAgentAuthenticator returns the authenticator for the agent operations, it accepts only the token that
+// was issued for the cluster in the request path
+func NewAgentAuthenticator(log logrus.FieldLogger, cfg Config, clusters ClusterStore) Authenticator {
+	if !cfg.EnableAuth {
+		return &noneAuthenticator{}
+	}
+	return &agentAuthenticator{log: log, clusters: clusters}
+}
+
+func (a *agentAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token := r.Header.Get(AgentTokenHeader)
+	route := middleware.MatchedRouteFrom(r)
+	if route == nil {
+		return nil, errors.New("agent token is only valid for agent operations")
+	}
+	clusterID, _, _ := route.Params.GetOK("cluster_id")
+	if len(clusterID) != 1 || clusterID[0] == "" {
+		return nil, errors.New("agent token is only valid for cluster operations")
+	}
+
+	cluster, err := a.clusters.GetCluster(clusterID[0])
+	if err != nil {
+		return nil, err
+	}
+	if cluster != nil && cluster.AgentTokenHash == "" {
+		// The image of the cluster was generated before the agent tokens were issued
+		return nil, errors.Errorf("cluster %s has no agent token, regenerate its discovery image", clusterID[0])
+	}
+	if token == "" {
+		return nil, ErrUnauthenticated
+	}
+	if cluster == nil ||
+		subtle.ConstantTimeCompare([]byte(cluster.AgentTokenHash), []byte(HashAgentToken(token))) != 1 {
+		return nil, errors.New("invalid agent token")
+	}
+	return &Identity{UserID: cluster.UserID, OrgID: cluster.OrgID, Role: AgentRole}, nil
+}
@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/restapi"
+	"github.com/openshift/assisted-service/restapi/operations/installer"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+const basePath = "/api/assisted-install/v1"
+
+var _ = Describe("agent token", func() {
+	It("is random and verified by its hash", func() {
+		token1, hash1, err := GenerateAgentToken()
+		Expect(err).ShouldNot(HaveOccurred())
+		token2, hash2, err := GenerateAgentToken()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(token1).ShouldNot(Equal(token2))
+		Expect(hash1).ShouldNot(Equal(hash2))
+		Expect(HashAgentToken(token1)).Should(Equal(hash1))
+		Expect(hash1).ShouldNot(ContainSubstring(token1))
+	})
+})
+
+var _ = Describe("agent authentication", func() {
+	var (
+		ctrl      *gomock.Controller
+		clusters  map[string]*common.Cluster
+		handler   http.Handler
+		mockAPI   *restapi.MockInstallerAPI
+		userKey   *testKey
+		clusterA  strfmt.UUID
+		clusterB  strfmt.UUID
+		tokenA    string
+		tokenB    string
+		jwksFile  string
+		seenRoles []string
+	)
+
+	createCluster := func(userID, orgID string) (strfmt.UUID, string) {
+		id := strfmt.UUID(uuid.New().String())
+		token, hash, err := GenerateAgentToken()
+		Expect(err).ShouldNot(HaveOccurred())
+		clusters[id.String()] = &common.Cluster{
+			Cluster:        models.Cluster{ID: &id, UserID: userID, OrgID: orgID},
+			AgentTokenHash: hash,
+		}
+		return id, token
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clusters = make(map[string]*common.Cluster)
+		mockClusters := NewMockClusterStore(ctrl)
+		mockClusters.EXPECT().GetCluster(gomock.Any()).DoAndReturn(func(clusterID string) (*common.Cluster, error) {
+			return clusters[clusterID], nil
+		}).AnyTimes()
+		clusterA, tokenA = createCluster("user1", "org1")
+		clusterB, tokenB = createCluster("user2", "org2")
+
+		userKey = newRSAKey("key1")
+		f, err := ioutil.TempFile("", "jwks")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = f.Write(jwks(userKey))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(f.Close()).ShouldNot(HaveOccurred())
+		jwksFile = f.Name()
+		cfg := Config{EnableAuth: true, JwksFile: jwksFile, Issuer: testIssuer,
+			UserIDClaim: "sub", OrgIDClaim: "org_id", RoleClaim: "role"}
+		users, err := NewAuthenticator(logrus.New(), cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		agents := NewAgentAuthenticator(logrus.New(), cfg, mockClusters)
+
+		seenRoles = nil
+		record := func(args mock.Arguments) {
+			params := args.Get(1)
+			var r *http.Request
+			switch p := params.(type) {
+			case installer.GetNextStepsParams:
+				r = p.HTTPRequest
+			case installer.ListClustersParams:
+				r = p.HTTPRequest
+			case installer.GetClusterParams:
+				r = p.HTTPRequest
+			}
+			seenRoles = append(seenRoles, UserRoleFromContext(r.Context()))
+		}
+		mockAPI = &restapi.MockInstallerAPI{}
+		mockAPI.On("GetNextSteps", mock.Anything, mock.Anything).Run(record).
+			Return(installer.NewGetNextStepsOK().WithPayload(&models.Steps{}))
+		mockAPI.On("ListClusters", mock.Anything, mock.Anything).Run(record).
+			Return(installer.NewListClustersOK().WithPayload(models.ClusterList{}))
+		mockAPI.On("GetCluster", mock.Anything, mock.Anything).Run(record).
+			Return(installer.NewGetClusterOK().WithPayload(&models.Cluster{}))
+		handler, err = restapi.Handler(restapi.Config{
+			InstallerAPI:    mockAPI,
+			Logger:          logrus.Printf,
+			InnerMiddleware: Middleware(logrus.New(), users, agents, nil),
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Remove(jwksFile)
+		ctrl.Finish()
+	})
+
+	nextSteps := func(clusterID strfmt.UUID, setAuth func(*http.Request)) int {
+		req := httptest.NewRequest(http.MethodGet,
+			fmt.Sprintf("%s/clusters/%s/hosts/%s/instructions", basePath, clusterID, uuid.New().String()), nil)
+		setAuth(req)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	listClusters := func(setAuth func(*http.Request)) int {
+		req := httptest.NewRequest(http.MethodGet, basePath+"/clusters", nil)
+		setAuth(req)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	getCluster := func(clusterID strfmt.UUID, setAuth func(*http.Request)) int {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/clusters/%s", basePath, clusterID), nil)
+		setAuth(req)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	agentToken := func(token string) func(*http.Request) {
+		return func(r *http.Request) { r.Header.Set(AgentTokenHeader, token) }
+	}
+
+	userToken := func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer "+userKey.token(validClaims()))
+	}
+
+	It("accepts the token of the cluster in the path", func() {
+		Expect(nextSteps(clusterA, agentToken(tokenA))).Should(Equal(http.StatusOK))
+		Expect(nextSteps(clusterB, agentToken(tokenB))).Should(Equal(http.StatusOK))
+		Expect(seenRoles).Should(Equal([]string{AgentRole, AgentRole}))
+	})
+
+	It("rejects the token of another cluster", func() {
+		Expect(nextSteps(clusterB, agentToken(tokenA))).Should(Equal(http.StatusUnauthorized))
+		Expect(nextSteps(clusterA, agentToken(tokenB))).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects a token for an unknown cluster", func() {
+		Expect(nextSteps(strfmt.UUID(uuid.New().String()), agentToken(tokenA))).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects a token after the cluster got a new one", func() {
+		_, hash, err := GenerateAgentToken()
+		Expect(err).ShouldNot(HaveOccurred())
+		clusters[clusterA.String()].AgentTokenHash = hash
+		Expect(nextSteps(clusterA, agentToken(tokenA))).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects agent requests without a token", func() {
+		Expect(nextSteps(clusterA, func(*http.Request) {})).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects user tokens on agent operations", func() {
+		Expect(nextSteps(clusterA, userToken)).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects agent tokens on user operations", func() {
+		Expect(listClusters(agentToken(tokenA))).Should(Equal(http.StatusUnauthorized))
+		Expect(listClusters(userToken)).Should(Equal(http.StatusOK))
+		Expect(seenRoles).Should(Equal([]string{"user"}))
+	})
+
+	It("accepts the token of the cluster in the path on installer operations", func() {
+		Expect(getCluster(clusterA, agentToken(tokenA))).Should(Equal(http.StatusOK))
+		Expect(getCluster(clusterA, agentToken(tokenB))).Should(Equal(http.StatusUnauthorized))
+		Expect(seenRoles).Should(Equal([]string{AgentRole}))
+	})
+
+	It("prefers user tokens on installer operations", func() {
+		Expect(getCluster(clusterA, userToken)).Should(Equal(http.StatusOK))
+		Expect(getCluster(clusterB, func(r *http.Request) {
+			userToken(r)
+			agentToken(tokenB)(r)
+		})).Should(Equal(http.StatusOK))
+		Expect(seenRoles).Should(Equal([]string{"user", "user"}))
+	})
+
+	It("rejects the agents of clusters whose image predates the agent tokens", func() {
+		clusters[clusterA.String()].AgentTokenHash = ""
+		req := httptest.NewRequest(http.MethodGet,
+			fmt.Sprintf("%s/clusters/%s/hosts/%s/instructions", basePath, clusterA, uuid.New().String()), nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		Expect(rec.Code).Should(Equal(http.StatusUnauthorized))
+		Expect(rec.Body.String()).Should(ContainSubstring("regenerate its discovery image"))
+		Expect(nextSteps(clusterA, agentToken(tokenA))).Should(Equal(http.StatusUnauthorized))
+	})
+})
@@ -2,7 +2,13 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 type contextKey string
@@ -14,18 +20,93 @@ const AdminUserRole = "admin"
 const DefaultUserID = "0000000"
 const DefaultOrgID = "0000000"
 
-// Fake auth Middleware handler to add username from headers to request context
-func GetUserInfoMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Update the context, as the jwt middleware will update it
-		ctx := r.Context()
-		ctx = UserIDToContext(ctx, DefaultUserID)
-		ctx = OrgIDToContext(ctx, DefaultOrgID)
-		ctx = UserRoleToContext(ctx, AdminUserRole)
-		*r = *r.WithContext(ctx)
-
-		next.ServeHTTP(w, r)
-	})
+// ErrUnauthenticated is returned by an Authenticator when the request doesn't carry any credentials
+var ErrUnauthenticated = errors.New("missing credentials")
+
+type Config struct {
+	EnableAuth  bool   `envconfig:"ENABLE_AUTH" default:"false"`
+	JwksFile    string `envconfig:"JWKS_FILE" default:""`
+	JwksURL     string `envconfig:"JWKS_URL" default:""`
+	Issuer      string `envconfig:"JWT_ISSUER" default:""`
+	Audience    string `envconfig:"JWT_AUDIENCE" default:""`
+	UserIDClaim string `envconfig:"JWT_USER_ID_CLAIM" default:"sub"`
+	OrgIDClaim  string `envconfig:"JWT_ORG_ID_CLAIM" default:"org_id"`
+	RoleClaim   string `envconfig:"JWT_ROLE_CLAIM" default:"role"`
+	// The key the URLs for downloading without credentials are signed with, shared by all the replicas
+	URLSigningKey string `envconfig:"URL_SIGNING_KEY" default:""`
+}
+
+// Identity is the authenticated caller of a request
+type Identity struct {
+	UserID string
+	OrgID  string
+	Role   string
+}
+
+type Authenticator interface {
+	// Authenticate validates the credentials carried by the request and returns the caller identity.
+	// ErrUnauthenticated is returned if the request carries no credentials at all.
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// NewAuthenticator returns the authenticator matching the configuration, a JWT authenticator if
+// authentication is enabled, otherwise one that treats every caller as the default admin user
+func NewAuthenticator(log logrus.FieldLogger, cfg Config) (Authenticator, error) {
+	if !cfg.EnableAuth {
+		log.Warn("Authentication is disabled, all requests are handled as the default admin user")
+		return &noneAuthenticator{}, nil
+	}
+	return NewJWTAuthenticator(log, cfg)
+}
+
+type noneAuthenticator struct{}
+
+func (a *noneAuthenticator) Authenticate(_ *http.Request) (*Identity, error) {
+	return &Identity{UserID: DefaultUserID, OrgID: DefaultOrgID, Role: AdminUserRole}, nil
+}
+
+// Middleware authenticates the agent operations, and the installer operations called with an agent token but
+// without user credentials, with the agent authenticator, the presigned operations with the presigned authenticator and all other operations with
+// the user authenticator, stores the caller identity
+// in the request context and rejects requests that fail authentication with 401. It expects the route to be
+// already matched, i.e. to be used as inner middleware.
+func Middleware(log logrus.FieldLogger, users Authenticator, agents Authenticator, presigned Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authenticator := users
+			if route := middleware.MatchedRouteFrom(r); route != nil {
+				switch {
+				case IsAgentOperation(route.Operation.ID):
+					authenticator = agents
+				case IsInstallerOperation(route.Operation.ID) && r.Header.Get("Authorization") == "" &&
+					r.Header.Get(AgentTokenHeader) != "":
+					authenticator = agents
+				case IsPresignedOperation(route.Operation.ID):
+					authenticator = presigned
+				}
+			}
+			identity, err := authenticator.Authenticate(r)
+			if err != nil {
+				log.WithError(err).Infof("Failed to authenticate request %s %s", r.Method, r.URL.Path)
+				writeUnauthorized(w, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(IdentityToContext(r.Context(), identity)))
+		})
+	}
+}
+
+func writeUnauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(common.GenerateError(http.StatusUnauthorized, errors.Wrap(err, "unauthorized")))
+}
+
+func IdentityToContext(ctx context.Context, identity *Identity) context.Context {
+	ctx = UserIDToContext(ctx, identity.UserID)
+	ctx = OrgIDToContext(ctx, identity.OrgID)
+	return UserRoleToContext(ctx, identity.Role)
 }
 
 func UserIDFromContext(ctx context.Context) string {
@@ -0,0 +1,351 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const testIssuer = "https://sso.example.com/auth"
+
+type testKey struct {
+	kid    string
+	alg    jose.SignatureAlgorithm
+	signer interface{}
+	public interface{}
+}
+
+func newRSAKey(kid string) *testKey {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ShouldNot(HaveOccurred())
+	return &testKey{kid: kid, alg: jose.RS256, signer: priv, public: &priv.PublicKey}
+}
+
+func newECKey(kid string) *testKey {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ShouldNot(HaveOccurred())
+	return &testKey{kid: kid, alg: jose.ES256, signer: priv, public: &priv.PublicKey}
+}
+
+func jwks(keys ...*testKey) []byte {
+	set := jose.JSONWebKeySet{}
+	for _, k := range keys {
+		set.Keys = append(set.Keys, jose.JSONWebKey{Key: k.public, KeyID: k.kid, Algorithm: string(k.alg), Use: "sig"})
+	}
+	data, err := json.Marshal(set)
+	Expect(err).ShouldNot(HaveOccurred())
+	return data
+}
+
+func (k *testKey) token(claims map[string]interface{}) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: k.alg, Key: k.signer},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", k.kid))
+	Expect(err).ShouldNot(HaveOccurred())
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	Expect(err).ShouldNot(HaveOccurred())
+	return token
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":    testIssuer,
+		"sub":    "user1",
+		"org_id": "org1",
+		"role":   "user",
+		"aud":    "assisted-service",
+		"iat":    time.Now().Unix(),
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func request(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/assisted-install/v1/clusters", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+var _ = Describe("JWT authenticator", func() {
+	var (
+		key      *testKey
+		other    *testKey
+		jwksFile string
+		cfg      Config
+		a        Authenticator
+	)
+
+	BeforeEach(func() {
+		key = newRSAKey("key1")
+		other = newECKey("key2")
+		f, err := ioutil.TempFile("", "jwks")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = f.Write(jwks(key, other))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(f.Close()).ShouldNot(HaveOccurred())
+		jwksFile = f.Name()
+		cfg = Config{
+			EnableAuth:  true,
+			JwksFile:    jwksFile,
+			Issuer:      testIssuer,
+			Audience:    "assisted-service",
+			UserIDClaim: "sub",
+			OrgIDClaim:  "org_id",
+			RoleClaim:   "role",
+		}
+		a, err = NewAuthenticator(logrus.New(), cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Remove(jwksFile)
+	})
+
+	It("accepts a valid RSA token", func() {
+		identity, err := a.Authenticate(request(key.token(validClaims())))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*identity).Should(Equal(Identity{UserID: "user1", OrgID: "org1", Role: "user"}))
+	})
+
+	It("accepts a valid EC token", func() {
+		_, err := a.Authenticate(request(other.token(validClaims())))
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("uses the configured claim names", func() {
+		cfg.UserIDClaim = "account_id"
+		cfg.OrgIDClaim = "account_org"
+		cfg.RoleClaim = "assisted_role"
+		var err error
+		a, err = NewAuthenticator(logrus.New(), cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		claims := validClaims()
+		claims["account_id"] = "user2"
+		claims["account_org"] = "org2"
+		claims["assisted_role"] = AdminUserRole
+		identity, err := a.Authenticate(request(key.token(claims)))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*identity).Should(Equal(Identity{UserID: "user2", OrgID: "org2", Role: AdminUserRole}))
+	})
+
+	It("rejects a request without a token", func() {
+		_, err := a.Authenticate(request(""))
+		Expect(err).Should(Equal(ErrUnauthenticated))
+	})
+
+	It("rejects a non bearer authorization header", func() {
+		req := request("")
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		_, err := a.Authenticate(req)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rejects a malformed token", func() {
+		_, err := a.Authenticate(request("not-a-jwt"))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rejects a token signed by an unknown key", func() {
+		stranger := newRSAKey("key1")
+		_, err := a.Authenticate(request(stranger.token(validClaims())))
+		Expect(err).Should(HaveOccurred())
+		stranger = newRSAKey("key3")
+		_, err = a.Authenticate(request(stranger.token(validClaims())))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rejects an expired token", func() {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		_, err := a.Authenticate(request(key.token(claims)))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rejects a token without expiration", func() {
+		claims := validClaims()
+		delete(claims, "exp")
+		_, err := a.Authenticate(request(key.token(claims)))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rejects a token from another issuer", func() {
+		claims := validClaims()
+		claims["iss"] = "https://evil.example.com"
+		_, err := a.Authenticate(request(key.token(claims)))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rejects a token for another audience", func() {
+		claims := validClaims()
+		claims["aud"] = "other-service"
+		_, err := a.Authenticate(request(key.token(claims)))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rejects a token without the org claim", func() {
+		claims := validClaims()
+		delete(claims, "org_id")
+		_, err := a.Authenticate(request(key.token(claims)))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rejects a token with an empty user or org", func() {
+		for _, claim := range []string{"sub", "org_id"} {
+			for _, value := range []interface{}{"", "  ", nil} {
+				claims := validClaims()
+				claims[claim] = value
+				_, err := a.Authenticate(request(key.token(claims)))
+				Expect(err).Should(HaveOccurred(), fmt.Sprintf("%s=%v", claim, value))
+			}
+		}
+	})
+
+	It("rejects a token without the role claim", func() {
+		claims := validClaims()
+		delete(claims, "role")
+		_, err := a.Authenticate(request(key.token(claims)))
+		Expect(err).Should(MatchError("token is missing the role claim"))
+		claims["role"] = ""
+		_, err = a.Authenticate(request(key.token(claims)))
+		Expect(err).Should(MatchError("token has an empty role claim"))
+	})
+
+	It("fails without a key source", func() {
+		cfg.JwksFile = ""
+		cfg.Issuer = ""
+		_, err := NewAuthenticator(logrus.New(), cfg)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("rejects a key set holding private keys", func() {
+		data, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.signer, KeyID: key.kid}}})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ioutil.WriteFile(jwksFile, data, 0600)).ShouldNot(HaveOccurred())
+		_, err = NewAuthenticator(logrus.New(), cfg)
+		Expect(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("OIDC issuer discovery", func() {
+	var (
+		key    *testKey
+		server *httptest.Server
+		keySet []byte
+	)
+
+	BeforeEach(func() {
+		key = newRSAKey("key1")
+		keySet = jwks(key)
+		mux := http.NewServeMux()
+		server = httptest.NewServer(mux)
+		mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"issuer": %q, "jwks_uri": %q}`, server.URL, server.URL+"/certs")
+		})
+		mux.HandleFunc("/certs", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(keySet)
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("validates tokens with the published keys", func() {
+		a, err := NewAuthenticator(logrus.New(), Config{EnableAuth: true, Issuer: server.URL,
+			UserIDClaim: "sub", OrgIDClaim: "org_id", RoleClaim: "role"})
+		Expect(err).ShouldNot(HaveOccurred())
+		claims := validClaims()
+		claims["iss"] = server.URL
+		identity, err := a.Authenticate(request(key.token(claims)))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(identity.UserID).Should(Equal("user1"))
+	})
+
+	It("fails when the issuer is unreachable", func() {
+		server.Close()
+		_, err := NewAuthenticator(logrus.New(), Config{EnableAuth: true, Issuer: server.URL})
+		Expect(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("auth middleware", func() {
+	var (
+		key     *testKey
+		handler http.Handler
+		called  bool
+		seen    Identity
+	)
+
+	BeforeEach(func() {
+		key = newRSAKey("key1")
+		f, err := ioutil.TempFile("", "jwks")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = f.Write(jwks(key))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(f.Close()).ShouldNot(HaveOccurred())
+		defer os.Remove(f.Name())
+		a, err := NewAuthenticator(logrus.New(), Config{EnableAuth: true, JwksFile: f.Name(), Issuer: testIssuer,
+			UserIDClaim: "sub", OrgIDClaim: "org_id", RoleClaim: "role"})
+		Expect(err).ShouldNot(HaveOccurred())
+		called = false
+		handler = Middleware(logrus.New(), a, a, a)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			seen = Identity{
+				UserID: UserIDFromContext(r.Context()),
+				OrgID:  OrgIDFromContext(r.Context()),
+				Role:   UserRoleFromContext(r.Context()),
+			}
+		}))
+	})
+
+	It("stores the identity in the request context", func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, request(key.token(validClaims())))
+		Expect(rec.Code).Should(Equal(http.StatusOK))
+		Expect(called).Should(BeTrue())
+		Expect(seen).Should(Equal(Identity{UserID: "user1", OrgID: "org1", Role: "user"}))
+	})
+
+	It("responds 401 to unauthenticated requests", func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, request(""))
+		Expect(rec.Code).Should(Equal(http.StatusUnauthorized))
+		Expect(rec.Header().Get("WWW-Authenticate")).Should(Equal("Bearer"))
+		Expect(called).Should(BeFalse())
+	})
+
+	It("responds 401 to invalid tokens", func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, request(newRSAKey("key1").token(validClaims())))
+		Expect(rec.Code).Should(Equal(http.StatusUnauthorized))
+		Expect(called).Should(BeFalse())
+	})
+
+	It("treats every caller as admin when authentication is disabled", func() {
+		a, err := NewAuthenticator(logrus.New(), Config{EnableAuth: false})
+		Expect(err).ShouldNot(HaveOccurred())
+		identity, err := a.Authenticate(request(""))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*identity).Should(Equal(Identity{UserID: DefaultUserID, OrgID: DefaultOrgID, Role: AdminUserRole}))
+	})
+})
+
+func TestAuth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Auth Suite")
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const UserRole = "user"
+
+// ReadOnlyUserRole can only call the List, Get and Download operations of its org
+const ReadOnlyUserRole = "read-only"
+
+// SupportUserRole can read the events of any org, but nothing that holds pull secrets or credentials
+const SupportUserRole = "support"
+
+// policy maps each API operation, by its swagger operationId, to the roles allowed to call it.
+// Admins are allowed to call every operation, operations that are missing from the table are allowed
+// only for admins.
+var policy = map[string][]string{
+	// clusters
+	"RegisterCluster":              {UserRole},
+	"ListClusters":                 {UserRole, ReadOnlyUserRole},
+	"GetCluster":                   {UserRole, ReadOnlyUserRole, AgentRole},
+	"UpdateCluster":                {UserRole},
+	"UpdateClusterInstallConfig":   {UserRole},
+	"DeregisterCluster":            {UserRole},
+	"GenerateClusterISO":           {UserRole},
+	"DownloadClusterISO":           {UserRole, ReadOnlyUserRole},
+	"GetPresignedForClusterISO":    {UserRole, ReadOnlyUserRole},
+	"DownloadClusterISOPresigned":  {PresignedURLRole},
+	"DownloadClusterFiles":         {UserRole, ReadOnlyUserRole, AgentRole},
+	"GetCredentials":               {UserRole, ReadOnlyUserRole},
+	"DownloadClusterKubeconfig":    {UserRole, ReadOnlyUserRole},
+	"UploadClusterIngressCert":     {UserRole, AgentRole},
+	"InstallCluster":               {UserRole},
+	"CancelInstallation":           {UserRole},
+	"ResetCluster":                 {UserRole},
+	"CompleteInstallation":         {UserRole, AgentRole},
+	"GetFreeAddresses":             {UserRole, ReadOnlyUserRole},
+	"GetClusterConnectivityMatrix": {UserRole, ReadOnlyUserRole},
+
+	// hosts
+	"ListHosts":      {UserRole, ReadOnlyUserRole, AgentRole},
+	"GetHost":        {UserRole, ReadOnlyUserRole},
+	"DeregisterHost": {UserRole},
+	"SetDebugStep":   {UserRole},
+	"EnableHost":     {UserRole},
+	"DisableHost":    {UserRole},
+
+	// discovery agent
+	"RegisterHost":              {AgentRole},
+	"GetNextSteps":              {AgentRole},
+	"PostStepReply":             {AgentRole},
+	"UpdateHostInstallProgress": {AgentRole},
+
+	// events
+	"ListEvents": {UserRole, ReadOnlyUserRole, SupportUserRole},
+
+	// versions and domains
+	"ListManagedDomains":             {UserRole, ReadOnlyUserRole, SupportUserRole},
+	"ListComponentVersions":          {UserRole, ReadOnlyUserRole, SupportUserRole},
+	"ListSupportedOpenshiftVersions": {UserRole, ReadOnlyUserRole, SupportUserRole},
+}
+
+// IsAllowed returns whether the role is allowed to call the operation
+func IsAllowed(role string, operationID string) bool {
+	if role == AdminUserRole {
+		return true
+	}
+	for _, allowed := range policy[operationID] {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthzMiddleware rejects with 403 the requests whose caller role isn't allowed to call the matched
+// operation. It expects the identity to be already stored in the request context by Middleware.
+func AuthzMiddleware(log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := middleware.MatchedRouteFrom(r)
+			if route == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			role := UserRoleFromContext(r.Context())
+			if !IsAllowed(role, route.Operation.ID) {
+				log.Infof("User %s with role %q is not allowed to call %s",
+					UserIDFromContext(r.Context()), role, route.Operation.ID)
+				writeForbidden(w, errors.Errorf("role %q is not allowed to call %s", role, route.Operation.ID))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeForbidden(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(common.GenerateError(http.StatusForbidden, err))
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-openapi/loads"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/restapi"
+	"github.com/openshift/assisted-service/restapi/operations/installer"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+const testClusterID = "0e8fc6fb-0b02-4be5-a0a4-3d4bb6e9e65d"
+
+var _ = Describe("authorization policy", func() {
+	It("covers every API operation", func() {
+		doc, err := loads.Analyzed(restapi.SwaggerJSON, "")
+		Expect(err).ShouldNot(HaveOccurred())
+		for _, id := range doc.Analyzer.OperationIDs() {
+			Expect(policy).Should(HaveKey(id))
+		}
+		Expect(policy).Should(HaveLen(len(doc.Analyzer.OperationIDs())))
+	})
+
+	It("allows admins to call every operation", func() {
+		for id := range policy {
+			Expect(IsAllowed(AdminUserRole, id)).Should(BeTrue(), id)
+		}
+	})
+
+	It("allows users to call every operation but the agent and presigned ones", func() {
+		for id := range policy {
+			Expect(IsAllowed(UserRole, id)).Should(Equal(!IsAgentOperation(id) && !IsPresignedOperation(id)), id)
+		}
+	})
+
+	It("allows agents to call only the agent and installer operations", func() {
+		for id := range policy {
+			Expect(IsAllowed(AgentRole, id)).Should(Equal(IsAgentOperation(id) || IsInstallerOperation(id)), id)
+		}
+	})
+
+	It("allows signed URLs to call only the presigned operations", func() {
+		for id := range policy {
+			Expect(IsAllowed(PresignedURLRole, id)).Should(Equal(IsPresignedOperation(id)), id)
+		}
+	})
+
+	It("allows read-only users to list, get and download", func() {
+		for _, id := range []string{"ListClusters", "GetCluster", "ListHosts", "GetHost", "DownloadClusterISO",
+			"DownloadClusterFiles", "DownloadClusterKubeconfig", "ListEvents"} {
+			Expect(IsAllowed(ReadOnlyUserRole, id)).Should(BeTrue(), id)
+		}
+		for _, id := range []string{"RegisterCluster", "UpdateCluster", "InstallCluster", "ResetCluster",
+			"CancelInstallation", "DeregisterCluster", "DeregisterHost", "GenerateClusterISO", "EnableHost"} {
+			Expect(IsAllowed(ReadOnlyUserRole, id)).Should(BeFalse(), id)
+		}
+	})
+
+	It("allows support to read events but not secrets or credentials", func() {
+		Expect(IsAllowed(SupportUserRole, "ListEvents")).Should(BeTrue())
+		for _, id := range []string{"GetCredentials", "DownloadClusterKubeconfig", "DownloadClusterFiles",
+			"DownloadClusterISO", "GetCluster", "UpdateCluster", "InstallCluster"} {
+			Expect(IsAllowed(SupportUserRole, id)).Should(BeFalse(), id)
+		}
+	})
+
+	It("rejects unknown roles and operations", func() {
+		Expect(IsAllowed("", "ListClusters")).Should(BeFalse())
+		Expect(IsAllowed("superuser", "ListClusters")).Should(BeFalse())
+		Expect(IsAllowed(UserRole, "UnknownOperation")).Should(BeFalse())
+	})
+})
+
+var _ = Describe("authorization middleware", func() {
+	var handler http.Handler
+
+	BeforeEach(func() {
+		mockAPI := &restapi.MockInstallerAPI{}
+		mockAPI.On("ListClusters", mock.Anything, mock.Anything).
+			Return(installer.NewListClustersOK().WithPayload(models.ClusterList{}))
+		mockAPI.On("ResetCluster", mock.Anything, mock.Anything).
+			Return(installer.NewResetClusterAccepted().WithPayload(&models.Cluster{}))
+		withRole := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := IdentityToContext(r.Context(), &Identity{UserID: "user1", OrgID: "org1", Role: r.Header.Get("X-Role")})
+				next.ServeHTTP(w, r.WithContext(ctx))
+			})
+		}
+		var err error
+		handler, err = restapi.Handler(restapi.Config{
+			InstallerAPI: mockAPI,
+			Logger:       logrus.Printf,
+			InnerMiddleware: func(next http.Handler) http.Handler {
+				return withRole(AuthzMiddleware(logrus.New())(next))
+			},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	call := func(method, path, role string) int {
+		req := httptest.NewRequest(method, basePath+path, nil)
+		req.Header.Set("X-Role", role)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	It("passes allowed requests", func() {
+		Expect(call(http.MethodGet, "/clusters", ReadOnlyUserRole)).Should(Equal(http.StatusOK))
+		Expect(call(http.MethodPost, "/clusters/"+testClusterID+"/actions/reset", UserRole)).
+			Should(Equal(http.StatusAccepted))
+	})
+
+	It("responds 403 to forbidden requests", func() {
+		Expect(call(http.MethodPost, "/clusters/"+testClusterID+"/actions/reset", ReadOnlyUserRole)).
+			Should(Equal(http.StatusForbidden))
+		Expect(call(http.MethodGet, "/clusters", SupportUserRole)).Should(Equal(http.StatusForbidden))
+		Expect(call(http.MethodGet, "/clusters", "")).Should(Equal(http.StatusForbidden))
+	})
+})
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=clusters.go -package=auth -destination=mock_clusters.go
+
+// ClusterStore looks up the clusters that the agent tokens and the signed URLs are issued for
+type ClusterStore interface {
+	// GetCluster returns the owner of the cluster and the credentials issued for it, nil if there is no such cluster
+	GetCluster(clusterID string) (*common.Cluster, error)
+}
+
+type dbClusterStore struct {
+	db *gorm.DB
+}
+
+func NewClusterStore(db *gorm.DB) ClusterStore {
+	return &dbClusterStore{db: db}
+}
+
+func (s *dbClusterStore) GetCluster(clusterID string) (*common.Cluster, error) {
+	var cluster common.Cluster
+	if err := s.db.Select("id, user_id, org_id, agent_token_hash, image_created_at").
+		First(&cluster, "id = ?", clusterID).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get cluster %s", clusterID)
+	}
+	return &cluster, nil
+}
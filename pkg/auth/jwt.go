@@ -0,0 +1,249 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	// clockSkewLeeway is the tolerated difference between the token issuer clock and ours
+	clockSkewLeeway = time.Minute
+	// minJwksRefreshInterval limits how often the remote key set is fetched again when a token is
+	// signed by an unknown key
+	minJwksRefreshInterval = time.Minute
+)
+
+type jwtAuthenticator struct {
+	log         logrus.FieldLogger
+	cfg         Config
+	fetchKeys   func() (*jose.JSONWebKeySet, error)
+	remote      bool
+	lock        sync.RWMutex
+	keys        *jose.JSONWebKeySet
+	lastRefresh time.Time
+}
+
+// NewJWTAuthenticator returns an authenticator validating bearer JWTs against the key set read from
+// the configured JWKS file, JWKS URL or the jwks_uri published by the configured OIDC issuer
+func NewJWTAuthenticator(log logrus.FieldLogger, cfg Config) (Authenticator, error) {
+	a := &jwtAuthenticator{log: log, cfg: cfg}
+	switch {
+	case cfg.JwksFile != "":
+		a.fetchKeys = func() (*jose.JSONWebKeySet, error) { return readJwksFile(cfg.JwksFile) }
+	case cfg.JwksURL != "":
+		a.fetchKeys = func() (*jose.JSONWebKeySet, error) { return fetchJwks(cfg.JwksURL) }
+		a.remote = true
+	case cfg.Issuer != "":
+		a.fetchKeys = func() (*jose.JSONWebKeySet, error) { return discoverJwks(cfg.Issuer) }
+		a.remote = true
+	default:
+		return nil, errors.New("authentication is enabled but none of JWKS file, JWKS URL or issuer is configured")
+	}
+	keys, err := a.fetchKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load JWT signing keys")
+	}
+	a.keys = keys
+	a.lastRefresh = time.Now()
+	return a, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return nil, ErrUnauthenticated
+	}
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return nil, errors.New("authorization header is not a bearer token")
+	}
+	token, err := jwt.ParseSigned(strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix)))
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed token")
+	}
+	if len(token.Headers) != 1 {
+		return nil, errors.New("token must have exactly one signature")
+	}
+	key, err := a.signingKey(token.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var standard jwt.Claims
+	custom := map[string]interface{}{}
+	if err = token.Claims(key.Key, &standard, &custom); err != nil {
+		return nil, errors.Wrap(err, "invalid token signature")
+	}
+	expected := jwt.Expected{Issuer: a.cfg.Issuer, Time: time.Now()}
+	if a.cfg.Audience != "" {
+		expected.Audience = jwt.Audience{a.cfg.Audience}
+	}
+	if err = standard.ValidateWithLeeway(expected, clockSkewLeeway); err != nil {
+		return nil, errors.Wrap(err, "invalid token claims")
+	}
+	if standard.Expiry == nil {
+		return nil, errors.New("token has no expiration time")
+	}
+
+	identity := &Identity{}
+	if identity.UserID, err = stringClaim(custom, a.cfg.UserIDClaim, true); err != nil {
+		return nil, err
+	}
+	if identity.OrgID, err = stringClaim(custom, a.cfg.OrgIDClaim, true); err != nil {
+		return nil, err
+	}
+	// A token without a role would be authenticated only to be forbidden every operation
+	if identity.Role, err = stringClaim(custom, a.cfg.RoleClaim, true); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// signingKey looks the key up in the cached key set, refreshing remote key sets once in a while to pick
+// up rotated keys
+func (a *jwtAuthenticator) signingKey(kid string) (*jose.JSONWebKey, error) {
+	if key := a.lookupKey(kid); key != nil {
+		return key, nil
+	}
+	if a.remote && a.refreshKeys() {
+		if key := a.lookupKey(kid); key != nil {
+			return key, nil
+		}
+	}
+	return nil, errors.Errorf("token is signed by unknown key %q", kid)
+}
+
+func (a *jwtAuthenticator) lookupKey(kid string) *jose.JSONWebKey {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	var keys []jose.JSONWebKey
+	if kid == "" {
+		// without a key ID the token can only be matched to a key set holding a single key
+		if len(a.keys.Keys) == 1 {
+			keys = a.keys.Keys
+		}
+	} else {
+		keys = a.keys.Key(kid)
+	}
+	for i := range keys {
+		if keys[i].Use == "" || keys[i].Use == "sig" {
+			return &keys[i]
+		}
+	}
+	return nil
+}
+
+func (a *jwtAuthenticator) refreshKeys() bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if time.Since(a.lastRefresh) < minJwksRefreshInterval {
+		return false
+	}
+	a.lastRefresh = time.Now()
+	keys, err := a.fetchKeys()
+	if err != nil {
+		a.log.WithError(err).Warn("Failed to refresh JWT signing keys")
+		return false
+	}
+	a.keys = keys
+	return true
+}
+
+func stringClaim(claims map[string]interface{}, name string, required bool) (string, error) {
+	value, ok := claims[name]
+	if !ok || value == nil {
+		if required {
+			return "", errors.Errorf("token is missing the %s claim", name)
+		}
+		return "", nil
+	}
+	switch v := value.(type) {
+	case string:
+		// An empty user or org would put the caller in a tenant shared by all such tokens
+		if strings.TrimSpace(v) == "" && required {
+			return "", errors.Errorf("token has an empty %s claim", name)
+		}
+		return v, nil
+	case float64:
+		return fmt.Sprintf("%.0f", v), nil
+	default:
+		return "", errors.Errorf("token claim %s must be a string", name)
+	}
+}
+
+func readJwksFile(path string) (*jose.JSONWebKeySet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseJwks(data)
+}
+
+func fetchJwks(url string) (*jose.JSONWebKeySet, error) {
+	data, err := httpGet(url)
+	if err != nil {
+		return nil, err
+	}
+	return parseJwks(data)
+}
+
+// discoverJwks fetches the key set published by an OIDC issuer through its discovery document
+func discoverJwks(issuer string) (*jose.JSONWebKeySet, error) {
+	data, err := httpGet(strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, err
+	}
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JwksURI string `json:"jwks_uri"`
+	}
+	if err = json.Unmarshal(data, &discovery); err != nil {
+		return nil, errors.Wrap(err, "failed to parse OIDC discovery document")
+	}
+	if discovery.Issuer != issuer {
+		return nil, errors.Errorf("OIDC discovery document issuer %q doesn't match %q", discovery.Issuer, issuer)
+	}
+	if discovery.JwksURI == "" {
+		return nil, errors.New("OIDC discovery document has no jwks_uri")
+	}
+	return fetchJwks(discovery.JwksURI)
+}
+
+func parseJwks(data []byte) (*jose.JSONWebKeySet, error) {
+	keys := &jose.JSONWebKeySet{}
+	if err := json.Unmarshal(data, keys); err != nil {
+		return nil, errors.Wrap(err, "failed to parse JWKS")
+	}
+	if len(keys.Keys) == 0 {
+		return nil, errors.New("JWKS holds no keys")
+	}
+	for _, key := range keys.Keys {
+		if !key.IsPublic() {
+			return nil, errors.Errorf("JWKS key %q is not a public key", key.KeyID)
+		}
+	}
+	return keys, nil
+}
+
+func httpGet(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to get %s, status code %d", url, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// PresignedURLRole is the role of the callers that are authenticated by a signed URL
+const PresignedURLRole = "presigned-url"
+
+// presignedOperations are called with a signed, time limited URL instead of credentials, by clients such as
+// BMC virtual media that can't send them
+var presignedOperations = map[string]bool{
+	"DownloadClusterISOPresigned": true,
+}
+
+func IsPresignedOperation(operationID string) bool {
+	return presignedOperations[operationID]
+}
+
+// URLSigner signs the URLs of the discovery images. A signature covers the creation time of the image, so
+// the URLs are revoked when a new image is generated, as well as when the cluster is deregistered.
+type URLSigner struct {
+	key []byte
+}
+
+// NewURLSigner returns a signer with the configured key, or with a random key when none is configured, in
+// which case the URLs are valid only until the service restarts and only on the replica that signed them
+func NewURLSigner(log logrus.FieldLogger, cfg Config) (*URLSigner, error) {
+	if cfg.URLSigningKey != "" {
+		return &URLSigner{key: []byte(cfg.URLSigningKey)}, nil
+	}
+	log.Warn("URL signing key is not set, signed URLs are valid only until the service restarts")
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "failed to generate URL signing key")
+	}
+	return &URLSigner{key: key}, nil
+}
+
+// Sign returns the signature of the URL of the cluster image created at imageCreatedAt
+func (s *URLSigner) Sign(clusterID string, imageCreatedAt time.Time, expiresAt int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s:%d:%d", clusterID, imageCreatedAt.UnixNano(), expiresAt)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks that the signature is valid for the cluster image and that the URL didn't expire
+func (s *URLSigner) Verify(clusterID string, imageCreatedAt time.Time, expiresAt int64, signature string) error {
+	if time.Now().Unix() > expiresAt {
+		return errors.New("the URL expired")
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.Sign(clusterID, imageCreatedAt, expiresAt))) {
+		return errors.New("invalid URL signature")
+	}
+	return nil
+}
+
+type presignedAuthenticator struct {
+	log      logrus.FieldLogger
+	signer   *URLSigner
+	clusters ClusterStore
+}
+
+// NewPresignedAuthenticator returns the authenticator for the presigned operations. Signatures are always
+// verified, also when authentication is disabled, so that the URLs expire and get revoked the same way.
+func NewPresignedAuthenticator(log logrus.FieldLogger, signer *URLSigner, clusters ClusterStore) Authenticator {
+	return &presignedAuthenticator{log: log, signer: signer, clusters: clusters}
+}
+
+func (a *presignedAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	query := r.URL.Query()
+	signature := query.Get("signature")
+	if signature == "" {
+		return nil, ErrUnauthenticated
+	}
+	expiresAt, err := strconv.ParseInt(query.Get("expires_at"), 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid URL expiration time")
+	}
+	route := middleware.MatchedRouteFrom(r)
+	if route == nil {
+		return nil, errors.New("signed URLs are only valid for presigned operations")
+	}
+	clusterID, _, _ := route.Params.GetOK("cluster_id")
+	if len(clusterID) != 1 || clusterID[0] == "" {
+		return nil, errors.New("signed URLs are only valid for cluster operations")
+	}
+
+	cluster, err := a.clusters.GetCluster(clusterID[0])
+	if err != nil {
+		return nil, err
+	}
+	if cluster == nil || cluster.ImageInfo == nil || time.Time(cluster.ImageInfo.CreatedAt).IsZero() {
+		return nil, errors.New("invalid URL signature")
+	}
+	if err = a.signer.Verify(clusterID[0], time.Time(cluster.ImageInfo.CreatedAt), expiresAt, signature); err != nil {
+		return nil, err
+	}
+	return &Identity{UserID: cluster.UserID, OrgID: cluster.OrgID, Role: PresignedURLRole}, nil
+}
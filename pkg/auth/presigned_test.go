@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/internal/common"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/restapi"
+	"github.com/openshift/assisted-service/restapi/operations/installer"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("URL signer", func() {
+	var (
+		signer    *URLSigner
+		clusterID = uuid.New().String()
+		createdAt = time.Now()
+		expiresAt = time.Now().Add(time.Hour).Unix()
+	)
+
+	BeforeEach(func() {
+		var err error
+		signer, err = NewURLSigner(logrus.New(), Config{URLSigningKey: "key"})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("verifies its signatures", func() {
+		signature := signer.Sign(clusterID, createdAt, expiresAt)
+		Expect(signer.Verify(clusterID, createdAt, expiresAt, signature)).ShouldNot(HaveOccurred())
+	})
+
+	It("rejects expired URLs", func() {
+		expired := time.Now().Add(-time.Minute).Unix()
+		signature := signer.Sign(clusterID, createdAt, expired)
+		Expect(signer.Verify(clusterID, createdAt, expired, signature)).Should(HaveOccurred())
+	})
+
+	It("rejects the signatures of another cluster, image or expiration time", func() {
+		signature := signer.Sign(clusterID, createdAt, expiresAt)
+		Expect(signer.Verify(uuid.New().String(), createdAt, expiresAt, signature)).Should(HaveOccurred())
+		Expect(signer.Verify(clusterID, createdAt.Add(time.Second), expiresAt, signature)).Should(HaveOccurred())
+		Expect(signer.Verify(clusterID, createdAt, expiresAt+1, signature)).Should(HaveOccurred())
+	})
+
+	It("rejects the signatures of another key", func() {
+		other, err := NewURLSigner(logrus.New(), Config{})
+		Expect(err).ShouldNot(HaveOccurred())
+		signature := other.Sign(clusterID, createdAt, expiresAt)
+		Expect(signer.Verify(clusterID, createdAt, expiresAt, signature)).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("presigned authentication", func() {
+	var (
+		ctrl      *gomock.Controller
+		clusters  map[string]*common.Cluster
+		handler   http.Handler
+		signer    *URLSigner
+		clusterID strfmt.UUID
+		createdAt time.Time
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		clusterID = strfmt.UUID(uuid.New().String())
+		createdAt = time.Now()
+		clusters = map[string]*common.Cluster{clusterID.String(): {Cluster: models.Cluster{
+			ID:        &clusterID,
+			UserID:    "user1",
+			OrgID:     "org1",
+			ImageInfo: &models.ImageInfo{CreatedAt: strfmt.DateTime(createdAt)},
+		}}}
+		mockClusters := NewMockClusterStore(ctrl)
+		mockClusters.EXPECT().GetCluster(gomock.Any()).DoAndReturn(func(clusterID string) (*common.Cluster, error) {
+			return clusters[clusterID], nil
+		}).AnyTimes()
+
+		var err error
+		signer, err = NewURLSigner(logrus.New(), Config{URLSigningKey: "key"})
+		Expect(err).ShouldNot(HaveOccurred())
+		cfg := Config{EnableAuth: true}
+		mockAPI := &restapi.MockInstallerAPI{}
+		mockAPI.On("DownloadClusterISOPresigned", mock.Anything, mock.Anything).
+			Return(installer.NewDownloadClusterISOPresignedOK().WithPayload(ioutil.NopCloser(strings.NewReader("image"))))
+		handler, err = restapi.Handler(restapi.Config{
+			InstallerAPI: mockAPI,
+			Logger:       logrus.Printf,
+			InnerMiddleware: Middleware(logrus.New(), &noneAuthenticator{}, NewAgentAuthenticator(logrus.New(), cfg, mockClusters),
+				NewPresignedAuthenticator(logrus.New(), signer, mockClusters)),
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	download := func(expiresAt int64, signature string) int {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/clusters/%s/downloads/image-presigned?expires_at=%d&signature=%s",
+			basePath, clusterID, expiresAt, signature), nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	It("accepts a valid signature", func() {
+		expiresAt := time.Now().Add(time.Hour).Unix()
+		Expect(download(expiresAt, signer.Sign(clusterID.String(), createdAt, expiresAt))).Should(Equal(http.StatusOK))
+	})
+
+	It("rejects an expired URL", func() {
+		expiresAt := time.Now().Add(-time.Minute).Unix()
+		Expect(download(expiresAt, signer.Sign(clusterID.String(), createdAt, expiresAt))).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects an invalid signature", func() {
+		expiresAt := time.Now().Add(time.Hour).Unix()
+		Expect(download(expiresAt, "invalid")).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects the URL after a new image was generated", func() {
+		expiresAt := time.Now().Add(time.Hour).Unix()
+		signature := signer.Sign(clusterID.String(), createdAt, expiresAt)
+		clusters[clusterID.String()].ImageInfo.CreatedAt = strfmt.DateTime(createdAt.Add(time.Minute))
+		Expect(download(expiresAt, signature)).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects the URL after the cluster was deregistered", func() {
+		expiresAt := time.Now().Add(time.Hour).Unix()
+		signature := signer.Sign(clusterID.String(), createdAt, expiresAt)
+		delete(clusters, clusterID.String())
+		Expect(download(expiresAt, signature)).Should(Equal(http.StatusUnauthorized))
+	})
+})
@@ -4,11 +4,17 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/pkg/errors"
 )
 
+// ErrRangeNotSatisfiable is returned when none of the requested bytes are in the file
+var ErrRangeNotSatisfiable = errors.New("The requested range is not satisfiable")
+
 func NewResponder(next middleware.Responder, fname string, length int64) middleware.Responder {
 	return &fileMiddlewareResponder{
 		next:     next,
@@ -30,3 +36,146 @@ func (f *fileMiddlewareResponder) WriteResponse(rw http.ResponseWriter, r runtim
 	}
 	f.next.WriteResponse(rw, r)
 }
+
+// FileInfo describes the served file for the conditional and range headers
+type FileInfo struct {
+	Name         string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// Range is a single byte range of a file
+type Range struct {
+	Start  int64
+	Length int64
+}
+
+// ParseRange returns the range of the file that should be served for the Range and If-Range
+// headers, or nil when the whole file should be served. Only single byte ranges are supported,
+// a request for multiple ranges gets the whole file, the same as with an invalid Range header.
+func ParseRange(rangeHeader, ifRange string, file FileInfo) (*Range, error) {
+	if rangeHeader == "" || !ifRangeMatches(ifRange, file) {
+		return nil, nil
+	}
+	spec := strings.TrimSpace(rangeHeader)
+	if !strings.HasPrefix(spec, "bytes=") {
+		return nil, nil
+	}
+	spec = strings.TrimSpace(strings.TrimPrefix(spec, "bytes="))
+	if strings.Contains(spec, ",") {
+		return nil, nil
+	}
+	dash := strings.Index(spec, "-")
+	if dash < 0 {
+		return nil, nil
+	}
+	first, last := strings.TrimSpace(spec[:dash]), strings.TrimSpace(spec[dash+1:])
+
+	// A suffix range of the last bytes of the file
+	if first == "" {
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix < 0 {
+			return nil, nil
+		}
+		if suffix == 0 || file.Size == 0 {
+			return nil, ErrRangeNotSatisfiable
+		}
+		if suffix > file.Size {
+			suffix = file.Size
+		}
+		return &Range{Start: file.Size - suffix, Length: suffix}, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	end := file.Size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return nil, nil
+		}
+		if end >= file.Size {
+			end = file.Size - 1
+		}
+	}
+	if start >= file.Size {
+		return nil, ErrRangeNotSatisfiable
+	}
+	return &Range{Start: start, Length: end - start + 1}, nil
+}
+
+// ifRangeMatches checks the If-Range validator, a strong entity tag or the last modification date
+func ifRangeMatches(ifRange string, file FileInfo) bool {
+	ifRange = strings.TrimSpace(ifRange)
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		return file.ETag != "" && ifRange == quoteETag(file.ETag)
+	}
+	date, err := http.ParseTime(ifRange)
+	if err != nil {
+		return false
+	}
+	return !file.LastModified.IsZero() && date.Equal(file.LastModified.UTC().Truncate(time.Second))
+}
+
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) {
+		return etag
+	}
+	return strconv.Quote(etag)
+}
+
+// NewRangeResponder serves the file, or the requested range of it, with the headers clients use
+// to resume downloads
+func NewRangeResponder(next middleware.Responder, file FileInfo, rng *Range) middleware.Responder {
+	return &rangeResponder{next: next, file: file, rng: rng}
+}
+
+type rangeResponder struct {
+	next middleware.Responder
+	file FileInfo
+	rng  *Range
+}
+
+func (f *rangeResponder) WriteResponse(rw http.ResponseWriter, r runtime.Producer) {
+	setValidators(rw, f.file)
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", f.file.Name))
+	length := f.file.Size
+	if f.rng != nil {
+		length = f.rng.Length
+		rw.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", f.rng.Start, f.rng.Start+f.rng.Length-1, f.file.Size))
+	}
+	rw.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	f.next.WriteResponse(rw, r)
+}
+
+// NewRangeNotSatisfiableResponder adds the size of the file to the response of an unsatisfiable range
+func NewRangeNotSatisfiableResponder(next middleware.Responder, file FileInfo) middleware.Responder {
+	return &rangeNotSatisfiableResponder{next: next, file: file}
+}
+
+type rangeNotSatisfiableResponder struct {
+	next middleware.Responder
+	file FileInfo
+}
+
+func (f *rangeNotSatisfiableResponder) WriteResponse(rw http.ResponseWriter, r runtime.Producer) {
+	setValidators(rw, f.file)
+	rw.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", f.file.Size))
+	f.next.WriteResponse(rw, r)
+}
+
+func setValidators(rw http.ResponseWriter, file FileInfo) {
+	rw.Header().Set("Accept-Ranges", "bytes")
+	if file.ETag != "" {
+		rw.Header().Set("ETag", quoteETag(file.ETag))
+	}
+	if !file.LastModified.IsZero() {
+		rw.Header().Set("Last-Modified", file.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,93 @@
+package filemiddleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFileMiddleware(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "File middleware tests Suite")
+}
+
+var _ = Describe("ParseRange", func() {
+	lastModified := time.Date(2020, 7, 1, 10, 30, 0, 0, time.UTC)
+	file := FileInfo{Name: "discovery.iso", Size: 100, ETag: "abc", LastModified: lastModified}
+
+	tests := []struct {
+		name        string
+		rangeHeader string
+		ifRange     string
+		expected    *Range
+		err         error
+	}{
+		{name: "no range", rangeHeader: "", expected: nil},
+		{name: "closed range", rangeHeader: "bytes=10-19", expected: &Range{Start: 10, Length: 10}},
+		{name: "open range", rangeHeader: "bytes=90-", expected: &Range{Start: 90, Length: 10}},
+		{name: "suffix range", rangeHeader: "bytes=-30", expected: &Range{Start: 70, Length: 30}},
+		{name: "suffix longer than the file", rangeHeader: "bytes=-300", expected: &Range{Start: 0, Length: 100}},
+		{name: "end past the file", rangeHeader: "bytes=50-500", expected: &Range{Start: 50, Length: 50}},
+		{name: "start past the file", rangeHeader: "bytes=100-", err: ErrRangeNotSatisfiable},
+		{name: "empty suffix", rangeHeader: "bytes=-0", err: ErrRangeNotSatisfiable},
+		{name: "multiple ranges", rangeHeader: "bytes=0-1,5-6", expected: nil},
+		{name: "other unit", rangeHeader: "items=0-1", expected: nil},
+		{name: "reversed range", rangeHeader: "bytes=20-10", expected: nil},
+		{name: "invalid range", rangeHeader: "bytes=a-b", expected: nil},
+		{name: "matching etag", rangeHeader: "bytes=10-19", ifRange: `"abc"`, expected: &Range{Start: 10, Length: 10}},
+		{name: "different etag", rangeHeader: "bytes=10-19", ifRange: `"def"`, expected: nil},
+		{name: "weak etag", rangeHeader: "bytes=10-19", ifRange: `W/"abc"`, expected: nil},
+		{name: "matching date", rangeHeader: "bytes=10-19", ifRange: lastModified.Format(http.TimeFormat), expected: &Range{Start: 10, Length: 10}},
+		{name: "different date", rangeHeader: "bytes=10-19", ifRange: lastModified.Add(time.Hour).Format(http.TimeFormat), expected: nil},
+	}
+
+	for i := range tests {
+		t := tests[i]
+		It(t.name, func() {
+			rng, err := ParseRange(t.rangeHeader, t.ifRange, file)
+			if t.err != nil {
+				Expect(err).Should(Equal(t.err))
+			} else {
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+			Expect(rng).Should(Equal(t.expected))
+		})
+	}
+})
+
+var _ = Describe("NewRangeResponder", func() {
+	file := FileInfo{Name: "discovery.iso", Size: 100, ETag: "abc", LastModified: time.Date(2020, 7, 1, 10, 30, 0, 0, time.UTC)}
+	next := middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	It("sets the headers of the whole file", func() {
+		rec := httptest.NewRecorder()
+		NewRangeResponder(next, file, nil).WriteResponse(rec, nil)
+		Expect(rec.Header().Get("Content-Length")).Should(Equal("100"))
+		Expect(rec.Header().Get("Content-Range")).Should(BeEmpty())
+		Expect(rec.Header().Get("Accept-Ranges")).Should(Equal("bytes"))
+		Expect(rec.Header().Get("ETag")).Should(Equal(`"abc"`))
+		Expect(rec.Header().Get("Last-Modified")).Should(Equal("Wed, 01 Jul 2020 10:30:00 GMT"))
+		Expect(rec.Header().Get("Content-Disposition")).Should(Equal(`attachment; filename="discovery.iso"`))
+	})
+
+	It("sets the headers of a range", func() {
+		rec := httptest.NewRecorder()
+		NewRangeResponder(next, file, &Range{Start: 10, Length: 20}).WriteResponse(rec, nil)
+		Expect(rec.Header().Get("Content-Length")).Should(Equal("20"))
+		Expect(rec.Header().Get("Content-Range")).Should(Equal("bytes 10-29/100"))
+	})
+
+	It("sets the size of an unsatisfiable range", func() {
+		rec := httptest.NewRecorder()
+		NewRangeNotSatisfiableResponder(next, file).WriteResponse(rec, nil)
+		Expect(rec.Header().Get("Content-Range")).Should(Equal("bytes */100"))
+	})
+})
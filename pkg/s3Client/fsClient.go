@@ -0,0 +1,293 @@
+package s3Client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	logutil "github.com/openshift/assisted-service/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// StorageS3 keeps the files in an S3 bucket
+	StorageS3 = "s3"
+	// StorageFilesystem keeps the files in a local directory, for deployments without an S3 service
+	StorageFilesystem = "filesystem"
+
+	// The tags of the objects are kept apart from the buckets, so that they are never listed as objects
+	fsTagsDir = ".tags"
+)
+
+type Config struct {
+	Storage   string `envconfig:"STORAGE" default:"s3"` // s3 or filesystem, the k8s jobs can only upload their files to s3
+	FSBaseDir string `envconfig:"STORAGE_FS_BASE_DIR" default:"/data/storage"`
+}
+
+// fsClient implements the storage on the local filesystem, each bucket is a directory under
+// the base directory and object names are paths relative to it
+type fsClient struct {
+	log     logrus.FieldLogger
+	baseDir string
+}
+
+func NewFSClient(baseDir string, logger logrus.FieldLogger) (S3Client, error) {
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "Unable to create storage directory %s", baseDir)
+	}
+	return &fsClient{log: logger, baseDir: baseDir}, nil
+}
+
+func (f *fsClient) objectPath(objectName, s3Bucket string) (string, error) {
+	bucketDir := filepath.Join(f.baseDir, s3Bucket)
+	path := filepath.Join(bucketDir, filepath.FromSlash(objectName))
+	if s3Bucket == "" || strings.HasPrefix(s3Bucket, ".") || !strings.HasPrefix(path, bucketDir+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid object name %s in %s", objectName, s3Bucket)
+	}
+	return path, nil
+}
+
+func (f *fsClient) tagsPath(objectName, s3Bucket string) string {
+	return filepath.Join(f.baseDir, fsTagsDir, s3Bucket, filepath.FromSlash(objectName)+".json")
+}
+
+// writeFile replaces the file atomically, readers never see a partially written object
+func writeFile(path string, reader io.Reader, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	written, err := io.Copy(tmp, reader)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if written != size {
+		return errors.Errorf("expected %d bytes but got %d", size, written)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *fsClient) PushDataToS3(ctx context.Context, data []byte, fileName string, s3Bucket string) error {
+	return f.UploadStream(ctx, bytes.NewReader(data), int64(len(data)), fileName, s3Bucket)
+}
+
+func (f *fsClient) UploadStream(ctx context.Context, reader io.Reader, size int64, fileName string, s3Bucket string) error {
+	log := logutil.FromContext(ctx, f.log)
+	path, err := f.objectPath(fileName, s3Bucket)
+	if err != nil {
+		return err
+	}
+	if err = writeFile(path, reader, size); err != nil {
+		err = errors.Wrapf(err, "Unable to upload %s to %s", fileName, s3Bucket)
+		log.Error(err)
+		return err
+	}
+	// A new object doesn't keep the tags of the one it replaced
+	if err = os.Remove(f.tagsPath(fileName, s3Bucket)); err != nil && !os.IsNotExist(err) {
+		log.WithError(err).Warnf("Failed to remove the tags of %s", fileName)
+	}
+	log.Infof("Successfully uploaded %s to %s", fileName, s3Bucket)
+	return nil
+}
+
+func (f *fsClient) DownloadFileFromS3(ctx context.Context, fileName string, s3Bucket string) (io.ReadCloser, int64, error) {
+	log := logutil.FromContext(ctx, f.log)
+	log.Infof("Downloading %s from bucket %s", fileName, s3Bucket)
+	file, info, err := f.openObject(ctx, fileName, s3Bucket)
+	if err != nil {
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+func (f *fsClient) openObject(ctx context.Context, objectName string, s3Bucket string) (*os.File, os.FileInfo, error) {
+	log := logutil.FromContext(ctx, f.log)
+	path, err := f.objectPath(objectName, s3Bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Warnf("%s doesn't exists in bucket %s", objectName, s3Bucket)
+			return nil, nil, errors.Errorf("%s doesn't exist", objectName)
+		}
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return file, info, nil
+}
+
+func (f *fsClient) DoesObjectExist(ctx context.Context, objectName string, s3Bucket string) (bool, error) {
+	log := logutil.FromContext(ctx, f.log)
+	log.Infof("Verifying if %s exists in %s", objectName, s3Bucket)
+	path, err := f.objectPath(objectName, s3Bucket)
+	if err != nil {
+		return false, err
+	}
+	if _, err = os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get %s from %s", objectName, s3Bucket)
+	}
+	return true, nil
+}
+
+func (f *fsClient) DeleteFileFromS3(ctx context.Context, fileName string, s3Bucket string) error {
+	log := logutil.FromContext(ctx, f.log)
+	log.Infof("Deleting file if %s exists in %s", fileName, s3Bucket)
+	path, err := f.objectPath(fileName, s3Bucket)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			log.Warnf("File %s does not exists in %s", fileName, s3Bucket)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete %s from %s", fileName, s3Bucket)
+	}
+	if err = os.Remove(f.tagsPath(fileName, s3Bucket)); err != nil && !os.IsNotExist(err) {
+		log.WithError(err).Warnf("Failed to remove the tags of %s", fileName)
+	}
+	log.Infof("Deleted file %s from %s", fileName, s3Bucket)
+	return nil
+}
+
+// UpdateObjectTag replaces the tags of the object, the same as the S3 implementation does
+func (f *fsClient) UpdateObjectTag(ctx context.Context, objectName, s3Bucket, key, value string) (bool, error) {
+	log := logutil.FromContext(ctx, f.log)
+	log.Infof("Adding tag: %s - %s", key, value)
+	exists, err := f.DoesObjectExist(ctx, objectName, s3Bucket)
+	if err != nil || !exists {
+		return false, err
+	}
+	data, err := json.Marshal(map[string]string{key: value})
+	if err != nil {
+		return false, err
+	}
+	if err = writeFile(f.tagsPath(objectName, s3Bucket), bytes.NewReader(data), int64(len(data))); err != nil {
+		return false, errors.Wrapf(err, "failed to update tags on %s/%s", s3Bucket, objectName)
+	}
+	return true, nil
+}
+
+func (f *fsClient) GetObjectTags(ctx context.Context, objectName, s3Bucket string) (map[string]string, error) {
+	exists, err := f.DoesObjectExist(ctx, objectName, s3Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("%s doesn't exist", objectName)
+	}
+	tags := map[string]string{}
+	data, err := ioutil.ReadFile(f.tagsPath(objectName, s3Bucket))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return tags, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get tags of %s/%s", s3Bucket, objectName)
+	}
+	if err = json.Unmarshal(data, &tags); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse tags of %s/%s", s3Bucket, objectName)
+	}
+	return tags, nil
+}
+
+func (f *fsClient) ListObjectsByPrefix(ctx context.Context, prefix string, s3Bucket string) ([]ObjectInfo, error) {
+	bucketDir := filepath.Join(f.baseDir, s3Bucket)
+	var objects []ObjectInfo
+	err := filepath.Walk(bucketDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == bucketDir {
+				return nil
+			}
+			return err
+		}
+		// Skip the temporary files of uploads in progress
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(bucketDir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, ObjectInfo{Key: key, Size: info.Size(), LastModified: info.ModTime(), ETag: fsETag(info)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list objects with prefix %s in %s", prefix, s3Bucket)
+	}
+	return objects, nil
+}
+
+// fsETag derives the entity tag from the size and modification time, objects are always replaced
+// by a rename so a new upload changes it
+func fsETag(info os.FileInfo) string {
+	return fmt.Sprintf("%x-%x", info.ModTime().UnixNano(), info.Size())
+}
+
+func (f *fsClient) GetObjectInfo(ctx context.Context, objectName, s3Bucket string) (ObjectInfo, error) {
+	log := logutil.FromContext(ctx, f.log)
+	path, err := f.objectPath(objectName, s3Bucket)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Warnf("%s doesn't exists in bucket %s", objectName, s3Bucket)
+			return ObjectInfo{}, errors.Errorf("%s doesn't exist", objectName)
+		}
+		return ObjectInfo{}, errors.Wrapf(err, "failed to get %s from %s", objectName, s3Bucket)
+	}
+	return ObjectInfo{Key: objectName, Size: info.Size(), LastModified: info.ModTime(), ETag: fsETag(info)}, nil
+}
+
+type limitedFile struct {
+	io.Reader
+	io.Closer
+}
+
+func (f *fsClient) DownloadRange(ctx context.Context, objectName, s3Bucket string, offset, length int64, etag string) (io.ReadCloser, error) {
+	log := logutil.FromContext(ctx, f.log)
+	log.Infof("Downloading bytes %d-%d of %s from bucket %s", offset, offset+length-1, objectName, s3Bucket)
+	file, info, err := f.openObject(ctx, objectName, s3Bucket)
+	if err != nil {
+		return nil, err
+	}
+	// The open file keeps the contents it was opened with, even if the object is replaced meanwhile
+	if etag != "" && fsETag(info) != etag {
+		file.Close()
+		log.Infof("%s has changed, it no longer has the entity tag %s", objectName, etag)
+		return nil, ErrObjectChanged
+	}
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return limitedFile{Reader: io.LimitReader(file, length), Closer: file}, nil
+}
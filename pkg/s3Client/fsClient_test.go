@@ -0,0 +1,164 @@
+package s3Client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+func TestStorage(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Storage tests Suite")
+}
+
+var _ = Describe("fsClient", func() {
+	const bucket = "test"
+	var (
+		ctx     = context.Background()
+		baseDir string
+		client  S3Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseDir, err = ioutil.TempDir("", "storage")
+		Expect(err).ShouldNot(HaveOccurred())
+		log := logrus.New()
+		log.SetOutput(ioutil.Discard)
+		client, err = NewFSClient(baseDir, log)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(baseDir)
+	})
+
+	download := func(objectName string) string {
+		reader, size, err := client.DownloadFileFromS3(ctx, objectName, bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer reader.Close()
+		content, err := ioutil.ReadAll(reader)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(int64(len(content))).Should(Equal(size))
+		return string(content)
+	}
+
+	It("pushes, downloads and deletes objects", func() {
+		Expect(client.PushDataToS3(ctx, []byte("kubeconfig"), "cluster-id/kubeconfig", bucket)).ShouldNot(HaveOccurred())
+		Expect(download("cluster-id/kubeconfig")).Should(Equal("kubeconfig"))
+		exists, err := client.DoesObjectExist(ctx, "cluster-id/kubeconfig", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(exists).Should(BeTrue())
+
+		Expect(client.DeleteFileFromS3(ctx, "cluster-id/kubeconfig", bucket)).ShouldNot(HaveOccurred())
+		exists, err = client.DoesObjectExist(ctx, "cluster-id/kubeconfig", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(exists).Should(BeFalse())
+		_, _, err = client.DownloadFileFromS3(ctx, "cluster-id/kubeconfig", bucket)
+		Expect(err).Should(HaveOccurred())
+		// deleting a missing object isn't an error, the same as with S3
+		Expect(client.DeleteFileFromS3(ctx, "cluster-id/kubeconfig", bucket)).ShouldNot(HaveOccurred())
+	})
+
+	It("uploads streams", func() {
+		Expect(client.UploadStream(ctx, strings.NewReader("image"), 5, "discovery-image-1", bucket)).ShouldNot(HaveOccurred())
+		Expect(download("discovery-image-1")).Should(Equal("image"))
+		Expect(client.UploadStream(ctx, strings.NewReader("short"), 10, "discovery-image-2", bucket)).Should(HaveOccurred())
+		exists, err := client.DoesObjectExist(ctx, "discovery-image-2", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(exists).Should(BeFalse())
+	})
+
+	It("updates tags", func() {
+		updated, err := client.UpdateObjectTag(ctx, "discovery-image-1", bucket, "create_sec_since_epoch", "1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(updated).Should(BeFalse())
+
+		Expect(client.PushDataToS3(ctx, []byte("image"), "discovery-image-1", bucket)).ShouldNot(HaveOccurred())
+		tags, err := client.GetObjectTags(ctx, "discovery-image-1", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tags).Should(BeEmpty())
+		updated, err = client.UpdateObjectTag(ctx, "discovery-image-1", bucket, "create_sec_since_epoch", "1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(updated).Should(BeTrue())
+		tags, err = client.GetObjectTags(ctx, "discovery-image-1", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tags).Should(Equal(map[string]string{"create_sec_since_epoch": "1"}))
+
+		// replacing the object drops its tags
+		Expect(client.PushDataToS3(ctx, []byte("image"), "discovery-image-1", bucket)).ShouldNot(HaveOccurred())
+		tags, err = client.GetObjectTags(ctx, "discovery-image-1", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tags).Should(BeEmpty())
+	})
+
+	It("lists objects by prefix", func() {
+		objects, err := client.ListObjectsByPrefix(ctx, "discovery-image-", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(objects).Should(BeEmpty())
+
+		Expect(client.PushDataToS3(ctx, []byte("image"), "discovery-image-1", bucket)).ShouldNot(HaveOccurred())
+		Expect(client.PushDataToS3(ctx, []byte("image"), "discovery-image-2", bucket)).ShouldNot(HaveOccurred())
+		Expect(client.PushDataToS3(ctx, []byte("kubeconfig"), "cluster-id/kubeconfig", bucket)).ShouldNot(HaveOccurred())
+		_, err = client.UpdateObjectTag(ctx, "discovery-image-1", bucket, "create_sec_since_epoch", "1")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		objects, err = client.ListObjectsByPrefix(ctx, "discovery-image-", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		var keys []string
+		for _, object := range objects {
+			keys = append(keys, object.Key)
+			Expect(object.Size).Should(Equal(int64(5)))
+		}
+		Expect(keys).Should(ConsistOf("discovery-image-1", "discovery-image-2"))
+
+		objects, err = client.ListObjectsByPrefix(ctx, "cluster-id/", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(objects).Should(HaveLen(1))
+		Expect(objects[0].Key).Should(Equal("cluster-id/kubeconfig"))
+	})
+
+	It("downloads ranges", func() {
+		Expect(client.PushDataToS3(ctx, []byte("discovery image"), "discovery-image-1", bucket)).ShouldNot(HaveOccurred())
+		info, err := client.GetObjectInfo(ctx, "discovery-image-1", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(info.Size).Should(Equal(int64(15)))
+		Expect(info.ETag).ShouldNot(BeEmpty())
+
+		reader, err := client.DownloadRange(ctx, "discovery-image-1", bucket, 10, 5, "")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer reader.Close()
+		content, err := ioutil.ReadAll(reader)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(content)).Should(Equal("image"))
+
+		_, err = client.GetObjectInfo(ctx, "discovery-image-2", bucket)
+		Expect(err).Should(HaveOccurred())
+		_, err = client.DownloadRange(ctx, "discovery-image-2", bucket, 0, 1, "")
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("downloads a range only from the object with the entity tag", func() {
+		Expect(client.PushDataToS3(ctx, []byte("discovery image"), "discovery-image-1", bucket)).ShouldNot(HaveOccurred())
+		info, err := client.GetObjectInfo(ctx, "discovery-image-1", bucket)
+		Expect(err).ShouldNot(HaveOccurred())
+		reader, err := client.DownloadRange(ctx, "discovery-image-1", bucket, 0, 9, info.ETag)
+		Expect(err).ShouldNot(HaveOccurred())
+		reader.Close()
+
+		Expect(client.PushDataToS3(ctx, []byte("new image"), "discovery-image-1", bucket)).ShouldNot(HaveOccurred())
+		_, err = client.DownloadRange(ctx, "discovery-image-1", bucket, 0, 9, info.ETag)
+		Expect(err).Should(Equal(ErrObjectChanged))
+	})
+
+	It("rejects objects outside of the bucket", func() {
+		Expect(client.PushDataToS3(ctx, []byte("data"), "../escape", bucket)).Should(HaveOccurred())
+		Expect(client.PushDataToS3(ctx, []byte("data"), "object", ".tags")).Should(HaveOccurred())
+	})
+})
@@ -48,6 +48,20 @@ func (mr *MockS3ClientMockRecorder) PushDataToS3(ctx, data, fileName, s3Bucket i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushDataToS3", reflect.TypeOf((*MockS3Client)(nil).PushDataToS3), ctx, data, fileName, s3Bucket)
 }
 
+// UploadStream mocks base method
+func (m *MockS3Client) UploadStream(ctx context.Context, reader io.Reader, size int64, fileName, s3Bucket string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadStream", ctx, reader, size, fileName, s3Bucket)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadStream indicates an expected call of UploadStream
+func (mr *MockS3ClientMockRecorder) UploadStream(ctx, reader, size, fileName, s3Bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadStream", reflect.TypeOf((*MockS3Client)(nil).UploadStream), ctx, reader, size, fileName, s3Bucket)
+}
+
 // DownloadFileFromS3 mocks base method
 func (m *MockS3Client) DownloadFileFromS3(ctx context.Context, fileName, s3Bucket string) (io.ReadCloser, int64, error) {
 	m.ctrl.T.Helper()
@@ -107,3 +121,63 @@ func (mr *MockS3ClientMockRecorder) DeleteFileFromS3(ctx, fileName, s3Bucket int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileFromS3", reflect.TypeOf((*MockS3Client)(nil).DeleteFileFromS3), ctx, fileName, s3Bucket)
 }
+
+// GetObjectTags mocks base method
+func (m *MockS3Client) GetObjectTags(ctx context.Context, objectName, s3Bucket string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectTags", ctx, objectName, s3Bucket)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectTags indicates an expected call of GetObjectTags
+func (mr *MockS3ClientMockRecorder) GetObjectTags(ctx, objectName, s3Bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectTags", reflect.TypeOf((*MockS3Client)(nil).GetObjectTags), ctx, objectName, s3Bucket)
+}
+
+// ListObjectsByPrefix mocks base method
+func (m *MockS3Client) ListObjectsByPrefix(ctx context.Context, prefix, s3Bucket string) ([]ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjectsByPrefix", ctx, prefix, s3Bucket)
+	ret0, _ := ret[0].([]ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjectsByPrefix indicates an expected call of ListObjectsByPrefix
+func (mr *MockS3ClientMockRecorder) ListObjectsByPrefix(ctx, prefix, s3Bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectsByPrefix", reflect.TypeOf((*MockS3Client)(nil).ListObjectsByPrefix), ctx, prefix, s3Bucket)
+}
+
+// GetObjectInfo mocks base method
+func (m *MockS3Client) GetObjectInfo(ctx context.Context, objectName, s3Bucket string) (ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectInfo", ctx, objectName, s3Bucket)
+	ret0, _ := ret[0].(ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectInfo indicates an expected call of GetObjectInfo
+func (mr *MockS3ClientMockRecorder) GetObjectInfo(ctx, objectName, s3Bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectInfo", reflect.TypeOf((*MockS3Client)(nil).GetObjectInfo), ctx, objectName, s3Bucket)
+}
+
+// DownloadRange mocks base method
+func (m *MockS3Client) DownloadRange(ctx context.Context, objectName, s3Bucket string, offset, length int64, etag string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadRange", ctx, objectName, s3Bucket, offset, length, etag)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadRange indicates an expected call of DownloadRange
+func (mr *MockS3ClientMockRecorder) DownloadRange(ctx, objectName, s3Bucket, offset, length, etag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadRange", reflect.TypeOf((*MockS3Client)(nil).DownloadRange), ctx, objectName, s3Bucket, offset, length, etag)
+}
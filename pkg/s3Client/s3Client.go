@@ -3,9 +3,12 @@ package s3Client
 import (
 	"bytes"
 	"context"
+	"encoding/xml"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
+	"time"
 
 	logutil "github.com/openshift/assisted-service/pkg/log"
 
@@ -17,10 +20,26 @@ import (
 //go:generate mockgen -source=s3Client.go -package=s3Client -destination=mock_s3client.go
 type S3Client interface {
 	PushDataToS3(ctx context.Context, data []byte, fileName string, s3Bucket string) error
+	UploadStream(ctx context.Context, reader io.Reader, size int64, fileName string, s3Bucket string) error
 	DownloadFileFromS3(ctx context.Context, fileName string, s3Bucket string) (io.ReadCloser, int64, error)
 	DoesObjectExist(ctx context.Context, fileName string, s3Bucket string) (bool, error)
 	UpdateObjectTag(ctx context.Context, objectName, s3Bucket, key, value string) (bool, error)
 	DeleteFileFromS3(ctx context.Context, fileName string, s3Bucket string) error
+	GetObjectTags(ctx context.Context, objectName, s3Bucket string) (map[string]string, error)
+	ListObjectsByPrefix(ctx context.Context, prefix string, s3Bucket string) ([]ObjectInfo, error)
+	GetObjectInfo(ctx context.Context, objectName, s3Bucket string) (ObjectInfo, error)
+	DownloadRange(ctx context.Context, objectName, s3Bucket string, offset, length int64, etag string) (io.ReadCloser, error)
+}
+
+// ErrObjectChanged is returned by DownloadRange when the object no longer has the expected entity tag
+var ErrObjectChanged = errors.New("the object has changed")
+
+// ObjectInfo describes an object of the storage
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	ETag         string
 }
 
 type s3Client struct {
@@ -50,6 +69,18 @@ func (s s3Client) PushDataToS3(ctx context.Context, data []byte, fileName string
 	return nil
 }
 
+func (s s3Client) UploadStream(ctx context.Context, reader io.Reader, size int64, fileName string, s3Bucket string) error {
+	log := logutil.FromContext(ctx, s.log)
+	_, err := s.client.PutObject(s3Bucket, fileName, reader, size, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	if err != nil {
+		err = errors.Wrapf(err, "Unable to upload %s to %s", fileName, s3Bucket)
+		log.Error(err)
+		return err
+	}
+	log.Infof("Successfully uploaded %s to %s", fileName, s3Bucket)
+	return nil
+}
+
 func (s s3Client) DownloadFileFromS3(ctx context.Context, fileName string, s3Bucket string) (io.ReadCloser, int64, error) {
 	log := logutil.FromContext(ctx, s.log)
 	log.Infof("Downloading %s from bucket %s", fileName, s3Bucket)
@@ -118,3 +149,89 @@ func (s s3Client) UpdateObjectTag(ctx context.Context, objectName, s3Bucket, key
 	}
 	return true, nil
 }
+
+func (s s3Client) GetObjectTags(ctx context.Context, objectName, s3Bucket string) (map[string]string, error) {
+	log := logutil.FromContext(ctx, s.log)
+	body, err := s.client.GetObjectTagging(s3Bucket, objectName)
+	if err != nil {
+		errResponse := minio.ToErrorResponse(err)
+		if errResponse.Code == "NoSuchKey" {
+			log.Warnf("%s doesn't exists in bucket %s", objectName, s3Bucket)
+			return nil, errors.Errorf("%s doesn't exist", objectName)
+		}
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to get tags of %s/%s", s3Bucket, objectName))
+	}
+	var tagging struct {
+		Tags []struct {
+			Key   string `xml:"Key"`
+			Value string `xml:"Value"`
+		} `xml:"TagSet>Tag"`
+	}
+	if err = xml.Unmarshal([]byte(body), &tagging); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse tags of %s/%s", s3Bucket, objectName))
+	}
+	tags := make(map[string]string, len(tagging.Tags))
+	for _, tag := range tagging.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags, nil
+}
+
+func (s s3Client) ListObjectsByPrefix(ctx context.Context, prefix string, s3Bucket string) ([]ObjectInfo, error) {
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	var objects []ObjectInfo
+	for object := range s.client.ListObjectsV2(s3Bucket, prefix, true, doneCh) {
+		if object.Err != nil {
+			return nil, errors.Wrap(object.Err, fmt.Sprintf("failed to list objects with prefix %s in %s", prefix, s3Bucket))
+		}
+		objects = append(objects, ObjectInfo{Key: object.Key, Size: object.Size, LastModified: object.LastModified, ETag: object.ETag})
+	}
+	return objects, nil
+}
+
+func (s s3Client) GetObjectInfo(ctx context.Context, objectName, s3Bucket string) (ObjectInfo, error) {
+	log := logutil.FromContext(ctx, s.log)
+	stat, err := s.client.StatObject(s3Bucket, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		errResponse := minio.ToErrorResponse(err)
+		if errResponse.Code == "NoSuchKey" {
+			log.Warnf("%s doesn't exists in bucket %s", objectName, s3Bucket)
+			return ObjectInfo{}, errors.Errorf("%s doesn't exist", objectName)
+		}
+		return ObjectInfo{}, errors.Wrap(err, fmt.Sprintf("failed to get %s from %s", objectName, s3Bucket))
+	}
+	return ObjectInfo{Key: stat.Key, Size: stat.Size, LastModified: stat.LastModified, ETag: stat.ETag}, nil
+}
+
+// DownloadRange returns length bytes of the object, starting at offset. When the etag is given the bytes are
+// read only if the object still has this entity tag, ErrObjectChanged is returned otherwise.
+func (s s3Client) DownloadRange(ctx context.Context, objectName, s3Bucket string, offset, length int64, etag string) (io.ReadCloser, error) {
+	log := logutil.FromContext(ctx, s.log)
+	log.Infof("Downloading bytes %d-%d of %s from bucket %s", offset, offset+length-1, objectName, s3Bucket)
+	opts := minio.GetObjectOptions{}
+	if err := opts.SetRange(offset, offset+length-1); err != nil {
+		return nil, err
+	}
+	if etag != "" {
+		if err := opts.SetMatchETag(etag); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := s.client.GetObject(s3Bucket, objectName, opts)
+	if err != nil {
+		log.WithError(err).Errorf("Failed to get %s file", objectName)
+		return nil, err
+	}
+	// The object is requested lazily, stat it to find out whether the precondition holds before any byte is served
+	if _, err = resp.Stat(); err != nil {
+		resp.Close()
+		if minio.ToErrorResponse(err).StatusCode == http.StatusPreconditionFailed {
+			log.Infof("%s has changed, it no longer has the entity tag %s", objectName, etag)
+			return nil, ErrObjectChanged
+		}
+		log.WithError(err).Errorf("Failed to get %s file", objectName)
+		return nil, err
+	}
+	return resp, nil
+}
@@ -57,6 +57,9 @@ type InstallerAPI interface {
 	/* DownloadClusterISO Downloads the OpenShift per-cluster discovery ISO. */
 	DownloadClusterISO(ctx context.Context, params installer.DownloadClusterISOParams) middleware.Responder
 
+	/* DownloadClusterISOPresigned Downloads the OpenShift per-cluster discovery ISO with a signed URL. */
+	DownloadClusterISOPresigned(ctx context.Context, params installer.DownloadClusterISOPresignedParams) middleware.Responder
+
 	/* DownloadClusterKubeconfig Downloads the kubeconfig file for this cluster. */
 	DownloadClusterKubeconfig(ctx context.Context, params installer.DownloadClusterKubeconfigParams) middleware.Responder
 
@@ -69,12 +72,18 @@ type InstallerAPI interface {
 	/* GetCluster Retrieves the details of the OpenShift bare metal cluster. */
 	GetCluster(ctx context.Context, params installer.GetClusterParams) middleware.Responder
 
+	/* GetClusterConnectivityMatrix Retrieves the L2 and L3 connectivity between the hosts of the cluster, aggregated from their connectivity reports. */
+	GetClusterConnectivityMatrix(ctx context.Context, params installer.GetClusterConnectivityMatrixParams) middleware.Responder
+
 	/* GetCredentials Get the the cluster admin credentials. */
 	GetCredentials(ctx context.Context, params installer.GetCredentialsParams) middleware.Responder
 
 	/* GetFreeAddresses Retrieves the free address list for a network. */
 	GetFreeAddresses(ctx context.Context, params installer.GetFreeAddressesParams) middleware.Responder
 
+	/* GetPresignedForClusterISO Creates a time limited URL for downloading the discovery ISO without credentials, e.g. by BMC virtual media. */
+	GetPresignedForClusterISO(ctx context.Context, params installer.GetPresignedForClusterISOParams) middleware.Responder
+
 	/* GetHost Retrieves the details of the OpenShift bare metal host. */
 	GetHost(ctx context.Context, params installer.GetHostParams) middleware.Responder
 
@@ -108,6 +117,9 @@ type InstallerAPI interface {
 	/* UpdateCluster Updates an OpenShift bare metal cluster definition. */
 	UpdateCluster(ctx context.Context, params installer.UpdateClusterParams) middleware.Responder
 
+	/* UpdateClusterInstallConfig Override values in the install config. */
+	UpdateClusterInstallConfig(ctx context.Context, params installer.UpdateClusterInstallConfigParams) middleware.Responder
+
 	/* UpdateHostInstallProgress Update installation progress */
 	UpdateHostInstallProgress(ctx context.Context, params installer.UpdateHostInstallProgressParams) middleware.Responder
 
@@ -129,6 +141,9 @@ type ManagedDomainsAPI interface {
 type VersionsAPI interface {
 	/* ListComponentVersions List of componenets versions */
 	ListComponentVersions(ctx context.Context, params versions.ListComponentVersionsParams) middleware.Responder
+
+	/* ListSupportedOpenshiftVersions Retrieves the OpenShift versions the service can install. */
+	ListSupportedOpenshiftVersions(ctx context.Context, params versions.ListSupportedOpenshiftVersionsParams) middleware.Responder
 }
 
 // Config is configuration for Handler
@@ -197,6 +212,10 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx := params.HTTPRequest.Context()
 		return c.InstallerAPI.DownloadClusterISO(ctx, params)
 	})
+	api.InstallerDownloadClusterISOPresignedHandler = installer.DownloadClusterISOPresignedHandlerFunc(func(params installer.DownloadClusterISOPresignedParams) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		return c.InstallerAPI.DownloadClusterISOPresigned(ctx, params)
+	})
 	api.InstallerDownloadClusterKubeconfigHandler = installer.DownloadClusterKubeconfigHandlerFunc(func(params installer.DownloadClusterKubeconfigParams) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		return c.InstallerAPI.DownloadClusterKubeconfig(ctx, params)
@@ -213,6 +232,10 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx := params.HTTPRequest.Context()
 		return c.InstallerAPI.GetCluster(ctx, params)
 	})
+	api.InstallerGetClusterConnectivityMatrixHandler = installer.GetClusterConnectivityMatrixHandlerFunc(func(params installer.GetClusterConnectivityMatrixParams) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		return c.InstallerAPI.GetClusterConnectivityMatrix(ctx, params)
+	})
 	api.InstallerGetCredentialsHandler = installer.GetCredentialsHandlerFunc(func(params installer.GetCredentialsParams) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		return c.InstallerAPI.GetCredentials(ctx, params)
@@ -221,6 +244,10 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx := params.HTTPRequest.Context()
 		return c.InstallerAPI.GetFreeAddresses(ctx, params)
 	})
+	api.InstallerGetPresignedForClusterISOHandler = installer.GetPresignedForClusterISOHandlerFunc(func(params installer.GetPresignedForClusterISOParams) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		return c.InstallerAPI.GetPresignedForClusterISO(ctx, params)
+	})
 	api.InstallerGetHostHandler = installer.GetHostHandlerFunc(func(params installer.GetHostParams) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		return c.InstallerAPI.GetHost(ctx, params)
@@ -241,6 +268,10 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx := params.HTTPRequest.Context()
 		return c.VersionsAPI.ListComponentVersions(ctx, params)
 	})
+	api.VersionsListSupportedOpenshiftVersionsHandler = versions.ListSupportedOpenshiftVersionsHandlerFunc(func(params versions.ListSupportedOpenshiftVersionsParams) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		return c.VersionsAPI.ListSupportedOpenshiftVersions(ctx, params)
+	})
 	api.EventsListEventsHandler = events.ListEventsHandlerFunc(func(params events.ListEventsParams) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		return c.EventsAPI.ListEvents(ctx, params)
@@ -277,6 +308,10 @@ func HandlerAPI(c Config) (http.Handler, *operations.AssistedInstallAPI, error)
 		ctx := params.HTTPRequest.Context()
 		return c.InstallerAPI.UpdateCluster(ctx, params)
 	})
+	api.InstallerUpdateClusterInstallConfigHandler = installer.UpdateClusterInstallConfigHandlerFunc(func(params installer.UpdateClusterInstallConfigParams) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		return c.InstallerAPI.UpdateClusterInstallConfig(ctx, params)
+	})
 	api.InstallerUpdateHostInstallProgressHandler = installer.UpdateHostInstallProgressHandlerFunc(func(params installer.UpdateHostInstallProgressParams) middleware.Responder {
 		ctx := params.HTTPRequest.Context()
 		return c.InstallerAPI.UpdateHostInstallProgress(ctx, params)
@@ -419,6 +419,55 @@ func init() {
         }
       }
     },
+    "/clusters/{cluster_id}/connectivity-matrix": {
+      "get": {
+        "tags": [
+          "installer"
+        ],
+        "summary": "Retrieves the L2 and L3 connectivity between the hosts of the cluster, aggregated from their connectivity reports.",
+        "operationId": "GetClusterConnectivityMatrix",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "name": "cluster_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "enum": [
+              "json",
+              "csv"
+            ],
+            "type": "string",
+            "default": "json",
+            "description": "The format of the matrix, csv returns a row per NIC pair as text/csv.",
+            "name": "format",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/connectivity-matrix"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/clusters/{cluster_id}/credentials": {
       "get": {
         "tags": [
@@ -496,6 +545,18 @@ func init() {
             "name": "file_name",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "The byte range of the file to download.",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Serves the requested range only when the entity tag or the last modification date match the file.",
+            "name": "If-Range",
+            "in": "header"
           }
         ],
         "responses": {
@@ -505,6 +566,12 @@ func init() {
               "type": "file"
             }
           },
+          "206": {
+            "description": "Partial content.",
+            "schema": {
+              "type": "file"
+            }
+          },
           "404": {
             "description": "Error.",
             "schema": {
@@ -517,6 +584,12 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
+          "416": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -543,6 +616,18 @@ func init() {
             "name": "cluster_id",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "The byte range of the file to download.",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Serves the requested range only when the entity tag or the last modification date match the file.",
+            "name": "If-Range",
+            "in": "header"
           }
         ],
         "responses": {
@@ -553,6 +638,13 @@ func init() {
               "format": "binary"
             }
           },
+          "206": {
+            "description": "Partial content.",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            }
+          },
           "400": {
             "description": "Error.",
             "schema": {
@@ -565,6 +657,18 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
+          "409": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "416": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -597,7 +701,7 @@ func init() {
           }
         ],
         "responses": {
-          "201": {
+          "202": {
             "description": "Success.",
             "schema": {
               "$ref": "#/definitions/cluster"
@@ -630,6 +734,150 @@ func init() {
         }
       }
     },
+    "/clusters/{cluster_id}/downloads/image-presigned": {
+      "get": {
+        "produces": [
+          "application/octet-stream"
+        ],
+        "tags": [
+          "installer"
+        ],
+        "summary": "Downloads the OpenShift per-cluster discovery ISO with a signed URL.",
+        "operationId": "DownloadClusterISOPresigned",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "name": "cluster_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "The expiration time of the URL, in seconds since the epoch.",
+            "name": "expires_at",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The signature of the URL.",
+            "name": "signature",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The byte range of the file to download.",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Serves the requested range only when the entity tag or the last modification date match the file.",
+            "name": "If-Range",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            }
+          },
+          "206": {
+            "description": "Partial content.",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "401": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "416": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/clusters/{cluster_id}/downloads/image-url": {
+      "get": {
+        "tags": [
+          "installer"
+        ],
+        "summary": "Creates a time limited URL for downloading the discovery ISO without credentials, e.g. by BMC virtual media.",
+        "operationId": "GetPresignedForClusterISO",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "name": "cluster_id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/presigned"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/clusters/{cluster_id}/downloads/kubeconfig": {
       "get": {
         "produces": [
@@ -694,7 +942,7 @@ func init() {
             "required": true
           },
           {
-            "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$",
+            "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
             "type": "string",
             "name": "network",
             "in": "query",
@@ -759,6 +1007,12 @@ func init() {
               "$ref": "#/definitions/host-list"
             }
           },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -1216,13 +1470,13 @@ func init() {
         }
       }
     },
-    "/clusters/{cluster_id}/uploads/ingress-cert": {
-      "post": {
+    "/clusters/{cluster_id}/install-config": {
+      "patch": {
         "tags": [
           "installer"
         ],
-        "summary": "Transfer the ingress certificate for the cluster.",
-        "operationId": "UploadClusterIngressCert",
+        "summary": "Override values in the install config.",
+        "operationId": "UpdateClusterInstallConfig",
         "parameters": [
           {
             "type": "string",
@@ -1232,11 +1486,11 @@ func init() {
             "required": true
           },
           {
-            "name": "ingress-cert-params",
+            "name": "install-config-params",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/ingress-cert-params"
+              "$ref": "#/definitions/install-config-params"
             }
           }
         ],
@@ -1256,45 +1510,11 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
-          "500": {
+          "409": {
             "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
-          }
-        }
-      }
-    },
-    "/component_versions": {
-      "get": {
-        "tags": [
-          "versions"
-        ],
-        "summary": "List of componenets versions",
-        "operationId": "ListComponentVersions",
-        "responses": {
-          "200": {
-            "description": "Success.",
-            "schema": {
-              "$ref": "#/definitions/list-versions"
-            }
-          }
-        }
-      }
-    },
-    "/domains": {
-      "get": {
-        "tags": [
-          "managed_domains"
-        ],
-        "summary": "List of managed DNS domains",
-        "operationId": "ListManagedDomains",
-        "responses": {
-          "200": {
-            "description": "Success.",
-            "schema": {
-              "$ref": "#/definitions/list-managed-domains"
-            }
           },
           "500": {
             "description": "Error.",
@@ -1305,10 +1525,99 @@ func init() {
         }
       }
     },
-    "/events/{entity_id}": {
-      "get": {
+    "/clusters/{cluster_id}/uploads/ingress-cert": {
+      "post": {
         "tags": [
-          "events"
+          "installer"
+        ],
+        "summary": "Transfer the ingress certificate for the cluster.",
+        "operationId": "UploadClusterIngressCert",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "name": "cluster_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "ingress-cert-params",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ingress-cert-params"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success."
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/component_versions": {
+      "get": {
+        "tags": [
+          "versions"
+        ],
+        "summary": "List of componenets versions",
+        "operationId": "ListComponentVersions",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/list-versions"
+            }
+          }
+        }
+      }
+    },
+    "/domains": {
+      "get": {
+        "tags": [
+          "managed_domains"
+        ],
+        "summary": "List of managed DNS domains",
+        "operationId": "ListManagedDomains",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/list-managed-domains"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/events/{entity_id}": {
+      "get": {
+        "tags": [
+          "events"
         ],
         "summary": "Lists events for an entity_id",
         "operationId": "ListEvents",
@@ -1328,6 +1637,12 @@ func init() {
               "$ref": "#/definitions/event-list"
             }
           },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -1336,6 +1651,23 @@ func init() {
           }
         }
       }
+    },
+    "/openshift_versions": {
+      "get": {
+        "tags": [
+          "versions"
+        ],
+        "summary": "Retrieves the OpenShift versions the service can install.",
+        "operationId": "ListSupportedOpenshiftVersions",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/openshift-versions"
+            }
+          }
+        }
+      }
     }
   },
   "definitions": {
@@ -1361,10 +1693,15 @@ func init() {
         "status_info"
       ],
       "properties": {
+        "additional_trust_bundle": {
+          "description": "PEM-encoded X.509 certificate bundle. The discovered hosts and the installed cluster trust these certificates in addition to the ones of the system, e.g. of a TLS-intercepting proxy or of a mirror registry.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:TEXT\""
+        },
         "api_vip": {
           "description": "Virtual IP used to reach the OpenShift cluster API.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$"
         },
         "base_dns_domain": {
           "description": "Base domain of the cluster. All DNS records must be sub-domains of this base and include the cluster name.",
@@ -1373,12 +1710,12 @@ func init() {
         "cluster_network_cidr": {
           "description": "IP address block from which Pod IPs are allocated This block must not overlap with existing physical networks. These IP addresses are used for the Pod network, and if you need to access the Pods from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         },
         "cluster_network_host_prefix": {
-          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
+          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. For IPv6 cluster networks the prefix length may be up to 128. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
           "type": "integer",
-          "maximum": 32,
+          "maximum": 128,
           "minimum": 1
         },
         "created_at": {
@@ -1387,6 +1724,10 @@ func init() {
           "format": "date-time",
           "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
         },
+        "hardware_profile": {
+          "description": "The hardware profile that the hosts of the cluster are validated against. When empty, the profile of the OpenShift version or the default profile of the service is used.",
+          "type": "string"
+        },
         "host_networks": {
           "description": "List of host networks to be filled during query.",
           "type": "array",
@@ -1408,6 +1749,14 @@ func init() {
           "description": "Self link.",
           "type": "string"
         },
+        "http_proxy": {
+          "description": "A proxy URL to use for creating HTTP connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string"
+        },
+        "https_proxy": {
+          "description": "A proxy URL to use for creating HTTPS connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string"
+        },
         "id": {
           "description": "Unique identifier of the object.",
           "type": "string",
@@ -1424,7 +1773,7 @@ func init() {
         "ingress_vip": {
           "description": "Virtual IP used for cluster ingress traffic.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$"
         },
         "install_completed_at": {
           "description": "The time that this cluster completed installation.",
@@ -1432,6 +1781,11 @@ func init() {
           "format": "date-time",
           "x-go-custom-tag": "gorm:\"type:timestamp with time zone;default:'2000-01-01 00:00:00z'\""
         },
+        "install_config_overrides": {
+          "description": "JSON encoded partial install config that is merged over the generated one.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
         "install_started_at": {
           "description": "The time that this cluster began installation.",
           "type": "string",
@@ -1448,30 +1802,75 @@ func init() {
         "machine_network_cidr": {
           "description": "A CIDR that all hosts belonging to the cluster should have an interfaces with IP address that belongs to this CIDR. The api_vip belongs to this CIDR.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
+        "mirror_registries_config": {
+          "description": "JSON encoded mirror-registries-config, the mirror registries that the images are pulled from instead of their source registries.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
         },
         "name": {
           "description": "Name of the OpenShift cluster.",
           "type": "string"
         },
-        "openshift_version": {
-          "description": "Version of the OpenShift cluster.",
+        "network_type": {
+          "description": "The cluster network provider. OpenShiftSDN supports only IPv4, IPv6 and dual-stack networks require OVNKubernetes.",
           "type": "string",
           "enum": [
-            "4.5"
-          ]
+            "OpenShiftSDN",
+            "OVNKubernetes"
+          ],
+          "x-go-custom-tag": "gorm:\"default:'OpenShiftSDN'\""
+        },
+        "no_proxy": {
+          "description": "A comma-separated list of destination domain names, domains, IP addresses or other network CIDRs to exclude proxying.",
+          "type": "string"
+        },
+        "openshift_version": {
+          "description": "Version of the OpenShift cluster, one of the versions the service supports.",
+          "type": "string"
         },
         "org_id": {
           "type": "string"
         },
+        "platform": {
+          "description": "Platform of the cluster, the hosts are provisioned by the baremetal operator or by nothing.",
+          "type": "string",
+          "enum": [
+            "baremetal",
+            "none"
+          ],
+          "x-go-custom-tag": "gorm:\"default:'baremetal'\""
+        },
         "pull_secret_set": {
           "description": "True if the pull-secret has been added to the cluster",
           "type": "boolean"
         },
+        "secondary_cluster_network_cidr": {
+          "description": "An additional IP address block from which Pod IPs are allocated in a dual-stack cluster. Must be of the other IP family than cluster_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
+        "secondary_cluster_network_host_prefix": {
+          "description": "The subnet prefix length to assign to each individual node out of secondary_cluster_network_cidr.",
+          "type": "integer",
+          "maximum": 128,
+          "minimum": 1
+        },
+        "secondary_machine_network_cidr": {
+          "description": "An additional CIDR that the hosts of a dual-stack cluster have addresses in. Must be of the other IP family than machine_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
+        "secondary_service_network_cidr": {
+          "description": "An additional IP address pool to use for service IP addresses in a dual-stack cluster. Must be of the other IP family than service_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
         "service_network_cidr": {
           "description": "The IP address pool to use for service IP addresses. You can enter only one IP address pool. If you need to access the services from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         },
         "ssh_public_key": {
           "description": "SSH public key for debugging OpenShift nodes.",
@@ -1510,6 +1909,11 @@ func init() {
         },
         "user_id": {
           "type": "string"
+        },
+        "validations_info": {
+          "description": "Json formatted string containing the validations results for each validation id grouped by category (pull-secret, etc.)",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
         }
       }
     },
@@ -1520,6 +1924,10 @@ func init() {
         "openshift_version"
       ],
       "properties": {
+        "additional_trust_bundle": {
+          "description": "PEM-encoded X.509 certificate bundle. The discovered hosts and the installed cluster trust these certificates in addition to the ones of the system, e.g. of a TLS-intercepting proxy or of a mirror registry.",
+          "type": "string"
+        },
         "base_dns_domain": {
           "description": "Base domain of the cluster. All DNS records must be sub-domains of this base and include the cluster name.",
           "type": "string"
@@ -1528,40 +1936,91 @@ func init() {
           "description": "IP address block from which Pod IPs are allocated This block must not overlap with existing physical networks. These IP addresses are used for the Pod network, and if you need to access the Pods from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
           "default": "10.128.0.0/14",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         },
         "cluster_network_host_prefix": {
-          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
+          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. For IPv6 cluster networks the prefix length may be up to 128. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
           "type": "integer",
           "default": 23,
-          "maximum": 32,
+          "maximum": 128,
           "minimum": 1
         },
+        "hardware_profile": {
+          "description": "The hardware profile that the hosts of the cluster are validated against. When empty, the profile of the OpenShift version or the default profile of the service is used.",
+          "type": "string"
+        },
+        "http_proxy": {
+          "description": "A proxy URL to use for creating HTTP connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string"
+        },
+        "https_proxy": {
+          "description": "A proxy URL to use for creating HTTPS connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string"
+        },
         "ingress_vip": {
           "description": "Virtual IP used for cluster ingress traffic.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$"
+        },
+        "mirror_registries": {
+          "description": "The mirror registries that the images are pulled from instead of their source registries, for disconnected installations.",
+          "$ref": "#/definitions/mirror-registries-config"
         },
         "name": {
           "description": "Name of the OpenShift cluster.",
           "type": "string"
         },
+        "network_type": {
+          "description": "The cluster network provider. OpenShiftSDN supports only IPv4, IPv6 and dual-stack networks require OVNKubernetes.",
+          "type": "string",
+          "default": "OpenShiftSDN",
+          "enum": [
+            "OpenShiftSDN",
+            "OVNKubernetes"
+          ]
+        },
+        "no_proxy": {
+          "description": "A comma-separated list of destination domain names, domains, IP addresses or other network CIDRs to exclude proxying.",
+          "type": "string"
+        },
         "openshift_version": {
-          "description": "Version of the OpenShift cluster.",
+          "description": "Version of the OpenShift cluster, one of the versions the service supports.",
+          "type": "string"
+        },
+        "platform": {
+          "description": "Platform of the cluster, the hosts are provisioned by the baremetal operator or by nothing.",
           "type": "string",
+          "default": "baremetal",
           "enum": [
-            "4.5"
+            "baremetal",
+            "none"
           ]
         },
         "pull_secret": {
           "description": "The pull secret that obtained from the Pull Secret page on the Red Hat OpenShift Cluster Manager site.",
           "type": "string"
         },
+        "secondary_cluster_network_cidr": {
+          "description": "An additional IP address block from which Pod IPs are allocated in a dual-stack cluster. Must be of the other IP family than cluster_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
+        "secondary_cluster_network_host_prefix": {
+          "description": "The subnet prefix length to assign to each individual node out of secondary_cluster_network_cidr.",
+          "type": "integer",
+          "maximum": 128,
+          "minimum": 1
+        },
+        "secondary_service_network_cidr": {
+          "description": "An additional IP address pool to use for service IP addresses in a dual-stack cluster. Must be of the other IP family than service_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
         "service_network_cidr": {
           "description": "The IP address pool to use for service IP addresses. You can enter only one IP address pool. If you need to access the services from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
           "default": "172.30.0.0/16",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         },
         "ssh_public_key": {
           "description": "SSH public key for debugging OpenShift nodes.",
@@ -1578,10 +2037,15 @@ func init() {
     "cluster-update-params": {
       "type": "object",
       "properties": {
+        "additional_trust_bundle": {
+          "description": "PEM-encoded X.509 certificate bundle. The discovered hosts and the installed cluster trust these certificates in addition to the ones of the system, e.g. of a TLS-intercepting proxy or of a mirror registry.",
+          "type": "string",
+          "x-nullable": true
+        },
         "api_vip": {
           "description": "Virtual IP used to reach the OpenShift cluster API.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$",
           "x-nullable": true
         },
         "base_dns_domain": {
@@ -1592,16 +2056,40 @@ func init() {
         "cluster_network_cidr": {
           "description": "IP address block from which Pod IPs are allocated This block must not overlap with existing physical networks. These IP addresses are used for the Pod network, and if you need to access the Pods from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
           "x-nullable": true
         },
         "cluster_network_host_prefix": {
-          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
+          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. For IPv6 cluster networks the prefix length may be up to 128. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
           "type": "integer",
-          "maximum": 32,
+          "maximum": 128,
           "minimum": 1,
           "x-nullable": true
         },
+        "hardware_profile": {
+          "description": "The hardware profile that the hosts of the cluster are validated against. When empty, the profile of the OpenShift version or the default profile of the service is used.",
+          "type": "string",
+          "x-nullable": true
+        },
+        "hosts_installation_disks": {
+          "description": "The desired installation disk for hosts associated with the cluster.",
+          "type": "array",
+          "items": {
+            "type": "object",
+            "properties": {
+              "id": {
+                "type": "string",
+                "format": "uuid"
+              },
+              "installation_disk_id": {
+                "description": "The by-path, WWN or serial number of a valid disk of the host, empty to let the service choose the disk.",
+                "type": "string"
+              }
+            }
+          },
+          "x-go-custom-tag": "gorm:\"type:varchar(64)[]\"",
+          "x-nullable": true
+        },
         "hosts_names": {
           "description": "The desired hostname for hosts associated with the cluster.",
           "type": "array",
@@ -1638,26 +2126,89 @@ func init() {
           "x-go-custom-tag": "gorm:\"type:varchar(64)[]\"",
           "x-nullable": true
         },
+        "http_proxy": {
+          "description": "A proxy URL to use for creating HTTP connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string",
+          "x-nullable": true
+        },
+        "https_proxy": {
+          "description": "A proxy URL to use for creating HTTPS connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string",
+          "x-nullable": true
+        },
         "ingress_vip": {
           "description": "Virtual IP used for cluster ingress traffic.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$",
           "x-nullable": true
         },
+        "mirror_registries": {
+          "description": "The mirror registries that the images are pulled from instead of their source registries, for disconnected installations.",
+          "x-nullable": true,
+          "$ref": "#/definitions/mirror-registries-config"
+        },
         "name": {
           "description": "OpenShift cluster name",
           "type": "string",
           "x-nullable": true
         },
+        "network_type": {
+          "description": "The cluster network provider. OpenShiftSDN supports only IPv4, IPv6 and dual-stack networks require OVNKubernetes.",
+          "type": "string",
+          "enum": [
+            "OpenShiftSDN",
+            "OVNKubernetes"
+          ],
+          "x-nullable": true
+        },
+        "no_proxy": {
+          "description": "A comma-separated list of destination domain names, domains, IP addresses or other network CIDRs to exclude proxying.",
+          "type": "string",
+          "x-nullable": true
+        },
+        "platform": {
+          "description": "Platform of the cluster, the hosts are provisioned by the baremetal operator or by nothing.",
+          "type": "string",
+          "enum": [
+            "baremetal",
+            "none"
+          ],
+          "x-nullable": true
+        },
         "pull_secret": {
           "description": "The pull secret that obtained from the Pull Secret page on the Red Hat OpenShift Cluster Manager site.",
           "type": "string",
           "x-nullable": true
         },
+        "secondary_cluster_network_cidr": {
+          "description": "An additional IP address block from which Pod IPs are allocated in a dual-stack cluster. Must be of the other IP family than cluster_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
+          "x-nullable": true
+        },
+        "secondary_cluster_network_host_prefix": {
+          "description": "The subnet prefix length to assign to each individual node out of secondary_cluster_network_cidr.",
+          "type": "integer",
+          "maximum": 128,
+          "minimum": 1,
+          "x-nullable": true
+        },
+        "secondary_machine_network_cidr": {
+          "description": "An additional CIDR that the hosts of a dual-stack cluster have addresses in. Must be of the other IP family than machine_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
+          "x-nullable": true
+        },
+        "secondary_service_network_cidr": {
+          "description": "An additional IP address pool to use for service IP addresses in a dual-stack cluster. Must be of the other IP family than service_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
+          "x-nullable": true
+        },
         "service_network_cidr": {
           "description": "The IP address pool to use for service IP addresses. You can enter only one IP address pool. If you need to access the services from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
           "x-nullable": true
         },
         "ssh_public_key": {
@@ -1667,6 +2218,13 @@ func init() {
         }
       }
     },
+    "cluster-validation-id": {
+      "type": "string",
+      "enum": [
+        "pull-secret-has-required-registries",
+        "pull-secret-credentials-valid"
+      ]
+    },
     "completion-params": {
       "type": "object",
       "required": [
@@ -1719,6 +2277,98 @@ func init() {
         "$ref": "#/definitions/connectivity-check-host"
       }
     },
+    "connectivity-matrix": {
+      "type": "object",
+      "properties": {
+        "host_pairs": {
+          "description": "The connectivity from each host of the cluster to each of the other hosts.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/connectivity-matrix-host-pair"
+          }
+        },
+        "hosts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/connectivity-matrix-host"
+          }
+        },
+        "nic_pairs": {
+          "description": "The connectivity from each NIC of the hosts to each of the addresses of the other hosts, as reported by the hosts.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/connectivity-matrix-nic-pair"
+          }
+        }
+      }
+    },
+    "connectivity-matrix-host": {
+      "type": "object",
+      "properties": {
+        "host_id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "hostname": {
+          "type": "string"
+        },
+        "reported": {
+          "description": "Whether the host has sent a connectivity report.",
+          "type": "boolean"
+        }
+      }
+    },
+    "connectivity-matrix-host-pair": {
+      "type": "object",
+      "properties": {
+        "asymmetric": {
+          "description": "The connectivity between the hosts depends on the direction, one of them is reached by the other on a layer that it can't reach it back on.",
+          "type": "boolean"
+        },
+        "l2_status": {
+          "$ref": "#/definitions/connectivity-status"
+        },
+        "l3_status": {
+          "$ref": "#/definitions/connectivity-status"
+        },
+        "remote_host_id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "source_host_id": {
+          "type": "string",
+          "format": "uuid"
+        }
+      }
+    },
+    "connectivity-matrix-nic-pair": {
+      "type": "object",
+      "properties": {
+        "l2_status": {
+          "$ref": "#/definitions/connectivity-status"
+        },
+        "l3_status": {
+          "$ref": "#/definitions/connectivity-status"
+        },
+        "remote_host_id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "remote_ip_address": {
+          "type": "string"
+        },
+        "remote_mac": {
+          "type": "string"
+        },
+        "source_host_id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "source_nic": {
+          "type": "string"
+        }
+      }
+    },
     "connectivity-remote-host": {
       "type": "object",
       "properties": {
@@ -1751,6 +2401,15 @@ func init() {
         }
       }
     },
+    "connectivity-status": {
+      "type": "string",
+      "enum": [
+        "reachable",
+        "partial",
+        "unreachable",
+        "unknown"
+      ]
+    },
     "cpu": {
       "type": "object",
       "properties": {
@@ -1808,18 +2467,44 @@ func init() {
         "drive_type": {
           "type": "string"
         },
+        "has_lvm": {
+          "description": "The disk or one of its partitions is an LVM physical volume.",
+          "type": "boolean"
+        },
+        "has_partitions": {
+          "description": "The disk has existing partitions.",
+          "type": "boolean"
+        },
         "hctl": {
           "type": "string"
         },
+        "installation_eligibility": {
+          "$ref": "#/definitions/disk-installation-eligibility"
+        },
+        "is_installation_media": {
+          "description": "The disk is the medium that the host booted the discovery image from, e.g. a USB drive.",
+          "type": "boolean"
+        },
         "model": {
           "type": "string"
         },
+        "multipath_member": {
+          "description": "The disk is one of the paths of a multipath device.",
+          "type": "boolean"
+        },
         "name": {
           "type": "string"
         },
         "path": {
           "type": "string"
         },
+        "read_only": {
+          "type": "boolean"
+        },
+        "removable": {
+          "description": "The disk is removable, e.g. a USB drive or an SD card.",
+          "type": "boolean"
+        },
         "serial": {
           "type": "string"
         },
@@ -1834,6 +2519,22 @@ func init() {
         }
       }
     },
+    "disk-installation-eligibility": {
+      "description": "Whether the host can be installed on the disk, set by the service.",
+      "type": "object",
+      "properties": {
+        "eligible": {
+          "type": "boolean"
+        },
+        "not_eligible_reasons": {
+          "description": "The reasons that the host can't be installed on the disk.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "error": {
       "type": "object",
       "required": [
@@ -1922,14 +2623,14 @@ func init() {
       "type": "array",
       "items": {
         "type": "string",
-        "format": "ipv4"
+        "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))$"
       }
     },
     "free_addresses_request": {
       "type": "array",
       "items": {
         "type": "string",
-        "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+        "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
       }
     },
     "free_network_addresses": {
@@ -1939,12 +2640,12 @@ func init() {
           "type": "array",
           "items": {
             "type": "string",
-            "format": "ipv4"
+            "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))$"
           }
         },
         "network": {
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         }
       }
     },
@@ -2005,6 +2706,10 @@ func init() {
           "format": "uuid",
           "x-go-custom-tag": "gorm:\"primary_key\""
         },
+        "installation_disk_id": {
+          "description": "The by-path, WWN or serial number of the disk that the host is installed on, selected by the user. When empty, the service chooses the disk.",
+          "type": "string"
+        },
         "installer_version": {
           "description": "Installer version",
           "type": "string"
@@ -2186,6 +2891,71 @@ func init() {
         "Failed"
       ]
     },
+    "host-static-network-config": {
+      "type": "object",
+      "required": [
+        "mac_address",
+        "ip_address",
+        "prefix_length"
+      ],
+      "properties": {
+        "bond_mac_addresses": {
+          "description": "The MAC addresses of the other interfaces of the bond.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "pattern": "^([0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}$"
+          }
+        },
+        "bond_mode": {
+          "description": "Bonds the interface with the interfaces of bond_mac_addresses in this mode.",
+          "type": "string",
+          "enum": [
+            "balance-rr",
+            "active-backup",
+            "balance-xor",
+            "broadcast",
+            "802.3ad",
+            "balance-tlb",
+            "balance-alb"
+          ]
+        },
+        "dns_servers": {
+          "description": "The IP addresses of the DNS servers.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "gateway": {
+          "description": "The default gateway, must be on the network of the IP address.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$"
+        },
+        "ip_address": {
+          "description": "The static IP address of the host.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))$"
+        },
+        "mac_address": {
+          "description": "The MAC address of the interface of the host to configure.",
+          "type": "string",
+          "pattern": "^([0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}$"
+        },
+        "prefix_length": {
+          "description": "The prefix length of the network of the IP address.",
+          "type": "integer",
+          "maximum": 128,
+          "minimum": 1
+        },
+        "vlan_id": {
+          "description": "Configures the IP address on this VLAN of the interface, or of the bond.",
+          "type": "integer",
+          "maximum": 4094,
+          "minimum": 1
+        }
+      }
+    },
     "host-validation-id": {
       "type": "string",
       "enum": [
@@ -2200,7 +2970,13 @@ func init() {
         "has-memory-for-role",
         "hostname-unique",
         "hostname-valid",
-        "belongs-to-machine-cidr"
+        "belongs-to-machine-cidr",
+        "belongs-to-majority-group",
+        "has-required-cpu-flags",
+        "has-min-nic-speed",
+        "has-required-disks",
+        "has-allowed-vendor",
+        "valid-installation-disk"
       ]
     },
     "host_network": {
@@ -2218,6 +2994,27 @@ func init() {
         }
       }
     },
+    "image-content-source": {
+      "type": "object",
+      "required": [
+        "source",
+        "mirrors"
+      ],
+      "properties": {
+        "mirrors": {
+          "description": "The repositories that the images of the source are mirrored to, in order of preference.",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string"
+          }
+        },
+        "source": {
+          "description": "The repository of the images, e.g. quay.io/openshift-release-dev/ocp-release.",
+          "type": "string"
+        }
+      }
+    },
     "image-create-params": {
       "type": "object",
       "properties": {
@@ -2228,6 +3025,13 @@ func init() {
         "ssh_public_key": {
           "description": "SSH public key for debugging the installation.",
           "type": "string"
+        },
+        "static_network_config": {
+          "description": "Static network configurations of the hosts that have no DHCP on the machine network.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/host-static-network-config"
+          }
         }
       }
     },
@@ -2239,6 +3043,10 @@ func init() {
           "format": "date-time",
           "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
         },
+        "error_message": {
+          "description": "The reason the generation of the image failed.",
+          "type": "string"
+        },
         "generator_version": {
           "description": "Image generator version",
           "type": "string"
@@ -2251,12 +3059,26 @@ func init() {
           "description": "SSH public key for debugging the installation",
           "type": "string",
           "x-go-custom-tag": "gorm:\"type:varchar(1024)\""
+        },
+        "state": {
+          "description": "The state of the generation of the image.",
+          "type": "string",
+          "enum": [
+            "pending",
+            "building",
+            "ready",
+            "failed"
+          ]
         }
       }
     },
     "ingress-cert-params": {
       "type": "string"
     },
+    "install-config-params": {
+      "description": "JSON encoded partial install config, merged over the generated install config. Only networking.networkType,\nadditionalTrustBundle, imageContentSources and fips can be overridden. The additionalTrustBundle and\nimageContentSources are added to the ones generated from the cluster configuration.\n",
+      "type": "string"
+    },
     "interface": {
       "type": "object",
       "properties": {
@@ -2405,7 +3227,8 @@ func init() {
         "provider": {
           "type": "string",
           "enum": [
-            "route53"
+            "route53",
+            "rfc2136"
           ]
         }
       }
@@ -2421,6 +3244,78 @@ func init() {
         }
       }
     },
+    "mirror-registries-config": {
+      "type": "object",
+      "properties": {
+        "ca_certificate": {
+          "description": "PEM-encoded X.509 certificate bundle of the CAs that signed the certificates of the mirror registries.",
+          "type": "string"
+        },
+        "image_content_sources": {
+          "description": "The repositories of the images and the repositories of the mirror registries they are mirrored to.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/image-content-source"
+          }
+        }
+      }
+    },
+    "openshift-version": {
+      "description": "A supported OpenShift version. The minimal hardware of its hosts is set by the hardware profile mapped to the version, not by the catalog.",
+      "type": "object",
+      "required": [
+        "display_name",
+        "release_image"
+      ],
+      "properties": {
+        "controller_image": {
+          "description": "The image of the controller that completes the installation of this version.",
+          "type": "string"
+        },
+        "display_name": {
+          "description": "Name of the version to show to users.",
+          "type": "string"
+        },
+        "installer_image": {
+          "description": "The image that installs the hosts of this version.",
+          "type": "string"
+        },
+        "kubeconfig_generator_image": {
+          "description": "The image that generates the ignition files and kubeconfig of this version.",
+          "type": "string"
+        },
+        "release_image": {
+          "description": "The release image the clusters of this version are installed from.",
+          "type": "string"
+        },
+        "rhcos_image": {
+          "description": "The RHCOS live ISO the discovery images of this version are generated from. When empty, the base ISO of the service is used.",
+          "type": "string"
+        }
+      }
+    },
+    "openshift-versions": {
+      "type": "object",
+      "additionalProperties": {
+        "$ref": "#/definitions/openshift-version"
+      }
+    },
+    "presigned": {
+      "type": "object",
+      "required": [
+        "url",
+        "expires_at"
+      ],
+      "properties": {
+        "expires_at": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "url": {
+          "type": "string"
+        }
+      }
+    },
     "step": {
       "type": "object",
       "properties": {
@@ -2922,6 +3817,55 @@ func init() {
         }
       }
     },
+    "/clusters/{cluster_id}/connectivity-matrix": {
+      "get": {
+        "tags": [
+          "installer"
+        ],
+        "summary": "Retrieves the L2 and L3 connectivity between the hosts of the cluster, aggregated from their connectivity reports.",
+        "operationId": "GetClusterConnectivityMatrix",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "name": "cluster_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "enum": [
+              "json",
+              "csv"
+            ],
+            "type": "string",
+            "default": "json",
+            "description": "The format of the matrix, csv returns a row per NIC pair as text/csv.",
+            "name": "format",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/connectivity-matrix"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/clusters/{cluster_id}/credentials": {
       "get": {
         "tags": [
@@ -2942,7 +3886,167 @@ func init() {
           "200": {
             "description": "Success.",
             "schema": {
-              "$ref": "#/definitions/credentials"
+              "$ref": "#/definitions/credentials"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/clusters/{cluster_id}/downloads/files": {
+      "get": {
+        "produces": [
+          "application/octet-stream"
+        ],
+        "tags": [
+          "installer"
+        ],
+        "summary": "Downloads files relating to the installed/installing cluster.",
+        "operationId": "DownloadClusterFiles",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "name": "cluster_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "enum": [
+              "bootstrap.ign",
+              "master.ign",
+              "metadata.json",
+              "worker.ign",
+              "kubeadmin-password",
+              "kubeconfig",
+              "kubeconfig-noingress",
+              "install-config.yaml"
+            ],
+            "type": "string",
+            "name": "file_name",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The byte range of the file to download.",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Serves the requested range only when the entity tag or the last modification date match the file.",
+            "name": "If-Range",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "206": {
+            "description": "Partial content.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "416": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/clusters/{cluster_id}/downloads/image": {
+      "get": {
+        "produces": [
+          "application/octet-stream"
+        ],
+        "tags": [
+          "installer"
+        ],
+        "summary": "Downloads the OpenShift per-cluster discovery ISO.",
+        "operationId": "DownloadClusterISO",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "name": "cluster_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The byte range of the file to download.",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Serves the requested range only when the entity tag or the last modification date match the file.",
+            "name": "If-Range",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            }
+          },
+          "206": {
+            "description": "Partial content.",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
             }
           },
           "404": {
@@ -2957,6 +4061,12 @@ func init() {
               "$ref": "#/definitions/error"
             }
           },
+          "416": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -2964,18 +4074,13 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/clusters/{cluster_id}/downloads/files": {
-      "get": {
-        "produces": [
-          "application/octet-stream"
-        ],
+      },
+      "post": {
         "tags": [
           "installer"
         ],
-        "summary": "Downloads files relating to the installed/installing cluster.",
-        "operationId": "DownloadClusterFiles",
+        "summary": "Creates a new OpenShift per-cluster discovery ISO.",
+        "operationId": "GenerateClusterISO",
         "parameters": [
           {
             "type": "string",
@@ -2985,27 +4090,25 @@ func init() {
             "required": true
           },
           {
-            "enum": [
-              "bootstrap.ign",
-              "master.ign",
-              "metadata.json",
-              "worker.ign",
-              "kubeadmin-password",
-              "kubeconfig",
-              "kubeconfig-noingress",
-              "install-config.yaml"
-            ],
-            "type": "string",
-            "name": "file_name",
-            "in": "query",
-            "required": true
+            "name": "image-create-params",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/image-create-params"
+            }
           }
         ],
         "responses": {
-          "200": {
+          "202": {
             "description": "Success.",
             "schema": {
-              "type": "file"
+              "$ref": "#/definitions/cluster"
+            }
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
             }
           },
           "404": {
@@ -3029,7 +4132,7 @@ func init() {
         }
       }
     },
-    "/clusters/{cluster_id}/downloads/image": {
+    "/clusters/{cluster_id}/downloads/image-presigned": {
       "get": {
         "produces": [
           "application/octet-stream"
@@ -3037,8 +4140,8 @@ func init() {
         "tags": [
           "installer"
         ],
-        "summary": "Downloads the OpenShift per-cluster discovery ISO.",
-        "operationId": "DownloadClusterISO",
+        "summary": "Downloads the OpenShift per-cluster discovery ISO with a signed URL.",
+        "operationId": "DownloadClusterISOPresigned",
         "parameters": [
           {
             "type": "string",
@@ -3046,6 +4149,33 @@ func init() {
             "name": "cluster_id",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "The expiration time of the URL, in seconds since the epoch.",
+            "name": "expires_at",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The signature of the URL.",
+            "name": "signature",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The byte range of the file to download.",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Serves the requested range only when the entity tag or the last modification date match the file.",
+            "name": "If-Range",
+            "in": "header"
           }
         ],
         "responses": {
@@ -3056,18 +4186,43 @@ func init() {
               "format": "binary"
             }
           },
+          "206": {
+            "description": "Partial content.",
+            "schema": {
+              "type": "string",
+              "format": "binary"
+            }
+          },
           "400": {
             "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
           },
+          "401": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "404": {
             "description": "Error.",
             "schema": {
               "$ref": "#/definitions/error"
             }
           },
+          "409": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "416": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -3075,13 +4230,15 @@ func init() {
             }
           }
         }
-      },
-      "post": {
+      }
+    },
+    "/clusters/{cluster_id}/downloads/image-url": {
+      "get": {
         "tags": [
           "installer"
         ],
-        "summary": "Creates a new OpenShift per-cluster discovery ISO.",
-        "operationId": "GenerateClusterISO",
+        "summary": "Creates a time limited URL for downloading the discovery ISO without credentials, e.g. by BMC virtual media.",
+        "operationId": "GetPresignedForClusterISO",
         "parameters": [
           {
             "type": "string",
@@ -3089,27 +4246,13 @@ func init() {
             "name": "cluster_id",
             "in": "path",
             "required": true
-          },
-          {
-            "name": "image-create-params",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/image-create-params"
-            }
           }
         ],
         "responses": {
-          "201": {
+          "200": {
             "description": "Success.",
             "schema": {
-              "$ref": "#/definitions/cluster"
-            }
-          },
-          "400": {
-            "description": "Error.",
-            "schema": {
-              "$ref": "#/definitions/error"
+              "$ref": "#/definitions/presigned"
             }
           },
           "404": {
@@ -3197,7 +4340,7 @@ func init() {
             "required": true
           },
           {
-            "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$",
+            "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
             "type": "string",
             "name": "network",
             "in": "query",
@@ -3262,6 +4405,12 @@ func init() {
               "$ref": "#/definitions/host-list"
             }
           },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -3719,6 +4868,61 @@ func init() {
         }
       }
     },
+    "/clusters/{cluster_id}/install-config": {
+      "patch": {
+        "tags": [
+          "installer"
+        ],
+        "summary": "Override values in the install config.",
+        "operationId": "UpdateClusterInstallConfig",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "name": "cluster_id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "install-config-params",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/install-config-params"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "Success."
+          },
+          "400": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/clusters/{cluster_id}/uploads/ingress-cert": {
       "post": {
         "tags": [
@@ -3831,6 +5035,12 @@ func init() {
               "$ref": "#/definitions/event-list"
             }
           },
+          "404": {
+            "description": "Error.",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
           "500": {
             "description": "Error.",
             "schema": {
@@ -3839,9 +5049,39 @@ func init() {
           }
         }
       }
+    },
+    "/openshift_versions": {
+      "get": {
+        "tags": [
+          "versions"
+        ],
+        "summary": "Retrieves the OpenShift versions the service can install.",
+        "operationId": "ListSupportedOpenshiftVersions",
+        "responses": {
+          "200": {
+            "description": "Success.",
+            "schema": {
+              "$ref": "#/definitions/openshift-versions"
+            }
+          }
+        }
+      }
     }
   },
   "definitions": {
+    "ClusterUpdateParamsHostsInstallationDisksItems0": {
+      "type": "object",
+      "properties": {
+        "id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "installation_disk_id": {
+          "description": "The by-path, WWN or serial number of a valid disk of the host, empty to let the service choose the disk.",
+          "type": "string"
+        }
+      }
+    },
     "ClusterUpdateParamsHostsNamesItems0": {
       "type": "object",
       "properties": {
@@ -3888,10 +5128,15 @@ func init() {
         "status_info"
       ],
       "properties": {
+        "additional_trust_bundle": {
+          "description": "PEM-encoded X.509 certificate bundle. The discovered hosts and the installed cluster trust these certificates in addition to the ones of the system, e.g. of a TLS-intercepting proxy or of a mirror registry.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:TEXT\""
+        },
         "api_vip": {
           "description": "Virtual IP used to reach the OpenShift cluster API.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$"
         },
         "base_dns_domain": {
           "description": "Base domain of the cluster. All DNS records must be sub-domains of this base and include the cluster name.",
@@ -3900,12 +5145,12 @@ func init() {
         "cluster_network_cidr": {
           "description": "IP address block from which Pod IPs are allocated This block must not overlap with existing physical networks. These IP addresses are used for the Pod network, and if you need to access the Pods from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         },
         "cluster_network_host_prefix": {
-          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
+          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. For IPv6 cluster networks the prefix length may be up to 128. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
           "type": "integer",
-          "maximum": 32,
+          "maximum": 128,
           "minimum": 1
         },
         "created_at": {
@@ -3914,6 +5159,10 @@ func init() {
           "format": "date-time",
           "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
         },
+        "hardware_profile": {
+          "description": "The hardware profile that the hosts of the cluster are validated against. When empty, the profile of the OpenShift version or the default profile of the service is used.",
+          "type": "string"
+        },
         "host_networks": {
           "description": "List of host networks to be filled during query.",
           "type": "array",
@@ -3935,6 +5184,14 @@ func init() {
           "description": "Self link.",
           "type": "string"
         },
+        "http_proxy": {
+          "description": "A proxy URL to use for creating HTTP connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string"
+        },
+        "https_proxy": {
+          "description": "A proxy URL to use for creating HTTPS connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string"
+        },
         "id": {
           "description": "Unique identifier of the object.",
           "type": "string",
@@ -3951,7 +5208,7 @@ func init() {
         "ingress_vip": {
           "description": "Virtual IP used for cluster ingress traffic.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$"
         },
         "install_completed_at": {
           "description": "The time that this cluster completed installation.",
@@ -3959,6 +5216,11 @@ func init() {
           "format": "date-time",
           "x-go-custom-tag": "gorm:\"type:timestamp with time zone;default:'2000-01-01 00:00:00z'\""
         },
+        "install_config_overrides": {
+          "description": "JSON encoded partial install config that is merged over the generated one.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
+        },
         "install_started_at": {
           "description": "The time that this cluster began installation.",
           "type": "string",
@@ -3975,30 +5237,75 @@ func init() {
         "machine_network_cidr": {
           "description": "A CIDR that all hosts belonging to the cluster should have an interfaces with IP address that belongs to this CIDR. The api_vip belongs to this CIDR.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
+        "mirror_registries_config": {
+          "description": "JSON encoded mirror-registries-config, the mirror registries that the images are pulled from instead of their source registries.",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
         },
         "name": {
           "description": "Name of the OpenShift cluster.",
           "type": "string"
         },
-        "openshift_version": {
-          "description": "Version of the OpenShift cluster.",
+        "network_type": {
+          "description": "The cluster network provider. OpenShiftSDN supports only IPv4, IPv6 and dual-stack networks require OVNKubernetes.",
           "type": "string",
           "enum": [
-            "4.5"
-          ]
+            "OpenShiftSDN",
+            "OVNKubernetes"
+          ],
+          "x-go-custom-tag": "gorm:\"default:'OpenShiftSDN'\""
+        },
+        "no_proxy": {
+          "description": "A comma-separated list of destination domain names, domains, IP addresses or other network CIDRs to exclude proxying.",
+          "type": "string"
+        },
+        "openshift_version": {
+          "description": "Version of the OpenShift cluster, one of the versions the service supports.",
+          "type": "string"
         },
         "org_id": {
           "type": "string"
         },
+        "platform": {
+          "description": "Platform of the cluster, the hosts are provisioned by the baremetal operator or by nothing.",
+          "type": "string",
+          "enum": [
+            "baremetal",
+            "none"
+          ],
+          "x-go-custom-tag": "gorm:\"default:'baremetal'\""
+        },
         "pull_secret_set": {
           "description": "True if the pull-secret has been added to the cluster",
           "type": "boolean"
         },
+        "secondary_cluster_network_cidr": {
+          "description": "An additional IP address block from which Pod IPs are allocated in a dual-stack cluster. Must be of the other IP family than cluster_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
+        "secondary_cluster_network_host_prefix": {
+          "description": "The subnet prefix length to assign to each individual node out of secondary_cluster_network_cidr.",
+          "type": "integer",
+          "maximum": 128,
+          "minimum": 1
+        },
+        "secondary_machine_network_cidr": {
+          "description": "An additional CIDR that the hosts of a dual-stack cluster have addresses in. Must be of the other IP family than machine_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
+        "secondary_service_network_cidr": {
+          "description": "An additional IP address pool to use for service IP addresses in a dual-stack cluster. Must be of the other IP family than service_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
         "service_network_cidr": {
           "description": "The IP address pool to use for service IP addresses. You can enter only one IP address pool. If you need to access the services from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         },
         "ssh_public_key": {
           "description": "SSH public key for debugging OpenShift nodes.",
@@ -4037,6 +5344,11 @@ func init() {
         },
         "user_id": {
           "type": "string"
+        },
+        "validations_info": {
+          "description": "Json formatted string containing the validations results for each validation id grouped by category (pull-secret, etc.)",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"type:text\""
         }
       }
     },
@@ -4047,6 +5359,10 @@ func init() {
         "openshift_version"
       ],
       "properties": {
+        "additional_trust_bundle": {
+          "description": "PEM-encoded X.509 certificate bundle. The discovered hosts and the installed cluster trust these certificates in addition to the ones of the system, e.g. of a TLS-intercepting proxy or of a mirror registry.",
+          "type": "string"
+        },
         "base_dns_domain": {
           "description": "Base domain of the cluster. All DNS records must be sub-domains of this base and include the cluster name.",
           "type": "string"
@@ -4055,40 +5371,91 @@ func init() {
           "description": "IP address block from which Pod IPs are allocated This block must not overlap with existing physical networks. These IP addresses are used for the Pod network, and if you need to access the Pods from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
           "default": "10.128.0.0/14",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         },
         "cluster_network_host_prefix": {
-          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
+          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. For IPv6 cluster networks the prefix length may be up to 128. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
           "type": "integer",
           "default": 23,
-          "maximum": 32,
+          "maximum": 128,
           "minimum": 1
         },
+        "hardware_profile": {
+          "description": "The hardware profile that the hosts of the cluster are validated against. When empty, the profile of the OpenShift version or the default profile of the service is used.",
+          "type": "string"
+        },
+        "http_proxy": {
+          "description": "A proxy URL to use for creating HTTP connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string"
+        },
+        "https_proxy": {
+          "description": "A proxy URL to use for creating HTTPS connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string"
+        },
         "ingress_vip": {
           "description": "Virtual IP used for cluster ingress traffic.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$"
+        },
+        "mirror_registries": {
+          "description": "The mirror registries that the images are pulled from instead of their source registries, for disconnected installations.",
+          "$ref": "#/definitions/mirror-registries-config"
+        },
+        "name": {
+          "description": "Name of the OpenShift cluster.",
+          "type": "string"
+        },
+        "network_type": {
+          "description": "The cluster network provider. OpenShiftSDN supports only IPv4, IPv6 and dual-stack networks require OVNKubernetes.",
+          "type": "string",
+          "default": "OpenShiftSDN",
+          "enum": [
+            "OpenShiftSDN",
+            "OVNKubernetes"
+          ]
         },
-        "name": {
-          "description": "Name of the OpenShift cluster.",
+        "no_proxy": {
+          "description": "A comma-separated list of destination domain names, domains, IP addresses or other network CIDRs to exclude proxying.",
           "type": "string"
         },
         "openshift_version": {
-          "description": "Version of the OpenShift cluster.",
+          "description": "Version of the OpenShift cluster, one of the versions the service supports.",
+          "type": "string"
+        },
+        "platform": {
+          "description": "Platform of the cluster, the hosts are provisioned by the baremetal operator or by nothing.",
           "type": "string",
+          "default": "baremetal",
           "enum": [
-            "4.5"
+            "baremetal",
+            "none"
           ]
         },
         "pull_secret": {
           "description": "The pull secret that obtained from the Pull Secret page on the Red Hat OpenShift Cluster Manager site.",
           "type": "string"
         },
+        "secondary_cluster_network_cidr": {
+          "description": "An additional IP address block from which Pod IPs are allocated in a dual-stack cluster. Must be of the other IP family than cluster_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
+        "secondary_cluster_network_host_prefix": {
+          "description": "The subnet prefix length to assign to each individual node out of secondary_cluster_network_cidr.",
+          "type": "integer",
+          "maximum": 128,
+          "minimum": 1
+        },
+        "secondary_service_network_cidr": {
+          "description": "An additional IP address pool to use for service IP addresses in a dual-stack cluster. Must be of the other IP family than service_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
+        },
         "service_network_cidr": {
           "description": "The IP address pool to use for service IP addresses. You can enter only one IP address pool. If you need to access the services from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
           "default": "172.30.0.0/16",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         },
         "ssh_public_key": {
           "description": "SSH public key for debugging OpenShift nodes.",
@@ -4105,10 +5472,15 @@ func init() {
     "cluster-update-params": {
       "type": "object",
       "properties": {
+        "additional_trust_bundle": {
+          "description": "PEM-encoded X.509 certificate bundle. The discovered hosts and the installed cluster trust these certificates in addition to the ones of the system, e.g. of a TLS-intercepting proxy or of a mirror registry.",
+          "type": "string",
+          "x-nullable": true
+        },
         "api_vip": {
           "description": "Virtual IP used to reach the OpenShift cluster API.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$",
           "x-nullable": true
         },
         "base_dns_domain": {
@@ -4119,16 +5491,30 @@ func init() {
         "cluster_network_cidr": {
           "description": "IP address block from which Pod IPs are allocated This block must not overlap with existing physical networks. These IP addresses are used for the Pod network, and if you need to access the Pods from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
           "x-nullable": true
         },
         "cluster_network_host_prefix": {
-          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
+          "description": "The subnet prefix length to assign to each individual node. For example, if clusterNetworkHostPrefix is set to 23, then each node is assigned a /23 subnet out of the given cidr (clusterNetworkCIDR), which allows for 510 (2^(32 - 23) - 2) pod IPs addresses. For IPv6 cluster networks the prefix length may be up to 128. If you are required to provide access to nodes from an external network, configure load balancers and routers to manage the traffic.",
           "type": "integer",
-          "maximum": 32,
+          "maximum": 128,
           "minimum": 1,
           "x-nullable": true
         },
+        "hardware_profile": {
+          "description": "The hardware profile that the hosts of the cluster are validated against. When empty, the profile of the OpenShift version or the default profile of the service is used.",
+          "type": "string",
+          "x-nullable": true
+        },
+        "hosts_installation_disks": {
+          "description": "The desired installation disk for hosts associated with the cluster.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ClusterUpdateParamsHostsInstallationDisksItems0"
+          },
+          "x-go-custom-tag": "gorm:\"type:varchar(64)[]\"",
+          "x-nullable": true
+        },
         "hosts_names": {
           "description": "The desired hostname for hosts associated with the cluster.",
           "type": "array",
@@ -4147,26 +5533,89 @@ func init() {
           "x-go-custom-tag": "gorm:\"type:varchar(64)[]\"",
           "x-nullable": true
         },
+        "http_proxy": {
+          "description": "A proxy URL to use for creating HTTP connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string",
+          "x-nullable": true
+        },
+        "https_proxy": {
+          "description": "A proxy URL to use for creating HTTPS connections outside the cluster. http://\\\u003cusername\\\u003e:\\\u003cpswd\\\u003e@\\\u003cip\\\u003e:\\\u003cport\\\u003e",
+          "type": "string",
+          "x-nullable": true
+        },
         "ingress_vip": {
           "description": "Virtual IP used for cluster ingress traffic.",
           "type": "string",
-          "pattern": "^(([0-9]{1,3}\\.){3}[0-9]{1,3})?$",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$",
           "x-nullable": true
         },
+        "mirror_registries": {
+          "description": "The mirror registries that the images are pulled from instead of their source registries, for disconnected installations.",
+          "x-nullable": true,
+          "$ref": "#/definitions/mirror-registries-config"
+        },
         "name": {
           "description": "OpenShift cluster name",
           "type": "string",
           "x-nullable": true
         },
+        "network_type": {
+          "description": "The cluster network provider. OpenShiftSDN supports only IPv4, IPv6 and dual-stack networks require OVNKubernetes.",
+          "type": "string",
+          "enum": [
+            "OpenShiftSDN",
+            "OVNKubernetes"
+          ],
+          "x-nullable": true
+        },
+        "no_proxy": {
+          "description": "A comma-separated list of destination domain names, domains, IP addresses or other network CIDRs to exclude proxying.",
+          "type": "string",
+          "x-nullable": true
+        },
+        "platform": {
+          "description": "Platform of the cluster, the hosts are provisioned by the baremetal operator or by nothing.",
+          "type": "string",
+          "enum": [
+            "baremetal",
+            "none"
+          ],
+          "x-nullable": true
+        },
         "pull_secret": {
           "description": "The pull secret that obtained from the Pull Secret page on the Red Hat OpenShift Cluster Manager site.",
           "type": "string",
           "x-nullable": true
         },
+        "secondary_cluster_network_cidr": {
+          "description": "An additional IP address block from which Pod IPs are allocated in a dual-stack cluster. Must be of the other IP family than cluster_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
+          "x-nullable": true
+        },
+        "secondary_cluster_network_host_prefix": {
+          "description": "The subnet prefix length to assign to each individual node out of secondary_cluster_network_cidr.",
+          "type": "integer",
+          "maximum": 128,
+          "minimum": 1,
+          "x-nullable": true
+        },
+        "secondary_machine_network_cidr": {
+          "description": "An additional CIDR that the hosts of a dual-stack cluster have addresses in. Must be of the other IP family than machine_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
+          "x-nullable": true
+        },
+        "secondary_service_network_cidr": {
+          "description": "An additional IP address pool to use for service IP addresses in a dual-stack cluster. Must be of the other IP family than service_network_cidr.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
+          "x-nullable": true
+        },
         "service_network_cidr": {
           "description": "The IP address pool to use for service IP addresses. You can enter only one IP address pool. If you need to access the services from an external network, configure load balancers and routers to manage the traffic.",
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$",
           "x-nullable": true
         },
         "ssh_public_key": {
@@ -4176,6 +5625,13 @@ func init() {
         }
       }
     },
+    "cluster-validation-id": {
+      "type": "string",
+      "enum": [
+        "pull-secret-has-required-registries",
+        "pull-secret-credentials-valid"
+      ]
+    },
     "completion-params": {
       "type": "object",
       "required": [
@@ -4228,6 +5684,98 @@ func init() {
         "$ref": "#/definitions/connectivity-check-host"
       }
     },
+    "connectivity-matrix": {
+      "type": "object",
+      "properties": {
+        "host_pairs": {
+          "description": "The connectivity from each host of the cluster to each of the other hosts.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/connectivity-matrix-host-pair"
+          }
+        },
+        "hosts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/connectivity-matrix-host"
+          }
+        },
+        "nic_pairs": {
+          "description": "The connectivity from each NIC of the hosts to each of the addresses of the other hosts, as reported by the hosts.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/connectivity-matrix-nic-pair"
+          }
+        }
+      }
+    },
+    "connectivity-matrix-host": {
+      "type": "object",
+      "properties": {
+        "host_id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "hostname": {
+          "type": "string"
+        },
+        "reported": {
+          "description": "Whether the host has sent a connectivity report.",
+          "type": "boolean"
+        }
+      }
+    },
+    "connectivity-matrix-host-pair": {
+      "type": "object",
+      "properties": {
+        "asymmetric": {
+          "description": "The connectivity between the hosts depends on the direction, one of them is reached by the other on a layer that it can't reach it back on.",
+          "type": "boolean"
+        },
+        "l2_status": {
+          "$ref": "#/definitions/connectivity-status"
+        },
+        "l3_status": {
+          "$ref": "#/definitions/connectivity-status"
+        },
+        "remote_host_id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "source_host_id": {
+          "type": "string",
+          "format": "uuid"
+        }
+      }
+    },
+    "connectivity-matrix-nic-pair": {
+      "type": "object",
+      "properties": {
+        "l2_status": {
+          "$ref": "#/definitions/connectivity-status"
+        },
+        "l3_status": {
+          "$ref": "#/definitions/connectivity-status"
+        },
+        "remote_host_id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "remote_ip_address": {
+          "type": "string"
+        },
+        "remote_mac": {
+          "type": "string"
+        },
+        "source_host_id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "source_nic": {
+          "type": "string"
+        }
+      }
+    },
     "connectivity-remote-host": {
       "type": "object",
       "properties": {
@@ -4260,6 +5808,15 @@ func init() {
         }
       }
     },
+    "connectivity-status": {
+      "type": "string",
+      "enum": [
+        "reachable",
+        "partial",
+        "unreachable",
+        "unknown"
+      ]
+    },
     "cpu": {
       "type": "object",
       "properties": {
@@ -4317,18 +5874,44 @@ func init() {
         "drive_type": {
           "type": "string"
         },
+        "has_lvm": {
+          "description": "The disk or one of its partitions is an LVM physical volume.",
+          "type": "boolean"
+        },
+        "has_partitions": {
+          "description": "The disk has existing partitions.",
+          "type": "boolean"
+        },
         "hctl": {
           "type": "string"
         },
+        "installation_eligibility": {
+          "$ref": "#/definitions/disk-installation-eligibility"
+        },
+        "is_installation_media": {
+          "description": "The disk is the medium that the host booted the discovery image from, e.g. a USB drive.",
+          "type": "boolean"
+        },
         "model": {
           "type": "string"
         },
+        "multipath_member": {
+          "description": "The disk is one of the paths of a multipath device.",
+          "type": "boolean"
+        },
         "name": {
           "type": "string"
         },
         "path": {
           "type": "string"
         },
+        "read_only": {
+          "type": "boolean"
+        },
+        "removable": {
+          "description": "The disk is removable, e.g. a USB drive or an SD card.",
+          "type": "boolean"
+        },
         "serial": {
           "type": "string"
         },
@@ -4343,6 +5926,22 @@ func init() {
         }
       }
     },
+    "disk-installation-eligibility": {
+      "description": "Whether the host can be installed on the disk, set by the service.",
+      "type": "object",
+      "properties": {
+        "eligible": {
+          "type": "boolean"
+        },
+        "not_eligible_reasons": {
+          "description": "The reasons that the host can't be installed on the disk.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "error": {
       "type": "object",
       "required": [
@@ -4431,14 +6030,14 @@ func init() {
       "type": "array",
       "items": {
         "type": "string",
-        "format": "ipv4"
+        "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))$"
       }
     },
     "free_addresses_request": {
       "type": "array",
       "items": {
         "type": "string",
-        "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+        "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
       }
     },
     "free_network_addresses": {
@@ -4448,12 +6047,12 @@ func init() {
           "type": "array",
           "items": {
             "type": "string",
-            "format": "ipv4"
+            "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))$"
           }
         },
         "network": {
           "type": "string",
-          "pattern": "^([0-9]{1,3}\\.){3}[0-9]{1,3}\\/[0-9]|[1-2][0-9]|3[0-2]?$"
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\/(?:(?:[0-9])|(?:[1-2][0-9])|(?:3[0-2])))|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,})/(?:(?:[0-9])|(?:[1-9][0-9])|(?:1[0-1][0-9])|(?:12[0-8])))$"
         }
       }
     },
@@ -4514,6 +6113,10 @@ func init() {
           "format": "uuid",
           "x-go-custom-tag": "gorm:\"primary_key\""
         },
+        "installation_disk_id": {
+          "description": "The by-path, WWN or serial number of the disk that the host is installed on, selected by the user. When empty, the service chooses the disk.",
+          "type": "string"
+        },
         "installer_version": {
           "description": "Installer version",
           "type": "string"
@@ -4695,6 +6298,71 @@ func init() {
         "Failed"
       ]
     },
+    "host-static-network-config": {
+      "type": "object",
+      "required": [
+        "mac_address",
+        "ip_address",
+        "prefix_length"
+      ],
+      "properties": {
+        "bond_mac_addresses": {
+          "description": "The MAC addresses of the other interfaces of the bond.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "pattern": "^([0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}$"
+          }
+        },
+        "bond_mode": {
+          "description": "Bonds the interface with the interfaces of bond_mac_addresses in this mode.",
+          "type": "string",
+          "enum": [
+            "balance-rr",
+            "active-backup",
+            "balance-xor",
+            "broadcast",
+            "802.3ad",
+            "balance-tlb",
+            "balance-alb"
+          ]
+        },
+        "dns_servers": {
+          "description": "The IP addresses of the DNS servers.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "gateway": {
+          "description": "The default gateway, must be on the network of the IP address.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))?$"
+        },
+        "ip_address": {
+          "description": "The static IP address of the host.",
+          "type": "string",
+          "pattern": "^(?:(?:(?:[0-9]{1,3}\\.){3}[0-9]{1,3})|(?:(?:[0-9a-fA-F]*:[0-9a-fA-F]*){2,}))$"
+        },
+        "mac_address": {
+          "description": "The MAC address of the interface of the host to configure.",
+          "type": "string",
+          "pattern": "^([0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}$"
+        },
+        "prefix_length": {
+          "description": "The prefix length of the network of the IP address.",
+          "type": "integer",
+          "maximum": 128,
+          "minimum": 1
+        },
+        "vlan_id": {
+          "description": "Configures the IP address on this VLAN of the interface, or of the bond.",
+          "type": "integer",
+          "maximum": 4094,
+          "minimum": 1
+        }
+      }
+    },
     "host-validation-id": {
       "type": "string",
       "enum": [
@@ -4709,7 +6377,13 @@ func init() {
         "has-memory-for-role",
         "hostname-unique",
         "hostname-valid",
-        "belongs-to-machine-cidr"
+        "belongs-to-machine-cidr",
+        "belongs-to-majority-group",
+        "has-required-cpu-flags",
+        "has-min-nic-speed",
+        "has-required-disks",
+        "has-allowed-vendor",
+        "valid-installation-disk"
       ]
     },
     "host_network": {
@@ -4727,6 +6401,27 @@ func init() {
         }
       }
     },
+    "image-content-source": {
+      "type": "object",
+      "required": [
+        "source",
+        "mirrors"
+      ],
+      "properties": {
+        "mirrors": {
+          "description": "The repositories that the images of the source are mirrored to, in order of preference.",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string"
+          }
+        },
+        "source": {
+          "description": "The repository of the images, e.g. quay.io/openshift-release-dev/ocp-release.",
+          "type": "string"
+        }
+      }
+    },
     "image-create-params": {
       "type": "object",
       "properties": {
@@ -4737,6 +6432,13 @@ func init() {
         "ssh_public_key": {
           "description": "SSH public key for debugging the installation.",
           "type": "string"
+        },
+        "static_network_config": {
+          "description": "Static network configurations of the hosts that have no DHCP on the machine network.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/host-static-network-config"
+          }
         }
       }
     },
@@ -4748,6 +6450,10 @@ func init() {
           "format": "date-time",
           "x-go-custom-tag": "gorm:\"type:timestamp with time zone\""
         },
+        "error_message": {
+          "description": "The reason the generation of the image failed.",
+          "type": "string"
+        },
         "generator_version": {
           "description": "Image generator version",
           "type": "string"
@@ -4760,12 +6466,26 @@ func init() {
           "description": "SSH public key for debugging the installation",
           "type": "string",
           "x-go-custom-tag": "gorm:\"type:varchar(1024)\""
+        },
+        "state": {
+          "description": "The state of the generation of the image.",
+          "type": "string",
+          "enum": [
+            "pending",
+            "building",
+            "ready",
+            "failed"
+          ]
         }
       }
     },
     "ingress-cert-params": {
       "type": "string"
     },
+    "install-config-params": {
+      "description": "JSON encoded partial install config, merged over the generated install config. Only networking.networkType,\nadditionalTrustBundle, imageContentSources and fips can be overridden. The additionalTrustBundle and\nimageContentSources are added to the ones generated from the cluster configuration.\n",
+      "type": "string"
+    },
     "interface": {
       "type": "object",
       "properties": {
@@ -4914,7 +6634,8 @@ func init() {
         "provider": {
           "type": "string",
           "enum": [
-            "route53"
+            "route53",
+            "rfc2136"
           ]
         }
       }
@@ -4930,6 +6651,78 @@ func init() {
         }
       }
     },
+    "mirror-registries-config": {
+      "type": "object",
+      "properties": {
+        "ca_certificate": {
+          "description": "PEM-encoded X.509 certificate bundle of the CAs that signed the certificates of the mirror registries.",
+          "type": "string"
+        },
+        "image_content_sources": {
+          "description": "The repositories of the images and the repositories of the mirror registries they are mirrored to.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/image-content-source"
+          }
+        }
+      }
+    },
+    "openshift-version": {
+      "description": "A supported OpenShift version. The minimal hardware of its hosts is set by the hardware profile mapped to the version, not by the catalog.",
+      "type": "object",
+      "required": [
+        "display_name",
+        "release_image"
+      ],
+      "properties": {
+        "controller_image": {
+          "description": "The image of the controller that completes the installation of this version.",
+          "type": "string"
+        },
+        "display_name": {
+          "description": "Name of the version to show to users.",
+          "type": "string"
+        },
+        "installer_image": {
+          "description": "The image that installs the hosts of this version.",
+          "type": "string"
+        },
+        "kubeconfig_generator_image": {
+          "description": "The image that generates the ignition files and kubeconfig of this version.",
+          "type": "string"
+        },
+        "release_image": {
+          "description": "The release image the clusters of this version are installed from.",
+          "type": "string"
+        },
+        "rhcos_image": {
+          "description": "The RHCOS live ISO the discovery images of this version are generated from. When empty, the base ISO of the service is used.",
+          "type": "string"
+        }
+      }
+    },
+    "openshift-versions": {
+      "type": "object",
+      "additionalProperties": {
+        "$ref": "#/definitions/openshift-version"
+      }
+    },
+    "presigned": {
+      "type": "object",
+      "required": [
+        "url",
+        "expires_at"
+      ],
+      "properties": {
+        "expires_at": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "url": {
+          "type": "string"
+        }
+      }
+    },
     "step": {
       "type": "object",
       "properties": {
@@ -128,6 +128,22 @@ func (_m *MockInstallerAPI) DownloadClusterISO(ctx context.Context, params insta
 	return r0
 }
 
+// DownloadClusterISOPresigned provides a mock function with given fields: ctx, params
+func (_m *MockInstallerAPI) DownloadClusterISOPresigned(ctx context.Context, params installer.DownloadClusterISOPresignedParams) middleware.Responder {
+	ret := _m.Called(ctx, params)
+
+	var r0 middleware.Responder
+	if rf, ok := ret.Get(0).(func(context.Context, installer.DownloadClusterISOPresignedParams) middleware.Responder); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(middleware.Responder)
+		}
+	}
+
+	return r0
+}
+
 // DownloadClusterKubeconfig provides a mock function with given fields: ctx, params
 func (_m *MockInstallerAPI) DownloadClusterKubeconfig(ctx context.Context, params installer.DownloadClusterKubeconfigParams) middleware.Responder {
 	ret := _m.Called(ctx, params)
@@ -192,6 +208,22 @@ func (_m *MockInstallerAPI) GetCluster(ctx context.Context, params installer.Get
 	return r0
 }
 
+// GetClusterConnectivityMatrix provides a mock function with given fields: ctx, params
+func (_m *MockInstallerAPI) GetClusterConnectivityMatrix(ctx context.Context, params installer.GetClusterConnectivityMatrixParams) middleware.Responder {
+	ret := _m.Called(ctx, params)
+
+	var r0 middleware.Responder
+	if rf, ok := ret.Get(0).(func(context.Context, installer.GetClusterConnectivityMatrixParams) middleware.Responder); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(middleware.Responder)
+		}
+	}
+
+	return r0
+}
+
 // GetCredentials provides a mock function with given fields: ctx, params
 func (_m *MockInstallerAPI) GetCredentials(ctx context.Context, params installer.GetCredentialsParams) middleware.Responder {
 	ret := _m.Called(ctx, params)
@@ -224,6 +256,22 @@ func (_m *MockInstallerAPI) GetFreeAddresses(ctx context.Context, params install
 	return r0
 }
 
+// GetPresignedForClusterISO provides a mock function with given fields: ctx, params
+func (_m *MockInstallerAPI) GetPresignedForClusterISO(ctx context.Context, params installer.GetPresignedForClusterISOParams) middleware.Responder {
+	ret := _m.Called(ctx, params)
+
+	var r0 middleware.Responder
+	if rf, ok := ret.Get(0).(func(context.Context, installer.GetPresignedForClusterISOParams) middleware.Responder); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(middleware.Responder)
+		}
+	}
+
+	return r0
+}
+
 // GetHost provides a mock function with given fields: ctx, params
 func (_m *MockInstallerAPI) GetHost(ctx context.Context, params installer.GetHostParams) middleware.Responder {
 	ret := _m.Called(ctx, params)
@@ -400,6 +448,22 @@ func (_m *MockInstallerAPI) UpdateCluster(ctx context.Context, params installer.
 	return r0
 }
 
+// UpdateClusterInstallConfig provides a mock function with given fields: ctx, params
+func (_m *MockInstallerAPI) UpdateClusterInstallConfig(ctx context.Context, params installer.UpdateClusterInstallConfigParams) middleware.Responder {
+	ret := _m.Called(ctx, params)
+
+	var r0 middleware.Responder
+	if rf, ok := ret.Get(0).(func(context.Context, installer.UpdateClusterInstallConfigParams) middleware.Responder); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(middleware.Responder)
+		}
+	}
+
+	return r0
+}
+
 // UpdateHostInstallProgress provides a mock function with given fields: ctx, params
 func (_m *MockInstallerAPI) UpdateHostInstallProgress(ctx context.Context, params installer.UpdateHostInstallProgressParams) middleware.Responder {
 	ret := _m.Called(ctx, params)
@@ -31,3 +31,19 @@ func (_m *MockVersionsAPI) ListComponentVersions(ctx context.Context, params ver
 
 	return r0
 }
+
+// ListSupportedOpenshiftVersions provides a mock function with given fields: ctx, params
+func (_m *MockVersionsAPI) ListSupportedOpenshiftVersions(ctx context.Context, params versions.ListSupportedOpenshiftVersionsParams) middleware.Responder {
+	ret := _m.Called(ctx, params)
+
+	var r0 middleware.Responder
+	if rf, ok := ret.Get(0).(func(context.Context, versions.ListSupportedOpenshiftVersionsParams) middleware.Responder); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(middleware.Responder)
+		}
+	}
+
+	return r0
+}
@@ -68,6 +68,9 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerDownloadClusterISOHandler: installer.DownloadClusterISOHandlerFunc(func(params installer.DownloadClusterISOParams) middleware.Responder {
 			return middleware.NotImplemented("operation installer.DownloadClusterISO has not yet been implemented")
 		}),
+		InstallerDownloadClusterISOPresignedHandler: installer.DownloadClusterISOPresignedHandlerFunc(func(params installer.DownloadClusterISOPresignedParams) middleware.Responder {
+			return middleware.NotImplemented("operation installer.DownloadClusterISOPresigned has not yet been implemented")
+		}),
 		InstallerDownloadClusterKubeconfigHandler: installer.DownloadClusterKubeconfigHandlerFunc(func(params installer.DownloadClusterKubeconfigParams) middleware.Responder {
 			return middleware.NotImplemented("operation installer.DownloadClusterKubeconfig has not yet been implemented")
 		}),
@@ -80,12 +83,18 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerGetClusterHandler: installer.GetClusterHandlerFunc(func(params installer.GetClusterParams) middleware.Responder {
 			return middleware.NotImplemented("operation installer.GetCluster has not yet been implemented")
 		}),
+		InstallerGetClusterConnectivityMatrixHandler: installer.GetClusterConnectivityMatrixHandlerFunc(func(params installer.GetClusterConnectivityMatrixParams) middleware.Responder {
+			return middleware.NotImplemented("operation installer.GetClusterConnectivityMatrix has not yet been implemented")
+		}),
 		InstallerGetCredentialsHandler: installer.GetCredentialsHandlerFunc(func(params installer.GetCredentialsParams) middleware.Responder {
 			return middleware.NotImplemented("operation installer.GetCredentials has not yet been implemented")
 		}),
 		InstallerGetFreeAddressesHandler: installer.GetFreeAddressesHandlerFunc(func(params installer.GetFreeAddressesParams) middleware.Responder {
 			return middleware.NotImplemented("operation installer.GetFreeAddresses has not yet been implemented")
 		}),
+		InstallerGetPresignedForClusterISOHandler: installer.GetPresignedForClusterISOHandlerFunc(func(params installer.GetPresignedForClusterISOParams) middleware.Responder {
+			return middleware.NotImplemented("operation installer.GetPresignedForClusterISO has not yet been implemented")
+		}),
 		InstallerGetHostHandler: installer.GetHostHandlerFunc(func(params installer.GetHostParams) middleware.Responder {
 			return middleware.NotImplemented("operation installer.GetHost has not yet been implemented")
 		}),
@@ -101,6 +110,9 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		VersionsListComponentVersionsHandler: versions.ListComponentVersionsHandlerFunc(func(params versions.ListComponentVersionsParams) middleware.Responder {
 			return middleware.NotImplemented("operation versions.ListComponentVersions has not yet been implemented")
 		}),
+		VersionsListSupportedOpenshiftVersionsHandler: versions.ListSupportedOpenshiftVersionsHandlerFunc(func(params versions.ListSupportedOpenshiftVersionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation versions.ListSupportedOpenshiftVersions has not yet been implemented")
+		}),
 		EventsListEventsHandler: events.ListEventsHandlerFunc(func(params events.ListEventsParams) middleware.Responder {
 			return middleware.NotImplemented("operation events.ListEvents has not yet been implemented")
 		}),
@@ -128,6 +140,9 @@ func NewAssistedInstallAPI(spec *loads.Document) *AssistedInstallAPI {
 		InstallerUpdateClusterHandler: installer.UpdateClusterHandlerFunc(func(params installer.UpdateClusterParams) middleware.Responder {
 			return middleware.NotImplemented("operation installer.UpdateCluster has not yet been implemented")
 		}),
+		InstallerUpdateClusterInstallConfigHandler: installer.UpdateClusterInstallConfigHandlerFunc(func(params installer.UpdateClusterInstallConfigParams) middleware.Responder {
+			return middleware.NotImplemented("operation installer.UpdateClusterInstallConfig has not yet been implemented")
+		}),
 		InstallerUpdateHostInstallProgressHandler: installer.UpdateHostInstallProgressHandlerFunc(func(params installer.UpdateHostInstallProgressParams) middleware.Responder {
 			return middleware.NotImplemented("operation installer.UpdateHostInstallProgress has not yet been implemented")
 		}),
@@ -184,6 +199,8 @@ type AssistedInstallAPI struct {
 	InstallerDownloadClusterFilesHandler installer.DownloadClusterFilesHandler
 	// InstallerDownloadClusterISOHandler sets the operation handler for the download cluster i s o operation
 	InstallerDownloadClusterISOHandler installer.DownloadClusterISOHandler
+	// InstallerDownloadClusterISOPresignedHandler sets the operation handler for the download cluster i s o presigned operation
+	InstallerDownloadClusterISOPresignedHandler installer.DownloadClusterISOPresignedHandler
 	// InstallerDownloadClusterKubeconfigHandler sets the operation handler for the download cluster kubeconfig operation
 	InstallerDownloadClusterKubeconfigHandler installer.DownloadClusterKubeconfigHandler
 	// InstallerEnableHostHandler sets the operation handler for the enable host operation
@@ -192,10 +209,14 @@ type AssistedInstallAPI struct {
 	InstallerGenerateClusterISOHandler installer.GenerateClusterISOHandler
 	// InstallerGetClusterHandler sets the operation handler for the get cluster operation
 	InstallerGetClusterHandler installer.GetClusterHandler
+	// InstallerGetClusterConnectivityMatrixHandler sets the operation handler for the get cluster connectivity matrix operation
+	InstallerGetClusterConnectivityMatrixHandler installer.GetClusterConnectivityMatrixHandler
 	// InstallerGetCredentialsHandler sets the operation handler for the get credentials operation
 	InstallerGetCredentialsHandler installer.GetCredentialsHandler
 	// InstallerGetFreeAddressesHandler sets the operation handler for the get free addresses operation
 	InstallerGetFreeAddressesHandler installer.GetFreeAddressesHandler
+	// InstallerGetPresignedForClusterISOHandler sets the operation handler for the get presigned for cluster i s o operation
+	InstallerGetPresignedForClusterISOHandler installer.GetPresignedForClusterISOHandler
 	// InstallerGetHostHandler sets the operation handler for the get host operation
 	InstallerGetHostHandler installer.GetHostHandler
 	// InstallerGetNextStepsHandler sets the operation handler for the get next steps operation
@@ -206,6 +227,8 @@ type AssistedInstallAPI struct {
 	InstallerListClustersHandler installer.ListClustersHandler
 	// VersionsListComponentVersionsHandler sets the operation handler for the list component versions operation
 	VersionsListComponentVersionsHandler versions.ListComponentVersionsHandler
+	// VersionsListSupportedOpenshiftVersionsHandler sets the operation handler for the list supported openshift versions operation
+	VersionsListSupportedOpenshiftVersionsHandler versions.ListSupportedOpenshiftVersionsHandler
 	// EventsListEventsHandler sets the operation handler for the list events operation
 	EventsListEventsHandler events.ListEventsHandler
 	// InstallerListHostsHandler sets the operation handler for the list hosts operation
@@ -224,6 +247,8 @@ type AssistedInstallAPI struct {
 	InstallerSetDebugStepHandler installer.SetDebugStepHandler
 	// InstallerUpdateClusterHandler sets the operation handler for the update cluster operation
 	InstallerUpdateClusterHandler installer.UpdateClusterHandler
+	// InstallerUpdateClusterInstallConfigHandler sets the operation handler for the update cluster install config operation
+	InstallerUpdateClusterInstallConfigHandler installer.UpdateClusterInstallConfigHandler
 	// InstallerUpdateHostInstallProgressHandler sets the operation handler for the update host install progress operation
 	InstallerUpdateHostInstallProgressHandler installer.UpdateHostInstallProgressHandler
 	// InstallerUploadClusterIngressCertHandler sets the operation handler for the upload cluster ingress cert operation
@@ -318,6 +343,9 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerDownloadClusterISOHandler == nil {
 		unregistered = append(unregistered, "installer.DownloadClusterISOHandler")
 	}
+	if o.InstallerDownloadClusterISOPresignedHandler == nil {
+		unregistered = append(unregistered, "installer.DownloadClusterISOPresignedHandler")
+	}
 	if o.InstallerDownloadClusterKubeconfigHandler == nil {
 		unregistered = append(unregistered, "installer.DownloadClusterKubeconfigHandler")
 	}
@@ -330,12 +358,18 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerGetClusterHandler == nil {
 		unregistered = append(unregistered, "installer.GetClusterHandler")
 	}
+	if o.InstallerGetClusterConnectivityMatrixHandler == nil {
+		unregistered = append(unregistered, "installer.GetClusterConnectivityMatrixHandler")
+	}
 	if o.InstallerGetCredentialsHandler == nil {
 		unregistered = append(unregistered, "installer.GetCredentialsHandler")
 	}
 	if o.InstallerGetFreeAddressesHandler == nil {
 		unregistered = append(unregistered, "installer.GetFreeAddressesHandler")
 	}
+	if o.InstallerGetPresignedForClusterISOHandler == nil {
+		unregistered = append(unregistered, "installer.GetPresignedForClusterISOHandler")
+	}
 	if o.InstallerGetHostHandler == nil {
 		unregistered = append(unregistered, "installer.GetHostHandler")
 	}
@@ -351,6 +385,9 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.VersionsListComponentVersionsHandler == nil {
 		unregistered = append(unregistered, "versions.ListComponentVersionsHandler")
 	}
+	if o.VersionsListSupportedOpenshiftVersionsHandler == nil {
+		unregistered = append(unregistered, "versions.ListSupportedOpenshiftVersionsHandler")
+	}
 	if o.EventsListEventsHandler == nil {
 		unregistered = append(unregistered, "events.ListEventsHandler")
 	}
@@ -378,6 +415,9 @@ func (o *AssistedInstallAPI) Validate() error {
 	if o.InstallerUpdateClusterHandler == nil {
 		unregistered = append(unregistered, "installer.UpdateClusterHandler")
 	}
+	if o.InstallerUpdateClusterInstallConfigHandler == nil {
+		unregistered = append(unregistered, "installer.UpdateClusterInstallConfigHandler")
+	}
 	if o.InstallerUpdateHostInstallProgressHandler == nil {
 		unregistered = append(unregistered, "installer.UpdateHostInstallProgressHandler")
 	}
@@ -505,6 +545,10 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/clusters/{cluster_id}/downloads/image-presigned"] = installer.NewDownloadClusterISOPresigned(o.context, o.InstallerDownloadClusterISOPresignedHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/clusters/{cluster_id}/downloads/kubeconfig"] = installer.NewDownloadClusterKubeconfig(o.context, o.InstallerDownloadClusterKubeconfigHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
@@ -521,6 +565,10 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/clusters/{cluster_id}/connectivity-matrix"] = installer.NewGetClusterConnectivityMatrix(o.context, o.InstallerGetClusterConnectivityMatrixHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/clusters/{cluster_id}/credentials"] = installer.NewGetCredentials(o.context, o.InstallerGetCredentialsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -529,6 +577,10 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/clusters/{cluster_id}/downloads/image-url"] = installer.NewGetPresignedForClusterISO(o.context, o.InstallerGetPresignedForClusterISOHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/clusters/{cluster_id}/hosts/{host_id}"] = installer.NewGetHost(o.context, o.InstallerGetHostHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -549,6 +601,10 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/openshift_versions"] = versions.NewListSupportedOpenshiftVersions(o.context, o.VersionsListSupportedOpenshiftVersionsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/events/{entity_id}"] = events.NewListEvents(o.context, o.EventsListEventsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -582,6 +638,10 @@ func (o *AssistedInstallAPI) initHandlerCache() {
 		o.handlers["PATCH"] = make(map[string]http.Handler)
 	}
 	o.handlers["PATCH"]["/clusters/{cluster_id}"] = installer.NewUpdateCluster(o.context, o.InstallerUpdateClusterHandler)
+	if o.handlers["PATCH"] == nil {
+		o.handlers["PATCH"] = make(map[string]http.Handler)
+	}
+	o.handlers["PATCH"]["/clusters/{cluster_id}/install-config"] = installer.NewUpdateClusterInstallConfig(o.context, o.InstallerUpdateClusterInstallConfigHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
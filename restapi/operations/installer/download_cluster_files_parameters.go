@@ -41,6 +41,16 @@ type DownloadClusterFilesParams struct {
 	  In: query
 	*/
 	FileName string
+
+	/*Serves the requested range only when the entity tag or the last modification date match the file.
+	  In: header
+	*/
+	IfRange *string
+
+	/*The byte range of the file to download.
+	  In: header
+	*/
+	Range *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -64,6 +74,14 @@ func (o *DownloadClusterFilesParams) BindRequest(r *http.Request, route *middlew
 		res = append(res, err)
 	}
 
+	if err := o.bindIfRange(r.Header[http.CanonicalHeaderKey("If-Range")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.bindRange(r.Header[http.CanonicalHeaderKey("Range")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -137,3 +155,39 @@ func (o *DownloadClusterFilesParams) validateFileName(formats strfmt.Registry) e
 
 	return nil
 }
+
+// bindIfRange binds and validates parameter IfRange from header.
+func (o *DownloadClusterFilesParams) bindIfRange(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.IfRange = &raw
+
+	return nil
+}
+
+// bindRange binds and validates parameter Range from header.
+func (o *DownloadClusterFilesParams) bindRange(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Range = &raw
+
+	return nil
+}
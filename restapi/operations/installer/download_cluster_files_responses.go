@@ -56,6 +56,48 @@ func (o *DownloadClusterFilesOK) WriteResponse(rw http.ResponseWriter, producer
 	}
 }
 
+// DownloadClusterFilesPartialContentCode is the HTTP code returned for type DownloadClusterFilesPartialContent
+const DownloadClusterFilesPartialContentCode int = 206
+
+/*DownloadClusterFilesPartialContent Partial content.
+
+swagger:response downloadClusterFilesPartialContent
+*/
+type DownloadClusterFilesPartialContent struct {
+
+	/*
+	  In: Body
+	*/
+	Payload io.ReadCloser `json:"body,omitempty"`
+}
+
+// NewDownloadClusterFilesPartialContent creates DownloadClusterFilesPartialContent with default headers values
+func NewDownloadClusterFilesPartialContent() *DownloadClusterFilesPartialContent {
+
+	return &DownloadClusterFilesPartialContent{}
+}
+
+// WithPayload adds the payload to the download cluster files partial content response
+func (o *DownloadClusterFilesPartialContent) WithPayload(payload io.ReadCloser) *DownloadClusterFilesPartialContent {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download cluster files partial content response
+func (o *DownloadClusterFilesPartialContent) SetPayload(payload io.ReadCloser) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadClusterFilesPartialContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(206)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
 // DownloadClusterFilesNotFoundCode is the HTTP code returned for type DownloadClusterFilesNotFound
 const DownloadClusterFilesNotFoundCode int = 404
 
@@ -144,6 +186,50 @@ func (o *DownloadClusterFilesConflict) WriteResponse(rw http.ResponseWriter, pro
 	}
 }
 
+// DownloadClusterFilesRequestedRangeNotSatisfiableCode is the HTTP code returned for type DownloadClusterFilesRequestedRangeNotSatisfiable
+const DownloadClusterFilesRequestedRangeNotSatisfiableCode int = 416
+
+/*DownloadClusterFilesRequestedRangeNotSatisfiable Error.
+
+swagger:response downloadClusterFilesRequestedRangeNotSatisfiable
+*/
+type DownloadClusterFilesRequestedRangeNotSatisfiable struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDownloadClusterFilesRequestedRangeNotSatisfiable creates DownloadClusterFilesRequestedRangeNotSatisfiable with default headers values
+func NewDownloadClusterFilesRequestedRangeNotSatisfiable() *DownloadClusterFilesRequestedRangeNotSatisfiable {
+
+	return &DownloadClusterFilesRequestedRangeNotSatisfiable{}
+}
+
+// WithPayload adds the payload to the download cluster files requested range not satisfiable response
+func (o *DownloadClusterFilesRequestedRangeNotSatisfiable) WithPayload(payload *models.Error) *DownloadClusterFilesRequestedRangeNotSatisfiable {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download cluster files requested range not satisfiable response
+func (o *DownloadClusterFilesRequestedRangeNotSatisfiable) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadClusterFilesRequestedRangeNotSatisfiable) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(416)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // DownloadClusterFilesInternalServerErrorCode is the HTTP code returned for type DownloadClusterFilesInternalServerError
 const DownloadClusterFilesInternalServerErrorCode int = 500
 
@@ -35,6 +35,16 @@ type DownloadClusterISOParams struct {
 	  In: path
 	*/
 	ClusterID strfmt.UUID
+
+	/*Serves the requested range only when the entity tag or the last modification date match the file.
+	  In: header
+	*/
+	IfRange *string
+
+	/*The byte range of the file to download.
+	  In: header
+	*/
+	Range *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -51,6 +61,14 @@ func (o *DownloadClusterISOParams) BindRequest(r *http.Request, route *middlewar
 		res = append(res, err)
 	}
 
+	if err := o.bindIfRange(r.Header[http.CanonicalHeaderKey("If-Range")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.bindRange(r.Header[http.CanonicalHeaderKey("Range")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -89,3 +107,39 @@ func (o *DownloadClusterISOParams) validateClusterID(formats strfmt.Registry) er
 	}
 	return nil
 }
+
+// bindIfRange binds and validates parameter IfRange from header.
+func (o *DownloadClusterISOParams) bindIfRange(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.IfRange = &raw
+
+	return nil
+}
+
+// bindRange binds and validates parameter Range from header.
+func (o *DownloadClusterISOParams) bindRange(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Range = &raw
+
+	return nil
+}
@@ -56,6 +56,48 @@ func (o *DownloadClusterISOOK) WriteResponse(rw http.ResponseWriter, producer ru
 	}
 }
 
+// DownloadClusterISOPartialContentCode is the HTTP code returned for type DownloadClusterISOPartialContent
+const DownloadClusterISOPartialContentCode int = 206
+
+/*DownloadClusterISOPartialContent Partial content.
+
+swagger:response downloadClusterISOPartialContent
+*/
+type DownloadClusterISOPartialContent struct {
+
+	/*
+	  In: Body
+	*/
+	Payload io.ReadCloser `json:"body,omitempty"`
+}
+
+// NewDownloadClusterISOPartialContent creates DownloadClusterISOPartialContent with default headers values
+func NewDownloadClusterISOPartialContent() *DownloadClusterISOPartialContent {
+
+	return &DownloadClusterISOPartialContent{}
+}
+
+// WithPayload adds the payload to the download cluster i s o partial content response
+func (o *DownloadClusterISOPartialContent) WithPayload(payload io.ReadCloser) *DownloadClusterISOPartialContent {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download cluster i s o partial content response
+func (o *DownloadClusterISOPartialContent) SetPayload(payload io.ReadCloser) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadClusterISOPartialContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(206)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
 // DownloadClusterISOBadRequestCode is the HTTP code returned for type DownloadClusterISOBadRequest
 const DownloadClusterISOBadRequestCode int = 400
 
@@ -144,6 +186,94 @@ func (o *DownloadClusterISONotFound) WriteResponse(rw http.ResponseWriter, produ
 	}
 }
 
+// DownloadClusterISOConflictCode is the HTTP code returned for type DownloadClusterISOConflict
+const DownloadClusterISOConflictCode int = 409
+
+/*DownloadClusterISOConflict Error.
+
+swagger:response downloadClusterISOConflict
+*/
+type DownloadClusterISOConflict struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDownloadClusterISOConflict creates DownloadClusterISOConflict with default headers values
+func NewDownloadClusterISOConflict() *DownloadClusterISOConflict {
+
+	return &DownloadClusterISOConflict{}
+}
+
+// WithPayload adds the payload to the download cluster i s o conflict response
+func (o *DownloadClusterISOConflict) WithPayload(payload *models.Error) *DownloadClusterISOConflict {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download cluster i s o conflict response
+func (o *DownloadClusterISOConflict) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadClusterISOConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DownloadClusterISORequestedRangeNotSatisfiableCode is the HTTP code returned for type DownloadClusterISORequestedRangeNotSatisfiable
+const DownloadClusterISORequestedRangeNotSatisfiableCode int = 416
+
+/*DownloadClusterISORequestedRangeNotSatisfiable Error.
+
+swagger:response downloadClusterISORequestedRangeNotSatisfiable
+*/
+type DownloadClusterISORequestedRangeNotSatisfiable struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDownloadClusterISORequestedRangeNotSatisfiable creates DownloadClusterISORequestedRangeNotSatisfiable with default headers values
+func NewDownloadClusterISORequestedRangeNotSatisfiable() *DownloadClusterISORequestedRangeNotSatisfiable {
+
+	return &DownloadClusterISORequestedRangeNotSatisfiable{}
+}
+
+// WithPayload adds the payload to the download cluster i s o requested range not satisfiable response
+func (o *DownloadClusterISORequestedRangeNotSatisfiable) WithPayload(payload *models.Error) *DownloadClusterISORequestedRangeNotSatisfiable {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download cluster i s o requested range not satisfiable response
+func (o *DownloadClusterISORequestedRangeNotSatisfiable) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadClusterISORequestedRangeNotSatisfiable) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(416)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // DownloadClusterISOInternalServerErrorCode is the HTTP code returned for type DownloadClusterISOInternalServerError
 const DownloadClusterISOInternalServerErrorCode int = 500
 
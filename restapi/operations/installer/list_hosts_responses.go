@@ -60,6 +60,50 @@ func (o *ListHostsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Pro
 	}
 }
 
+// ListHostsNotFoundCode is the HTTP code returned for type ListHostsNotFound
+const ListHostsNotFoundCode int = 404
+
+/*ListHostsNotFound Error.
+
+swagger:response listHostsNotFound
+*/
+type ListHostsNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewListHostsNotFound creates ListHostsNotFound with default headers values
+func NewListHostsNotFound() *ListHostsNotFound {
+
+	return &ListHostsNotFound{}
+}
+
+// WithPayload adds the payload to the list hosts not found response
+func (o *ListHostsNotFound) WithPayload(payload *models.Error) *ListHostsNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list hosts not found response
+func (o *ListHostsNotFound) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListHostsNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // ListHostsInternalServerErrorCode is the HTTP code returned for type ListHostsInternalServerError
 const ListHostsInternalServerErrorCode int = 500
 
@@ -56,7 +56,7 @@ var (
 	validFreeAddresses = models.FreeNetworksAddresses{
 		{
 			Network: "1.2.3.0/24",
-			FreeAddresses: []strfmt.IPv4{
+			FreeAddresses: []string{
 				"1.2.3.8",
 				"1.2.3.9",
 				"1.2.3.5",
@@ -86,6 +86,7 @@ var _ = Describe("Cluster tests", func() {
 		Expect(swag.StringValue(cluster.GetPayload().Status)).Should(Equal("insufficient"))
 		Expect(swag.StringValue(cluster.GetPayload().StatusInfo)).Should(Equal(clusterInsufficientStateInfo))
 		Expect(cluster.GetPayload().StatusUpdatedAt).ShouldNot(Equal(strfmt.DateTime(time.Time{})))
+		setAgentToken(*cluster.GetPayload().ID)
 	})
 
 	JustBeforeEach(func() {
@@ -248,6 +249,7 @@ var _ = Describe("cluster install", func() {
 		})
 		Expect(err).NotTo(HaveOccurred())
 		cluster = registerClusterReply.GetPayload()
+		setAgentToken(*cluster.ID)
 		log.Infof("Register cluster %s", cluster.ID.String())
 	})
 
@@ -292,6 +294,7 @@ var _ = Describe("cluster install", func() {
 		generateHWPostStepReply(h2, validHwInfo, "h2")
 		h3 := registerHost(clusterID)
 		generateHWPostStepReply(h3, validHwInfo, "h3")
+		generateFullMeshConnectivity(ctx, h1, h2, h3)
 
 		apiVip := "1.2.3.5"
 		ingressVip := "1.2.3.6"
@@ -615,7 +618,7 @@ var _ = Describe("cluster install", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			defer os.Remove(file.Name())
-			_, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "bootstrap.ign"}, file)
+			_, _, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "bootstrap.ign"}, file)
 			Expect(reflect.TypeOf(err)).To(Equal(reflect.TypeOf(installer.NewDownloadClusterFilesConflict())))
 
 			_, err = bmclient.Installer.InstallCluster(ctx, &installer.InstallClusterParams{ClusterID: clusterID})
@@ -623,13 +626,13 @@ var _ = Describe("cluster install", func() {
 			waitForClusterInstallationToStart(clusterID)
 
 			missingClusterId := strfmt.UUID(uuid.New().String())
-			_, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: missingClusterId, FileName: "bootstrap.ign"}, file)
+			_, _, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: missingClusterId, FileName: "bootstrap.ign"}, file)
 			Expect(reflect.TypeOf(err)).Should(Equal(reflect.TypeOf(installer.NewDownloadClusterFilesNotFound())))
 
-			_, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "not_real_file"}, file)
+			_, _, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "not_real_file"}, file)
 			Expect(err).Should(HaveOccurred())
 
-			_, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "bootstrap.ign"}, file)
+			_, _, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "bootstrap.ign"}, file)
 			Expect(err).NotTo(HaveOccurred())
 			s, err := file.Stat()
 			Expect(err).NotTo(HaveOccurred())
@@ -646,7 +649,7 @@ var _ = Describe("cluster install", func() {
 			waitForClusterState(ctx, clusterID, models.ClusterStatusError, defaultWaitForClusterStateTimeout,
 				IgnoreStateInfo)
 
-			_, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "bootstrap.ign"}, file)
+			_, _, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "bootstrap.ign"}, file)
 			Expect(err).NotTo(HaveOccurred())
 			s, err := file.Stat()
 			Expect(err).NotTo(HaveOccurred())
@@ -709,7 +712,7 @@ var _ = Describe("cluster install", func() {
 				// Download kubeconfig before uploading
 				kubeconfigNoIngress, err := ioutil.TempFile("", "tmp")
 				Expect(err).NotTo(HaveOccurred())
-				_, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "kubeconfig-noingress"}, kubeconfigNoIngress)
+				_, _, err = bmclient.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID, FileName: "kubeconfig-noingress"}, kubeconfigNoIngress)
 				Expect(err).NotTo(HaveOccurred())
 				sni, err := kubeconfigNoIngress.Stat()
 				Expect(err).NotTo(HaveOccurred())
@@ -1011,6 +1014,7 @@ var _ = Describe("cluster install", func() {
 				h := registerHost(clusterID)
 				generateHWPostStepReply(h, validHwInfo, "hostname")
 				generateFAPostStepReply(h, validFreeAddresses)
+				generateFullMeshConnectivity(ctx, append(c.Hosts, h)...)
 				_, err = bmclient.Installer.UpdateCluster(ctx, &installer.UpdateClusterParams{
 					ClusterUpdateParams: &models.ClusterUpdateParams{HostsRoles: []*models.ClusterUpdateParamsHostsRolesItems0{
 						{ID: *h.ID, Role: models.HostRoleUpdateParamsMaster},
@@ -1189,6 +1193,22 @@ var _ = Describe("cluster install", func() {
 		})
 	})
 
+	It("connectivity matrix", func() {
+		clusterID := *cluster.ID
+		hosts := register3nodes(clusterID)
+
+		reply, err := bmclient.Installer.GetClusterConnectivityMatrix(ctx, &installer.GetClusterConnectivityMatrixParams{ClusterID: clusterID})
+		Expect(err).NotTo(HaveOccurred())
+		matrix := reply.GetPayload()
+		Expect(matrix.Hosts).Should(HaveLen(len(hosts)))
+		Expect(matrix.HostPairs).Should(HaveLen(6))
+		for _, p := range matrix.HostPairs {
+			Expect(p.L2Status).Should(Equal(models.ConnectivityStatusUnknown))
+			Expect(p.L3Status).Should(Equal(models.ConnectivityStatusReachable))
+			Expect(p.Asymmetric).Should(BeFalse())
+		}
+	})
+
 	It("install cluster requirement", func() {
 		clusterID := *cluster.ID
 
@@ -1245,6 +1265,7 @@ var _ = Describe("cluster install", func() {
 		generateHWPostStepReply(mh2, validHwInfo, "mh2")
 		mh3 := registerHost(clusterID)
 		generateHWPostStepReply(mh3, validHwInfo, "mh3")
+		generateFullMeshConnectivity(ctx, wh1, wh2, wh3, mh1, mh2, mh3)
 
 		apiVip := "1.2.3.5"
 		ingressVip := "1.2.3.6"
@@ -1427,6 +1448,7 @@ var _ = Describe("cluster install", func() {
 		By("Registering host with same hostname")
 		h4 := registerHost(clusterID)
 		generateHWPostStepReply(h4, validHwInfo, "h1")
+		generateFullMeshConnectivity(ctx, hosts[0], hosts[1], hosts[2], h4)
 		h4 = getHost(clusterID, *h4.ID)
 		waitForHostState(ctx, clusterID, *h1.ID, "insufficient", 60*time.Second)
 		Expect(h4.RequestedHostname).Should(Equal("h1"))
@@ -1532,6 +1554,70 @@ var _ = Describe("cluster install", func() {
 
 	})
 
+	It("[only_k8s]installation disk", func() {
+		clusterID := *cluster.ID
+		hosts := register3nodes(clusterID)
+		_, err := bmclient.Installer.UpdateCluster(ctx, &installer.UpdateClusterParams{
+			ClusterUpdateParams: &models.ClusterUpdateParams{HostsRoles: []*models.ClusterUpdateParamsHostsRolesItems0{
+				{ID: *hosts[0].ID, Role: models.HostRoleUpdateParamsMaster},
+			}},
+			ClusterID: clusterID,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		h1 := getHost(clusterID, *hosts[0].ID)
+		hwInfo := *validHwInfo
+		hwInfo.Disks = []*models.Disk{
+			{DriveType: "HDD", Name: "sdb", SizeBytes: validDiskSize, Serial: "S-SDB"},
+			{DriveType: "SSD", Name: "nvme0n1", SizeBytes: validDiskSize, Serial: "S-NVME"},
+			{DriveType: "SSD", Name: "sdc", SizeBytes: 1073741824, Serial: "S-SDC"},
+		}
+		generateHWPostStepReply(h1, &hwInfo, "h1")
+		waitForHostState(ctx, clusterID, *h1.ID, models.HostStatusKnown, 60*time.Second)
+
+		By("Setting a disk that is too small")
+		_, err = bmclient.Installer.UpdateCluster(ctx, &installer.UpdateClusterParams{
+			ClusterUpdateParams: &models.ClusterUpdateParams{HostsInstallationDisks: []*models.ClusterUpdateParamsHostsInstallationDisksItems0{
+				{ID: *h1.ID, InstallationDiskID: "S-SDC"},
+			}},
+			ClusterID: clusterID,
+		})
+		Expect(err).To(BeAssignableToTypeOf(installer.NewUpdateClusterBadRequest()))
+		Expect(getHost(clusterID, *h1.ID).InstallationDiskID).Should(BeEmpty())
+
+		By("Setting a valid disk")
+		_, err = bmclient.Installer.UpdateCluster(ctx, &installer.UpdateClusterParams{
+			ClusterUpdateParams: &models.ClusterUpdateParams{HostsInstallationDisks: []*models.ClusterUpdateParamsHostsInstallationDisksItems0{
+				{ID: *h1.ID, InstallationDiskID: "S-NVME"},
+			}},
+			ClusterID: clusterID,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getHost(clusterID, *h1.ID).InstallationDiskID).Should(Equal("S-NVME"))
+
+		By("Removing the disk from the inventory")
+		hwInfo.Disks = hwInfo.Disks[:1]
+		generateHWPostStepReply(h1, &hwInfo, "h1")
+		waitForHostState(ctx, clusterID, *h1.ID, models.HostStatusInsufficient, 60*time.Second)
+	})
+
+	It("[only_k8s]disk eligibility", func() {
+		clusterID := *cluster.ID
+		h1 := registerHost(clusterID)
+		hwInfo := *validHwInfo
+		hwInfo.Disks = []*models.Disk{
+			{DriveType: "HDD", Name: "sdb", SizeBytes: validDiskSize},
+			{DriveType: "HDD", Name: "sdc", SizeBytes: validDiskSize, Removable: true, IsInstallationMedia: true},
+		}
+		generateHWPostStepReply(h1, &hwInfo, "h1")
+
+		var inventory models.Inventory
+		Expect(json.Unmarshal([]byte(getHost(clusterID, *h1.ID).Inventory), &inventory)).ShouldNot(HaveOccurred())
+		Expect(inventory.Disks[0].InstallationEligibility.Eligible).Should(BeTrue())
+		Expect(inventory.Disks[1].InstallationEligibility.Eligible).Should(BeFalse())
+		Expect(inventory.Disks[1].InstallationEligibility.NotEligibleReasons).Should(Equal([]string{"Disk is the installation medium"}))
+	})
+
 	It("[only_k8s]different_roles_stages", func() {
 		clusterID := *cluster.ID
 		registerHostsAndSetRoles(clusterID, 4)
@@ -1603,6 +1689,7 @@ var _ = Describe("cluster install", func() {
 		By("Registering new host with same hostname as in node's inventory")
 		h4 := registerHost(clusterID)
 		generateHWPostStepReply(h4, validHwInfo, "h3")
+		generateFullMeshConnectivity(ctx, hosts[0], hosts[1], hosts[2], h4)
 		h4 = getHost(clusterID, *h4.ID)
 		waitForHostState(ctx, clusterID, *h4.ID, host.HostStatusPendingForInput, time.Minute)
 		waitForHostState(ctx, clusterID, *h3.ID, models.HostStatusInsufficient, time.Minute)
@@ -1614,6 +1701,7 @@ var _ = Describe("cluster install", func() {
 		By("Registering new host with same hostname as in node's requested_hostname")
 		h5 := registerHost(clusterID)
 		generateHWPostStepReply(h5, validHwInfo, "reqh0")
+		generateFullMeshConnectivity(ctx, hosts[0], hosts[1], hosts[2], h4, h5)
 		h5 = getHost(clusterID, *h5.ID)
 		waitForHostState(ctx, clusterID, *h5.ID, host.HostStatusPendingForInput, time.Minute)
 		waitForHostState(ctx, clusterID, *h1.ID, models.HostStatusInsufficient, time.Minute)
@@ -1694,6 +1782,7 @@ var _ = Describe("cluster install, with default network params", func() {
 		})
 		Expect(err).NotTo(HaveOccurred())
 		cluster = registerClusterReply.GetPayload()
+		setAgentToken(*cluster.ID)
 	})
 
 	It("[only_k8s]install cluster", func() {
@@ -1779,6 +1868,7 @@ func registerHostsAndSetRoles(clusterID strfmt.UUID, numHosts int) []*models.Hos
 		host := registerHost(clusterID)
 		generateHWPostStepReply(host, validHwInfo, hostname)
 		generateFAPostStepReply(host, validFreeAddresses)
+		hosts = append(hosts, host)
 		var role models.HostRoleUpdateParams
 		if i < 3 {
 			role = models.HostRoleUpdateParamsMaster
@@ -1793,6 +1883,7 @@ func registerHostsAndSetRoles(clusterID strfmt.UUID, numHosts int) []*models.Hos
 		})
 		Expect(err).NotTo(HaveOccurred())
 	}
+	generateFullMeshConnectivity(ctx, hosts...)
 	apiVip := ""
 	ingressVip := ""
 	_, err := bmclient.Installer.UpdateCluster(ctx, &installer.UpdateClusterParams{
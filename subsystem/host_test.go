@@ -35,6 +35,7 @@ var _ = Describe("Host tests", func() {
 			},
 		})
 		Expect(err).NotTo(HaveOccurred())
+		setAgentToken(*cluster.GetPayload().ID)
 	})
 
 	JustBeforeEach(func() {
@@ -283,8 +284,8 @@ var _ = Describe("Host tests", func() {
 		})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(freeAddressesReply.Payload).To(HaveLen(2))
-		Expect(freeAddressesReply.Payload[0]).To(Equal(strfmt.IPv4("10.0.0.0")))
-		Expect(freeAddressesReply.Payload[1]).To(Equal(strfmt.IPv4("10.0.0.1")))
+		Expect(freeAddressesReply.Payload[0]).To(Equal("10.0.0.0"))
+		Expect(freeAddressesReply.Payload[1]).To(Equal("10.0.0.1"))
 
 		freeAddressesReply, err = bmclient.Installer.GetFreeAddresses(ctx, &installer.GetFreeAddressesParams{
 			ClusterID: clusterID,
@@ -292,7 +293,7 @@ var _ = Describe("Host tests", func() {
 		})
 		Expect(err).ToNot(HaveOccurred())
 		Expect(freeAddressesReply.Payload).To(HaveLen(1))
-		Expect(freeAddressesReply.Payload[0]).To(Equal(strfmt.IPv4("10.0.1.0")))
+		Expect(freeAddressesReply.Payload[0]).To(Equal("10.0.1.0"))
 
 		freeAddressesReply, err = bmclient.Installer.GetFreeAddresses(ctx, &installer.GetFreeAddressesParams{
 			ClusterID: clusterID,
@@ -408,6 +409,7 @@ var _ = Describe("Host tests", func() {
 			},
 		})
 		Expect(err).NotTo(HaveOccurred())
+		setAgentToken(*cluster2.GetPayload().ID)
 
 		// register to cluster2
 		_, err = bmclient.Installer.RegisterHost(ctx, &installer.RegisterHostParams{
@@ -1,12 +1,14 @@
 package subsystem
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -48,12 +50,14 @@ var _ = Describe("system-test image tests", func() {
 		}
 		defer os.Remove(file.Name())
 
-		_, err = bmclient.Installer.GenerateClusterISO(ctx, &installer.GenerateClusterISOParams{
+		generateReply, err := bmclient.Installer.GenerateClusterISO(ctx, &installer.GenerateClusterISOParams{
 			ClusterID:         clusterID,
 			ImageCreateParams: &models.ImageCreateParams{},
 		})
 		Expect(err).NotTo(HaveOccurred())
-		_, err = bmclient.Installer.DownloadClusterISO(ctx, &installer.DownloadClusterISOParams{
+		Expect(generateReply.GetPayload().ImageInfo.State).Should(Equal(models.ImageInfoStatePending))
+		waitForImageState(ctx, clusterID, models.ImageInfoStateReady, 5*time.Minute)
+		_, _, err = bmclient.Installer.DownloadClusterISO(ctx, &installer.DownloadClusterISOParams{
 			ClusterID: clusterID,
 		}, file)
 		Expect(err).NotTo(HaveOccurred())
@@ -75,6 +79,23 @@ var _ = Describe("system-test image tests", func() {
 		}
 		Expect(nRegisteredEvents).ShouldNot(Equal(0))
 
+		By("resuming the download")
+		content, err := ioutil.ReadFile(file.Name())
+		Expect(err).NotTo(HaveOccurred())
+		var partial bytes.Buffer
+		_, partialReply, err := bmclient.Installer.DownloadClusterISO(ctx, &installer.DownloadClusterISOParams{
+			ClusterID: clusterID,
+			Range:     swag.String(fmt.Sprintf("bytes=%d-", len(content)-10)),
+		}, &partial)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(partialReply).NotTo(BeNil())
+		Expect(partial.Bytes()).Should(Equal(content[len(content)-10:]))
+
+		_, _, err = bmclient.Installer.DownloadClusterISO(ctx, &installer.DownloadClusterISOParams{
+			ClusterID: clusterID,
+			Range:     swag.String(fmt.Sprintf("bytes=%d-", len(content))),
+		}, &partial)
+		Expect(reflect.TypeOf(err)).Should(Equal(reflect.TypeOf(installer.NewDownloadClusterISORequestedRangeNotSatisfiable())))
 	})
 })
 
@@ -94,7 +115,7 @@ var _ = Describe("image tests", func() {
 	})
 
 	It("download_non_existing_cluster", func() {
-		_, err = bmclient.Installer.DownloadClusterISO(ctx, &installer.DownloadClusterISOParams{ClusterID: *strToUUID(uuid.New().String())}, file)
+		_, _, err = bmclient.Installer.DownloadClusterISO(ctx, &installer.DownloadClusterISOParams{ClusterID: *strToUUID(uuid.New().String())}, file)
 		Expect(err).Should(HaveOccurred())
 	})
 
@@ -106,9 +127,24 @@ var _ = Describe("image tests", func() {
 			},
 		})
 		Expect(err).NotTo(HaveOccurred())
-		_, err = bmclient.Installer.DownloadClusterISO(ctx, &installer.DownloadClusterISOParams{
+		_, _, err = bmclient.Installer.DownloadClusterISO(ctx, &installer.DownloadClusterISOParams{
 			ClusterID: *cluster.GetPayload().ID,
 		}, file)
-		Expect(reflect.TypeOf(err)).Should(Equal(reflect.TypeOf(installer.NewDownloadClusterISONotFound())))
+		Expect(reflect.TypeOf(err)).Should(Equal(reflect.TypeOf(installer.NewDownloadClusterISOConflict())))
 	})
 })
+
+func waitForImageState(ctx context.Context, clusterID strfmt.UUID, state string, timeout time.Duration) {
+	log.Infof("Waiting for the image of cluster %s state %s", clusterID, state)
+	for start := time.Now(); time.Since(start) < timeout; {
+		rep, err := bmclient.Installer.GetCluster(ctx, &installer.GetClusterParams{ClusterID: clusterID})
+		Expect(err).NotTo(HaveOccurred())
+		if rep.GetPayload().ImageInfo.State == state {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	rep, err := bmclient.Installer.GetCluster(ctx, &installer.GetClusterParams{ClusterID: clusterID})
+	Expect(err).NotTo(HaveOccurred())
+	ExpectWithOffset(1, rep.GetPayload().ImageInfo.State).Should(Equal(state))
+}
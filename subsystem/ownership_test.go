@@ -0,0 +1,266 @@
+package subsystem
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/assisted-service/client"
+	"github.com/openshift/assisted-service/client/events"
+	"github.com/openshift/assisted-service/client/installer"
+	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/auth"
+)
+
+// expectStatus verifies the status of a response that the client doesn't have a type for
+func expectStatus(err error, code int) {
+	Expect(err).Should(HaveOccurred())
+	Expect(err).Should(BeAssignableToTypeOf(&runtime.APIError{}))
+	Expect(err.(*runtime.APIError).Code).Should(Equal(code))
+}
+
+var _ = Describe("Org ownership", func() {
+	var (
+		ctx       = context.Background()
+		owner     *client.AssistedInstall
+		readOnly  *client.AssistedInstall
+		stranger  *client.AssistedInstall
+		clusterID strfmt.UUID
+		hostID    strfmt.UUID
+	)
+
+	BeforeEach(func() {
+		owner = newClient(&authTransport{token: userToken("owner", "org1", auth.UserRole)})
+		readOnly = newClient(&authTransport{token: userToken("viewer", "org1", auth.ReadOnlyUserRole)})
+
+		reply, err := owner.Installer.RegisterCluster(ctx, &installer.RegisterClusterParams{
+			NewClusterParams: &models.ClusterCreateParams{
+				Name:             swag.String("owned-cluster"),
+				OpenshiftVersion: swag.String("4.5"),
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		clusterID = *reply.GetPayload().ID
+		setAgentToken(clusterID)
+		hostID = *registerHost(clusterID).ID
+
+		// The stranger has a cluster of its own, so it holds a valid agent token of another cluster
+		strangerToken := userToken("stranger", "org2", auth.UserRole)
+		strangerReply, err := newClient(&authTransport{token: strangerToken}).Installer.RegisterCluster(ctx,
+			&installer.RegisterClusterParams{
+				NewClusterParams: &models.ClusterCreateParams{
+					Name:             swag.String("other-cluster"),
+					OpenshiftVersion: swag.String("4.5"),
+				},
+			})
+		Expect(err).NotTo(HaveOccurred())
+		stranger = newClient(&authTransport{token: strangerToken, agentToken: setAgentToken(*strangerReply.GetPayload().ID)})
+	})
+
+	AfterEach(func() {
+		clearDB()
+	})
+
+	userRoutes := []struct {
+		name     string
+		notFound interface{}
+		call     func(cli *client.AssistedInstall) error
+	}{
+		{"GetCluster", installer.NewGetClusterNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.GetCluster(ctx, &installer.GetClusterParams{ClusterID: clusterID})
+			return err
+		}},
+		{"UpdateCluster", installer.NewUpdateClusterNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.UpdateCluster(ctx, &installer.UpdateClusterParams{ClusterID: clusterID,
+				ClusterUpdateParams: &models.ClusterUpdateParams{Name: swag.String("stolen-cluster")}})
+			return err
+		}},
+		{"UpdateClusterInstallConfig", installer.NewUpdateClusterInstallConfigNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.UpdateClusterInstallConfig(ctx, &installer.UpdateClusterInstallConfigParams{
+				ClusterID: clusterID, InstallConfigParams: `{"fips":true}`})
+			return err
+		}},
+		{"DeregisterCluster", installer.NewDeregisterClusterNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.DeregisterCluster(ctx, &installer.DeregisterClusterParams{ClusterID: clusterID})
+			return err
+		}},
+		{"GenerateClusterISO", installer.NewGenerateClusterISONotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.GenerateClusterISO(ctx, &installer.GenerateClusterISOParams{ClusterID: clusterID,
+				ImageCreateParams: &models.ImageCreateParams{}})
+			return err
+		}},
+		{"DownloadClusterISO", installer.NewDownloadClusterISONotFound(), func(cli *client.AssistedInstall) error {
+			_, _, err := cli.Installer.DownloadClusterISO(ctx, &installer.DownloadClusterISOParams{ClusterID: clusterID},
+				ioutil.Discard)
+			return err
+		}},
+		{"GetPresignedForClusterISO", installer.NewGetPresignedForClusterISONotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.GetPresignedForClusterISO(ctx, &installer.GetPresignedForClusterISOParams{
+				ClusterID: clusterID})
+			return err
+		}},
+		{"DownloadClusterFiles", installer.NewDownloadClusterFilesNotFound(), func(cli *client.AssistedInstall) error {
+			_, _, err := cli.Installer.DownloadClusterFiles(ctx, &installer.DownloadClusterFilesParams{ClusterID: clusterID,
+				FileName: "bootstrap.ign"}, ioutil.Discard)
+			return err
+		}},
+		{"DownloadClusterKubeconfig", installer.NewDownloadClusterKubeconfigNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.DownloadClusterKubeconfig(ctx, &installer.DownloadClusterKubeconfigParams{
+				ClusterID: clusterID}, ioutil.Discard)
+			return err
+		}},
+		{"GetCredentials", installer.NewGetCredentialsNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.GetCredentials(ctx, &installer.GetCredentialsParams{ClusterID: clusterID})
+			return err
+		}},
+		{"UploadClusterIngressCert", installer.NewUploadClusterIngressCertNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.UploadClusterIngressCert(ctx, &installer.UploadClusterIngressCertParams{
+				ClusterID: clusterID, IngressCertParams: "cert"})
+			return err
+		}},
+		{"InstallCluster", installer.NewInstallClusterNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.InstallCluster(ctx, &installer.InstallClusterParams{ClusterID: clusterID})
+			return err
+		}},
+		{"CancelInstallation", installer.NewCancelInstallationNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.CancelInstallation(ctx, &installer.CancelInstallationParams{ClusterID: clusterID})
+			return err
+		}},
+		{"ResetCluster", installer.NewResetClusterNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.ResetCluster(ctx, &installer.ResetClusterParams{ClusterID: clusterID})
+			return err
+		}},
+		{"CompleteInstallation", installer.NewCompleteInstallationNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.CompleteInstallation(ctx, &installer.CompleteInstallationParams{ClusterID: clusterID,
+				CompletionParams: &models.CompletionParams{IsSuccess: swag.Bool(true)}})
+			return err
+		}},
+		{"GetFreeAddresses", installer.NewGetFreeAddressesNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.GetFreeAddresses(ctx, &installer.GetFreeAddressesParams{ClusterID: clusterID,
+				Network: "10.0.0.0/24"})
+			return err
+		}},
+		{"GetClusterConnectivityMatrix", installer.NewGetClusterConnectivityMatrixNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.GetClusterConnectivityMatrix(ctx, &installer.GetClusterConnectivityMatrixParams{
+				ClusterID: clusterID})
+			return err
+		}},
+		{"ListEvents", events.NewListEventsNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Events.ListEvents(ctx, &events.ListEventsParams{EntityID: clusterID})
+			return err
+		}},
+		{"ListHosts", installer.NewListHostsNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.ListHosts(ctx, &installer.ListHostsParams{ClusterID: clusterID})
+			return err
+		}},
+		{"GetHost", installer.NewGetHostNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.GetHost(ctx, &installer.GetHostParams{ClusterID: clusterID, HostID: hostID})
+			return err
+		}},
+		{"DeregisterHost", installer.NewDeregisterHostNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.DeregisterHost(ctx, &installer.DeregisterHostParams{ClusterID: clusterID,
+				HostID: hostID})
+			return err
+		}},
+		{"EnableHost", installer.NewEnableHostNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.EnableHost(ctx, &installer.EnableHostParams{ClusterID: clusterID, HostID: hostID})
+			return err
+		}},
+		{"DisableHost", installer.NewDisableHostNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.DisableHost(ctx, &installer.DisableHostParams{ClusterID: clusterID, HostID: hostID})
+			return err
+		}},
+		{"SetDebugStep", installer.NewSetDebugStepNotFound(), func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.SetDebugStep(ctx, &installer.SetDebugStepParams{ClusterID: clusterID,
+				HostID: hostID, Step: &models.DebugStep{Command: swag.String("ls")}})
+			return err
+		}},
+	}
+
+	for _, r := range userRoutes {
+		r := r
+		It("hides the cluster from another org in "+r.name, func() {
+			Expect(r.call(stranger)).Should(BeAssignableToTypeOf(r.notFound))
+		})
+	}
+
+	agentRoutes := []struct {
+		name string
+		call func(cli *client.AssistedInstall) error
+	}{
+		{"RegisterHost", func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.RegisterHost(ctx, &installer.RegisterHostParams{ClusterID: clusterID,
+				NewHostParams: &models.HostCreateParams{HostID: strToUUID(uuid.New().String())}})
+			return err
+		}},
+		{"GetNextSteps", func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.GetNextSteps(ctx, &installer.GetNextStepsParams{ClusterID: clusterID,
+				HostID: hostID})
+			return err
+		}},
+		{"PostStepReply", func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.PostStepReply(ctx, &installer.PostStepReplyParams{ClusterID: clusterID,
+				HostID: hostID, Reply: &models.StepReply{StepType: models.StepTypeExecute, StepID: "step"}})
+			return err
+		}},
+		{"UpdateHostInstallProgress", func(cli *client.AssistedInstall) error {
+			_, err := cli.Installer.UpdateHostInstallProgress(ctx, &installer.UpdateHostInstallProgressParams{
+				ClusterID: clusterID, HostID: hostID,
+				HostProgress: &models.HostProgress{CurrentStage: models.HostStageRebooting}})
+			return err
+		}},
+	}
+
+	for _, r := range agentRoutes {
+		r := r
+		It("rejects the agent token of another cluster in "+r.name, func() {
+			expectStatus(r.call(stranger), http.StatusUnauthorized)
+		})
+	}
+
+	It("lets the installer read the cluster of its agent token only", func() {
+		agentToken, _ := agentTokens.Load(clusterID.String())
+		installerClient := newClient(&authTransport{agentToken: agentToken.(string)})
+		_, err := installerClient.Installer.GetCluster(ctx, &installer.GetClusterParams{ClusterID: clusterID})
+		Expect(err).NotTo(HaveOccurred())
+		hosts, err := installerClient.Installer.ListHosts(ctx, &installer.ListHostsParams{ClusterID: clusterID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hosts.GetPayload()).Should(HaveLen(1))
+
+		otherToken, _, err := auth.GenerateAgentToken()
+		Expect(err).NotTo(HaveOccurred())
+		strangerAgent := newClient(&authTransport{agentToken: otherToken})
+		_, err = strangerAgent.Installer.GetCluster(ctx, &installer.GetClusterParams{ClusterID: clusterID})
+		expectStatus(err, http.StatusUnauthorized)
+	})
+
+	It("forbids read-only users to change the cluster of their org", func() {
+		_, err := readOnly.Installer.GetCluster(ctx, &installer.GetClusterParams{ClusterID: clusterID})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = readOnly.Installer.UpdateCluster(ctx, &installer.UpdateClusterParams{ClusterID: clusterID,
+			ClusterUpdateParams: &models.ClusterUpdateParams{Name: swag.String("renamed-cluster")}})
+		expectStatus(err, http.StatusForbidden)
+		_, err = readOnly.Installer.DeregisterHost(ctx, &installer.DeregisterHostParams{ClusterID: clusterID,
+			HostID: hostID})
+		expectStatus(err, http.StatusForbidden)
+	})
+
+	It("keeps the cluster of the owner unchanged", func() {
+		for _, r := range userRoutes {
+			_ = r.call(stranger)
+		}
+		reply, err := owner.Installer.GetCluster(ctx, &installer.GetClusterParams{ClusterID: clusterID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reply.GetPayload().Name).Should(Equal("owned-cluster"))
+		Expect(reply.GetPayload().Hosts).Should(HaveLen(1))
+		list, err := bmclient.Installer.ListClusters(ctx, &installer.ListClustersParams{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.GetPayload()).Should(HaveLen(2))
+	})
+})
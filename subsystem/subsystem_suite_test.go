@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/openshift/assisted-service/client"
+	"github.com/openshift/assisted-service/pkg/auth"
 	"github.com/sirupsen/logrus"
 )
 
@@ -33,13 +34,10 @@ func init() {
 		log.Fatal(err.Error())
 	}
 
-	bmclient = client.New(client.Config{
-		URL: &url.URL{
-			Scheme: client.DefaultSchemes[0],
-			Host:   Options.InventoryHost,
-			Path:   client.DefaultBasePath,
-		},
-	})
+	signingKey, err = readSigningKey("test_jwk_private.json")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 
 	db, err = gorm.Open("postgres",
 		fmt.Sprintf("host=%s port=%s user=admin dbname=installer password=admin sslmode=disable",
@@ -49,8 +47,21 @@ func init() {
 	}
 }
 
+// newClient returns a client that authenticates its requests with the transport
+func newClient(transport *authTransport) *client.AssistedInstall {
+	return client.New(client.Config{
+		URL: &url.URL{
+			Scheme: client.DefaultSchemes[0],
+			Host:   Options.InventoryHost,
+			Path:   client.DefaultBasePath,
+		},
+		Transport: transport,
+	})
+}
+
 func TestSubsystem(t *testing.T) {
 	RegisterFailHandler(Fail)
+	bmclient = newClient(&authTransport{token: userToken("admin", "admin-org", auth.AdminUserRole)})
 	clearDB()
 	RunSpecs(t, "Subsystem Suite")
 }
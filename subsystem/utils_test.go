@@ -2,13 +2,22 @@ package subsystem
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sync"
 	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"github.com/openshift/assisted-service/client/installer"
+	"github.com/openshift/assisted-service/internal/common"
 	"github.com/openshift/assisted-service/models"
+	"github.com/openshift/assisted-service/pkg/auth"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 const (
@@ -16,6 +25,77 @@ const (
 	defaultWaitForClusterStateTimeout = 30 * time.Second
 )
 
+// signingKey is the private key of the key set that the service under test is deployed with
+var signingKey *jose.JSONWebKey
+
+// agentTokens holds the agent token of each cluster, by cluster ID
+var agentTokens sync.Map
+
+var clusterPathRegexp = regexp.MustCompile(`/clusters/([^/]+)`)
+
+func readSigningKey(path string) (*jose.JSONWebKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var key jose.JSONWebKey
+	if err = json.Unmarshal(data, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// userToken returns a token of the user, signed with the key the service trusts
+func userToken(userID, orgID, role string) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.SignatureAlgorithm(signingKey.Algorithm), Key: signingKey},
+		(&jose.SignerOptions{}).WithType("JWT"))
+	Expect(err).NotTo(HaveOccurred())
+	token, err := jwt.Signed(signer).Claims(map[string]interface{}{
+		"sub":    userID,
+		"org_id": orgID,
+		"role":   role,
+		"exp":    time.Now().Add(24 * time.Hour).Unix(),
+	}).CompactSerialize()
+	Expect(err).NotTo(HaveOccurred())
+	return token
+}
+
+// setAgentToken issues a new agent token for the cluster, that the clients pass in the agent operations
+// of the cluster
+func setAgentToken(clusterID strfmt.UUID) string {
+	token, hash, err := auth.GenerateAgentToken()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(db.Model(&common.Cluster{}).Where("id = ?", clusterID.String()).
+		Update("agent_token_hash", hash).Error).NotTo(HaveOccurred())
+	agentTokens.Store(clusterID.String(), token)
+	return token
+}
+
+// authTransport adds the user token and an agent token to the requests, the service picks the one that matches
+// the operation. The agent token is the one set by setAgentToken for the cluster in the request path, unless a
+// fixed agent token is given.
+type authTransport struct {
+	token      string
+	agentToken string
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	agentToken := t.agentToken
+	if match := clusterPathRegexp.FindStringSubmatch(req.URL.Path); agentToken == "" && match != nil {
+		if token, ok := agentTokens.Load(match[1]); ok {
+			agentToken = token.(string)
+		}
+	}
+	if agentToken != "" {
+		req.Header.Set(auth.AgentTokenHeader, agentToken)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
 func clearDB() {
 	db.Delete(&models.Host{})
 	db.Delete(&models.Cluster{})
@@ -83,3 +163,34 @@ func updateProgressWithInfo(hostID strfmt.UUID, clusterID strfmt.UUID, current_s
 	Expect(err).ShouldNot(HaveOccurred())
 	Expect(updateReply).Should(BeAssignableToTypeOf(installer.NewUpdateHostInstallProgressOK()))
 }
+
+// generateFullMeshConnectivity reports that each of the hosts has L3 connectivity to all the others on the machine network
+func generateFullMeshConnectivity(ctx context.Context, hosts ...*models.Host) {
+	for _, h := range hosts {
+		var report models.ConnectivityReport
+		for _, other := range hosts {
+			if other.ID.String() == h.ID.String() {
+				continue
+			}
+			report.RemoteHosts = append(report.RemoteHosts, &models.ConnectivityRemoteHost{
+				HostID: *other.ID,
+				L3Connectivity: []*models.L3Connectivity{
+					{RemoteIPAddress: "1.2.3.4", Successful: true},
+				},
+			})
+		}
+		output, err := json.Marshal(&report)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = bmclient.Installer.PostStepReply(ctx, &installer.PostStepReplyParams{
+			ClusterID: h.ClusterID,
+			HostID:    *h.ID,
+			Reply: &models.StepReply{
+				ExitCode: 0,
+				Output:   string(output),
+				StepID:   string(models.StepTypeConnectivityCheck),
+				StepType: models.StepTypeConnectivityCheck,
+			},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+}
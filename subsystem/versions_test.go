@@ -16,3 +16,12 @@ var _ = Describe("test versions", func() {
 		Expect(len(reply.GetPayload().Versions)).To(Equal(6))
 	})
 })
+
+var _ = Describe("test openshift versions", func() {
+	It("get openshift versions list", func() {
+		reply, err := bmclient.Versions.ListSupportedOpenshiftVersions(context.Background(),
+			&versions.ListSupportedOpenshiftVersionsParams{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(reply.GetPayload()).Should(HaveKey("4.5"))
+	})
+})
@@ -10,6 +10,16 @@ DST_FILE = os.path.join(os.getcwd(), "build/assisted-service.yaml")
 
 TEST_CLUSTER_MONITOR_INTERVAL = "1s"
 TEST_HOST_MONITOR_INTERVAL = "1s"
+# The subsystem tests sign their tokens with the private key of this key set
+TEST_JWKS_FILE = os.path.join(os.getcwd(), "subsystem/test_jwks.json")
+TEST_JWKS_CONFIGMAP = "assisted-service-subsystem-jwks"
+TEST_JWKS_MOUNT_PATH = "/etc/assisted-service/jwks"
+
+
+def deploy_test_jwks(namespace):
+    print(utils.check_output(
+        "kubectl create configmap {} --namespace {} --from-file=jwks.json={} --dry-run -o yaml | kubectl apply -f -".format(
+            TEST_JWKS_CONFIGMAP, namespace, TEST_JWKS_FILE)))
 
 def main():
     parser = argparse.ArgumentParser()
@@ -29,6 +39,11 @@ def main():
                 data["spec"]["template"]["spec"]["containers"][0]["env"] = []
             data["spec"]["template"]["spec"]["containers"][0]["env"].append({'name':'CLUSTER_MONITOR_INTERVAL', 'value': TEST_CLUSTER_MONITOR_INTERVAL})
             data["spec"]["template"]["spec"]["containers"][0]["env"].append({'name':'HOST_MONITOR_INTERVAL', 'value': TEST_HOST_MONITOR_INTERVAL})
+            data["spec"]["template"]["spec"]["containers"][0]["env"].append({'name':'ENABLE_AUTH', 'value': 'true'})
+            data["spec"]["template"]["spec"]["containers"][0]["env"].append({'name':'JWKS_FILE', 'value': os.path.join(TEST_JWKS_MOUNT_PATH, "jwks.json")})
+            data["spec"]["template"]["spec"]["containers"][0].setdefault("volumeMounts", []).append({'name': 'jwks', 'mountPath': TEST_JWKS_MOUNT_PATH, 'readOnly': True})
+            data["spec"]["template"]["spec"].setdefault("volumes", []).append({'name': 'jwks', 'configMap': {'name': TEST_JWKS_CONFIGMAP}})
+            deploy_test_jwks(deploy_options.namespace)
             data["spec"]["template"]["spec"]["containers"][0]["imagePullPolicy"] = "Never"
         else:
             data["spec"]["template"]["spec"]["containers"][0]["imagePullPolicy"] = "Always"